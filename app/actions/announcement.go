@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateEditAnnouncement is used to create a new announcement or edit existing
+type CreateEditAnnouncement struct {
+	ID          int                       `route:"id"`
+	Text        string                    `json:"text"`
+	Severity    enum.AnnouncementSeverity `json:"severity"`
+	Audience    enum.AnnouncementAudience `json:"audience"`
+	StartsAt    *time.Time                `json:"startsAt"`
+	EndsAt      *time.Time                `json:"endsAt"`
+	Dismissible bool                      `json:"dismissible"`
+
+	Announcement *entity.Announcement
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditAnnouncement) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditAnnouncement) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.ID > 0 {
+		getAnnouncement := &query.GetAnnouncementByID{AnnouncementID: action.ID}
+		if err := bus.Dispatch(ctx, getAnnouncement); err != nil {
+			return validate.Error(err)
+		}
+		action.Announcement = getAnnouncement.Result
+	}
+
+	if action.Text == "" {
+		result.AddFieldFailure("text", "Text is required.")
+	} else if len(action.Text) > 1000 {
+		result.AddFieldFailure("text", "Text must have less than 1000 characters.")
+	}
+
+	if action.Severity == 0 {
+		result.AddFieldFailure("severity", "Severity must be one of info, warning or critical.")
+	}
+
+	if action.Audience == 0 {
+		result.AddFieldFailure("audience", "Audience must be one of everyone, members or staff.")
+	}
+
+	if action.StartsAt == nil {
+		now := time.Now()
+		action.StartsAt = &now
+	}
+
+	if action.EndsAt != nil && !action.EndsAt.After(*action.StartsAt) {
+		result.AddFieldFailure("endsAt", "End time must be after start time.")
+	}
+
+	return result
+}
+
+// DeleteAnnouncement is used to delete an existing announcement
+type DeleteAnnouncement struct {
+	ID int `route:"id"`
+
+	Announcement *entity.Announcement
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteAnnouncement) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeleteAnnouncement) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getAnnouncement := &query.GetAnnouncementByID{AnnouncementID: action.ID}
+	if err := bus.Dispatch(ctx, getAnnouncement); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Announcement = getAnnouncement.Result
+	return validate.Success()
+}
@@ -56,10 +56,14 @@ func (action *InviteUsers) Validate(ctx context.Context, user *entity.User) *val
 			result.AddFieldFailure("recipients", "Too many recipients. We limit at 30 recipients per invite.")
 		}
 
+		tenant, _ := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
 		for _, email := range action.Recipients {
 			if email != "" {
 				messages := validate.Email(ctx, email)
 				result.AddFieldFailure("recipients", messages...)
+				if len(messages) == 0 && tenant != nil && !tenant.EmailRules.Allows(email) {
+					result.AddFieldFailure("recipients", fmt.Sprintf("'%s' is not allowed by the email rules of this site.", email))
+				}
 			}
 		}
 
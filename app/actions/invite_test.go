@@ -82,6 +82,26 @@ func TestInviteUsers_InvalidRecipient(t *testing.T) {
 	Expect(action.Invitations).IsNil()
 }
 
+func TestInviteUsers_BlockedByEmailRules(t *testing.T) {
+	RegisterT(t)
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{
+		EmailRules: entity.TenantEmailRules{AllowedDomains: []string{"got.com"}},
+	})
+
+	action := &actions.InviteUsers{
+		Subject: "Share your feedback.",
+		Message: "Use this link to join our community: %invite%",
+		Recipients: []string{
+			"jon.snow@got.com",
+			"tyrion@lannister.com",
+		},
+	}
+	result := action.Validate(ctx, nil)
+	ExpectFailed(result, "recipients")
+	Expect(action.Invitations).IsNil()
+}
+
 func TestInviteUsers_Valid(t *testing.T) {
 	RegisterT(t)
 
@@ -0,0 +1,111 @@
+package actions
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateEditOAuthApp is used to register a new third-party application or edit existing
+type CreateEditOAuthApp struct {
+	ID           int      `route:"id"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirectURIs"`
+	Scopes       []string `json:"scopes"`
+
+	App *entity.OAuthApp
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditOAuthApp) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditOAuthApp) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.ID > 0 {
+		getApp := &query.GetOAuthAppByID{ID: action.ID}
+		if err := bus.Dispatch(ctx, getApp); err != nil {
+			return validate.Error(err)
+		}
+		action.App = getApp.Result
+	}
+
+	action.Name = strings.TrimSpace(action.Name)
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 60 {
+		result.AddFieldFailure("name", "Name must have less than 60 characters.")
+	}
+
+	if len(action.RedirectURIs) == 0 {
+		result.AddFieldFailure("redirectURIs", "At least one redirect URI is required.")
+	} else if len(action.RedirectURIs) > 10 {
+		result.AddFieldFailure("redirectURIs", "Up to 10 redirect URIs are allowed.")
+	}
+	for _, uri := range action.RedirectURIs {
+		if !isValidOAuthRedirectURI(uri) {
+			result.AddFieldFailure("redirectURIs", "'"+uri+"' is not a valid redirect URI. Use an absolute HTTPS URL without fragment.")
+		}
+	}
+
+	if len(action.Scopes) == 0 {
+		result.AddFieldFailure("scopes", "At least one scope is required.")
+	}
+	for _, scope := range action.Scopes {
+		if !entity.IsValidOAuthScope(scope) {
+			result.AddFieldFailure("scopes", "'"+scope+"' is not a valid scope.")
+		}
+	}
+
+	return result
+}
+
+// Redirect URIs must be absolute HTTPS URLs, except on localhost to ease the development of integrations
+func isValidOAuthRedirectURI(uri string) bool {
+	if len(uri) > 300 {
+		return false
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+		return false
+	}
+
+	if u.Scheme == "https" {
+		return true
+	}
+
+	hostname := u.Hostname()
+	return u.Scheme == "http" && (hostname == "localhost" || hostname == "127.0.0.1" || hostname == "::1")
+}
+
+// DeleteOAuthApp is used to delete an existing third-party application
+type DeleteOAuthApp struct {
+	ID int `route:"id"`
+
+	App *entity.OAuthApp
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteOAuthApp) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeleteOAuthApp) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getApp := &query.GetOAuthAppByID{ID: action.ID}
+	if err := bus.Dispatch(ctx, getApp); err != nil {
+		return validate.Error(err)
+	}
+
+	action.App = getApp.Result
+	return validate.Success()
+}
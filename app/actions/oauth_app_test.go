@@ -0,0 +1,66 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+func TestCreateEditOAuthApp_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		expected []string
+		action   *actions.CreateEditOAuthApp
+	}{
+		{
+			expected: []string{"name", "redirectURIs", "scopes"},
+			action:   &actions.CreateEditOAuthApp{Name: "  "},
+		},
+		{
+			expected: []string{"name", "redirectURIs", "scopes"},
+			action: &actions.CreateEditOAuthApp{
+				Name:         rand.String(61),
+				RedirectURIs: []string{"http://example.com/callback"},
+				Scopes:       []string{"everything"},
+			},
+		},
+		{
+			expected: []string{"redirectURIs"},
+			action: &actions.CreateEditOAuthApp{
+				Name:         "Zapier",
+				RedirectURIs: []string{"/callback"},
+				Scopes:       []string{"read"},
+			},
+		},
+		{
+			expected: []string{"redirectURIs"},
+			action: &actions.CreateEditOAuthApp{
+				Name:         "Zapier",
+				RedirectURIs: []string{"https://zapier.com/callback#token"},
+				Scopes:       []string{"read"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), nil)
+		ExpectFailed(result, testCase.expected...)
+	}
+}
+
+func TestCreateEditOAuthApp_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.CreateEditOAuthApp{
+		Name:         " Zapier ",
+		RedirectURIs: []string{"https://zapier.com/callback", "http://localhost:3000/callback"},
+		Scopes:       []string{"read", "write", "admin"},
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Name).Equals("Zapier")
+}
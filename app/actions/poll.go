@@ -0,0 +1,186 @@
+package actions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateNewPoll is used to attach a new poll to an existing post
+type CreateNewPoll struct {
+	Number            int                        `route:"number"`
+	Question          string                     `json:"question"`
+	Options           []string                   `json:"options"`
+	IsMultipleChoice  bool                       `json:"isMultipleChoice"`
+	ResultsVisibility enum.PollResultsVisibility `json:"resultsVisibility"`
+	ClosesAt          *time.Time                 `json:"closesAt"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateNewPoll) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *CreateNewPoll) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	action.Question = strings.TrimSpace(action.Question)
+	if action.Question == "" {
+		result.AddFieldFailure("question", "Question is required.")
+	} else if len(action.Question) > 200 {
+		result.AddFieldFailure("question", "Question must have less than 200 characters.")
+	}
+
+	seen := make(map[string]bool)
+	for i, option := range action.Options {
+		option = strings.TrimSpace(option)
+		action.Options[i] = option
+		if option == "" {
+			result.AddFieldFailure("options", "Options cannot be empty.")
+		} else if len(option) > 100 {
+			result.AddFieldFailure("options", "Options must have less than 100 characters.")
+		} else if seen[strings.ToLower(option)] {
+			result.AddFieldFailure("options", "Options must be unique.")
+		}
+		seen[strings.ToLower(option)] = true
+	}
+
+	if len(action.Options) < 2 || len(action.Options) > 10 {
+		result.AddFieldFailure("options", "A poll must have between 2 and 10 options.")
+	}
+
+	if action.ResultsVisibility == 0 {
+		action.ResultsVisibility = enum.PollResultsPublic
+	} else if action.ResultsVisibility.Name() == "unknown" {
+		result.AddFieldFailure("resultsVisibility", "Results visibility is invalid.")
+	}
+
+	if action.ClosesAt != nil && !action.ClosesAt.After(time.Now()) {
+		result.AddFieldFailure("closesAt", "Close date must be in the future.")
+	}
+
+	return result
+}
+
+// VoteOnPoll is used to set the choices of current user on a poll
+type VoteOnPoll struct {
+	Number    int   `route:"number"`
+	PollID    int   `route:"id"`
+	OptionIDs []int `json:"options"`
+
+	Poll *entity.Poll
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *VoteOnPoll) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *VoteOnPoll) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	poll, err := getPostPoll(ctx, action.Number, action.PollID)
+	if err != nil {
+		return validate.Error(err)
+	}
+	action.Poll = poll
+
+	if poll.IsClosed() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.pollclosed"))
+	}
+
+	result := validate.Success()
+	if len(action.OptionIDs) == 0 {
+		result.AddFieldFailure("options", propertyIsRequired(ctx, "options"))
+	} else if !poll.IsMultipleChoice && len(action.OptionIDs) > 1 {
+		result.AddFieldFailure("options", i18n.T(ctx, "validation.custom.pollsinglechoice"))
+	}
+
+	for _, optionID := range action.OptionIDs {
+		if !poll.HasOption(optionID) {
+			result.AddFieldFailure("options", propertyIsInvalid(ctx, "options"))
+			break
+		}
+	}
+
+	return result
+}
+
+// RemovePollVote is used to remove the choices of current user from a poll
+type RemovePollVote struct {
+	Number int `route:"number"`
+	PollID int `route:"id"`
+
+	Poll *entity.Poll
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *RemovePollVote) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *RemovePollVote) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	poll, err := getPostPoll(ctx, action.Number, action.PollID)
+	if err != nil {
+		return validate.Error(err)
+	}
+	action.Poll = poll
+
+	if poll.IsClosed() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.pollclosed"))
+	}
+
+	return validate.Success()
+}
+
+// DeletePoll is used to delete a poll and all its votes
+type DeletePoll struct {
+	Number int `route:"number"`
+	PollID int `route:"id"`
+
+	Poll *entity.Poll
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeletePoll) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *DeletePoll) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	poll, err := getPostPoll(ctx, action.Number, action.PollID)
+	if err != nil {
+		return validate.Error(err)
+	}
+	action.Poll = poll
+	return validate.Success()
+}
+
+func getPostPoll(ctx context.Context, number, pollID int) (*entity.Poll, error) {
+	getPost := &query.GetPostByNumber{Number: number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return nil, err
+	}
+
+	getPoll := &query.GetPollByID{Post: getPost.Result, PollID: pollID}
+	if err := bus.Dispatch(ctx, getPoll); err != nil {
+		return nil, err
+	}
+	return getPoll.Result, nil
+}
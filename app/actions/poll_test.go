@@ -0,0 +1,89 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+func TestCreateNewPoll_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	past := time.Now().Add(-1 * time.Hour)
+	testCases := []struct {
+		field  string
+		action *actions.CreateNewPoll
+	}{
+		{"question", &actions.CreateNewPoll{Question: "", Options: []string{"A", "B"}}},
+		{"question", &actions.CreateNewPoll{Question: rand.String(201), Options: []string{"A", "B"}}},
+		{"options", &actions.CreateNewPoll{Question: "Which one?", Options: []string{"A"}}},
+		{"options", &actions.CreateNewPoll{Question: "Which one?", Options: []string{"A", " a "}}},
+		{"options", &actions.CreateNewPoll{Question: "Which one?", Options: []string{"A", ""}}},
+		{"options", &actions.CreateNewPoll{Question: "Which one?", Options: []string{"A", rand.String(101)}}},
+		{"resultsVisibility", &actions.CreateNewPoll{Question: "Which one?", Options: []string{"A", "B"}, ResultsVisibility: 9}},
+		{"closesAt", &actions.CreateNewPoll{Question: "Which one?", Options: []string{"A", "B"}, ClosesAt: &past}},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), nil)
+		ExpectFailed(result, testCase.field)
+	}
+}
+
+func TestCreateNewPoll_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	action := &actions.CreateNewPoll{Number: 1, Question: " Which platform do you use? ", Options: []string{"Windows ", "macOS", "Linux"}}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Question).Equals("Which platform do you use?")
+	Expect(action.Options).Equals([]string{"Windows", "macOS", "Linux"})
+	Expect(action.ResultsVisibility).Equals(enum.PollResultsPublic)
+	Expect(action.Post.ID).Equals(1)
+}
+
+func TestVoteOnPoll(t *testing.T) {
+	RegisterT(t)
+
+	past := time.Now().Add(-1 * time.Hour)
+	polls := map[int]*entity.Poll{
+		1: {ID: 1, Options: []*entity.PollOption{{ID: 10}, {ID: 11}}},
+		2: {ID: 2, IsMultipleChoice: true, Options: []*entity.PollOption{{ID: 20}, {ID: 21}}},
+		3: {ID: 3, ClosesAt: &past, Options: []*entity.PollOption{{ID: 30}, {ID: 31}}},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPollByID) error {
+		q.Result = polls[q.PollID]
+		return nil
+	})
+
+	ExpectSuccess((&actions.VoteOnPoll{PollID: 1, OptionIDs: []int{10}}).Validate(context.Background(), nil))
+	ExpectSuccess((&actions.VoteOnPoll{PollID: 2, OptionIDs: []int{20, 21}}).Validate(context.Background(), nil))
+	ExpectFailed((&actions.VoteOnPoll{PollID: 1, OptionIDs: []int{10, 11}}).Validate(context.Background(), nil), "options")
+	ExpectFailed((&actions.VoteOnPoll{PollID: 1, OptionIDs: []int{20}}).Validate(context.Background(), nil), "options")
+	ExpectFailed((&actions.VoteOnPoll{PollID: 1, OptionIDs: []int{}}).Validate(context.Background(), nil), "options")
+	ExpectFailed((&actions.VoteOnPoll{PollID: 3, OptionIDs: []int{30}}).Validate(context.Background(), nil))
+	ExpectFailed((&actions.RemovePollVote{PollID: 3}).Validate(context.Background(), nil))
+}
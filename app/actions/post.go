@@ -2,6 +2,8 @@ package actions
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/getfider/fider/app/models/dto"
@@ -21,7 +23,10 @@ import (
 type CreateNewPost struct {
 	Title       string             `json:"title"`
 	Description string             `json:"description"`
+	TagSlug     string             `json:"tag" format:"lower"`
 	Attachments []*dto.ImageUpload `json:"attachments"`
+
+	Tag *entity.Tag
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -48,6 +53,28 @@ func (action *CreateNewPost) Validate(ctx context.Context, user *entity.User) *v
 		}
 	}
 
+	if action.TagSlug != "" {
+		getTag := &query.GetTagBySlug{Slug: action.TagSlug}
+		err := bus.Dispatch(ctx, getTag)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil || (!getTag.Result.IsPublic && (user == nil || !user.IsCollaborator())) {
+			result.AddFieldFailure("tag", propertyIsInvalid(ctx, "tag"))
+		} else {
+			action.Tag = getTag.Result
+		}
+	}
+
+	getTemplate := &query.GetPostTemplateForTag{Tag: action.Tag}
+	err := bus.Dispatch(ctx, getTemplate)
+	if err != nil && errors.Cause(err) != app.ErrNotFound {
+		return validate.Error(err)
+	} else if err == nil {
+		for _, section := range getTemplate.Result.MissingSections(action.Description) {
+			result.AddFieldFailure("description", i18n.T(ctx, "validation.custom.requiredsection", i18n.Params{"name": section}))
+		}
+	}
+
 	messages, err := validate.MultiImageUpload(ctx, nil, action.Attachments, validate.MultiImageUploadOpts{
 		MaxUploads:   3,
 		MaxKilobytes: 5120,
@@ -212,6 +239,212 @@ func (action *SetResponse) Validate(ctx context.Context, user *entity.User) *val
 	return result
 }
 
+// AddVote represents the action of a user voting on a post
+type AddVote struct {
+	Number     int                 `route:"number"`
+	Reason     string              `json:"reason"`
+	Importance enum.VoteImportance `json:"importance"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AddVote) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *AddVote) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	action.Reason = strings.TrimSpace(action.Reason)
+	if len(action.Reason) > 280 {
+		result.AddFieldFailure("reason", propertyMaxStringLen(ctx, "reason", 280))
+	}
+
+	if action.Importance != 0 && action.Importance.Name() == "unknown" {
+		result.AddFieldFailure("importance", propertyIsInvalid(ctx, "importance"))
+	}
+
+	return result
+}
+
+// SetPostScore represents the action of a staff member scoring a post for prioritization
+type SetPostScore struct {
+	Number     int      `route:"number"`
+	Reach      *float64 `json:"reach"`
+	Impact     *float64 `json:"impact"`
+	Confidence *float64 `json:"confidence"`
+	Effort     *float64 `json:"effort"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *SetPostScore) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *SetPostScore) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if action.Reach != nil && *action.Reach < 0 {
+		result.AddFieldFailure("reach", "Reach cannot be negative.")
+	}
+
+	if action.Impact != nil && *action.Impact < 0 {
+		result.AddFieldFailure("impact", "Impact cannot be negative.")
+	}
+
+	if action.Confidence != nil && (*action.Confidence < 0 || *action.Confidence > 100) {
+		result.AddFieldFailure("confidence", "Confidence must be between 0 and 100.")
+	}
+
+	if action.Effort != nil && *action.Effort <= 0 {
+		result.AddFieldFailure("effort", "Effort must be greater than 0.")
+	}
+
+	return result
+}
+
+var (
+	postTargetQuarterRegex = regexp.MustCompile(`^\d{4}-Q[1-4]$`)
+	postTargetMonthRegex   = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])$`)
+)
+
+// SetPostTarget represents the action of a staff member setting when a post is expected to be released
+// An empty kind removes the target of the post
+type SetPostTarget struct {
+	Number   int    `route:"number"`
+	Kind     string `json:"kind" format:"lower"`
+	Value    string `json:"value"`
+	IsPublic *bool  `json:"isPublic"`
+
+	Post   *entity.Post
+	Target *entity.PostTarget
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *SetPostTarget) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *SetPostTarget) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if action.Kind == "" {
+		action.Target = nil
+		return result
+	}
+
+	var kind enum.PostTargetKind
+	_ = kind.UnmarshalText([]byte(action.Kind))
+
+	value := strings.TrimSpace(action.Value)
+	switch kind {
+	case enum.PostTargetQuarter:
+		value = strings.ToUpper(value)
+		if !postTargetQuarterRegex.MatchString(value) {
+			result.AddFieldFailure("value", "Quarter must be formatted as YYYY-QN, such as 2026-Q3.")
+		}
+	case enum.PostTargetMonth:
+		if !postTargetMonthRegex.MatchString(value) {
+			result.AddFieldFailure("value", "Month must be formatted as YYYY-MM, such as 2026-09.")
+		}
+	case enum.PostTargetMilestone:
+		if value == "" {
+			result.AddFieldFailure("value", "Milestone is required.")
+		} else if len(value) > 100 {
+			result.AddFieldFailure("value", "Milestone must have less than 100 characters.")
+		}
+	default:
+		result.AddFieldFailure("kind", "Kind must be one of quarter, month or milestone.")
+	}
+
+	action.Target = &entity.PostTarget{
+		Kind:     kind,
+		Value:    value,
+		IsPublic: action.IsPublic == nil || *action.IsPublic,
+	}
+	return result
+}
+
+// ReorderPost represents the action of a staff member moving a post right before or after another post of the same status
+type ReorderPost struct {
+	Number int `route:"number"`
+	Before int `json:"before"`
+	After  int `json:"after"`
+
+	Post      *entity.Post
+	Reference *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *ReorderPost) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *ReorderPost) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if (action.Before == 0) == (action.After == 0) {
+		return validate.Failed("Either before or after is required.")
+	}
+
+	field, number := "before", action.Before
+	if action.After != 0 {
+		field, number = "after", action.After
+	}
+
+	if number == action.Number {
+		result.AddFieldFailure(field, "Post cannot be moved relative to itself.")
+		return result
+	}
+
+	getReference := &query.GetPostByNumber{Number: number}
+	err := bus.Dispatch(ctx, getReference)
+	if errors.Cause(err) == app.ErrNotFound {
+		result.AddFieldFailure(field, "Post not found.")
+		return result
+	} else if err != nil {
+		return validate.Error(err)
+	}
+	action.Reference = getReference.Result
+
+	if action.Reference.Status != action.Post.Status {
+		result.AddFieldFailure(field, "Posts can only be reordered within the same status.")
+	}
+
+	return result
+}
+
 // DeletePost represents the action of an administrator deleting an existing Post
 type DeletePost struct {
 	Number int    `route:"number"`
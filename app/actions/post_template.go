@@ -0,0 +1,101 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateEditPostTemplate is used to create a new post template or edit existing
+type CreateEditPostTemplate struct {
+	ID               int      `route:"id"`
+	Name             string   `json:"name"`
+	Content          string   `json:"content"`
+	RequiredSections []string `json:"requiredSections"`
+	IsDefault        bool     `json:"isDefault"`
+	TagSlugs         []string `json:"tags" format:"lower"`
+
+	Template *entity.PostTemplate
+	Tags     []*entity.Tag
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditPostTemplate) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditPostTemplate) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.ID > 0 {
+		getTemplate := &query.GetPostTemplateByID{TemplateID: action.ID}
+		if err := bus.Dispatch(ctx, getTemplate); err != nil {
+			return validate.Error(err)
+		}
+		action.Template = getTemplate.Result
+	}
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 60 {
+		result.AddFieldFailure("name", "Name must have less than 60 characters.")
+	}
+
+	if action.Content == "" {
+		result.AddFieldFailure("content", "Content is required.")
+	} else if len(action.Content) > 10_000 {
+		result.AddFieldFailure("content", "Content must have less than 10 000 characters.")
+	}
+
+	template := &entity.PostTemplate{Content: action.Content}
+	for _, section := range action.RequiredSections {
+		if !template.HasSection(section) {
+			result.AddFieldFailure("requiredSections", fmt.Sprintf("Section '%s' must be a heading of the template content.", section))
+		}
+	}
+
+	action.Tags = make([]*entity.Tag, 0, len(action.TagSlugs))
+	for _, slug := range action.TagSlugs {
+		getTag := &query.GetTagBySlug{Slug: slug}
+		err := bus.Dispatch(ctx, getTag)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("tags", fmt.Sprintf("Tag '%s' does not exist.", slug))
+			continue
+		}
+		action.Tags = append(action.Tags, getTag.Result)
+	}
+
+	return result
+}
+
+// DeletePostTemplate is used to delete an existing post template
+type DeletePostTemplate struct {
+	ID int `route:"id"`
+
+	Template *entity.PostTemplate
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeletePostTemplate) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeletePostTemplate) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getTemplate := &query.GetPostTemplateByID{TemplateID: action.ID}
+	if err := bus.Dispatch(ctx, getTemplate); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Template = getTemplate.Result
+	return validate.Success()
+}
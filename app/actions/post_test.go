@@ -12,6 +12,7 @@ import (
 	"github.com/getfider/fider/app/actions"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/rand"
 )
 
 func TestCreateNewPost_InvalidPostTitles(t *testing.T) {
@@ -24,6 +25,9 @@ func TestCreateNewPost_InvalidPostTitles(t *testing.T) {
 		}
 		return app.ErrNotFound
 	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		return app.ErrNotFound
+	})
 
 	for _, title := range []string{
 		"me",
@@ -45,6 +49,9 @@ func TestCreateNewPost_ValidPostTitles(t *testing.T) {
 	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
 		return app.ErrNotFound
 	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		return app.ErrNotFound
+	})
 
 	for _, title := range []string{
 		"this is my new post",
@@ -56,6 +63,75 @@ func TestCreateNewPost_ValidPostTitles(t *testing.T) {
 	}
 }
 
+func TestCreateNewPost_RequiredTemplateSections(t *testing.T) {
+	RegisterT(t)
+
+	bug := &entity.Tag{ID: 1, Slug: "bug", Name: "Bug", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == bug.Slug {
+			q.Result = bug
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		if q.Tag == bug {
+			q.Result = &entity.PostTemplate{
+				Content:          "## Steps to reproduce\n\n## Expected behavior",
+				RequiredSections: []string{"Steps to reproduce"},
+			}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.CreateNewPost{Title: "the app crashes on login", TagSlug: "bug", Description: "## Steps to reproduce\n\n## Expected behavior\nIt works"}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "description")
+
+	action = &actions.CreateNewPost{Title: "the app crashes on login", TagSlug: "bug", Description: "## Steps to reproduce\nClick login"}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Tag).Equals(bug)
+
+	action = &actions.CreateNewPost{Title: "the app crashes on login", Description: "It just crashes"}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Tag).IsNil()
+}
+
+func TestCreateNewPost_InvalidTag(t *testing.T) {
+	RegisterT(t)
+
+	staffOnly := &entity.Tag{ID: 2, Slug: "staff-only", Name: "Staff Only", IsPublic: false}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == staffOnly.Slug {
+			q.Result = staffOnly
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		return app.ErrNotFound
+	})
+
+	for _, slug := range []string{"unknown", "staff-only"} {
+		action := &actions.CreateNewPost{Title: "this is my new post", TagSlug: slug}
+		result := action.Validate(context.Background(), &entity.User{Role: enum.RoleVisitor})
+		ExpectFailed(result, "tag")
+	}
+
+	action := &actions.CreateNewPost{Title: "this is my new post", TagSlug: "staff-only"}
+	result := action.Validate(context.Background(), &entity.User{Role: enum.RoleCollaborator})
+	ExpectSuccess(result)
+}
+
 func TestSetResponse_InvalidStatus(t *testing.T) {
 	RegisterT(t)
 
@@ -133,3 +209,111 @@ func TestDeleteComment(t *testing.T) {
 	authorized = action.IsAuthorized(context.Background(), administrator)
 	Expect(authorized).IsTrue()
 }
+
+func TestSetPostScore_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	negative, zero, tooHigh := -1.0, 0.0, 101.0
+	ExpectFailed((&actions.SetPostScore{Number: 1, Reach: &negative}).Validate(context.Background(), nil), "reach")
+	ExpectFailed((&actions.SetPostScore{Number: 1, Impact: &negative}).Validate(context.Background(), nil), "impact")
+	ExpectFailed((&actions.SetPostScore{Number: 1, Confidence: &tooHigh}).Validate(context.Background(), nil), "confidence")
+	ExpectFailed((&actions.SetPostScore{Number: 1, Effort: &zero}).Validate(context.Background(), nil), "effort")
+	ExpectSuccess((&actions.SetPostScore{Number: 1}).Validate(context.Background(), nil))
+}
+
+func TestSetPostTarget_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	ExpectFailed((&actions.SetPostTarget{Number: 1, Kind: "week", Value: "2026-W12"}).Validate(context.Background(), nil), "kind")
+	ExpectFailed((&actions.SetPostTarget{Number: 1, Kind: "quarter", Value: "Q3 2026"}).Validate(context.Background(), nil), "value")
+	ExpectFailed((&actions.SetPostTarget{Number: 1, Kind: "month", Value: "2026-13"}).Validate(context.Background(), nil), "value")
+	ExpectFailed((&actions.SetPostTarget{Number: 1, Kind: "milestone", Value: " "}).Validate(context.Background(), nil), "value")
+	ExpectFailed((&actions.SetPostTarget{Number: 1, Kind: "milestone", Value: rand.String(101)}).Validate(context.Background(), nil), "value")
+}
+
+func TestSetPostTarget_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	action := &actions.SetPostTarget{Number: 1, Kind: "quarter", Value: " 2026-q3 "}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.Target).Equals(&entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q3", IsPublic: true})
+
+	isPublic := false
+	action = &actions.SetPostTarget{Number: 1, Kind: "milestone", Value: "v2.0", IsPublic: &isPublic}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.Target).Equals(&entity.PostTarget{Kind: enum.PostTargetMilestone, Value: "v2.0", IsPublic: false})
+
+	action = &actions.SetPostTarget{Number: 1}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.Target).IsNil()
+	Expect(action.Post.ID).Equals(1)
+}
+
+func TestReorderPost_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		if q.Number == 9 {
+			return app.ErrNotFound
+		}
+		status := enum.PostPlanned
+		if q.Number == 3 {
+			status = enum.PostStarted
+		}
+		q.Result = &entity.Post{ID: q.Number, Number: q.Number, Status: status}
+		return nil
+	})
+
+	ExpectFailed((&actions.ReorderPost{Number: 1}).Validate(context.Background(), nil))
+	ExpectFailed((&actions.ReorderPost{Number: 1, Before: 2, After: 2}).Validate(context.Background(), nil))
+	ExpectFailed((&actions.ReorderPost{Number: 1, Before: 1}).Validate(context.Background(), nil), "before")
+	ExpectFailed((&actions.ReorderPost{Number: 1, After: 9}).Validate(context.Background(), nil), "after")
+	ExpectFailed((&actions.ReorderPost{Number: 1, After: 3}).Validate(context.Background(), nil), "after")
+}
+
+func TestReorderPost_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: q.Number, Number: q.Number, Status: enum.PostPlanned}
+		return nil
+	})
+
+	action := &actions.ReorderPost{Number: 1, Before: 2}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.Post.Number).Equals(1)
+	Expect(action.Reference.Number).Equals(2)
+
+	action = &actions.ReorderPost{Number: 1, After: 3}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.Reference.Number).Equals(3)
+}
+
+func TestAddVote_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	ExpectFailed((&actions.AddVote{Number: 1, Reason: rand.String(281)}).Validate(context.Background(), nil), "reason")
+	ExpectFailed((&actions.AddVote{Number: 1, Importance: enum.VoteImportance(9)}).Validate(context.Background(), nil), "importance")
+	ExpectSuccess((&actions.AddVote{Number: 1, Reason: "Needed for compliance", Importance: enum.VoteImportanceImportant}).Validate(context.Background(), nil))
+	ExpectSuccess((&actions.AddVote{Number: 1}).Validate(context.Background(), nil))
+}
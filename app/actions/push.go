@@ -0,0 +1,64 @@
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/webpush"
+)
+
+// SubscribeToPush is used to register a browser to receive push notifications
+type SubscribeToPush struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *SubscribeToPush) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *SubscribeToPush) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	action.Endpoint = strings.TrimSpace(action.Endpoint)
+	if action.Endpoint == "" {
+		result.AddFieldFailure("endpoint", propertyIsRequired(ctx, "endpoint"))
+	} else if err := webpush.ValidateEndpoint(action.Endpoint); err != nil {
+		result.AddFieldFailure("endpoint", propertyIsInvalid(ctx, "endpoint"))
+	}
+
+	if err := webpush.ValidateKeys(action.Keys.P256dh, action.Keys.Auth); err != nil {
+		result.AddFieldFailure("keys", propertyIsInvalid(ctx, "keys"))
+	}
+
+	return result
+}
+
+// UnsubscribeFromPush is used to stop sending push notifications to a browser
+type UnsubscribeFromPush struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UnsubscribeFromPush) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *UnsubscribeFromPush) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	action.Endpoint = strings.TrimSpace(action.Endpoint)
+	if action.Endpoint == "" {
+		result.AddFieldFailure("endpoint", propertyIsRequired(ctx, "endpoint"))
+	}
+
+	return result
+}
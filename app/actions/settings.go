@@ -46,20 +46,36 @@ func (action *UpdateUserSettings) Validate(ctx context.Context, user *entity.Use
 	}
 
 	action.Avatar.BlobKey = user.AvatarBlobKey
-	messages, err := validate.ImageUpload(ctx, action.Avatar, validate.ImageUploadOpts{
-		IsRequired:   action.AvatarType == enum.AvatarTypeCustom,
+	messages, err := validateAvatar(ctx, action.Avatar, action.AvatarType == enum.AvatarTypeCustom)
+	if err != nil {
+		return validate.Error(err)
+	}
+	result.AddFieldFailure("avatar", messages...)
+
+	validateUserSettings(ctx, result, action.Settings)
+	return result
+}
+
+func validateAvatar(ctx context.Context, avatar *dto.ImageUpload, isRequired bool) ([]string, error) {
+	return validate.ImageUpload(ctx, avatar, validate.ImageUploadOpts{
+		IsRequired:   isRequired,
 		MinHeight:    50,
 		MinWidth:     50,
 		ExactRatio:   true,
 		MaxKilobytes: 100,
 	})
-	if err != nil {
-		return validate.Error(err)
-	}
-	result.AddFieldFailure("avatar", messages...)
+}
+
+func validateUserSettings(ctx context.Context, result *validate.Result, settings map[string]string) {
+	if settings != nil {
+		for k, v := range settings {
+			if k == enum.NotificationRetentionSettingsKeyName {
+				if !enum.IsValidNotificationRetention(v) {
+					result.AddFieldFailure("settings", i18n.T(ctx, "validation.invalidvalue", i18n.Params{"name": k}, i18n.Params{"value": v}))
+				}
+				continue
+			}
 
-	if action.Settings != nil {
-		for k, v := range action.Settings {
 			ok := false
 			for _, e := range enum.AllNotificationEvents {
 				if e.UserSettingsKeyName == k {
@@ -74,6 +90,57 @@ func (action *UpdateUserSettings) Validate(ctx context.Context, user *entity.Use
 			}
 		}
 	}
+}
+
+// UpdateCurrentUserAvatar happens when users upload a new avatar
+type UpdateCurrentUserAvatar struct {
+	Avatar *dto.ImageUpload `json:"avatar"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateCurrentUserAvatar) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *UpdateCurrentUserAvatar) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Avatar == nil || action.Avatar.Upload == nil {
+		result.AddFieldFailure("avatar", propertyIsRequired(ctx, "avatar"))
+		return result
+	}
+
+	action.Avatar.BlobKey = user.AvatarBlobKey
+	action.Avatar.Remove = false
+	messages, err := validateAvatar(ctx, action.Avatar, true)
+	if err != nil {
+		return validate.Error(err)
+	}
+	result.AddFieldFailure("avatar", messages...)
+
+	return result
+}
+
+// UpdateCurrentUserNotificationSettings happens when users change how they're notified
+type UpdateCurrentUserNotificationSettings struct {
+	Settings map[string]string `json:"settings"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateCurrentUserNotificationSettings) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *UpdateCurrentUserNotificationSettings) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if len(action.Settings) == 0 {
+		result.AddFieldFailure("settings", propertyIsRequired(ctx, "settings"))
+		return result
+	}
 
+	validateUserSettings(ctx, result, action.Settings)
 	return result
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	. "github.com/getfider/fider/app/pkg/assert"
@@ -49,7 +50,10 @@ func TestInvalidSettings(t *testing.T) {
 			"bad_name": "3",
 		},
 		{
-			enum.NotificationEventNewComment.UserSettingsKeyName: "4",
+			enum.NotificationEventNewComment.UserSettingsKeyName: "8",
+		},
+		{
+			enum.NotificationEventNewComment.UserSettingsKeyName: "03",
 		},
 	} {
 		action := actions.NewUpdateUserSettings()
@@ -73,6 +77,9 @@ func TestValidSettings(t *testing.T) {
 		{
 			enum.NotificationEventNewComment.UserSettingsKeyName: enum.NotificationEventNewComment.DefaultSettingValue,
 		},
+		{
+			enum.NotificationEventNewComment.UserSettingsKeyName: "7",
+		},
 	} {
 		action := actions.NewUpdateUserSettings()
 		action.Name = "John Snow"
@@ -87,3 +94,37 @@ func TestValidSettings(t *testing.T) {
 		Expect(action.Avatar.BlobKey).Equals("jon.png")
 	}
 }
+
+func TestUpdateCurrentUserAvatar_RequiresUpload(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.UpdateCurrentUserAvatar{}
+	result := action.Validate(context.Background(), &entity.User{})
+	ExpectFailed(result, "avatar")
+
+	action = &actions.UpdateCurrentUserAvatar{Avatar: &dto.ImageUpload{Remove: true}}
+	result = action.Validate(context.Background(), &entity.User{})
+	ExpectFailed(result, "avatar")
+}
+
+func TestUpdateCurrentUserNotificationSettings(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.UpdateCurrentUserNotificationSettings{}
+	result := action.Validate(context.Background(), &entity.User{})
+	ExpectFailed(result, "settings")
+
+	action = &actions.UpdateCurrentUserNotificationSettings{
+		Settings: map[string]string{"bad_name": "1"},
+	}
+	result = action.Validate(context.Background(), &entity.User{})
+	ExpectFailed(result, "settings")
+
+	action = &actions.UpdateCurrentUserNotificationSettings{
+		Settings: map[string]string{
+			enum.NotificationEventNewComment.UserSettingsKeyName: "7",
+		},
+	}
+	result = action.Validate(context.Background(), &entity.User{})
+	ExpectSuccess(result)
+}
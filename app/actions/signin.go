@@ -9,6 +9,7 @@ import (
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
 	"github.com/getfider/fider/app/pkg/validate"
 )
 
@@ -51,9 +52,28 @@ func (action *SignInByEmail) Validate(ctx context.Context, user *entity.User) *v
 	messages := validate.Email(ctx, action.Email)
 	result.AddFieldFailure("email", messages...)
 
+	if len(messages) == 0 && !isEmailAllowed(ctx, action.Email) {
+		result.AddFieldFailure("email", i18n.T(ctx, "validation.custom.emailnotallowed", i18n.Params{"email": action.Email}))
+	}
+
 	return result
 }
 
+// isEmailAllowed returns true if given email satisfies the email rules of current tenant.
+// Administrators are always allowed so that they can't lock themselves out
+func isEmailAllowed(ctx context.Context, email string) bool {
+	tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if !ok || tenant.EmailRules.Allows(email) {
+		return true
+	}
+
+	getUser := &query.GetUserByEmail{Email: email}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		return false
+	}
+	return getUser.Result.IsAdministrator()
+}
+
 //GetEmail returns the email being verified
 func (action *SignInByEmail) GetEmail() string {
 	return action.Email
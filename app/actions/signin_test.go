@@ -4,8 +4,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
 )
 
 func TestSignInByEmail_EmptyEmail(t *testing.T) {
@@ -52,3 +57,29 @@ func TestCompleteProfile_LongName(t *testing.T) {
 	result := action.Validate(context.Background(), nil)
 	ExpectFailed(result, "name", "key")
 }
+
+func TestSignInByEmail_BlockedByEmailRules(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
+		if q.Email == "jon.snow@mailinator.com" {
+			q.Result = &entity.User{Email: q.Email, Role: enum.RoleAdministrator}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{
+		EmailRules: entity.TenantEmailRules{BlockDisposable: true},
+	})
+
+	action := actions.NewSignInByEmail()
+	action.Email = "arya.stark@mailinator.com"
+	ExpectFailed(action.Validate(ctx, nil), "email")
+
+	action.Email = "jon.snow@mailinator.com"
+	ExpectSuccess(action.Validate(ctx, nil))
+
+	action.Email = "arya.stark@got.com"
+	ExpectSuccess(action.Validate(ctx, nil))
+}
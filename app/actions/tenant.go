@@ -2,6 +2,11 @@ package actions
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 
@@ -9,6 +14,7 @@ import (
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/disposable"
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/i18n"
 	"github.com/getfider/fider/app/pkg/jwt"
@@ -69,6 +75,12 @@ func (action *CreateTenant) Validate(ctx context.Context, user *entity.User) *va
 
 	if env.IsSingleHostMode() {
 		action.Subdomain = "default"
+	} else if env.Config.SignUp.BlockDisposableEmails {
+		if action.UserClaims != nil && disposable.IsDisposableEmail(action.UserClaims.OAuthEmail) {
+			result.AddFieldFailure("token", "Disposable email addresses are not allowed.")
+		} else if action.Email != "" && disposable.IsDisposableEmail(action.Email) {
+			result.AddFieldFailure("email", "Disposable email addresses are not allowed.")
+		}
 	}
 
 	if action.TenantName == "" {
@@ -177,7 +189,8 @@ func (action *UpdateTenantSettings) Validate(ctx context.Context, user *entity.U
 
 //UpdateTenantAdvancedSettings is the input model used to update tenant advanced settings
 type UpdateTenantAdvancedSettings struct {
-	CustomCSS string `json:"customCSS"`
+	CustomCSS             string                     `json:"customCSS"`
+	PrioritizationFormula enum.PrioritizationFormula `json:"prioritizationFormula"`
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -187,7 +200,19 @@ func (action *UpdateTenantAdvancedSettings) IsAuthorized(ctx context.Context, us
 
 // Validate if current model is valid
 func (action *UpdateTenantAdvancedSettings) Validate(ctx context.Context, user *entity.User) *validate.Result {
-	return validate.Success()
+	result := validate.Success()
+
+	// A missing formula keeps the current one, so that saving only the custom CSS doesn't reset it
+	if action.PrioritizationFormula == 0 {
+		action.PrioritizationFormula = enum.PrioritizationRICE
+		if tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); ok && tenant.PrioritizationFormula != 0 {
+			action.PrioritizationFormula = tenant.PrioritizationFormula
+		}
+	} else if action.PrioritizationFormula.Name() == "unknown" {
+		result.AddFieldFailure("prioritizationFormula", "Prioritization formula is invalid.")
+	}
+
+	return result
 }
 
 //UpdateTenantPrivacy is the input model used to update tenant privacy settings
@@ -219,6 +244,10 @@ func (action *UpdateTenantEmailAuthAllowed) IsAuthorized(ctx context.Context, us
 func (action *UpdateTenantEmailAuthAllowed) Validate(ctx context.Context, user *entity.User) *validate.Result {
 	result := validate.Success()
 
+	if action.IsEmailAuthAllowed {
+		return result
+	}
+
 	activeProviders := &query.ListActiveOAuthProviders{}
 	if err := bus.Dispatch(ctx, activeProviders); err != nil {
 		return validate.Failed("Cannot retrieve OAuth providers")
@@ -230,3 +259,55 @@ func (action *UpdateTenantEmailAuthAllowed) Validate(ctx context.Context, user *
 
 	return result
 }
+
+var emailDomainRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// UpdateTenantEmailRules is the input model used to update tenant email rules
+type UpdateTenantEmailRules struct {
+	AllowedDomains  []string `json:"allowedDomains" format:"lower"`
+	BlockedDomains  []string `json:"blockedDomains" format:"lower"`
+	BlockDisposable bool     `json:"blockDisposable"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateTenantEmailRules) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.Role == enum.RoleAdministrator
+}
+
+// Validate if current model is valid
+func (action *UpdateTenantEmailRules) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	action.AllowedDomains = validateEmailDomains(result, "allowedDomains", action.AllowedDomains)
+	action.BlockedDomains = validateEmailDomains(result, "blockedDomains", action.BlockedDomains)
+
+	return result
+}
+
+// Rules returns the email rules described by this action
+func (action *UpdateTenantEmailRules) Rules() entity.TenantEmailRules {
+	return entity.TenantEmailRules{
+		AllowedDomains:  action.AllowedDomains,
+		BlockedDomains:  action.BlockedDomains,
+		BlockDisposable: action.BlockDisposable,
+	}
+}
+
+func validateEmailDomains(result *validate.Result, field string, domains []string) []string {
+	if len(domains) > 100 {
+		result.AddFieldFailure(field, "A maximum of 100 domains is allowed.")
+	}
+
+	cleaned := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.TrimSpace(domain), "@")
+		if domain == "" || slices.Contains(cleaned, domain) {
+			continue
+		}
+		if !emailDomainRegex.MatchString(domain) {
+			result.AddFieldFailure(field, fmt.Sprintf("'%s' is not a valid domain.", domain))
+		}
+		cleaned = append(cleaned, domain)
+	}
+	return cleaned
+}
@@ -0,0 +1,278 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/tenantconfig"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/gosimple/slug"
+)
+
+// ApplyTenantConfig is used to apply a declarative configuration to current tenant
+// Every item is validated with the same rules used when it's changed individually
+type ApplyTenantConfig struct {
+	Config *tenantconfig.Config
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *ApplyTenantConfig) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *ApplyTenantConfig) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Config == nil {
+		return validate.Failed("Configuration is required.")
+	}
+
+	steps := []func(context.Context, *entity.User, *validate.Result) error{
+		action.validateSettings,
+		action.validateTags,
+		action.validateWebhooks,
+		action.validateOAuthProviders,
+		action.validateSignInMethods,
+	}
+	for _, step := range steps {
+		if err := step(ctx, user, result); err != nil {
+			return validate.Error(err)
+		}
+	}
+
+	return result
+}
+
+func (action *ApplyTenantConfig) validateSettings(ctx context.Context, user *entity.User, result *validate.Result) error {
+	settings := action.Config.Settings
+	if settings == nil {
+		return nil
+	}
+
+	general := &UpdateTenantSettings{
+		Logo:           &dto.ImageUpload{},
+		Title:          settings.Title,
+		Invitation:     settings.Invitation,
+		WelcomeMessage: settings.WelcomeMessage,
+		Locale:         settings.Locale,
+	}
+	if err := addNestedFailures(result, "settings.", general.Validate(ctx, user)); err != nil {
+		return err
+	}
+
+	advanced := &UpdateTenantAdvancedSettings{
+		CustomCSS:             settings.CustomCSS,
+		PrioritizationFormula: settings.PrioritizationFormula,
+	}
+	if err := addNestedFailures(result, "settings.", advanced.Validate(ctx, user)); err != nil {
+		return err
+	}
+	settings.PrioritizationFormula = advanced.PrioritizationFormula
+
+	rules := &UpdateTenantEmailRules{
+		AllowedDomains:  settings.EmailRules.AllowedDomains,
+		BlockedDomains:  settings.EmailRules.BlockedDomains,
+		BlockDisposable: settings.EmailRules.BlockDisposable,
+	}
+	if err := addNestedFailures(result, "settings.emailRules.", rules.Validate(ctx, user)); err != nil {
+		return err
+	}
+	settings.EmailRules.AllowedDomains = rules.AllowedDomains
+	settings.EmailRules.BlockedDomains = rules.BlockedDomains
+
+	return nil
+}
+
+func (action *ApplyTenantConfig) validateTags(ctx context.Context, user *entity.User, result *validate.Result) error {
+	if action.Config.Tags == nil {
+		return nil
+	}
+
+	getTags := &query.GetAllTags{}
+	if err := bus.Dispatch(ctx, getTags); err != nil {
+		return err
+	}
+
+	seen := make([]string, 0, len(action.Config.Tags))
+	for i, tag := range action.Config.Tags {
+		prefix := fmt.Sprintf("tags[%d]", i)
+		if tag == nil {
+			result.AddFieldFailure(prefix, "Tag is required.")
+			continue
+		}
+
+		tagSlug := slug.Make(tag.Name)
+		if tag.Name != "" && slices.Contains(seen, tagSlug) {
+			result.AddFieldFailure(prefix+".name", fmt.Sprintf("Tag '%s' is defined more than once.", tag.Name))
+			continue
+		}
+		seen = append(seen, tagSlug)
+
+		createEdit := &CreateEditTag{Name: tag.Name, Color: tag.Color, IsPublic: tag.IsPublic}
+		if slices.ContainsFunc(getTags.Result, func(t *entity.Tag) bool { return t.Slug == tagSlug }) {
+			createEdit.Slug = tagSlug
+		}
+		if err := addNestedFailures(result, prefix+".", createEdit.Validate(ctx, user)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (action *ApplyTenantConfig) validateWebhooks(ctx context.Context, user *entity.User, result *validate.Result) error {
+	if len(action.Config.Webhooks) == 0 {
+		return nil
+	}
+
+	listWebhooks := &query.ListAllWebhooks{}
+	if err := bus.Dispatch(ctx, listWebhooks); err != nil {
+		return err
+	}
+
+	seen := make([]string, 0, len(action.Config.Webhooks))
+	for i, webhook := range action.Config.Webhooks {
+		prefix := fmt.Sprintf("webhooks[%d]", i)
+		if webhook == nil {
+			result.AddFieldFailure(prefix, "Webhook is required.")
+			continue
+		}
+
+		if webhook.Name != "" && slices.Contains(seen, webhook.Name) {
+			result.AddFieldFailure(prefix+".name", fmt.Sprintf("Webhook '%s' is defined more than once.", webhook.Name))
+			continue
+		}
+		seen = append(seen, webhook.Name)
+
+		// Header values are not exported, so the current ones are used when they're empty
+		headers := webhook.HTTPHeaders
+		if idx := slices.IndexFunc(listWebhooks.Result, func(w *entity.Webhook) bool { return w.Name == webhook.Name }); idx >= 0 {
+			headers = tenantconfig.WebhookHeaders(webhook.HTTPHeaders, listWebhooks.Result[idx].HttpHeaders)
+		}
+
+		createEdit := &CreateEditWebhook{
+			Name:        webhook.Name,
+			Type:        webhook.Type,
+			Status:      webhook.Status,
+			Format:      webhook.Format,
+			Url:         webhook.URL,
+			Content:     webhook.Content,
+			HttpMethod:  webhook.HTTPMethod,
+			HttpHeaders: headers,
+			Conditions:  webhook.Conditions.ToModel(),
+		}
+		if err := addNestedFailures(result, prefix+".", createEdit.Validate(ctx, user)); err != nil {
+			return err
+		}
+		webhook.Format = createEdit.Format
+	}
+
+	return nil
+}
+
+func (action *ApplyTenantConfig) validateOAuthProviders(ctx context.Context, user *entity.User, result *validate.Result) error {
+	if action.Config.OAuthProviders == nil {
+		return nil
+	}
+
+	listConfigs := &query.ListCustomOAuthConfig{}
+	if err := bus.Dispatch(ctx, listConfigs); err != nil {
+		return err
+	}
+
+	seen := make([]string, 0, len(action.Config.OAuthProviders))
+	for i, provider := range action.Config.OAuthProviders {
+		prefix := fmt.Sprintf("oauthProviders[%d]", i)
+		if provider == nil {
+			result.AddFieldFailure(prefix, "OAuth provider is required.")
+			continue
+		}
+
+		if provider.DisplayName != "" && slices.Contains(seen, provider.DisplayName) {
+			result.AddFieldFailure(prefix+".displayName", fmt.Sprintf("OAuth provider '%s' is defined more than once.", provider.DisplayName))
+			continue
+		}
+		seen = append(seen, provider.DisplayName)
+
+		// Disabling a provider is checked against the final configuration on validateSignInMethods
+		createEdit := NewCreateEditOAuthConfig()
+		createEdit.Status = enum.OAuthConfigEnabled
+		createEdit.DisplayName = provider.DisplayName
+		createEdit.ClientID = provider.ClientID
+		createEdit.ClientSecret = provider.ClientSecret
+		createEdit.AuthorizeURL = provider.AuthorizeURL
+		createEdit.TokenURL = provider.TokenURL
+		createEdit.ProfileURL = provider.ProfileURL
+		createEdit.Scope = provider.Scope
+		createEdit.IsTrusted = provider.IsTrusted
+		createEdit.JSONUserIDPath = provider.JSONUserIDPath
+		createEdit.JSONUserNamePath = provider.JSONUserNamePath
+		createEdit.JSONUserEmailPath = provider.JSONUserEmailPath
+
+		idx := slices.IndexFunc(listConfigs.Result, func(c *entity.OAuthConfig) bool { return c.DisplayName == provider.DisplayName })
+		if idx >= 0 {
+			createEdit.Provider = listConfigs.Result[idx].Provider
+		}
+		if err := addNestedFailures(result, prefix+".", createEdit.Validate(ctx, user)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateSignInMethods ensures that the tenant is left with at least one way to sign in
+func (action *ApplyTenantConfig) validateSignInMethods(ctx context.Context, user *entity.User, result *validate.Result) error {
+	isEmailAuthAllowed := ctx.Value(app.TenantCtxKey).(*entity.Tenant).IsEmailAuthAllowed
+	if action.Config.Settings != nil {
+		isEmailAuthAllowed = action.Config.Settings.IsEmailAuthAllowed
+	}
+	if isEmailAuthAllowed {
+		return nil
+	}
+
+	activeProviders := &query.ListActiveOAuthProviders{}
+	if err := bus.Dispatch(ctx, activeProviders); err != nil {
+		return err
+	}
+
+	// Custom providers are replaced by the ones on the configuration, when they are given
+	for _, provider := range activeProviders.Result {
+		if !provider.IsCustomProvider || action.Config.OAuthProviders == nil {
+			return nil
+		}
+	}
+
+	for _, provider := range action.Config.OAuthProviders {
+		if provider != nil && provider.Enabled {
+			return nil
+		}
+	}
+
+	result.AddFieldFailure("settings.isEmailAuthAllowed", "You cannot disable email authentication without any other provider enabled.")
+	return nil
+}
+
+// addNestedFailures copies the failures of a nested validation, prefixing their fields
+func addNestedFailures(result *validate.Result, prefix string, nested *validate.Result) error {
+	if nested.Err != nil {
+		return nested.Err
+	}
+
+	for _, item := range nested.Errors {
+		field := prefix + item.Field
+		if item.Field == "" {
+			field = prefix[:len(prefix)-1]
+		}
+		result.AddFieldFailure(field, item.Message)
+	}
+	return nil
+}
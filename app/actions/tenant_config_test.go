@@ -0,0 +1,167 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/tenantconfig"
+)
+
+func setupApplyTenantConfig(activeProviders ...*dto.OAuthProviderOption) context.Context {
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		q.Result = []*entity.Tag{{ID: 1, Name: "Bug", Slug: "bug", Color: "FF0000"}}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == "bug" {
+			q.Result = &entity.Tag{ID: 1, Name: "Bug", Slug: "bug", Color: "FF0000"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListAllWebhooks) error {
+		q.Result = []*entity.Webhook{
+			{ID: 5, Name: "Slack", Type: enum.WebhookNewPost, Status: enum.WebhookEnabled, Url: "https://hooks.slack.com/1", HttpMethod: "POST", HttpHeaders: entity.HttpHeaders{"Authorization": "Bearer s3cr3t"}},
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListCustomOAuthConfig) error {
+		q.Result = []*entity.OAuthConfig{}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveOAuthProviders) error {
+		q.Result = activeProviders
+		return nil
+	})
+
+	tenant := &entity.Tenant{ID: 1, IsEmailAuthAllowed: true}
+	return context.WithValue(context.Background(), app.TenantCtxKey, tenant)
+}
+
+func validTenantConfigSettings() *tenantconfig.Settings {
+	return &tenantconfig.Settings{
+		Title:              "Feedback",
+		Locale:             "en",
+		IsEmailAuthAllowed: true,
+		EmailRules: tenantconfig.EmailRules{
+			AllowedDomains: []string{" @example.com", "example.com"},
+		},
+	}
+}
+
+func TestApplyTenantConfig_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.ApplyTenantConfig{}
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleAdministrator})).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleCollaborator})).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+}
+
+func TestApplyTenantConfig_Valid(t *testing.T) {
+	RegisterT(t)
+	ctx := setupApplyTenantConfig()
+
+	action := &actions.ApplyTenantConfig{Config: &tenantconfig.Config{
+		Settings: validTenantConfigSettings(),
+		Tags: []*tenantconfig.Tag{
+			{Name: "Bug", Color: "00FF00"},
+			{Name: "Feature", Color: "0000FF"},
+		},
+	}}
+	result := action.Validate(ctx, nil)
+	ExpectSuccess(result)
+	Expect(action.Config.Settings.PrioritizationFormula).Equals(enum.PrioritizationRICE)
+	Expect(action.Config.Settings.EmailRules.AllowedDomains).Equals([]string{"example.com"})
+}
+
+func TestApplyTenantConfig_InvalidItems(t *testing.T) {
+	RegisterT(t)
+	ctx := setupApplyTenantConfig()
+
+	settings := validTenantConfigSettings()
+	settings.Title = ""
+	action := &actions.ApplyTenantConfig{Config: &tenantconfig.Config{
+		Settings: settings,
+		Tags: []*tenantconfig.Tag{
+			{Name: "Bug", Color: "00FF00"},
+			{Name: "bug", Color: "00FF00"},
+			{Name: "Feature", Color: "blue"},
+		},
+		Webhooks: []*tenantconfig.Webhook{
+			{Name: "Slack", Status: enum.WebhookDisabled, URL: "https://example.com", HTTPMethod: "POST"},
+		},
+		OAuthProviders: []*tenantconfig.OAuthProvider{
+			{DisplayName: "Okta", Enabled: true},
+		},
+	}}
+	result := action.Validate(ctx, nil)
+	ExpectFailed(result,
+		"settings.title",
+		"tags[1].name",
+		"tags[2].color",
+		"webhooks[0].type",
+		"oauthProviders[0].clientID",
+		"oauthProviders[0].clientSecret",
+		"oauthProviders[0].scope",
+		"oauthProviders[0].authorizeURL",
+		"oauthProviders[0].tokenURL",
+		"oauthProviders[0].jsonUserIDPath",
+	)
+}
+
+func TestApplyTenantConfig_WebhookHeaders(t *testing.T) {
+	RegisterT(t)
+	ctx := setupApplyTenantConfig()
+
+	action := &actions.ApplyTenantConfig{Config: &tenantconfig.Config{
+		Webhooks: []*tenantconfig.Webhook{
+			{Name: "Slack", Type: enum.WebhookNewPost, Status: enum.WebhookDisabled, URL: "https://hooks.slack.com/1", HTTPMethod: "POST", HTTPHeaders: map[string]string{"Authorization": ""}},
+		},
+	}}
+	ExpectSuccess(action.Validate(ctx, nil))
+
+	// Only existing headers can be left without a value
+	action.Config.Webhooks[0].HTTPHeaders["X-Team"] = ""
+	ExpectFailed(action.Validate(ctx, nil), "webhooks[0].value-X-Team")
+}
+
+func TestApplyTenantConfig_DisableEmailAuth_WithoutProviders(t *testing.T) {
+	RegisterT(t)
+	ctx := setupApplyTenantConfig(&dto.OAuthProviderOption{Provider: "_abc", IsCustomProvider: true})
+
+	settings := validTenantConfigSettings()
+	settings.IsEmailAuthAllowed = false
+	action := &actions.ApplyTenantConfig{Config: &tenantconfig.Config{
+		Settings:       settings,
+		OAuthProviders: []*tenantconfig.OAuthProvider{},
+	}}
+	result := action.Validate(ctx, nil)
+	ExpectFailed(result, "settings.isEmailAuthAllowed")
+}
+
+func TestApplyTenantConfig_DisableEmailAuth_WithBuiltInProvider(t *testing.T) {
+	RegisterT(t)
+	ctx := setupApplyTenantConfig(&dto.OAuthProviderOption{Provider: "github"})
+
+	settings := validTenantConfigSettings()
+	settings.IsEmailAuthAllowed = false
+	action := &actions.ApplyTenantConfig{Config: &tenantconfig.Config{
+		Settings:       settings,
+		OAuthProviders: []*tenantconfig.OAuthProvider{},
+	}}
+	result := action.Validate(ctx, nil)
+	ExpectSuccess(result)
+}
@@ -13,6 +13,7 @@ import (
 
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 )
 
 func TestCreateTenant_ShouldHaveVerificationKey(t *testing.T) {
@@ -112,6 +113,30 @@ func TestCreateTenant_EmptySubdomain(t *testing.T) {
 	ExpectFailed(result, "subdomain")
 }
 
+func TestCreateTenant_DisposableEmail(t *testing.T) {
+	RegisterT(t)
+	env.Config.SignUp.BlockDisposableEmails = true
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	action := actions.CreateTenant{
+		Name:           "Jon Snow",
+		Email:          "jon.snow@mailinator.com",
+		TenantName:     "My Company",
+		Subdomain:      "mycompany",
+		LegalAgreement: true,
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "email")
+
+	action.Email = "jon.snow@got.com"
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+}
+
 func TestUpdateTenantSettings_Unauthorized(t *testing.T) {
 	RegisterT(t)
 
@@ -181,3 +206,50 @@ func TestUpdateTenantSettings_ExistingTenant_WithLogo(t *testing.T) {
 	ExpectSuccess(result)
 	Expect(action.Logo.BlobKey).Equals("hello-world.png")
 }
+
+func TestUpdateTenantAdvancedSettings_KeepsCurrentFormula(t *testing.T) {
+	RegisterT(t)
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{
+		PrioritizationFormula: enum.PrioritizationICE,
+	})
+
+	action := &actions.UpdateTenantAdvancedSettings{CustomCSS: ".primary { color: red; }"}
+	ExpectSuccess(action.Validate(ctx, nil))
+	Expect(action.PrioritizationFormula).Equals(enum.PrioritizationICE)
+
+	action = &actions.UpdateTenantAdvancedSettings{PrioritizationFormula: enum.PrioritizationRICE}
+	ExpectSuccess(action.Validate(ctx, nil))
+	Expect(action.PrioritizationFormula).Equals(enum.PrioritizationRICE)
+
+	action = &actions.UpdateTenantAdvancedSettings{}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.PrioritizationFormula).Equals(enum.PrioritizationRICE)
+}
+
+func TestUpdateTenantEmailRules_InvalidDomains(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.UpdateTenantEmailRules{
+		AllowedDomains: []string{"got.com", "not a domain"},
+		BlockedDomains: []string{"@competitor"},
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "allowedDomains", "blockedDomains")
+}
+
+func TestUpdateTenantEmailRules_Valid(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.UpdateTenantEmailRules{
+		AllowedDomains:  []string{" got.com", "@got.com", "", "north.got.com"},
+		BlockDisposable: true,
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Rules()).Equals(entity.TenantEmailRules{
+		AllowedDomains:  []string{"got.com", "north.got.com"},
+		BlockedDomains:  []string{},
+		BlockDisposable: true,
+	})
+}
@@ -162,3 +162,60 @@ func (action *ChangeUserEmail) GetUser() *entity.User {
 func (action *ChangeUserEmail) GetKind() enum.EmailVerificationKind {
 	return enum.EmailVerificationKindChangeEmail
 }
+
+// MergeUsers is used to merge a duplicate user (source) into another user (target)
+type MergeUsers struct {
+	SourceUserID int  `json:"sourceUserID"`
+	TargetUserID int  `json:"targetUserID"`
+	BlockSource  bool `json:"blockSource"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *MergeUsers) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *MergeUsers) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.SourceUserID == user.ID {
+		result.AddFieldFailure("sourceUserID", "It is not allowed to merge your own account into another user.")
+	} else if err := validateTenantUser(ctx, user, action.SourceUserID); err == app.ErrNotFound {
+		result.AddFieldFailure("sourceUserID", "User not found.")
+	} else if err != nil {
+		return validate.Error(err)
+	}
+
+	if action.SourceUserID == action.TargetUserID {
+		result.AddFieldFailure("targetUserID", "A user cannot be merged into itself.")
+	} else if target, err := getTenantUser(ctx, user, action.TargetUserID); err == app.ErrNotFound {
+		result.AddFieldFailure("targetUserID", "User not found.")
+	} else if err != nil {
+		return validate.Error(err)
+	} else if target.Status != enum.UserActive {
+		result.AddFieldFailure("targetUserID", "Users can only be merged into an active user.")
+	}
+
+	return result
+}
+
+func validateTenantUser(ctx context.Context, user *entity.User, userID int) error {
+	_, err := getTenantUser(ctx, user, userID)
+	return err
+}
+
+func getTenantUser(ctx context.Context, user *entity.User, userID int) (*entity.User, error) {
+	userByID := &query.GetUserByID{UserID: userID}
+	err := bus.Dispatch(ctx, userByID)
+	if err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, app.ErrNotFound
+		}
+		return nil, err
+	}
+	if userByID.Result.Tenant.ID != user.Tenant.ID {
+		return nil, app.ErrNotFound
+	}
+	return userByID.Result, nil
+}
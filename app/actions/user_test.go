@@ -189,3 +189,71 @@ func TestChangeUserRole_CurrentUser(t *testing.T) {
 	result := action.Validate(context.Background(), currentUser)
 	ExpectFailed(result, "userID")
 }
+
+func TestMergeUsers_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		nil,
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+	} {
+		action := actions.MergeUsers{SourceUserID: 2, TargetUserID: 3}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+}
+
+func TestMergeUsers_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == 999 {
+			return app.ErrNotFound
+		}
+		tenantID := 1
+		if q.UserID == 4 {
+			tenantID = 2
+		}
+		status := enum.UserActive
+		if q.UserID == 5 {
+			status = enum.UserBlocked
+		} else if q.UserID == 6 {
+			status = enum.UserDeleted
+		}
+		q.Result = &entity.User{ID: q.UserID, Tenant: &entity.Tenant{ID: tenantID}, Status: status}
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	testCases := []struct {
+		action *actions.MergeUsers
+		failed []string
+	}{
+		{&actions.MergeUsers{SourceUserID: 1, TargetUserID: 2}, []string{"sourceUserID"}},
+		{&actions.MergeUsers{SourceUserID: 2, TargetUserID: 2}, []string{"targetUserID"}},
+		{&actions.MergeUsers{SourceUserID: 999, TargetUserID: 2}, []string{"sourceUserID"}},
+		{&actions.MergeUsers{SourceUserID: 2, TargetUserID: 4}, []string{"targetUserID"}},
+		{&actions.MergeUsers{SourceUserID: 2, TargetUserID: 5}, []string{"targetUserID"}},
+		{&actions.MergeUsers{SourceUserID: 2, TargetUserID: 6}, []string{"targetUserID"}},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), currentUser)
+		ExpectFailed(result, testCase.failed...)
+	}
+}
+
+func TestMergeUsers_Valid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = &entity.User{ID: q.UserID, Tenant: &entity.Tenant{ID: 1}, Status: enum.UserActive}
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.MergeUsers{SourceUserID: 3, TargetUserID: 2}
+	Expect(action.IsAuthorized(context.Background(), currentUser)).IsTrue()
+	ExpectSuccess(action.Validate(context.Background(), currentUser))
+}
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"fmt"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
@@ -10,13 +11,15 @@ import (
 )
 
 type CreateEditWebhook struct {
-	Name        string             `json:"name"`
-	Type        enum.WebhookType   `json:"type"`
-	Status      enum.WebhookStatus `json:"status"`
-	Url         string             `json:"url"`
-	Content     string             `json:"content"`
-	HttpMethod  string             `json:"http_method"`
-	HttpHeaders entity.HttpHeaders `json:"http_headers"`
+	Name        string                   `json:"name"`
+	Type        enum.WebhookType         `json:"type"`
+	Status      enum.WebhookStatus       `json:"status"`
+	Format      enum.WebhookFormat       `json:"format"`
+	Url         string                   `json:"url"`
+	Content     string                   `json:"content"`
+	HttpMethod  string                   `json:"http_method"`
+	HttpHeaders entity.HttpHeaders       `json:"http_headers"`
+	Conditions  entity.WebhookConditions `json:"conditions"`
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -47,6 +50,10 @@ func (action *CreateEditWebhook) Validate(ctx context.Context, _ *entity.User) *
 		result.AddFieldFailure("status", "Status is required.")
 	}
 
+	if action.Format == 0 {
+		action.Format = enum.WebhookFormatTemplate
+	}
+
 	runCompileCheck := action.Status == enum.WebhookEnabled
 	if action.Url == "" {
 		result.AddFieldFailure("url", "URL template is required.")
@@ -64,6 +71,7 @@ func (action *CreateEditWebhook) Validate(ctx context.Context, _ *entity.User) *
 	if runCompileCheck {
 		previewWebhook := &cmd.PreviewWebhook{
 			Type:    action.Type,
+			Format:  action.Format,
 			Url:     action.Url,
 			Content: action.Content,
 		}
@@ -106,13 +114,17 @@ func (action *CreateEditWebhook) Validate(ctx context.Context, _ *entity.User) *
 		}
 	}
 
+	validateWebhookConditions(result, action.Type, action.Conditions)
+
 	return result
 }
 
 type PreviewWebhook struct {
-	Type    enum.WebhookType `json:"type"`
-	Url     string           `json:"url"`
-	Content string           `json:"content"`
+	Type       enum.WebhookType         `json:"type"`
+	Format     enum.WebhookFormat       `json:"format"`
+	Url        string                   `json:"url"`
+	Content    string                   `json:"content"`
+	Conditions entity.WebhookConditions `json:"conditions"`
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -133,5 +145,39 @@ func (action *PreviewWebhook) Validate(context.Context, *entity.User) *validate.
 		result.AddFieldFailure("type", "Type must be valid.")
 	}
 
+	validateWebhookConditions(result, action.Type, action.Conditions)
+
 	return result
 }
+
+func validateWebhookConditions(result *validate.Result, webhookType enum.WebhookType, conditions entity.WebhookConditions) {
+	for _, tag := range append(conditions.IncludeTags, conditions.ExcludeTags...) {
+		if tag == "" {
+			result.AddFieldFailure("conditions", "Tag is required.")
+			break
+		}
+	}
+
+	if len(conditions.FromStatus) > 0 && webhookType != enum.WebhookChangeStatus {
+		result.AddFieldFailure("conditions", "Previous status can only be used on change status webhooks.")
+	}
+	for _, name := range append(conditions.FromStatus, conditions.ToStatus...) {
+		var status enum.PostStatus
+		if err := status.UnmarshalText([]byte(name)); err != nil || status.Name() != name {
+			result.AddFieldFailure("conditions", fmt.Sprintf("Status '%s' is not valid.", name))
+		}
+	}
+
+	for _, name := range conditions.AuthorRoles {
+		var role enum.Role
+		if err := role.UnmarshalText([]byte(name)); err != nil || role.String() != name {
+			result.AddFieldFailure("conditions", fmt.Sprintf("Role '%s' is not valid.", name))
+		}
+	}
+
+	if conditions.MinVotes < 0 {
+		result.AddFieldFailure("conditions", "Minimum votes must be zero or greater.")
+	} else if conditions.MinVotes > 0 && webhookType == enum.WebhookNewPost {
+		result.AddFieldFailure("conditions", "Minimum votes cannot be used on new post webhooks.")
+	}
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/tenantconfig"
+)
+
+const configUsage = `Usage:
+  fider config export [--tenant <subdomain>] [--format yaml|json]
+  fider config apply [--tenant <subdomain>] --file <path> [--dry-run]`
+
+// RunConfig exports the configuration of a tenant or applies a configuration file to it
+// Returns an exitcode, 0 for OK and 1 for ERROR
+func RunConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Println(configUsage)
+		return 1
+	}
+
+	flags := flag.NewFlagSet("config "+args[0], flag.ContinueOnError)
+	tenantDomain := flags.String("tenant", "", "subdomain or custom domain of the tenant, not needed on single host mode")
+	format := flags.String("format", "yaml", "format of the exported configuration, yaml or json")
+	file := flags.String("file", "", "path of the configuration file to apply, in yaml or json")
+	dryRun := flags.Bool("dry-run", false, "only print the changes, without applying them")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	switch args[0] {
+	case "export":
+		return runConfigCommand(*tenantDomain, func(ctx context.Context) (bool, error) {
+			return false, exportConfig(ctx, *format)
+		})
+	case "apply":
+		if *file == "" {
+			fmt.Println(configUsage)
+			return 1
+		}
+		return runConfigCommand(*tenantDomain, func(ctx context.Context) (bool, error) {
+			return !*dryRun, applyConfig(ctx, *file, *dryRun)
+		})
+	}
+
+	fmt.Println(configUsage)
+	return 1
+}
+
+func exportConfig(ctx context.Context, format string) error {
+	config, err := tenantconfig.Export(ctx)
+	if err != nil {
+		return err
+	}
+
+	content, err := tenantconfig.Marshal(config, format)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(content)
+	return err
+}
+
+func applyConfig(ctx context.Context, path string, dryRun bool) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read '%s'", path)
+	}
+
+	config, err := tenantconfig.Parse(content)
+	if err != nil {
+		return err
+	}
+
+	action := &actions.ApplyTenantConfig{Config: config}
+	user, _ := ctx.Value(app.UserCtxKey).(*entity.User)
+	if result := action.Validate(ctx, user); !result.Ok {
+		if result.Err != nil {
+			return result.Err
+		}
+		for _, item := range result.Errors {
+			fmt.Printf("%s: %s\n", item.Field, item.Message)
+		}
+		return errors.New("configuration is invalid")
+	}
+
+	changes, err := tenantconfig.Plan(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No changes, the tenant already matches this configuration.")
+		return nil
+	}
+
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	if dryRun {
+		fmt.Printf("%d change(s) planned, nothing was applied.\n", len(changes))
+		return nil
+	}
+
+	if err := tenantconfig.Apply(ctx, changes); err != nil {
+		return err
+	}
+	fmt.Printf("%d change(s) applied.\n", len(changes))
+	return nil
+}
+
+// runConfigCommand runs given command on behalf of the first administrator of the tenant
+func runConfigCommand(domain string, command func(ctx context.Context) (bool, error)) int {
+	return runInTransaction("CONFIG", func(ctx context.Context) (bool, error) {
+		ctx, err := withConfigTenant(ctx, domain)
+		if err != nil {
+			return false, err
+		}
+		return command(ctx)
+	})
+}
+
+// runInTransaction runs given command within a transaction
+// The transaction is only committed when the command succeeds and asks for it
+func runInTransaction(tag string, command func(ctx context.Context) (bool, error)) int {
+	bus.Init()
+
+	ctx := log.WithProperties(context.Background(), dto.Props{
+		log.PropertyKeyTag:       tag,
+		log.PropertyKeyContextID: rand.String(32),
+	})
+
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+	defer trx.MustRollback()
+
+	ctx = context.WithValue(ctx, app.TransactionCtxKey, trx)
+	commit, err := command(ctx)
+	if err != nil {
+		log.Error(ctx, err)
+		return 1
+	}
+
+	if commit {
+		if err := trx.Commit(); err != nil {
+			log.Error(ctx, err)
+			return 1
+		}
+	}
+	return 0
+}
+
+func withConfigTenant(ctx context.Context, domain string) (context.Context, error) {
+	tenant, err := getTenant(ctx, domain)
+	if err != nil {
+		return ctx, err
+	}
+	ctx = context.WithValue(ctx, app.TenantCtxKey, tenant)
+
+	allUsers := &query.GetAllUsers{}
+	if err := bus.Dispatch(ctx, allUsers); err != nil {
+		return ctx, err
+	}
+	for _, user := range allUsers.Result {
+		if user.Role == enum.RoleAdministrator && user.Status == enum.UserActive {
+			return context.WithValue(ctx, app.UserCtxKey, user), nil
+		}
+	}
+
+	return ctx, errors.New("tenant '%s' has no active administrator", tenant.Name)
+}
+
+// getTenant returns the tenant with given subdomain or custom domain, or the only tenant on single host mode
+func getTenant(ctx context.Context, domain string) (*entity.Tenant, error) {
+	if domain == "" {
+		if !env.IsSingleHostMode() {
+			return nil, errors.New("--tenant is required on multi host mode")
+		}
+		firstTenant := &query.GetFirstTenant{}
+		if err := bus.Dispatch(ctx, firstTenant); err != nil {
+			return nil, err
+		}
+		return firstTenant.Result, nil
+	}
+
+	byDomain := &query.GetTenantByDomain{Domain: domain}
+	if err := bus.Dispatch(ctx, byDomain); err != nil {
+		return nil, err
+	}
+	return byDomain.Result, nil
+}
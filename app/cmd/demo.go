@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/demo"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// RunDemo generates demo users, tags, posts, comments and votes into a new or existing tenant
+// Returns an exitcode, 0 for OK and 1 for ERROR
+func RunDemo(args []string) int {
+	defaults := demo.DefaultOptions()
+
+	flags := flag.NewFlagSet("demo", flag.ContinueOnError)
+	tenantDomain := flags.String("tenant", "", "subdomain or custom domain of the tenant, not needed on single host mode")
+	create := flags.Bool("create", false, "create the tenant when it doesn't exist yet")
+	name := flags.String("name", "Fider Demo", "name of the tenant, when it is created")
+	seed := flags.Int64("seed", defaults.Seed, "seed of the generator, the same seed always generates the same data")
+	users := flags.Int("users", defaults.Users, "number of users")
+	tags := flags.Int("tags", defaults.Tags, "number of tags")
+	posts := flags.Int("posts", defaults.Posts, "number of posts")
+	comments := flags.Int("comments", defaults.Comments, "number of comments")
+	votes := flags.Int("votes", defaults.Votes, "number of votes, including the votes of each post author")
+	days := flags.Int("days", defaults.Days, "number of days the posts are spread over")
+	var now time.Time
+	flags.Func("now", "date the data is generated relative to, as 2006-01-02 or RFC 3339, defaults to the current time", func(value string) error {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			parsed, err = time.Parse(time.DateOnly, value)
+		}
+		if err != nil {
+			return errors.New("'%s' is not a date such as 2006-01-02 or 2006-01-02T15:04:05Z", value)
+		}
+		now = parsed
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	opts := demo.Options{
+		Seed:     *seed,
+		Users:    *users,
+		Tags:     *tags,
+		Posts:    *posts,
+		Comments: *comments,
+		Votes:    *votes,
+		Days:     *days,
+		Now:      now,
+	}
+
+	return runInTransaction("DEMO", func(ctx context.Context) (bool, error) {
+		tenant, err := getOrCreateDemoTenant(ctx, *tenantDomain, *name, *create)
+		if err != nil {
+			return false, err
+		}
+
+		summary, err := demo.Generate(context.WithValue(ctx, app.TenantCtxKey, tenant), opts)
+		if err != nil {
+			return false, err
+		}
+
+		fmt.Printf("Generated into '%s': %d user(s), %d tag(s), %d post(s), %d comment(s) and %d vote(s).\n",
+			tenant.Name, summary.Users, summary.Tags, summary.Posts, summary.Comments, summary.Votes)
+		return true, nil
+	})
+}
+
+func getOrCreateDemoTenant(ctx context.Context, domain, name string, create bool) (*entity.Tenant, error) {
+	tenant, err := getTenant(ctx, domain)
+	if err == nil || errors.Cause(err) != app.ErrNotFound || !create {
+		return tenant, err
+	}
+
+	subdomain := domain
+	if env.IsSingleHostMode() {
+		subdomain = "default"
+	} else {
+		messages, err := validate.Subdomain(ctx, subdomain)
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) > 0 {
+			return nil, errors.New("'%s' can't be used as subdomain: %s", subdomain, strings.Join(messages, " "))
+		}
+	}
+
+	createTenant := &cmd.CreateTenant{
+		Name:      name,
+		Subdomain: subdomain,
+		Status:    enum.TenantActive,
+	}
+	if err := bus.Dispatch(ctx, createTenant); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Created tenant '%s'.\n", name)
+	return createTenant.Result, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+func TestRunDemo_InvalidNow(t *testing.T) {
+	RegisterT(t)
+
+	Expect(RunDemo([]string{"--now", "yesterday"})).Equals(1)
+	Expect(RunDemo([]string{"--now", "2024-13-01"})).Equals(1)
+}
+
+func TestGetOrCreateDemoTenant_InvalidSubdomain(t *testing.T) {
+	RegisterT(t)
+	env.Config.HostMode = "multi"
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByDomain) error {
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateTenant) error {
+		c.Result = &entity.Tenant{ID: 1, Name: c.Name, Subdomain: c.Subdomain}
+		return nil
+	})
+
+	for _, domain := range []string{"ab", "my_company", "feedback.mycompany.com", "admin"} {
+		tenant, err := getOrCreateDemoTenant(context.Background(), domain, "Fider Demo", true)
+		Expect(err).IsNotNil()
+		Expect(tenant).IsNil()
+	}
+	Expect(bus.GetCallCount(&cmd.CreateTenant{})).Equals(0)
+
+	tenant, err := getOrCreateDemoTenant(context.Background(), "mycompany", "Fider Demo", true)
+	Expect(err).IsNil()
+	Expect(tenant.Subdomain).Equals("mycompany")
+}
@@ -63,6 +63,14 @@ func routes(r *web.Engine) *web.Engine {
 		}
 	}
 
+	// OAuth2 clients send form encoded requests, so the token endpoint can't be protected by CSRF
+	oauth2 := r.Group()
+	{
+		oauth2.Use(middlewares.RequireTenant())
+		oauth2.Use(middlewares.BlockPendingTenants())
+		oauth2.Post("/oauth2/token", handlers.OAuthIssueToken())
+	}
+
 	r.Use(middlewares.CSRF())
 
 	r.Get("/terms", handlers.LegalPage("Terms of Service", "terms.md"))
@@ -70,6 +78,8 @@ func routes(r *web.Engine) *web.Engine {
 	r.Post("/_api/tenants", handlers.CreateTenant())
 	r.Get("/_api/tenants/:subdomain/availability", handlers.CheckAvailability())
 	r.Get("/signup", handlers.SignUp())
+	r.Get("/signup/review", handlers.ReviewSignUp())
+	r.Post("/_api/signup/review", handlers.ConfirmSignUpReview())
 	r.Get("/oauth/:provider", handlers.SignInByOAuth())
 	r.Get("/oauth/:provider/callback", handlers.OAuthCallback())
 
@@ -107,6 +117,7 @@ func routes(r *web.Engine) *web.Engine {
 	r.Get("/invite/verify", handlers.VerifySignInKey(enum.EmailVerificationKindUserInvitation))
 	r.Post("/_api/signin/complete", handlers.CompleteSignInProfile())
 	r.Post("/_api/signin", handlers.SignInByEmail())
+	r.Get("/oauth2/authorize", handlers.OAuthAuthorizePage())
 
 	//Block if it's private tenant with unauthenticated user
 	r.Use(middlewares.CheckTenantPrivacy())
@@ -115,6 +126,19 @@ func routes(r *web.Engine) *web.Engine {
 	r.Get("/posts/:number", handlers.PostDetails())
 	r.Get("/posts/:number/:slug", handlers.PostDetails())
 
+	ogImages := r.Group()
+	{
+		ogImages.Use(middlewares.TenantCache(30 * 24 * time.Hour))
+		ogImages.Get("/static/og/posts/:number/:version", handlers.PostOGImage())
+	}
+
+	badges := r.Group()
+	{
+		badges.Use(middlewares.TenantCache(5 * time.Minute))
+		badges.Get("/static/badges/posts", handlers.OpenPostsBadge())
+		badges.Get("/static/badges/posts/:number", handlers.PostBadge())
+	}
+
 	ui := r.Group()
 	{
 		//From this step, a User is required
@@ -129,8 +153,13 @@ func routes(r *web.Engine) *web.Engine {
 		ui.Post("/_api/user/regenerate-apikey", handlers.RegenerateAPIKey())
 		ui.Post("/_api/user/settings", handlers.UpdateUserSettings())
 		ui.Post("/_api/user/change-email", handlers.ChangeUserEmail())
+		ui.Delete("/_api/user/oauth-apps/:id", handlers.RevokeOAuthAuthorization())
+		ui.Post("/_api/oauth2/authorize", handlers.OAuthAuthorize())
 		ui.Post("/_api/notifications/read-all", handlers.ReadAllNotifications())
 		ui.Get("/_api/notifications/unread/total", handlers.TotalUnreadNotifications())
+		ui.Get("/_api/notifications/push/key", handlers.GetPushPublicKey())
+		ui.Post("/_api/notifications/push/subscriptions", handlers.SubscribeToPush())
+		ui.Delete("/_api/notifications/push/subscriptions", handlers.UnsubscribeFromPush())
 
 		// From this step, only Collaborators and Administrators are allowed
 		ui.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator))
@@ -153,6 +182,7 @@ func routes(r *web.Engine) *web.Engine {
 
 		ui.Get("/admin/export", handlers.Page("Export · Site Settings", "", "Administration/pages/Export.page"))
 		ui.Get("/admin/export/posts.csv", handlers.ExportPostsToCSV())
+		ui.Get("/admin/export/votes.csv", handlers.ExportVotesToCSV())
 		ui.Get("/admin/export/backup.zip", handlers.ExportBackupZip())
 		ui.Get("/admin/webhooks", handlers.ManageWebhooks())
 		ui.Post("/_api/admin/webhook", handlers.CreateWebhook())
@@ -165,10 +195,14 @@ func routes(r *web.Engine) *web.Engine {
 		ui.Post("/_api/admin/settings/advanced", handlers.UpdateAdvancedSettings())
 		ui.Post("/_api/admin/settings/privacy", handlers.UpdatePrivacy())
 		ui.Post("/_api/admin/settings/emailauth", handlers.UpdateEmailAuthAllowed())
+		ui.Get("/_api/admin/settings/email-rules", handlers.GetEmailRules())
+		ui.Post("/_api/admin/settings/email-rules", handlers.UpdateEmailRules())
 		ui.Post("/_api/admin/oauth", handlers.SaveOAuthConfig())
 		ui.Post("/_api/admin/roles/:role/users", handlers.ChangeUserRole())
 		ui.Put("/_api/admin/users/:userID/block", handlers.BlockUser())
 		ui.Delete("/_api/admin/users/:userID/block", handlers.UnblockUser())
+		ui.Post("/_api/admin/users/merge/preview", handlers.PreviewUserMerge())
+		ui.Post("/_api/admin/users/merge", handlers.MergeUsers())
 
 		if env.IsBillingEnabled() {
 			ui.Get("/admin/billing", handlers.ManageBilling())
@@ -182,9 +216,28 @@ func routes(r *web.Engine) *web.Engine {
 	{
 		publicApi.Get("/api/v1/posts", apiv1.SearchPosts())
 		publicApi.Get("/api/v1/tags", apiv1.ListTags())
+		publicApi.Get("/api/v1/tags/:slug/template", apiv1.GetPostTemplate())
+		publicApi.Get("/api/v1/post-templates/default", apiv1.GetPostTemplate())
 		publicApi.Get("/api/v1/posts/:number", apiv1.GetPost())
 		publicApi.Get("/api/v1/posts/:number/comments", apiv1.ListComments())
 		publicApi.Get("/api/v1/posts/:number/comments/:id", apiv1.GetComment())
+		publicApi.Get("/api/v1/posts/:number/polls", apiv1.ListPolls())
+		publicApi.Get("/api/v1/roadmap", apiv1.GetRoadmap())
+		publicApi.Get("/api/v1/webhooks/schemas/:type", apiv1.GetWebhookSchema())
+		publicApi.Get("/api/v1/announcements", apiv1.ListActiveAnnouncements())
+		publicApi.Get("/api/v1/graphql", apiv1.GraphQL())
+		publicApi.Post("/api/v1/graphql", apiv1.GraphQL())
+	}
+
+	// Operations on the account itself
+	// Available to authenticated users, but not to third-party applications
+	accountApi := r.Group()
+	{
+		accountApi.Use(middlewares.IsAuthenticated())
+		accountApi.Use(middlewares.BlockLockedTenants())
+		accountApi.Use(middlewares.BlockOAuthAccessTokens())
+
+		accountApi.Delete("/api/v1/me", apiv1.DeleteCurrentUser())
 	}
 
 	// Operations used to manage the content of a site
@@ -201,8 +254,21 @@ func routes(r *web.Engine) *web.Engine {
 		membersApi.Delete("/api/v1/posts/:number/comments/:id", apiv1.DeleteComment())
 		membersApi.Post("/api/v1/posts/:number/votes", apiv1.AddVote())
 		membersApi.Delete("/api/v1/posts/:number/votes", apiv1.RemoveVote())
+		membersApi.Post("/api/v1/posts/:number/polls/:id/votes", apiv1.VoteOnPoll())
+		membersApi.Delete("/api/v1/posts/:number/polls/:id/votes", apiv1.RemovePollVote())
 		membersApi.Post("/api/v1/posts/:number/subscription", apiv1.Subscribe())
 		membersApi.Delete("/api/v1/posts/:number/subscription", apiv1.Unsubscribe())
+		membersApi.Get("/api/v1/me", apiv1.GetCurrentUser())
+		membersApi.Put("/api/v1/me", apiv1.UpdateCurrentUser())
+		membersApi.Put("/api/v1/me/avatar", apiv1.UpdateCurrentUserAvatar())
+		membersApi.Put("/api/v1/me/email", apiv1.ChangeCurrentUserEmail())
+		membersApi.Get("/api/v1/me/settings", apiv1.GetCurrentUserSettings())
+		membersApi.Put("/api/v1/me/settings", apiv1.UpdateCurrentUserSettings())
+		membersApi.Get("/api/v1/me/subscriptions", apiv1.ListCurrentUserSubscriptions())
+		membersApi.Get("/api/v1/notifications", apiv1.ListNotifications())
+		membersApi.Post("/api/v1/notifications/read-all", apiv1.MarkAllNotificationsAsRead())
+		membersApi.Put("/api/v1/notifications/:id/read", apiv1.MarkNotificationAsRead())
+		membersApi.Delete("/api/v1/notifications/:id/read", apiv1.MarkNotificationAsUnread())
 
 		membersApi.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator))
 		membersApi.Put("/api/v1/posts/:number/status", apiv1.SetResponse())
@@ -218,12 +284,21 @@ func routes(r *web.Engine) *web.Engine {
 
 		staffApi.Get("/api/v1/users", apiv1.ListUsers())
 		staffApi.Get("/api/v1/posts/:number/votes", apiv1.ListVotes())
+		staffApi.Get("/api/v1/posts/:number/polls/:id/export", apiv1.ExportPollVotesToCSV())
+		staffApi.Get("/api/v1/posts/:number/stats", apiv1.GetPostStats())
+		staffApi.Get("/api/v1/stats/posts", apiv1.ListTopPostsByViews())
+		staffApi.Get("/api/v1/stats/referrers", apiv1.ListTopReferrers())
 		staffApi.Post("/api/v1/invitations/send", apiv1.SendInvites())
 		staffApi.Post("/api/v1/invitations/sample", apiv1.SendSampleInvite())
 
 		staffApi.Use(middlewares.BlockLockedTenants())
 		staffApi.Post("/api/v1/posts/:number/tags/:slug", apiv1.AssignTag())
 		staffApi.Delete("/api/v1/posts/:number/tags/:slug", apiv1.UnassignTag())
+		staffApi.Put("/api/v1/posts/:number/score", apiv1.SetPostScore())
+		staffApi.Put("/api/v1/posts/:number/target", apiv1.SetPostTarget())
+		staffApi.Put("/api/v1/posts/:number/rank", apiv1.ReorderPost())
+		staffApi.Post("/api/v1/posts/:number/polls", apiv1.CreatePoll())
+		staffApi.Delete("/api/v1/posts/:number/polls/:id", apiv1.DeletePoll())
 	}
 
 	// Operations used to manage a site
@@ -238,10 +313,60 @@ func routes(r *web.Engine) *web.Engine {
 		adminApi.Post("/api/v1/tags", apiv1.CreateEditTag())
 		adminApi.Put("/api/v1/tags/:slug", apiv1.CreateEditTag())
 		adminApi.Delete("/api/v1/tags/:slug", apiv1.DeleteTag())
+		adminApi.Get("/api/v1/post-templates", apiv1.ListPostTemplates())
+		adminApi.Post("/api/v1/post-templates", apiv1.CreateEditPostTemplate())
+		adminApi.Put("/api/v1/post-templates/:id", apiv1.CreateEditPostTemplate())
+		adminApi.Delete("/api/v1/post-templates/:id", apiv1.DeletePostTemplate())
+		adminApi.Get("/api/v1/announcements/all", apiv1.ListAllAnnouncements())
+		adminApi.Post("/api/v1/announcements", apiv1.CreateEditAnnouncement())
+		adminApi.Put("/api/v1/announcements/:id", apiv1.CreateEditAnnouncement())
+		adminApi.Delete("/api/v1/announcements/:id", apiv1.DeleteAnnouncement())
+		adminApi.Get("/api/v1/oauth-apps", apiv1.ListOAuthApps())
+		adminApi.Post("/api/v1/oauth-apps", apiv1.CreateEditOAuthApp())
+		adminApi.Put("/api/v1/oauth-apps/:id", apiv1.CreateEditOAuthApp())
+		adminApi.Delete("/api/v1/oauth-apps/:id", apiv1.DeleteOAuthApp())
+		adminApi.Get("/api/v1/admin/settings", apiv1.GetSettings())
+		adminApi.Put("/api/v1/admin/settings/general", handlers.UpdateSettings())
+		adminApi.Put("/api/v1/admin/settings/advanced", handlers.UpdateAdvancedSettings())
+		adminApi.Put("/api/v1/admin/settings/privacy", handlers.UpdatePrivacy())
+		adminApi.Put("/api/v1/admin/settings/email-auth", handlers.UpdateEmailAuthAllowed())
+		adminApi.Put("/api/v1/admin/settings/email-rules", handlers.UpdateEmailRules())
+		adminApi.Get("/api/v1/admin/webhooks", apiv1.ListWebhooks())
+		adminApi.Post("/api/v1/admin/webhooks", apiv1.CreateEditWebhook())
+		adminApi.Get("/api/v1/admin/webhooks/:id", apiv1.GetWebhook())
+		adminApi.Put("/api/v1/admin/webhooks/:id", apiv1.CreateEditWebhook())
+		adminApi.Delete("/api/v1/admin/webhooks/:id", apiv1.DeleteWebhook())
+		adminApi.Post("/api/v1/admin/webhooks/:id/test", apiv1.TestWebhook())
+		adminApi.Get("/api/v1/admin/oauth", apiv1.ListOAuthConfigs())
+		adminApi.Post("/api/v1/admin/oauth", apiv1.SaveOAuthConfig())
+		adminApi.Get("/api/v1/admin/oauth/:provider", apiv1.GetOAuthConfig())
+		adminApi.Post("/api/v1/admin/roles/:role/users", handlers.ChangeUserRole())
+		adminApi.Put("/api/v1/admin/users/:userID/block", apiv1.BlockUser())
+		adminApi.Delete("/api/v1/admin/users/:userID/block", apiv1.UnblockUser())
+		adminApi.Get("/api/v1/admin/config", apiv1.ExportTenantConfig())
+		adminApi.Post("/api/v1/admin/config", apiv1.ApplyTenantConfig())
 
 		adminApi.Use(middlewares.BlockLockedTenants())
 		adminApi.Delete("/api/v1/posts/:number", apiv1.DeletePost())
 	}
 
+	// Operations used to manage the Fider instance
+	// Only available to operators
+	operatorApi := r.Group()
+	{
+		operatorApi.Use(middlewares.SetLocale("en"))
+		operatorApi.Use(middlewares.IsAuthenticated())
+		operatorApi.Use(middlewares.IsOperator())
+
+		operatorApi.Get("/api/v1/operator/jobs", apiv1.ListJobs())
+		operatorApi.Get("/api/v1/operator/jobs/:name/runs", apiv1.ListJobRuns())
+		operatorApi.Put("/api/v1/operator/jobs/:name/pause", apiv1.PauseJob())
+		operatorApi.Delete("/api/v1/operator/jobs/:name/pause", apiv1.ResumeJob())
+		operatorApi.Post("/api/v1/operator/jobs/:name/run", apiv1.RunJob())
+		operatorApi.Get("/api/v1/operator/signups", apiv1.ListTenantSignUps())
+		operatorApi.Post("/api/v1/operator/signups/:id/approve", apiv1.ApproveTenantSignUp())
+		operatorApi.Post("/api/v1/operator/signups/:id/reject", apiv1.RejectTenantSignUp())
+	}
+
 	return r
 }
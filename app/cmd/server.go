@@ -32,6 +32,7 @@ import (
 	_ "github.com/getfider/fider/app/services/sqlstore/postgres"
 	_ "github.com/getfider/fider/app/services/userlist"
 	_ "github.com/getfider/fider/app/services/webhook"
+	_ "github.com/getfider/fider/app/services/webpush"
 )
 
 // RunServer starts the Fider Server
@@ -54,22 +55,30 @@ func RunServer() int {
 	return listenSignals(e)
 }
 
-// Starts all scheduled jobs
+// Starts all scheduled jobs, an invalid schedule on JOBS_SCHEDULES stops the server from starting
 func startJobs(ctx context.Context) {
 	c := cron.New()
-	_ = c.AddJob(jobs.NewJob(ctx, "PurgeExpiredNotificationsJob", jobs.PurgeExpiredNotificationsJobHandler{}))
-	_ = c.AddJob(jobs.NewJob(ctx, "EmailSupressionJob", jobs.EmailSupressionJobHandler{}))
+	addJob := func(name string, handler jobs.Handler) {
+		if err := jobs.Schedule(ctx, c, name, handler); err != nil {
+			panic(err)
+		}
+	}
+
+	addJob("PurgeExpiredNotificationsJob", jobs.PurgeExpiredNotificationsJobHandler{})
+	addJob("EmailSupressionJob", jobs.EmailSupressionJobHandler{})
+	addJob("PurgeExpiredAnnouncementsJob", jobs.PurgeExpiredAnnouncementsJobHandler{})
+	addJob("PurgeExpiredPostStatsJob", jobs.PurgeExpiredPostStatsJobHandler{})
 
 	if env.IsBillingEnabled() {
-		_ = c.AddJob(jobs.NewJob(ctx, "LockExpiredTenantsJob", jobs.LockExpiredTenantsJobHandler{}))
-		_ = c.AddJob(jobs.NewJob(ctx, "TrialReminder7DaysJob", jobs.TrialReminderJobHandler{
+		addJob("LockExpiredTenantsJob", jobs.LockExpiredTenantsJobHandler{})
+		addJob("TrialReminder7DaysJob", jobs.TrialReminderJobHandler{
 			Days:         7,
 			TemplateName: "trial_7days",
-		}))
-		_ = c.AddJob(jobs.NewJob(ctx, "TrialReminder1DayJob", jobs.TrialReminderJobHandler{
+		})
+		addJob("TrialReminder1DayJob", jobs.TrialReminderJobHandler{
 			Days:         1,
 			TemplateName: "trial_1day",
-		}))
+		})
 	}
 
 	c.Start()
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getfider/fider/app/pkg/webpush"
+)
+
+// RunGenerateVAPIDKeys prints a new pair of VAPID keys used to send Web Push notifications
+// Returns an exitcode, 0 for OK and 1 for ERROR
+func RunGenerateVAPIDKeys() int {
+	publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		fmt.Printf("Failed to generate VAPID keys: %s\n", err)
+		return 1
+	}
+
+	fmt.Printf("WEBPUSH_VAPID_PUBLIC_KEY=%s\n", publicKey)
+	fmt.Printf("WEBPUSH_VAPID_PRIVATE_KEY=%s\n", privateKey)
+	return 0
+}
@@ -11,6 +11,9 @@ var InvitePlaceholder = "%invite%"
 //ErrUserIDRequired is used when OAuth integration returns an empty user ID
 var ErrUserIDRequired = errors.New("UserID is required during OAuth integration")
 
+// ErrSignUpAlreadyReviewed is used when a sign-up is no longer pending once its review is stored
+var ErrSignUpAlreadyReviewed = errors.New("Sign-up has already been reviewed")
+
 type key string
 
 func createKey(name string) key {
@@ -33,4 +36,7 @@ var (
 	LocaleCtxKey      = createKey("LOCALE")
 	UserCtxKey        = createKey("USER")
 	LogPropsCtxKey    = createKey("LOG_PROPS")
+
+	// OAuthAccessTokenCtxKey is set when the request is made by a third-party application on behalf of the user
+	OAuthAccessTokenCtxKey = createKey("OAUTH_ACCESS_TOKEN")
 )
@@ -31,16 +31,24 @@ func AdvancedSettingsPage() web.HandlerFunc {
 			Page:  "Administration/pages/AdvancedSettings.page",
 			Title: "Advanced · Site Settings",
 			Data: web.Map{
-				"customCSS": c.Tenant().CustomCSS,
+				"customCSS":             c.Tenant().CustomCSS,
+				"prioritizationFormula": c.Tenant().PrioritizationFormula,
 			},
 		})
 	}
 }
 
 // UpdateSettings update current tenant' settings
+// Fields that are not sent keep their current value
 func UpdateSettings() web.HandlerFunc {
 	return func(c *web.Context) error {
+		tenant := c.Tenant()
 		action := actions.NewUpdateTenantSettings()
+		action.Title = tenant.Name
+		action.Invitation = tenant.Invitation
+		action.WelcomeMessage = tenant.WelcomeMessage
+		action.CNAME = tenant.CNAME
+		action.Locale = tenant.Locale
 		if result := c.BindTo(action); !result.Ok {
 			return c.HandleValidation(result)
 		}
@@ -75,15 +83,20 @@ func UpdateSettings() web.HandlerFunc {
 }
 
 // UpdateAdvancedSettings update current tenant' advanced settings
+// Fields that are not sent keep their current value
 func UpdateAdvancedSettings() web.HandlerFunc {
 	return func(c *web.Context) error {
-		action := new(actions.UpdateTenantAdvancedSettings)
+		action := &actions.UpdateTenantAdvancedSettings{
+			CustomCSS:             c.Tenant().CustomCSS,
+			PrioritizationFormula: c.Tenant().PrioritizationFormula,
+		}
 		if result := c.BindTo(action); !result.Ok {
 			return c.HandleValidation(result)
 		}
 
 		if err := bus.Dispatch(c, &cmd.UpdateTenantAdvancedSettings{
-			CustomCSS: action.CustomCSS,
+			CustomCSS:             action.CustomCSS,
+			PrioritizationFormula: action.PrioritizationFormula,
 		}); err != nil {
 			return c.Failure(err)
 		}
@@ -95,7 +108,7 @@ func UpdateAdvancedSettings() web.HandlerFunc {
 // UpdatePrivacy update current tenant's privacy settings
 func UpdatePrivacy() web.HandlerFunc {
 	return func(c *web.Context) error {
-		action := new(actions.UpdateTenantPrivacy)
+		action := &actions.UpdateTenantPrivacy{IsPrivate: c.Tenant().IsPrivate}
 		if result := c.BindTo(action); !result.Ok {
 			return c.HandleValidation(result)
 		}
@@ -114,7 +127,7 @@ func UpdatePrivacy() web.HandlerFunc {
 // UpdateEmailAuthAllowed update current tenant's allow email auth settings
 func UpdateEmailAuthAllowed() web.HandlerFunc {
 	return func(c *web.Context) error {
-		action := new(actions.UpdateTenantEmailAuthAllowed)
+		action := &actions.UpdateTenantEmailAuthAllowed{IsEmailAuthAllowed: c.Tenant().IsEmailAuthAllowed}
 		if result := c.BindTo(action); !result.Ok {
 			return c.HandleValidation(result)
 		}
@@ -130,6 +143,32 @@ func UpdateEmailAuthAllowed() web.HandlerFunc {
 	}
 }
 
+// GetEmailRules returns current tenant's email rules
+func GetEmailRules() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return c.Ok(c.Tenant().EmailRules)
+	}
+}
+
+// UpdateEmailRules update current tenant's email rules
+func UpdateEmailRules() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateTenantEmailRules)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		updateRules := &cmd.UpdateTenantEmailRules{
+			Rules: action.Rules(),
+		}
+		if err := bus.Dispatch(c, updateRules); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(updateRules.Rules)
+	}
+}
+
 // ManageMembers is the page used by administrators to change member's role
 func ManageMembers() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
@@ -156,6 +157,134 @@ func TestUpdatePrivacyHandler(t *testing.T) {
 	Expect(updateCmd.IsPrivate).IsTrue()
 }
 
+func TestUpdateSettingsHandler_KeepsMissingFields(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImage) error {
+		return nil
+	})
+
+	var updateSettings *cmd.UpdateTenantSettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTenantSettings) error {
+		updateSettings = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.UpdateSettings(), `{ "invitation": "Share your ideas", "locale": "en" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateSettings.Title).Equals(mock.DemoTenant.Name)
+	Expect(updateSettings.Invitation).Equals("Share your ideas")
+}
+
+func TestUpdateSettingsHandler_RequiresAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(handlers.UpdateSettings(), `{ "title": "My Site" }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+	Expect(bus.GetCallCount(&cmd.UpdateTenantSettings{})).Equals(0)
+}
+
+func TestUpdatePrivacyHandler_KeepsMissingFields(t *testing.T) {
+	RegisterT(t)
+	server := mock.NewServer()
+
+	var updatePrivacy *cmd.UpdateTenantPrivacySettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTenantPrivacySettings) error {
+		updatePrivacy = c
+		return nil
+	})
+
+	mock.DemoTenant.IsPrivate = true
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.UpdatePrivacy(), `{}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updatePrivacy.IsPrivate).IsTrue()
+}
+
+func TestUpdateEmailAuthAllowedHandler_CannotDisableWithoutProviders(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveOAuthProviders) error {
+		q.Result = []*dto.OAuthProviderOption{}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.UpdateEmailAuthAllowed(), `{ "isEmailAuthAllowed": false }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(bus.GetCallCount(&cmd.UpdateTenantEmailAuthAllowedSettings{})).Equals(0)
+}
+
+func TestUpdateEmailAuthAllowedHandler_Enable(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTenantEmailAuthAllowedSettings) error {
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.UpdateEmailAuthAllowed(), `{ "isEmailAuthAllowed": true }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(bus.GetCallCount(&query.ListActiveOAuthProviders{})).Equals(0)
+}
+
+func TestUpdateEmailRulesHandler(t *testing.T) {
+	RegisterT(t)
+
+	var updateCmd *cmd.UpdateTenantEmailRules
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTenantEmailRules) error {
+		updateCmd = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, query := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(
+			handlers.UpdateEmailRules(),
+			`{ "allowedDomains": ["GOT.com"], "blockedDomains": ["kingslanding.got.com"], "blockDisposable": true }`,
+		)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateCmd.Rules.AllowedDomains).Equals([]string{"got.com"})
+	Expect(updateCmd.Rules.BlockedDomains).Equals([]string{"kingslanding.got.com"})
+	Expect(updateCmd.Rules.BlockDisposable).IsTrue()
+	Expect(query.Contains("blockDisposable")).IsTrue()
+}
+
+func TestUpdateEmailRulesHandler_NonAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(
+			handlers.UpdateEmailRules(),
+			`{ "blockDisposable": true }`,
+		)
+
+	Expect(code).Equals(http.StatusForbidden)
+}
+
 func TestManageMembersHandler(t *testing.T) {
 	RegisterT(t)
 
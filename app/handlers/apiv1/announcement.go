@@ -0,0 +1,89 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListActiveAnnouncements returns the announcements currently displayed to current user
+func ListActiveAnnouncements() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.ListAnnouncements{ActiveOnly: true}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// ListAllAnnouncements returns all announcements, including scheduled ones
+func ListAllAnnouncements() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.ListAnnouncements{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditAnnouncement creates a new announcement or edit an existing one
+func CreateEditAnnouncement() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditAnnouncement)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.Announcement != nil {
+			updateAnnouncement := &cmd.UpdateAnnouncement{
+				AnnouncementID: action.Announcement.ID,
+				Text:           action.Text,
+				Severity:       action.Severity,
+				Audience:       action.Audience,
+				StartsAt:       *action.StartsAt,
+				EndsAt:         action.EndsAt,
+				Dismissible:    action.Dismissible,
+			}
+			if err := bus.Dispatch(c, updateAnnouncement); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateAnnouncement.Result)
+		}
+
+		addNewAnnouncement := &cmd.AddNewAnnouncement{
+			Text:        action.Text,
+			Severity:    action.Severity,
+			Audience:    action.Audience,
+			StartsAt:    *action.StartsAt,
+			EndsAt:      action.EndsAt,
+			Dismissible: action.Dismissible,
+		}
+		if err := bus.Dispatch(c, addNewAnnouncement); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addNewAnnouncement.Result)
+	}
+}
+
+// DeleteAnnouncement deletes an existing announcement
+func DeleteAnnouncement() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteAnnouncement)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.DeleteAnnouncement{Announcement: action.Announcement})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,99 @@
+package apiv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestListActiveAnnouncementsHandler(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	var listQuery *query.ListAnnouncements
+	bus.AddHandler(func(ctx context.Context, q *query.ListAnnouncements) error {
+		listQuery = q
+		q.Result = []*entity.Announcement{
+			{ID: 1, Text: "Voting closes soon", Severity: enum.AnnouncementInfo, Audience: enum.AnnouncementEveryone},
+		}
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		Execute(apiv1.ListActiveAnnouncements())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listQuery.ActiveOnly).IsTrue()
+
+	var result []map[string]any
+	Expect(json.NewDecoder(response.Body).Decode(&result)).IsNil()
+	Expect(result).HasLen(1)
+	Expect(result[0]["text"]).Equals("Voting closes soon")
+	Expect(result[0]["severity"]).Equals("info")
+	Expect(result[0]["audience"]).Equals("everyone")
+}
+
+func TestCreateAnnouncementHandler(t *testing.T) {
+	RegisterT(t)
+
+	var addNewAnnouncement *cmd.AddNewAnnouncement
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewAnnouncement) error {
+		addNewAnnouncement = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(apiv1.CreateEditAnnouncement(), `{ "text": "We're migrating on **Friday**", "severity": "warning", "audience": "members", "endsAt": "2100-01-01T00:00:00Z", "dismissible": true }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(addNewAnnouncement.Text).Equals("We're migrating on **Friday**")
+	Expect(addNewAnnouncement.Severity).Equals(enum.AnnouncementWarning)
+	Expect(addNewAnnouncement.Audience).Equals(enum.AnnouncementMembers)
+	Expect(addNewAnnouncement.StartsAt.IsZero()).IsFalse()
+	Expect(addNewAnnouncement.EndsAt.Year()).Equals(2100)
+	Expect(addNewAnnouncement.Dismissible).IsTrue()
+}
+
+func TestCreateAnnouncementHandler_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+
+	var testCases = []string{
+		`{ }`,
+		`{ "text": "Hello", "audience": "everyone" }`,
+		`{ "text": "Hello", "severity": "info", "audience": "admins" }`,
+		`{ "text": "Hello", "severity": "info", "audience": "everyone", "startsAt": "2026-10-20T00:00:00Z", "endsAt": "2026-10-19T00:00:00Z" }`,
+	}
+
+	for _, input := range testCases {
+		code, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AsUser(mock.JonSnow).
+			ExecutePost(apiv1.CreateEditAnnouncement(), input)
+		Expect(code).Equals(http.StatusBadRequest)
+	}
+}
+
+func TestCreateAnnouncementHandler_NonAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreateEditAnnouncement(), `{ "text": "Hello", "severity": "info", "audience": "everyone" }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+}
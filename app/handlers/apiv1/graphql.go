@@ -0,0 +1,506 @@
+package apiv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/graphql"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+const (
+	defaultGraphQLPageSize = 30
+	maxGraphQLPageSize     = 100
+)
+
+var graphqlLimits = graphql.Limits{
+	MaxDepth:      8,
+	MaxComplexity: 5000,
+}
+
+// GraphQL executes a read-only GraphQL query over posts, comments, votes, tags, users and notifications
+// Queries can be sent as JSON on the body of a POST request or on the query string of a GET request
+func GraphQL() web.HandlerFunc {
+	return func(c *web.Context) error {
+		request := graphql.Request{}
+		if c.Request.Method == http.MethodGet {
+			request.Query = c.QueryParam("query")
+			request.OperationName = c.QueryParam("operationName")
+			if variables := c.QueryParam("variables"); variables != "" {
+				if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+					return c.JSON(http.StatusBadRequest, graphqlError("Variables must be a JSON object."))
+				}
+			}
+		} else if err := json.Unmarshal([]byte(c.Request.Body), &request); err != nil {
+			return c.JSON(http.StatusBadRequest, graphqlError("Request body must be a JSON object."))
+		}
+
+		if request.Query == "" {
+			return c.JSON(http.StatusBadRequest, graphqlError("Query is required."))
+		}
+
+		response := graphql.Execute(c, graphqlSchema, request, graphqlLimits)
+		if response.Data == nil {
+			return c.JSON(http.StatusBadRequest, response)
+		}
+		return c.Ok(response)
+	}
+}
+
+func graphqlError(message string) *graphql.Response {
+	return &graphql.Response{Errors: []*graphql.Error{{Message: message}}}
+}
+
+// graphqlFailure logs unexpected errors and hides their details from the response
+func graphqlFailure(ctx context.Context, err error) error {
+	log.Error(ctx, err)
+	return fmt.Errorf("Failed to load data.")
+}
+
+func graphqlUser(ctx context.Context) *entity.User {
+	user, _ := ctx.Value(app.UserCtxKey).(*entity.User)
+	return user
+}
+
+func requireGraphQLStaff(ctx context.Context) error {
+	if user := graphqlUser(ctx); user == nil || !user.IsCollaborator() {
+		return fmt.Errorf("Only collaborators and administrators can query this field.")
+	}
+	return nil
+}
+
+func graphqlPageSize(args map[string]any) (int, error) {
+	limit := args["limit"].(int)
+	if limit < 1 || limit > maxGraphQLPageSize {
+		return 0, fmt.Errorf("Limit must be between 1 and %d.", maxGraphQLPageSize)
+	}
+	return limit, nil
+}
+
+func graphqlPageArgs() map[string]*graphql.Argument {
+	return map[string]*graphql.Argument{
+		"limit": {Type: graphql.Int, DefaultValue: defaultGraphQLPageSize},
+	}
+}
+
+// graphqlPageComplexity assumes lists are always full, so that queries can't go past the limit by paginating nested lists
+func graphqlPageComplexity(args map[string]any, childComplexity int) int {
+	limit, _ := args["limit"].(int)
+	return 1 + limit*childComplexity
+}
+
+func graphqlListComplexity(args map[string]any, childComplexity int) int {
+	return 1 + maxGraphQLPageSize*childComplexity
+}
+
+var graphqlUserType = &graphql.Object{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":        {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.User).ID })},
+		"name":      {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.User).Name })},
+		"role":      {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.User).Role })},
+		"status":    {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.User).Status })},
+		"avatarURL": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.User).AvatarURL })},
+		"email": {
+			Type: graphql.String,
+			// Emails are only visible to staff and to the users themselves
+			Resolve: graphql.Resolve(func(ctx context.Context, s any, args map[string]any) (any, error) {
+				user := s.(*entity.User)
+				viewer := graphqlUser(ctx)
+				if viewer != nil && (viewer.IsCollaborator() || viewer.ID == user.ID) {
+					return user.Email, nil
+				}
+				return nil, nil
+			}),
+		},
+	},
+}
+
+var graphqlTagType = &graphql.Object{
+	Name: "Tag",
+	Fields: graphql.Fields{
+		"id":       {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.Tag).ID })},
+		"name":     {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Tag).Name })},
+		"slug":     {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Tag).Slug })},
+		"color":    {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Tag).Color })},
+		"isPublic": {Type: graphql.Boolean, Resolve: graphql.Property(func(s any) any { return s.(*entity.Tag).IsPublic })},
+	},
+}
+
+var graphqlCommentType = &graphql.Object{
+	Name: "Comment",
+	Fields: graphql.Fields{
+		"id":        {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.Comment).ID })},
+		"content":   {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Comment).Content })},
+		"createdAt": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Comment).CreatedAt })},
+		"editedAt":  {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Comment).EditedAt })},
+		"author":    {Type: graphqlUserType, Resolve: graphql.Property(func(s any) any { return s.(*entity.Comment).User })},
+		"editedBy":  {Type: graphqlUserType, Resolve: graphql.Property(func(s any) any { return s.(*entity.Comment).EditedBy })},
+	},
+}
+
+var graphqlVoterType = &graphql.Object{
+	Name: "Voter",
+	Fields: graphql.Fields{
+		"id":        {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.VoteUser).ID })},
+		"name":      {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.VoteUser).Name })},
+		"email":     {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.VoteUser).Email })},
+		"avatarURL": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.VoteUser).AvatarURL })},
+	},
+}
+
+var graphqlVoteType = &graphql.Object{
+	Name: "Vote",
+	Fields: graphql.Fields{
+		"createdAt": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Vote).CreatedAt })},
+		"reason":    {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Vote).Reason })},
+		"importance": {Type: graphql.String, Resolve: graphql.Property(func(s any) any {
+			if importance := s.(*entity.Vote).Importance; importance > 0 {
+				return importance
+			}
+			return nil
+		})},
+		"user": {Type: graphqlVoterType, Resolve: graphql.Property(func(s any) any { return s.(*entity.Vote).User })},
+	},
+}
+
+var graphqlResponseType = &graphql.Object{
+	Name: "PostResponse",
+	Fields: graphql.Fields{
+		"text":        {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.PostResponse).Text })},
+		"respondedAt": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.PostResponse).RespondedAt })},
+		"author":      {Type: graphqlUserType, Resolve: graphql.Property(func(s any) any { return s.(*entity.PostResponse).User })},
+	},
+}
+
+var graphqlTargetType = &graphql.Object{
+	Name: "PostTarget",
+	Fields: graphql.Fields{
+		"kind":     {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.PostTarget).Kind })},
+		"value":    {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.PostTarget).Value })},
+		"name":     {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.PostTarget).String() })},
+		"isPublic": {Type: graphql.Boolean, Resolve: graphql.Property(func(s any) any { return s.(*entity.PostTarget).IsPublic })},
+	},
+}
+
+var graphqlPostType = &graphql.Object{
+	Name: "Post",
+	Fields: graphql.Fields{
+		"id":            {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).ID })},
+		"number":        {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).Number })},
+		"title":         {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).Title })},
+		"slug":          {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).Slug })},
+		"description":   {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).Description })},
+		"createdAt":     {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).CreatedAt })},
+		"status":        {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).Status })},
+		"votesCount":    {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).VotesCount })},
+		"commentsCount": {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).CommentsCount })},
+		"hasVoted":      {Type: graphql.Boolean, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).HasVoted })},
+		"author":        {Type: graphqlUserType, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).User })},
+		"response":      {Type: graphqlResponseType, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).Response })},
+		"target":        {Type: graphqlTargetType, Resolve: graphql.Property(func(s any) any { return s.(*entity.Post).Target })},
+		"tags": {
+			Type:    graphql.ListOf(graphqlTagType),
+			Resolve: resolvePostTags,
+		},
+		"comments": {
+			Type: graphql.ListOf(graphqlCommentType),
+			Args: map[string]*graphql.Argument{
+				"limit": {Type: graphql.Int, DefaultValue: defaultGraphQLPageSize},
+				"sort":  {Type: graphql.String, DefaultValue: "oldest"},
+			},
+			Resolve:    resolvePostComments,
+			Complexity: graphqlPageComplexity,
+		},
+		"votes": {
+			Type:       graphql.ListOf(graphqlVoteType),
+			Args:       graphqlPageArgs(),
+			Resolve:    resolvePostVotes,
+			Complexity: graphqlPageComplexity,
+		},
+	},
+}
+
+var graphqlNotificationType = &graphql.Object{
+	Name: "Notification",
+	Fields: graphql.Fields{
+		"id":        {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*entity.Notification).ID })},
+		"title":     {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Notification).Title })},
+		"link":      {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Notification).Link })},
+		"read":      {Type: graphql.Boolean, Resolve: graphql.Property(func(s any) any { return s.(*entity.Notification).Read })},
+		"createdAt": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*entity.Notification).CreatedAt })},
+		"post": {
+			Type:    graphqlPostType,
+			Resolve: resolveNotificationPosts,
+		},
+	},
+}
+
+var graphqlSchema = &graphql.Schema{
+	Query: &graphql.Object{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"posts": {
+				Type: graphql.ListOf(graphqlPostType),
+				Args: map[string]*graphql.Argument{
+					"query": {Type: graphql.String, DefaultValue: ""},
+					"view":  {Type: graphql.String, DefaultValue: "all"},
+					"tags":  {Type: graphql.ListOf(graphql.String)},
+					"limit": {Type: graphql.Int, DefaultValue: defaultGraphQLPageSize},
+				},
+				Resolve:    graphql.Resolve(resolvePosts),
+				Complexity: graphqlPageComplexity,
+			},
+			"post": {
+				Type: graphqlPostType,
+				Args: map[string]*graphql.Argument{
+					"number": {Type: graphql.Int},
+				},
+				Resolve: graphql.Resolve(resolvePost),
+			},
+			"tags": {
+				Type:       graphql.ListOf(graphqlTagType),
+				Resolve:    graphql.Resolve(resolveTags),
+				Complexity: graphqlListComplexity,
+			},
+			"users": {
+				Type:       graphql.ListOf(graphqlUserType),
+				Args:       graphqlPageArgs(),
+				Resolve:    graphql.Resolve(resolveUsers),
+				Complexity: graphqlPageComplexity,
+			},
+			"viewer": {
+				Type: graphqlUserType,
+				Resolve: graphql.Resolve(func(ctx context.Context, s any, args map[string]any) (any, error) {
+					return graphqlUser(ctx), nil
+				}),
+			},
+			"notifications": {
+				Type: graphql.ListOf(graphqlNotificationType),
+				Args: map[string]*graphql.Argument{
+					"unread": {Type: graphql.Boolean, DefaultValue: false},
+					"limit":  {Type: graphql.Int, DefaultValue: defaultGraphQLPageSize},
+				},
+				Resolve:    graphql.Resolve(resolveNotifications),
+				Complexity: graphqlPageComplexity,
+			},
+		},
+	},
+}
+
+func resolvePosts(ctx context.Context, s any, args map[string]any) (any, error) {
+	limit, err := graphqlPageSize(args)
+	if err != nil {
+		return nil, err
+	}
+
+	searchPosts := &query.SearchPosts{
+		Query: args["query"].(string),
+		View:  args["view"].(string),
+		Limit: strconv.Itoa(limit),
+	}
+	if tags, ok := args["tags"].([]any); ok {
+		for _, tag := range tags {
+			searchPosts.Tags = append(searchPosts.Tags, tag.(string))
+		}
+	}
+
+	if err := bus.Dispatch(ctx, searchPosts); err != nil {
+		return nil, graphqlFailure(ctx, err)
+	}
+	return searchPosts.Result, nil
+}
+
+func resolvePost(ctx context.Context, s any, args map[string]any) (any, error) {
+	number, ok := args["number"].(int)
+	if !ok {
+		return nil, fmt.Errorf("Argument 'number' is required.")
+	}
+
+	getPost := &query.GetPostByNumber{Number: number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, nil
+		}
+		return nil, graphqlFailure(ctx, err)
+	}
+	return getPost.Result, nil
+}
+
+func resolveTags(ctx context.Context, s any, args map[string]any) (any, error) {
+	getAllTags := &query.GetAllTags{}
+	if err := bus.Dispatch(ctx, getAllTags); err != nil {
+		return nil, graphqlFailure(ctx, err)
+	}
+	return getAllTags.Result, nil
+}
+
+func resolveUsers(ctx context.Context, s any, args map[string]any) (any, error) {
+	if err := requireGraphQLStaff(ctx); err != nil {
+		return nil, err
+	}
+	limit, err := graphqlPageSize(args)
+	if err != nil {
+		return nil, err
+	}
+
+	allUsers := &query.GetAllUsers{}
+	if err := bus.Dispatch(ctx, allUsers); err != nil {
+		return nil, graphqlFailure(ctx, err)
+	}
+	if len(allUsers.Result) > limit {
+		return allUsers.Result[:limit], nil
+	}
+	return allUsers.Result, nil
+}
+
+func resolveNotifications(ctx context.Context, s any, args map[string]any) (any, error) {
+	if graphqlUser(ctx) == nil {
+		return nil, fmt.Errorf("Authentication is required to query notifications.")
+	}
+	limit, err := graphqlPageSize(args)
+	if err != nil {
+		return nil, err
+	}
+
+	listNotifications := &query.ListNotifications{
+		UnreadOnly: args["unread"].(bool),
+		Limit:      limit,
+	}
+	if err := bus.Dispatch(ctx, listNotifications); err != nil {
+		return nil, graphqlFailure(ctx, err)
+	}
+	return listNotifications.Result, nil
+}
+
+// resolvePostTags loads all visible tags once for every post of the level,
+// which also hides private tags from users that are not allowed to see them
+func resolvePostTags(ctx context.Context, p graphql.ResolveParams) ([]any, error) {
+	getAllTags := &query.GetAllTags{}
+	if err := bus.Dispatch(ctx, getAllTags); err != nil {
+		return nil, graphqlFailure(ctx, err)
+	}
+
+	tagsBySlug := make(map[string]*entity.Tag, len(getAllTags.Result))
+	for _, tag := range getAllTags.Result {
+		tagsBySlug[tag.Slug] = tag
+	}
+
+	values := make([]any, len(p.Sources))
+	for i, source := range p.Sources {
+		tags := make([]*entity.Tag, 0)
+		for _, slug := range source.(*entity.Post).Tags {
+			if tag, ok := tagsBySlug[slug]; ok {
+				tags = append(tags, tag)
+			}
+		}
+		values[i] = tags
+	}
+	return values, nil
+}
+
+// graphqlPostIDs returns the distinct IDs of the posts of the level
+func graphqlPostIDs(sources []any) []int {
+	ids := make([]int, 0, len(sources))
+	seen := make(map[int]bool, len(sources))
+	for _, source := range sources {
+		post := source.(*entity.Post)
+		if !seen[post.ID] {
+			seen[post.ID] = true
+			ids = append(ids, post.ID)
+		}
+	}
+	return ids
+}
+
+// resolvePostComments loads the comments of every post of the level at once
+func resolvePostComments(ctx context.Context, p graphql.ResolveParams) ([]any, error) {
+	limit, err := graphqlPageSize(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	sort := p.Args["sort"].(string)
+	if sort != "oldest" && sort != "newest" {
+		return nil, fmt.Errorf("Sort must be either 'oldest' or 'newest'.")
+	}
+
+	getComments := &query.GetCommentsByPosts{PostIDs: graphqlPostIDs(p.Sources), Sort: sort, Limit: limit}
+	if err := bus.Dispatch(ctx, getComments); err != nil {
+		return nil, graphqlFailure(ctx, err)
+	}
+
+	values := make([]any, len(p.Sources))
+	for i, source := range p.Sources {
+		comments := getComments.Result[source.(*entity.Post).ID]
+		if comments == nil {
+			comments = make([]*entity.Comment, 0)
+		}
+		values[i] = comments
+	}
+	return values, nil
+}
+
+// resolvePostVotes loads the votes of every post of the level at once
+// Like on the REST API, votes are only visible to staff
+func resolvePostVotes(ctx context.Context, p graphql.ResolveParams) ([]any, error) {
+	if err := requireGraphQLStaff(ctx); err != nil {
+		return nil, err
+	}
+	limit, err := graphqlPageSize(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	listVotes := &query.ListVotesByPosts{PostIDs: graphqlPostIDs(p.Sources), Limit: limit, IncludeEmail: true, IncludeReason: true}
+	if err := bus.Dispatch(ctx, listVotes); err != nil {
+		return nil, graphqlFailure(ctx, err)
+	}
+
+	values := make([]any, len(p.Sources))
+	for i, source := range p.Sources {
+		votes := listVotes.Result[source.(*entity.Post).ID]
+		if votes == nil {
+			votes = make([]*entity.Vote, 0)
+		}
+		values[i] = votes
+	}
+	return values, nil
+}
+
+// resolveNotificationPosts loads the posts referred by all notifications of the level at once
+func resolveNotificationPosts(ctx context.Context, p graphql.ResolveParams) ([]any, error) {
+	numbers := make([]int, 0, len(p.Sources))
+	for _, source := range p.Sources {
+		if notification := source.(*entity.Notification); notification.Post != nil {
+			numbers = append(numbers, notification.Post.Number)
+		}
+	}
+
+	getPosts := &query.GetPostsByNumbers{Numbers: numbers}
+	if len(numbers) > 0 {
+		if err := bus.Dispatch(ctx, getPosts); err != nil {
+			return nil, graphqlFailure(ctx, err)
+		}
+	}
+
+	values := make([]any, len(p.Sources))
+	for i, source := range p.Sources {
+		notification := source.(*entity.Notification)
+		if notification.Post == nil {
+			continue
+		}
+		if post, ok := getPosts.Result[notification.Post.Number]; ok {
+			values[i] = post
+		}
+	}
+	return values, nil
+}
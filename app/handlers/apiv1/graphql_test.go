@@ -0,0 +1,188 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func mockGraphQLPosts() {
+	bus.AddHandler(func(ctx context.Context, q *query.SearchPosts) error {
+		q.Result = []*entity.Post{
+			{ID: 1, Number: 1, Title: "Add dark mode", Status: enum.PostOpen, Tags: []string{"ui", "private"}, User: mock.AryaStark},
+			{ID: 2, Number: 2, Title: "Support SSO", Status: enum.PostPlanned, Tags: []string{"ui"}, User: mock.JonSnow, Target: &entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q3", IsPublic: true}},
+		}
+		return nil
+	})
+
+	// Only public tags are returned for visitors
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		q.Result = []*entity.Tag{{ID: 1, Name: "UI", Slug: "ui", IsPublic: true}}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentsByPosts) error {
+		q.Result = make(map[int][]*entity.Comment)
+		for _, postID := range q.PostIDs {
+			q.Result[postID] = []*entity.Comment{{ID: postID * 10, Content: "+1", User: mock.AryaStark}}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListVotesByPosts) error {
+		q.Result = map[int][]*entity.Vote{
+			1: {{User: &entity.VoteUser{ID: 2, Name: "Arya Stark", Email: "arya.stark@got.com"}}},
+		}
+		return nil
+	})
+}
+
+func TestGraphQLHandler_PostsWithRelations(t *testing.T) {
+	RegisterT(t)
+	mockGraphQLPosts()
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		ExecutePostAsJSON(apiv1.GraphQL(), `{
+			"query": "query($limit: Int) { posts(limit: $limit) { number title status author { name email } tags { slug } target { kind name } comments(limit: 5) { id content } } }",
+			"variables": { "limit": 10 }
+		}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.Contains("errors")).IsFalse()
+	Expect(result.String("data.posts[0].title")).Equals("Add dark mode")
+	Expect(result.String("data.posts[0].status")).Equals("open")
+	Expect(result.String("data.posts[0].author.name")).Equals("Arya Stark")
+	Expect(result.String("data.posts[0].author.email")).Equals("")
+	Expect(result.String("data.posts[0].tags[0].slug")).Equals("ui")
+	Expect(result.String("data.posts[1].comments[0].id")).Equals("20")
+	Expect(result.Contains("data.posts[0].target.kind")).IsFalse()
+	Expect(result.String("data.posts[1].target.kind")).Equals("quarter")
+	Expect(result.String("data.posts[1].target.name")).Equals("Q3 2026")
+
+	// Tags and comments are loaded once for all posts
+	Expect(bus.GetCallCount(&query.GetAllTags{})).Equals(1)
+	Expect(bus.GetCallCount(&query.GetCommentsByPosts{})).Equals(1)
+}
+
+func TestGraphQLHandler_VotesAreOnlyVisibleToStaff(t *testing.T) {
+	RegisterT(t)
+	mockGraphQLPosts()
+
+	body := `{ "query": "{ posts { title votes { user { email } } } }" }`
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePostAsJSON(apiv1.GraphQL(), body)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("data.posts[0].title")).Equals("Add dark mode")
+	Expect(result.String("errors[0].message")).Equals("Only collaborators and administrators can query this field.")
+	Expect(bus.GetCallCount(&query.ListVotesByPosts{})).Equals(0)
+
+	code, result = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(apiv1.GraphQL(), body)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.Contains("errors")).IsFalse()
+	Expect(result.String("data.posts[0].votes[0].user.email")).Equals("arya.stark@got.com")
+	Expect(result.String("data.posts[1].votes[0].user.email")).Equals("")
+
+	// Votes are loaded once for all posts
+	Expect(bus.GetCallCount(&query.ListVotesByPosts{})).Equals(1)
+}
+
+func TestGraphQLHandler_Get(t *testing.T) {
+	RegisterT(t)
+	mockGraphQLPosts()
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/api/v1/graphql?query=%7B%20tags%20%7B%20name%20%7D%20%7D").
+		ExecuteAsJSON(apiv1.GraphQL())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("data.tags[0].name")).Equals("UI")
+}
+
+func TestGraphQLHandler_NotificationsRequireAuthentication(t *testing.T) {
+	RegisterT(t)
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		ExecutePostAsJSON(apiv1.GraphQL(), `{ "query": "{ notifications { title } }" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("errors[0].message")).Equals("Authentication is required to query notifications.")
+}
+
+func TestGraphQLHandler_NotificationsWithPosts(t *testing.T) {
+	RegisterT(t)
+	mockGraphQLPosts()
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListNotifications) error {
+		q.Result = []*entity.Notification{
+			{ID: 1, Title: "New comment", Post: &entity.NotificationPost{ID: 2, Number: 2}},
+			{ID: 2, Title: "Status changed", Post: &entity.NotificationPost{ID: 2, Number: 2}},
+			{ID: 3, Title: "New post", Post: &entity.NotificationPost{ID: 3, Number: 3}},
+			{ID: 4, Title: "Welcome"},
+		}
+		return nil
+	})
+
+	var getPosts *query.GetPostsByNumbers
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostsByNumbers) error {
+		getPosts = q
+		q.Result = map[int]*entity.Post{
+			2: {ID: 2, Number: 2, Title: "Support SSO", Status: enum.PostPlanned, User: mock.JonSnow},
+		}
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePostAsJSON(apiv1.GraphQL(), `{ "query": "{ notifications { title post { title } } }" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.Contains("errors")).IsFalse()
+	Expect(result.String("data.notifications[0].post.title")).Equals("Support SSO")
+	Expect(result.String("data.notifications[1].post.title")).Equals("Support SSO")
+	Expect(result.String("data.notifications[2].post.title")).Equals("")
+	Expect(result.String("data.notifications[3].post.title")).Equals("")
+
+	// Posts are loaded once for all notifications
+	Expect(bus.GetCallCount(&query.GetPostsByNumbers{})).Equals(1)
+	Expect(getPosts.Numbers).Equals([]int{2, 2, 3})
+}
+
+func TestGraphQLHandler_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+
+	var testCases = []string{
+		`{ }`,
+		`not json`,
+		`{ "query": "{ posts { unknown } }" }`,
+		`{ "query": "mutation { posts { title } }" }`,
+		`{ "query": "{ posts(limit: 100) { comments(limit: 100) { id content author { name } } } }" }`,
+		`{ "query": "{ posts { title } } }" }`,
+	}
+
+	for _, input := range testCases {
+		code, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			ExecutePost(apiv1.GraphQL(), input)
+		Expect(code).Equals(http.StatusBadRequest)
+	}
+}
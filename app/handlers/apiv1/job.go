@@ -0,0 +1,101 @@
+package apiv1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+const maxJobRunsPageSize = 200
+
+// ListJobs returns all background jobs along with their schedule and state
+func ListJobs() web.HandlerFunc {
+	return func(c *web.Context) error {
+		result := make([]*entity.Job, 0)
+		for _, job := range jobs.List() {
+			result = append(result, jobs.GetState(c, job))
+		}
+		return c.Ok(result)
+	}
+}
+
+// ListJobRuns returns the most recent runs of a background job
+func ListJobRuns() web.HandlerFunc {
+	return func(c *web.Context) error {
+		job, ok := jobs.Get(c.Param("name"))
+		if !ok {
+			return c.NotFound()
+		}
+
+		q := &query.ListJobRuns{JobName: job.Name}
+		if c.QueryParam("limit") != "" {
+			limit, err := c.QueryParamAsInt("limit")
+			if err != nil || limit < 1 || limit > maxJobRunsPageSize {
+				result := validate.Success()
+				result.AddFieldFailure("limit", fmt.Sprintf("Limit must be between 1 and %d.", maxJobRunsPageSize))
+				return c.HandleValidation(result)
+			}
+			q.Limit = limit
+		}
+
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// PauseJob stops a background job from running on its schedule
+func PauseJob() web.HandlerFunc {
+	return setJobPaused(true)
+}
+
+// ResumeJob makes a paused background job run on its schedule again
+func ResumeJob() web.HandlerFunc {
+	return setJobPaused(false)
+}
+
+func setJobPaused(paused bool) web.HandlerFunc {
+	return func(c *web.Context) error {
+		job, ok := jobs.Get(c.Param("name"))
+		if !ok {
+			return c.NotFound()
+		}
+
+		if err := jobs.SetPaused(c, job.Name, paused); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(jobs.GetState(c, job))
+	}
+}
+
+// RunJob starts a background job immediately, even if it's paused
+// Returns 409 Conflict when the job is already running
+func RunJob() web.HandlerFunc {
+	return func(c *web.Context) error {
+		job, ok := jobs.Get(c.Param("name"))
+		if !ok {
+			return c.NotFound()
+		}
+
+		started, err := job.RunNow()
+		if err != nil {
+			return c.Failure(err)
+		}
+		if !started {
+			return c.JSON(http.StatusConflict, web.Map{
+				"errors": []web.Map{{"message": fmt.Sprintf("Job '%s' is already running.", job.Name)}},
+			})
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
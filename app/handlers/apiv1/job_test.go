@@ -0,0 +1,156 @@
+package apiv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/robfig/cron"
+)
+
+type mockJobHandler struct{}
+
+func (h mockJobHandler) Schedule() string {
+	return "0 0 * * * *"
+}
+
+func (h mockJobHandler) Run(ctx jobs.Context) error {
+	return nil
+}
+
+func TestListJobsHandler(t *testing.T) {
+	RegisterT(t)
+	jobs.Schedule(context.Background(), cron.New(), "MockJob", mockJobHandler{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
+		switch q.Key {
+		case "jobs.MockJob.paused":
+			q.Value = "true"
+		case "jobs.MockJob.last_successful_run":
+			q.Value = "2026-10-01T10:00:00Z"
+		}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		Execute(apiv1.ListJobs())
+
+	Expect(code).Equals(http.StatusOK)
+
+	var result []*entity.Job
+	Expect(json.NewDecoder(response.Body).Decode(&result)).IsNil()
+	Expect(result).HasLen(1)
+	Expect(result[0].Name).Equals("MockJob")
+	Expect(result[0].Schedule).Equals("0 0 * * * *")
+	Expect(result[0].Paused).IsTrue()
+	Expect(result[0].LastSuccessfulRun.Format(time.RFC3339)).Equals("2026-10-01T10:00:00Z")
+	Expect(result[0].LastFailedRun).IsNil()
+}
+
+func TestListJobRunsHandler(t *testing.T) {
+	RegisterT(t)
+	jobs.Schedule(context.Background(), cron.New(), "MockJob", mockJobHandler{})
+
+	var listQuery *query.ListJobRuns
+	bus.AddHandler(func(ctx context.Context, q *query.ListJobRuns) error {
+		listQuery = q
+		q.Result = []*entity.JobRun{
+			{ID: 1, JobName: "MockJob", Status: enum.JobRunFailed, Error: "Failed"},
+		}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("name", "MockJob").
+		WithURL("http://demo.test.fider.io/api/v1/operator/jobs/MockJob/runs?limit=10").
+		ExecuteAsJSON(apiv1.ListJobRuns())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listQuery.JobName).Equals("MockJob")
+	Expect(listQuery.Limit).Equals(10)
+}
+
+func TestListJobRunsHandler_InvalidLimit(t *testing.T) {
+	RegisterT(t)
+	jobs.Schedule(context.Background(), cron.New(), "MockJob", mockJobHandler{})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("name", "MockJob").
+		WithURL("http://demo.test.fider.io/api/v1/operator/jobs/MockJob/runs?limit=1000").
+		ExecuteAsJSON(apiv1.ListJobRuns())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestPauseJobHandler(t *testing.T) {
+	RegisterT(t)
+	jobs.Schedule(context.Background(), cron.New(), "MockJob", mockJobHandler{})
+
+	var paused string
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetSystemSettings) error {
+		Expect(c.Key).Equals("jobs.MockJob.paused")
+		paused = c.Value
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
+		if q.Key == "jobs.MockJob.paused" {
+			q.Value = paused
+		}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("name", "MockJob").
+		Execute(apiv1.PauseJob())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(paused).Equals("true")
+
+	job := &entity.Job{}
+	Expect(json.NewDecoder(response.Body).Decode(job)).IsNil()
+	Expect(job.Paused).IsTrue()
+
+	code, response = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("name", "MockJob").
+		Execute(apiv1.ResumeJob())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(paused).Equals("false")
+
+	job = &entity.Job{}
+	Expect(json.NewDecoder(response.Body).Decode(job)).IsNil()
+	Expect(job.Paused).IsFalse()
+}
+
+func TestRunJobHandler_UnknownJob(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("name", "UnknownJob").
+		Execute(apiv1.RunJob())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
@@ -0,0 +1,181 @@
+package apiv1
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func currentUserProfile(user *entity.User) web.Map {
+	return web.Map{
+		"id":         user.ID,
+		"name":       user.Name,
+		"email":      user.Email,
+		"role":       user.Role,
+		"status":     user.Status,
+		"avatarType": user.AvatarType,
+		"avatarURL":  user.AvatarURL,
+	}
+}
+
+// GetCurrentUser returns the profile of current user
+func GetCurrentUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return c.Ok(currentUserProfile(c.User()))
+	}
+}
+
+// UpdateCurrentUser updates the profile of current user
+// Fields that are not sent keep their current value
+func UpdateCurrentUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := actions.NewUpdateUserSettings()
+		action.Name = c.User().Name
+		action.AvatarType = c.User().AvatarType
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.UploadImage{
+				Image:  action.Avatar,
+				Folder: "avatars",
+			},
+			&cmd.UpdateCurrentUser{
+				Name:       action.Name,
+				Avatar:     action.Avatar,
+				AvatarType: action.AvatarType,
+			},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		if len(action.Settings) > 0 {
+			if err := bus.Dispatch(c, &cmd.UpdateCurrentUserSettings{Settings: action.Settings}); err != nil {
+				return c.Failure(err)
+			}
+		}
+
+		if env.Config.UserList.Enabled {
+			c.Enqueue(tasks.UserListUpdateUser(c.User().ID, action.Name, ""))
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// UpdateCurrentUserAvatar uploads a new avatar and uses it as the avatar of current user
+func UpdateCurrentUserAvatar() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateCurrentUserAvatar)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.UploadImage{
+				Image:  action.Avatar,
+				Folder: "avatars",
+			},
+			&cmd.UpdateCurrentUser{
+				Name:       c.User().Name,
+				Avatar:     action.Avatar,
+				AvatarType: enum.AvatarTypeCustom,
+			},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// GetCurrentUserSettings returns the notification settings of current user
+func GetCurrentUserSettings() web.HandlerFunc {
+	return func(c *web.Context) error {
+		settings := &query.GetCurrentUserSettings{}
+		if err := bus.Dispatch(c, settings); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(settings.Result)
+	}
+}
+
+// UpdateCurrentUserSettings changes the notification settings of current user
+// Only the given settings are changed
+func UpdateCurrentUserSettings() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateCurrentUserNotificationSettings)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.UpdateCurrentUserSettings{Settings: action.Settings}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// ChangeCurrentUserEmail sends a confirmation link to the new email of current user
+// The email is only changed after the link is opened
+func ChangeCurrentUserEmail() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := actions.NewChangeUserEmail()
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.SaveVerificationKey{
+			Key:      action.VerificationKey,
+			Duration: 24 * time.Hour,
+			Request:  action,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		c.Enqueue(tasks.SendChangeEmailConfirmation(action))
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// ListCurrentUserSubscriptions returns all posts current user is subscribed to
+func ListCurrentUserSubscriptions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		subscriptions := &query.ListCurrentUserSubscriptions{}
+		if err := bus.Dispatch(c, subscriptions); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(subscriptions.Result)
+	}
+}
+
+// DeleteCurrentUser erases current user personal data
+func DeleteCurrentUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if err := bus.Dispatch(c, &cmd.DeleteCurrentUser{}); err != nil {
+			return c.Failure(err)
+		}
+
+		c.RemoveCookie(web.CookieAuthName)
+
+		if env.Config.UserList.Enabled {
+			c.Enqueue(tasks.UserListAddOrRemoveUser(c.User().ID, enum.RoleVisitor))
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,138 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestGetCurrentUserHandler(t *testing.T) {
+	RegisterT(t)
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecuteAsJSON(apiv1.GetCurrentUser())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.Int32("id")).Equals(mock.AryaStark.ID)
+	Expect(result.String("name")).Equals("Arya Stark")
+	Expect(result.String("email")).Equals("arya.stark@got.com")
+	Expect(result.String("role")).Equals("visitor")
+}
+
+func TestUpdateCurrentUserHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImage) error {
+		return nil
+	})
+
+	var updateUser *cmd.UpdateCurrentUser
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateCurrentUser) error {
+		updateUser = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateCurrentUserSettings) error {
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.UpdateCurrentUser(), `{ "name": "Arya", "avatarType": "letter" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateUser.Name).Equals("Arya")
+	Expect(updateUser.AvatarType).Equals(enum.AvatarTypeLetter)
+	Expect(bus.GetCallCount(&cmd.UpdateCurrentUserSettings{})).Equals(0)
+}
+
+func TestUpdateCurrentUserHandler_InvalidName(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.UpdateCurrentUser(), `{ "name": "", "avatarType": "letter" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(bus.GetCallCount(&cmd.UpdateCurrentUser{})).Equals(0)
+}
+
+func TestUpdateCurrentUserSettingsHandler(t *testing.T) {
+	RegisterT(t)
+
+	var updateSettings *cmd.UpdateCurrentUserSettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateCurrentUserSettings) error {
+		updateSettings = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.UpdateCurrentUserSettings(), `{ "settings": { "event_notification_new_comment": "3" } }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateSettings.Settings).Equals(map[string]string{
+		enum.NotificationEventNewComment.UserSettingsKeyName: "3",
+	})
+}
+
+func TestUpdateCurrentUserSettingsHandler_UnknownSetting(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.UpdateCurrentUserSettings(), `{ "settings": { "unknown": "3" } }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(bus.GetCallCount(&cmd.UpdateCurrentUserSettings{})).Equals(0)
+}
+
+func TestListCurrentUserSubscriptionsHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListCurrentUserSubscriptions) error {
+		q.Result = []*entity.PostSubscription{
+			{Number: 1, Title: "Add dark mode", Slug: "add-dark-mode", Status: enum.PostOpen},
+		}
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecuteAsJSON(apiv1.ListCurrentUserSubscriptions())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.ArrayLength()).Equals(1)
+}
+
+func TestDeleteCurrentUserHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteCurrentUser) error {
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		Execute(apiv1.DeleteCurrentUser())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(bus.GetCallCount(&cmd.DeleteCurrentUser{})).Equals(1)
+}
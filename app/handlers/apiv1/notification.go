@@ -0,0 +1,130 @@
+package apiv1
+
+import (
+	"fmt"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+const (
+	defaultNotificationsPageSize = 30
+	maxNotificationsPageSize     = 100
+)
+
+// ListNotifications returns notifications of current user, newest first
+// Notifications can be paginated with "limit" and "after" (ID of the last notification of previous page),
+// filtered with "unread=true" and grouped by post with "group=post".
+// Grouping only applies to the notifications of the requested page, so the same post can
+// be in more than one page. Paging still uses the "after" ID of the last notification of a page
+func ListNotifications() web.HandlerFunc {
+	return func(c *web.Context) error {
+		result := validate.Success()
+		q := &query.ListNotifications{
+			UnreadOnly: c.QueryParam("unread") == "true",
+			Limit:      defaultNotificationsPageSize,
+		}
+
+		after, err := c.QueryParamAsInt("after")
+		if err != nil || after < 0 {
+			result.AddFieldFailure("after", "After must be a valid notification ID.")
+		}
+		q.After = after
+
+		if c.QueryParam("limit") != "" {
+			limit, err := c.QueryParamAsInt("limit")
+			if err != nil || limit < 1 || limit > maxNotificationsPageSize {
+				result.AddFieldFailure("limit", fmt.Sprintf("Limit must be between 1 and %d.", maxNotificationsPageSize))
+			}
+			q.Limit = limit
+		}
+
+		group := c.QueryParam("group")
+		if group != "" && group != "post" {
+			result.AddFieldFailure("group", "Group must be 'post'.")
+		}
+
+		if !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		if group == "post" {
+			groups := entity.GroupNotificationsByPost(q.Result)
+			for _, g := range groups {
+				if g.Count > 1 {
+					g.Title = i18n.T(c, "notification.group.title", i18n.Params{"count": g.Count, "title": g.Post.Title})
+					g.Link = fmt.Sprintf("/posts/%d/%s", g.Post.Number, g.Post.Slug)
+				}
+			}
+			return c.Ok(groups)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// MarkNotificationAsRead marks a single notification of current user as read
+func MarkNotificationAsRead() web.HandlerFunc {
+	return func(c *web.Context) error {
+		notification, err := getCurrentUserNotification(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.MarkNotificationAsRead{ID: notification.ID}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// MarkNotificationAsUnread marks a single notification of current user as unread
+func MarkNotificationAsUnread() web.HandlerFunc {
+	return func(c *web.Context) error {
+		notification, err := getCurrentUserNotification(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.MarkNotificationAsUnread{ID: notification.ID}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// MarkAllNotificationsAsRead marks all notifications of current user as read
+func MarkAllNotificationsAsRead() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if err := bus.Dispatch(c, &cmd.MarkAllNotificationsAsRead{}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+func getCurrentUserNotification(c *web.Context) (*entity.Notification, error) {
+	id, err := c.ParamAsInt("id")
+	if err != nil {
+		return nil, app.ErrNotFound
+	}
+
+	q := &query.GetNotificationByID{ID: id}
+	if err := bus.Dispatch(c, q); err != nil {
+		return nil, err
+	}
+	return q.Result, nil
+}
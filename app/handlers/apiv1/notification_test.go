@@ -0,0 +1,142 @@
+package apiv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestListNotificationsHandler(t *testing.T) {
+	RegisterT(t)
+
+	var listNotifications *query.ListNotifications
+	bus.AddHandler(func(ctx context.Context, q *query.ListNotifications) error {
+		listNotifications = q
+		q.Result = []*entity.Notification{
+			{ID: 3, Title: "Third", Read: false, CreatedAt: time.Now()},
+			{ID: 2, Title: "Second", Read: false, CreatedAt: time.Now()},
+		}
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://demo.test.fider.io/api/v1/notifications?unread=true&after=4&limit=2").
+		ExecuteAsJSON(apiv1.ListNotifications())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.ArrayLength()).Equals(2)
+	Expect(listNotifications.UnreadOnly).IsTrue()
+	Expect(listNotifications.After).Equals(4)
+	Expect(listNotifications.Limit).Equals(2)
+}
+
+func TestListNotificationsHandler_GroupByPost(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.NotificationPost{ID: 1, Number: 1, Title: "Add dark mode", Slug: "add-dark-mode"}
+	bus.AddHandler(func(ctx context.Context, q *query.ListNotifications) error {
+		q.Result = []*entity.Notification{
+			{ID: 3, Title: "**Jon Snow** left a comment on **Add dark mode**", Post: post, CreatedAt: time.Now()},
+			{ID: 2, Title: "**Arya Stark** left a comment on **Add dark mode**", Post: post, Read: true, CreatedAt: time.Now()},
+			{ID: 1, Title: "**Sansa Stark** left a comment on **Add dark mode**", Post: post, CreatedAt: time.Now()},
+		}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://demo.test.fider.io/api/v1/notifications?group=post").
+		Execute(apiv1.ListNotifications())
+
+	Expect(code).Equals(http.StatusOK)
+
+	groups := []*entity.NotificationGroup{}
+	Expect(json.Unmarshal(response.Body.Bytes(), &groups)).IsNil()
+	Expect(groups).HasLen(1)
+	Expect(groups[0].Title).Equals("3 new notifications on **Add dark mode**")
+	Expect(groups[0].Link).Equals("/posts/1/add-dark-mode")
+	Expect(groups[0].Count).Equals(3)
+	Expect(groups[0].UnreadCount).Equals(2)
+	Expect(groups[0].Notifications).HasLen(3)
+}
+
+func TestListNotificationsHandler_InvalidQuery(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://demo.test.fider.io/api/v1/notifications?limit=500&group=user").
+		ExecuteAsJSON(apiv1.ListNotifications())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestMarkNotificationAsUnreadHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetNotificationByID) error {
+		q.Result = &entity.Notification{ID: q.ID, Read: true}
+		return nil
+	})
+
+	var markAsUnread *cmd.MarkNotificationAsUnread
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkNotificationAsUnread) error {
+		markAsUnread = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("id", 5).
+		Execute(apiv1.MarkNotificationAsUnread())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(markAsUnread.ID).Equals(5)
+}
+
+func TestMarkNotificationAsReadHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetNotificationByID) error {
+		return app.ErrNotFound
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("id", 5).
+		Execute(apiv1.MarkNotificationAsRead())
+
+	Expect(code).Equals(http.StatusNotFound)
+	ExpectHandler(&cmd.MarkNotificationAsRead{}).CalledTimes(0)
+}
+
+func TestMarkNotificationAsReadHandler_InvalidID(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("id", "abc").
+		Execute(apiv1.MarkNotificationAsRead())
+
+	Expect(code).Equals(http.StatusNotFound)
+	ExpectHandler(&query.GetNotificationByID{}).CalledTimes(0)
+	ExpectHandler(&cmd.MarkNotificationAsRead{}).CalledTimes(0)
+}
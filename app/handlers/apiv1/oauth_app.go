@@ -0,0 +1,79 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListOAuthApps returns all third-party applications registered on current tenant
+func ListOAuthApps() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.ListOAuthApps{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditOAuthApp registers a new third-party application or edit an existing one
+// The client secret is only returned when the application is created
+func CreateEditOAuthApp() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditOAuthApp)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.App != nil {
+			updateApp := &cmd.UpdateOAuthApp{
+				ID:           action.App.ID,
+				Name:         action.Name,
+				RedirectURIs: action.RedirectURIs,
+				Scopes:       action.Scopes,
+			}
+			if err := bus.Dispatch(c, updateApp); err != nil {
+				return c.Failure(err)
+			}
+
+			action.App.Name = action.Name
+			action.App.RedirectURIs = action.RedirectURIs
+			action.App.Scopes = action.Scopes
+			return c.Ok(action.App)
+		}
+
+		createApp := &cmd.CreateOAuthApp{
+			Name:         action.Name,
+			RedirectURIs: action.RedirectURIs,
+			Scopes:       action.Scopes,
+		}
+		if err := bus.Dispatch(c, createApp); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"app":          createApp.Result,
+			"clientSecret": createApp.ClientSecret,
+		})
+	}
+}
+
+// DeleteOAuthApp deletes an existing third-party application and revokes all its tokens
+func DeleteOAuthApp() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteOAuthApp)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteOAuthApp{ID: action.App.ID}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,76 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestCreateOAuthAppHandler(t *testing.T) {
+	RegisterT(t)
+
+	var createApp *cmd.CreateOAuthApp
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateOAuthApp) error {
+		createApp = c
+		c.Result = &entity.OAuthApp{ID: 1, Name: c.Name, ClientID: "client-id", RedirectURIs: c.RedirectURIs, Scopes: c.Scopes}
+		c.ClientSecret = "client-secret"
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(apiv1.CreateEditOAuthApp(), `{ "name": "Zapier", "redirectURIs": ["https://zapier.com/callback"], "scopes": ["read"] }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(createApp.Name).Equals("Zapier")
+	Expect(result.String("app.clientID")).Equals("client-id")
+	Expect(result.String("clientSecret")).Equals("client-secret")
+}
+
+func TestCreateOAuthAppHandler_RequiresAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreateEditOAuthApp(), `{ "name": "Zapier", "redirectURIs": ["https://zapier.com/callback"], "scopes": ["read"] }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+	Expect(bus.GetCallCount(&cmd.CreateOAuthApp{})).Equals(0)
+}
+
+func TestUpdateOAuthAppHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthAppByID) error {
+		q.Result = &entity.OAuthApp{ID: q.ID, Name: "Zapier", ClientID: "client-id"}
+		return nil
+	})
+
+	var updateApp *cmd.UpdateOAuthApp
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateOAuthApp) error {
+		updateApp = c
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 4).
+		ExecutePostAsJSON(apiv1.CreateEditOAuthApp(), `{ "name": "Zapier 2", "redirectURIs": ["https://zapier.com/callback"], "scopes": ["read", "write"] }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateApp.ID).Equals(4)
+	Expect(updateApp.Scopes).Equals([]string{"read", "write"})
+	Expect(result.String("name")).Equals("Zapier 2")
+	Expect(result.Contains("clientSecret")).IsFalse()
+}
@@ -0,0 +1,80 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListOAuthConfigs returns all custom OAuth providers of current tenant
+// Client secrets are masked
+func ListOAuthConfigs() web.HandlerFunc {
+	return func(c *web.Context) error {
+		listConfigs := &query.ListCustomOAuthConfig{}
+		if err := bus.Dispatch(c, listConfigs); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(listConfigs.Result)
+	}
+}
+
+// GetOAuthConfig returns the custom OAuth provider with given key
+func GetOAuthConfig() web.HandlerFunc {
+	return func(c *web.Context) error {
+		getConfig := &query.GetCustomOAuthConfigByProvider{
+			Provider: c.Param("provider"),
+		}
+		if err := bus.Dispatch(c, getConfig); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(getConfig.Result)
+	}
+}
+
+// SaveOAuthConfig creates a custom OAuth provider, or edit an existing one when "provider" is given
+// The client secret is kept when it's not sent
+func SaveOAuthConfig() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := actions.NewCreateEditOAuthConfig()
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.UploadImage{
+				Image:  action.Logo,
+				Folder: "logos",
+			},
+			&cmd.SaveCustomOAuthConfig{
+				ID:                action.ID,
+				Logo:              action.Logo,
+				Provider:          action.Provider,
+				Status:            action.Status,
+				DisplayName:       action.DisplayName,
+				ClientID:          action.ClientID,
+				ClientSecret:      action.ClientSecret,
+				AuthorizeURL:      action.AuthorizeURL,
+				TokenURL:          action.TokenURL,
+				Scope:             action.Scope,
+				ProfileURL:        action.ProfileURL,
+				IsTrusted:         action.IsTrusted,
+				JSONUserIDPath:    action.JSONUserIDPath,
+				JSONUserNamePath:  action.JSONUserNamePath,
+				JSONUserEmailPath: action.JSONUserEmailPath,
+			},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{"provider": action.Provider})
+	}
+}
@@ -0,0 +1,63 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestGetOAuthConfigHandler_MasksSecret(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomOAuthConfigByProvider) error {
+		q.Result = &entity.OAuthConfig{ID: 1, Provider: q.Provider, ClientSecret: "0123456789abcdef"}
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("provider", "_custom").
+		ExecuteAsJSON(apiv1.GetOAuthConfig())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("provider")).Equals("_custom")
+	Expect(result.String("clientSecret")).Equals("012...def")
+}
+
+func TestGetOAuthConfigHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomOAuthConfigByProvider) error {
+		return app.ErrNotFound
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("provider", "_unknown").
+		Execute(apiv1.GetOAuthConfig())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestSaveOAuthConfigHandler_InvalidRequest(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(apiv1.SaveOAuthConfig(), `{ "status": 1, "displayName": "" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(bus.GetCallCount(&cmd.SaveCustomOAuthConfig{})).Equals(0)
+}
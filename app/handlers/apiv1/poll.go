@@ -0,0 +1,147 @@
+package apiv1
+
+import (
+	"fmt"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/csv"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
+)
+
+// ListPolls returns all polls of a post, including results when visible to current user
+func ListPolls() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getPolls := &query.GetPollsByPost{Post: getPost.Result}
+		if err := bus.Dispatch(c, getPolls); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getPolls.Result)
+	}
+}
+
+// CreatePoll attaches a new poll to a post and notifies its subscribers
+func CreatePoll() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateNewPoll)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addNewPoll := &cmd.AddNewPoll{
+			Post:              action.Post,
+			Question:          action.Question,
+			Options:           action.Options,
+			IsMultipleChoice:  action.IsMultipleChoice,
+			ResultsVisibility: action.ResultsVisibility,
+			ClosesAt:          action.ClosesAt,
+		}
+		if err := bus.Dispatch(c, addNewPoll); err != nil {
+			return c.Failure(err)
+		}
+
+		c.Enqueue(tasks.NotifyAboutNewPoll(action.Post, addNewPoll.Result))
+
+		return c.Ok(addNewPoll.Result)
+	}
+}
+
+// DeletePoll deletes a poll and all its votes
+func DeletePoll() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeletePoll)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeletePoll{Poll: action.Poll}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// VoteOnPoll sets the choices of current user on a poll
+func VoteOnPoll() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.VoteOnPoll)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		setVote := &cmd.SetPollVote{Poll: action.Poll, OptionIDs: action.OptionIDs}
+		if err := bus.Dispatch(c, setVote); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// RemovePollVote removes the choices of current user from a poll
+func RemovePollVote() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.RemovePollVote)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.RemovePollVote{Poll: action.Poll}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// ExportPollVotesToCSV returns a CSV with all votes of a poll
+func ExportPollVotesToCSV() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		pollID, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getPoll := &query.GetPollByID{Post: getPost.Result, PollID: pollID}
+		if err := bus.Dispatch(c, getPoll); err != nil {
+			return c.Failure(err)
+		}
+
+		listVotes := &query.ListPollVotes{Poll: getPoll.Result}
+		if err := bus.Dispatch(c, listVotes); err != nil {
+			return c.Failure(err)
+		}
+
+		bytes, err := csv.FromPollVotes(listVotes.Result)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Attachment(fmt.Sprintf("poll-%d-votes.csv", pollID), "text/csv", bytes)
+	}
+}
@@ -0,0 +1,114 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestCreatePollHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "My first post", Slug: "my-first-post"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var newPoll *cmd.AddNewPoll
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPoll) error {
+		newPoll = c
+		c.Result = &entity.Poll{ID: 1, Question: c.Question}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", 1).
+		ExecutePost(apiv1.CreatePoll(), `{ "question": "Which platform do you use?", "options": ["Windows", "Linux"], "resultsVisibility": "staff" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(newPoll.Post).Equals(post)
+	Expect(newPoll.Question).Equals("Which platform do you use?")
+	Expect(newPoll.Options).Equals([]string{"Windows", "Linux"})
+	Expect(newPoll.ResultsVisibility).Equals(enum.PollResultsStaff)
+	Expect(newPoll.IsMultipleChoice).IsFalse()
+}
+
+func TestVoteOnPollHandler(t *testing.T) {
+	RegisterT(t)
+
+	poll := &entity.Poll{ID: 2, Options: []*entity.PollOption{{ID: 5}, {ID: 6}}}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPollByID) error {
+		q.Result = poll
+		return nil
+	})
+
+	var setVote *cmd.SetPollVote
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPollVote) error {
+		setVote = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		AddParam("id", 2).
+		ExecutePost(apiv1.VoteOnPoll(), `{ "options": [6] }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setVote.Poll).Equals(poll)
+	Expect(setVote.OptionIDs).Equals([]int{6})
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		AddParam("id", 2).
+		ExecutePost(apiv1.VoteOnPoll(), `{ "options": [7] }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestExportPollVotesToCSVHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPollByID) error {
+		q.Result = &entity.Poll{ID: q.PollID}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListPollVotes) error {
+		q.Result = []*entity.PollVote{}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", 1).
+		AddParam("id", 2).
+		Execute(apiv1.ExportPollVotesToCSV())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Disposition")).Equals(`attachment; filename="poll-2-votes.csv"`)
+	Expect(response.Body.String()).Equals("option_id,option,user_id,user_name,user_email,voted_at\n")
+}
@@ -1,6 +1,11 @@
 package apiv1
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
@@ -8,10 +13,15 @@ import (
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/ogimage"
+	"github.com/getfider/fider/app/pkg/validate"
 	"github.com/getfider/fider/app/pkg/web"
 	"github.com/getfider/fider/app/tasks"
 )
 
+const maxCommentsPageSize = 100
+
 // SearchPosts return existing posts based on search criteria
 func SearchPosts() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -60,6 +70,13 @@ func CreatePost() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		// Visitors can pick a tag to get its template, but only the staff can assign tags
+		if action.Tag != nil && c.User().IsCollaborator() {
+			if err = bus.Dispatch(c, &cmd.AssignTag{Tag: action.Tag, Post: newPost.Result}); err != nil {
+				return c.Failure(err)
+			}
+		}
+
 		c.Enqueue(tasks.NotifyAboutNewPost(newPost.Result))
 
 		metrics.TotalPosts.Inc()
@@ -116,6 +133,10 @@ func UpdatePost() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		if action.Title != action.Post.Title {
+			c.Enqueue(tasks.PurgePostOGImage(*action.Post))
+		}
+
 		return c.Ok(web.Map{})
 	}
 }
@@ -134,6 +155,7 @@ func SetResponse() web.HandlerFunc {
 		}
 
 		prevStatus := getPost.Result.Status
+		prevPost := *getPost.Result
 
 		var command bus.Msg
 		if action.Status == enum.PostDuplicate {
@@ -151,11 +173,112 @@ func SetResponse() web.HandlerFunc {
 		}
 
 		c.Enqueue(tasks.NotifyAboutStatusChange(getPost.Result, prevStatus))
+		if action.Status != prevStatus {
+			c.Enqueue(tasks.PurgePostOGImage(prevPost))
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// SetPostScore updates the prioritization fields of a post
+func SetPostScore() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SetPostScore)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.SetPostScore{
+			Post:       action.Post,
+			Reach:      action.Reach,
+			Impact:     action.Impact,
+			Confidence: action.Confidence,
+			Effort:     action.Effort,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// SetPostTarget updates when a post is expected to be released
+func SetPostTarget() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SetPostTarget)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		prevTarget := action.Post.Target
+		err := bus.Dispatch(c, &cmd.SetPostTarget{
+			Post:   action.Post,
+			Target: action.Target,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		c.Enqueue(tasks.NotifyAboutTargetChange(action.Post, prevTarget))
 
 		return c.Ok(web.Map{})
 	}
 }
 
+// ReorderPost moves a post right before or after another post of the same status
+func ReorderPost() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.ReorderPost)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		reorderPost := &cmd.ReorderPost{Post: action.Post}
+		if action.Before != 0 {
+			reorderPost.Before = action.Reference
+		} else {
+			reorderPost.After = action.Reference
+		}
+
+		if err := bus.Dispatch(c, reorderPost); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"rank": action.Post.Rank,
+		})
+	}
+}
+
+// GetRoadmap returns planned and started posts grouped by target release, ordered by date
+// Other statuses can be given with ?statuses=planned,started,completed
+// Posts are sorted by votes, or by the staff ranking within each status with ?view=ranked
+func GetRoadmap() web.HandlerFunc {
+	return func(c *web.Context) error {
+		getRoadmap := &query.GetRoadmap{View: c.QueryParam("view")}
+		if getRoadmap.View != "" && getRoadmap.View != "most-wanted" && getRoadmap.View != "ranked" {
+			return c.BadRequest(web.Map{})
+		}
+
+		for _, name := range c.QueryParamAsArray("statuses") {
+			var status enum.PostStatus
+			_ = status.UnmarshalText([]byte(name))
+			if status != enum.PostPlanned && status != enum.PostStarted && status != enum.PostCompleted {
+				return c.BadRequest(web.Map{})
+			}
+			getRoadmap.Statuses = append(getRoadmap.Statuses, status)
+		}
+
+		if err := bus.Dispatch(c, getRoadmap); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getRoadmap.Result)
+	}
+}
+
 // DeletePost deletes an existing post of current tenant
 func DeletePost() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -164,6 +287,7 @@ func DeletePost() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
+		prevPost := *action.Post
 		err := bus.Dispatch(c, &cmd.SetPostResponse{
 			Post:   action.Post,
 			Text:   action.Text,
@@ -174,12 +298,16 @@ func DeletePost() web.HandlerFunc {
 		}
 
 		c.Enqueue(tasks.NotifyAboutDeletedPost(action.Post, action.Text != ""))
+		c.Enqueue(tasks.PurgePostOGImage(prevPost))
 
 		return c.Ok(web.Map{})
 	}
 }
 
-// ListComments returns a list of all comments of a post
+// ListComments returns a list of comments of a post
+// Comments can be paginated with "limit" and "after" (ID of the last comment of previous page),
+// sorted with "sort" (oldest or newest) and filtered with "since" (RFC 3339 date).
+// When a page is full, the URL of the next one is sent on the Link header
 func ListComments() web.HandlerFunc {
 	return func(c *web.Context) error {
 		number, err := c.ParamAsInt("number")
@@ -193,14 +321,65 @@ func ListComments() web.HandlerFunc {
 		}
 
 		getComments := &query.GetCommentsByPost{Post: getPost.Result}
+		if result := parseCommentsQuery(c, getComments); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
 		if err := bus.Dispatch(c, getComments); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				result := validate.Success()
+				result.AddFieldFailure("after", "After must be the ID of a comment of this post.")
+				return c.HandleValidation(result)
+			}
 			return c.Failure(err)
 		}
 
+		if count := len(getComments.Result); getComments.Limit > 0 && count == getComments.Limit {
+			next := *c.Request.URL
+			params := next.Query()
+			params.Set("after", strconv.Itoa(getComments.Result[count-1].ID))
+			next.RawQuery = params.Encode()
+			c.Response.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+		}
+
 		return c.Ok(getComments.Result)
 	}
 }
 
+func parseCommentsQuery(c *web.Context, q *query.GetCommentsByPost) *validate.Result {
+	result := validate.Success()
+
+	q.Sort = c.QueryParam("sort")
+	if q.Sort == "" {
+		q.Sort = "oldest"
+	} else if q.Sort != "oldest" && q.Sort != "newest" {
+		result.AddFieldFailure("sort", "Sort must be either 'oldest' or 'newest'.")
+	}
+
+	if since := c.QueryParam("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			result.AddFieldFailure("since", "Since must be a valid RFC 3339 date.")
+		} else {
+			q.Since = &t
+		}
+	}
+
+	after, err := c.QueryParamAsInt("after")
+	if err != nil || after < 0 {
+		result.AddFieldFailure("after", "After must be a valid comment ID.")
+	}
+	q.After = after
+
+	limit, err := c.QueryParamAsInt("limit")
+	if err != nil || limit < 0 || limit > maxCommentsPageSize || (limit == 0 && c.QueryParam("limit") != "") {
+		result.AddFieldFailure("limit", fmt.Sprintf("Limit must be between 1 and %d.", maxCommentsPageSize))
+	}
+	q.Limit = limit
+
+	return result
+}
+
 // GetComment returns a single comment by its ID
 func GetComment() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -313,24 +492,52 @@ func DeleteComment() web.HandlerFunc {
 // AddVote adds current user to given post list of votes
 func AddVote() web.HandlerFunc {
 	return func(c *web.Context) error {
-		err := addOrRemove(c, func(post *entity.Post, user *entity.User) bus.Msg {
-			return &cmd.AddVote{Post: post, User: user}
+		action := new(actions.AddVote)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.AddVote{
+			Post:       action.Post,
+			User:       c.User(),
+			Reason:     action.Reason,
+			Importance: action.Importance,
 		})
+		if err != nil {
+			return c.Failure(err)
+		}
 
-		if err == nil {
-			metrics.TotalVotes.Inc()
+		if ogimage.VotesBucket(action.Post.VotesCount+1) != ogimage.VotesBucket(action.Post.VotesCount) {
+			c.Enqueue(tasks.PurgePostOGImage(*action.Post))
 		}
 
-		return err
+		metrics.TotalVotes.Inc()
+		return c.Ok(web.Map{})
 	}
 }
 
 // RemoveVote removes current user from given post list of votes
 func RemoveVote() web.HandlerFunc {
 	return func(c *web.Context) error {
-		return addOrRemove(c, func(post *entity.Post, user *entity.User) bus.Msg {
-			return &cmd.RemoveVote{Post: post, User: user}
-		})
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.RemoveVote{Post: getPost.Result, User: c.User()}); err != nil {
+			return c.Failure(err)
+		}
+
+		if ogimage.VotesBucket(getPost.Result.VotesCount-1) != ogimage.VotesBucket(getPost.Result.VotesCount) {
+			c.Enqueue(tasks.PurgePostOGImage(*getPost.Result))
+		}
+
+		return c.Ok(web.Map{})
 	}
 }
 
@@ -365,7 +572,7 @@ func ListVotes() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		listVotes := &query.ListPostVotes{PostID: getPost.Result.ID, IncludeEmail: true}
+		listVotes := &query.ListPostVotes{PostID: getPost.Result.ID, IncludeEmail: true, IncludeReason: true}
 		err = bus.Dispatch(c, listVotes)
 		if err != nil {
 			return c.Failure(err)
@@ -0,0 +1,102 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// GetPostTemplate returns the post template of given tag
+// or the default post template when no tag is selected
+func GetPostTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		var tag *entity.Tag
+		if slug := c.Param("slug"); slug != "" {
+			getTag := &query.GetTagBySlug{Slug: slug}
+			if err := bus.Dispatch(c, getTag); err != nil {
+				return c.Failure(err)
+			}
+
+			if !getTag.Result.IsPublic && (!c.IsAuthenticated() || !c.User().IsCollaborator()) {
+				return c.NotFound()
+			}
+			tag = getTag.Result
+		}
+
+		getTemplate := &query.GetPostTemplateForTag{Tag: tag}
+		if err := bus.Dispatch(c, getTemplate); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getTemplate.Result)
+	}
+}
+
+// ListPostTemplates returns all post templates
+func ListPostTemplates() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetAllPostTemplates{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditPostTemplate creates a new post template or edit an existing one
+func CreateEditPostTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditPostTemplate)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.Template != nil {
+			updateTemplate := &cmd.UpdatePostTemplate{
+				TemplateID:       action.Template.ID,
+				Name:             action.Name,
+				Content:          action.Content,
+				RequiredSections: action.RequiredSections,
+				IsDefault:        action.IsDefault,
+				Tags:             action.Tags,
+			}
+			if err := bus.Dispatch(c, updateTemplate); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateTemplate.Result)
+		}
+
+		addNewTemplate := &cmd.AddNewPostTemplate{
+			Name:             action.Name,
+			Content:          action.Content,
+			RequiredSections: action.RequiredSections,
+			IsDefault:        action.IsDefault,
+			Tags:             action.Tags,
+		}
+		if err := bus.Dispatch(c, addNewTemplate); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addNewTemplate.Result)
+	}
+}
+
+// DeletePostTemplate deletes an existing post template
+func DeletePostTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeletePostTemplate)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.DeletePostTemplate{Template: action.Template})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
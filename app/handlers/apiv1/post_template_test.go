@@ -0,0 +1,132 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestGetPostTemplateHandler(t *testing.T) {
+	RegisterT(t)
+
+	bug := &entity.Tag{ID: 1, Slug: "bug", Name: "Bug", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == bug.Slug {
+			q.Result = bug
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		if q.Tag == bug {
+			q.Result = &entity.PostTemplate{ID: 1, Name: "Bug Report", Content: "## Steps to reproduce"}
+		} else {
+			q.Result = &entity.PostTemplate{ID: 2, Name: "General", Content: "## Context", IsDefault: true}
+		}
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("slug", "bug").
+		ExecuteAsJSON(apiv1.GetPostTemplate())
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.String("name")).Equals("Bug Report")
+
+	code, query = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		ExecuteAsJSON(apiv1.GetPostTemplate())
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.String("name")).Equals("General")
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("slug", "unknown").
+		ExecuteAsJSON(apiv1.GetPostTemplate())
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestGetPostTemplateHandler_PrivateTag(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 1, Slug: q.Slug, Name: "Internal", IsPublic: false}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		q.Result = &entity.PostTemplate{ID: 1, Name: "Internal Report", Content: "## Details"}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("slug", "internal").
+		ExecuteAsJSON(apiv1.GetPostTemplate())
+	Expect(code).Equals(http.StatusNotFound)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("slug", "internal").
+		ExecuteAsJSON(apiv1.GetPostTemplate())
+	Expect(code).Equals(http.StatusOK)
+}
+
+func TestCreatePostTemplateHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 1, Slug: q.Slug, Name: "Bug", IsPublic: true}
+		return nil
+	})
+
+	var addNewTemplate *cmd.AddNewPostTemplate
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPostTemplate) error {
+		addNewTemplate = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(apiv1.CreateEditPostTemplate(), `{ "name": "Bug Report", "content": "## Steps to reproduce", "requiredSections": ["Steps to reproduce"], "tags": ["bug"] }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(addNewTemplate.Name).Equals("Bug Report")
+	Expect(addNewTemplate.RequiredSections).Equals([]string{"Steps to reproduce"})
+	Expect(addNewTemplate.Tags).HasLen(1)
+	Expect(addNewTemplate.Tags[0].Slug).Equals("bug")
+}
+
+func TestCreatePostTemplateHandler_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		return app.ErrNotFound
+	})
+
+	var testCases = []string{
+		`{ }`,
+		`{ "name": "Bug Report" }`,
+		`{ "name": "Bug Report", "content": "## Steps", "requiredSections": ["Expected behavior"] }`,
+		`{ "name": "Bug Report", "content": "## Steps", "tags": ["unknown"] }`,
+	}
+
+	for _, input := range testCases {
+		code, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AsUser(mock.JonSnow).
+			ExecutePost(apiv1.CreateEditPostTemplate(), input)
+		Expect(code).Equals(http.StatusBadRequest)
+	}
+}
@@ -2,6 +2,7 @@ package apiv1_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -38,6 +39,10 @@ func TestCreatePostHandler(t *testing.T) {
 		return app.ErrNotFound
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		return app.ErrNotFound
+	})
+
 	bus.AddHandler(func(ctx context.Context, c *cmd.SetAttachments) error { return nil })
 	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error { return nil })
 	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error { return nil })
@@ -52,9 +57,63 @@ func TestCreatePostHandler(t *testing.T) {
 	Expect(newPost.Description).Equals("")
 }
 
+func TestCreatePostHandler_WithTag(t *testing.T) {
+	RegisterT(t)
+
+	bug := &entity.Tag{ID: 1, Slug: "bug", Name: "Bug", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
+		c.Result = &entity.Post{ID: 1, Title: c.Title, Description: c.Description}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error { return app.ErrNotFound })
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = bug
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		q.Result = &entity.PostTemplate{Content: "## Steps to reproduce", RequiredSections: []string{"Steps to reproduce"}}
+		return nil
+	})
+
+	var assignTag *cmd.AssignTag
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignTag) error {
+		assignTag = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetAttachments) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error { return nil })
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreatePost(), `{ "title": "The app crashes on login", "tag": "bug" }`)
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(assignTag).IsNil()
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreatePost(), `{ "title": "The app crashes on login", "tag": "bug", "description": "## Steps to reproduce\nClick login" }`)
+	Expect(code).Equals(http.StatusOK)
+	Expect(assignTag).IsNil()
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(apiv1.CreatePost(), `{ "title": "The app crashes on login", "tag": "bug", "description": "## Steps to reproduce\nClick login" }`)
+	Expect(code).Equals(http.StatusOK)
+	Expect(assignTag.Tag).Equals(bug)
+	Expect(assignTag.Post.ID).Equals(1)
+}
+
 func TestCreatePostHandler_WithoutTitle(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateForTag) error {
+		return app.ErrNotFound
+	})
+
 	code, _ := mock.NewServer().
 		OnTenant(mock.DemoTenant).
 		AsUser(mock.JonSnow).
@@ -414,6 +473,158 @@ func TestSetResponseHandler_Duplicate_Itself(t *testing.T) {
 	Expect(code).Equals(http.StatusBadRequest)
 }
 
+func TestSetPostTargetHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "My First Post", Slug: "my-first-post", Status: enum.PostPlanned}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var setTarget *cmd.SetPostTarget
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostTarget) error {
+		setTarget = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.SetPostTarget(), `{ "kind": "month", "value": "2026-09", "isPublic": false }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setTarget.Post).Equals(post)
+	Expect(setTarget.Target).Equals(&entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: false})
+}
+
+func TestSetPostTargetHandler_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(apiv1.SetPostTarget(), `{ "kind": "month", "value": "2026-09" }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestReorderPostHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: q.Number, Number: q.Number, Status: enum.PostPlanned}
+		return nil
+	})
+
+	var reorderPost *cmd.ReorderPost
+	bus.AddHandler(func(ctx context.Context, c *cmd.ReorderPost) error {
+		reorderPost = c
+		rank := 1500.0
+		c.Post.Rank = &rank
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", 1).
+		ExecutePost(apiv1.ReorderPost(), `{ "after": 2 }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(reorderPost.Post.Number).Equals(1)
+	Expect(reorderPost.Before).IsNil()
+	Expect(reorderPost.After.Number).Equals(2)
+	Expect(response.Body.String()).ContainsSubstring(`"rank":1500`)
+}
+
+func TestReorderPostHandler_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(apiv1.ReorderPost(), `{ "before": 2 }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestGetRoadmapHandler(t *testing.T) {
+	RegisterT(t)
+
+	var getRoadmap *query.GetRoadmap
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoadmap) error {
+		getRoadmap = q
+		q.Result = []*entity.RoadmapGroup{
+			{
+				Target: &entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q3", IsPublic: true},
+				Posts:  []*entity.Post{{ID: 1, Number: 1, Title: "My First Post"}},
+			},
+			{
+				Target: nil,
+				Posts:  []*entity.Post{{ID: 2, Number: 2, Title: "My Second Post"}},
+			},
+		}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/api/v1/roadmap?statuses=planned,completed").
+		Execute(apiv1.GetRoadmap())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(getRoadmap.Statuses).Equals([]enum.PostStatus{enum.PostPlanned, enum.PostCompleted})
+
+	var groups []*entity.RoadmapGroup
+	Expect(json.Unmarshal(response.Body.Bytes(), &groups)).IsNil()
+	Expect(groups).HasLen(2)
+	Expect(groups[0].Target).Equals(&entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q3", IsPublic: true})
+	Expect(groups[0].Posts[0].Number).Equals(1)
+	Expect(groups[1].Target).IsNil()
+	Expect(groups[1].Posts[0].Number).Equals(2)
+}
+
+func TestGetRoadmapHandler_Ranked(t *testing.T) {
+	RegisterT(t)
+
+	var getRoadmap *query.GetRoadmap
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoadmap) error {
+		getRoadmap = q
+		q.Result = []*entity.RoadmapGroup{}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/api/v1/roadmap?view=ranked").
+		Execute(apiv1.GetRoadmap())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(getRoadmap.View).Equals("ranked")
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/api/v1/roadmap?view=trending").
+		Execute(apiv1.GetRoadmap())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestGetRoadmapHandler_InvalidStatus(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/api/v1/roadmap?statuses=declined").
+		Execute(apiv1.GetRoadmap())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
 func TestAddVoteHandler(t *testing.T) {
 	RegisterT(t)
 
@@ -440,6 +651,33 @@ func TestAddVoteHandler(t *testing.T) {
 	Expect(addVote.User).Equals(mock.AryaStark)
 }
 
+func TestAddVoteHandler_WithReason(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var addVote *cmd.AddVote
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error {
+		addVote = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.AddVote(), `{ "reason": " We need this for our audit ", "importance": "critical" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(addVote.Post).Equals(post)
+	Expect(addVote.Reason).Equals("We need this for our audit")
+	Expect(addVote.Importance).Equals(enum.VoteImportanceCritical)
+}
+
 func TestAddVoteHandler_InvalidPost(t *testing.T) {
 	RegisterT(t)
 
@@ -654,3 +892,107 @@ func TestListCommentHandler(t *testing.T) {
 	Expect(query.IsArray()).IsTrue()
 	Expect(query.ArrayLength()).Equals(2)
 }
+
+func TestListCommentHandler_Paginated(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var getComments *query.GetCommentsByPost
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentsByPost) error {
+		getComments = q
+		q.Result = []*entity.Comment{
+			{ID: 6, Content: "Sixth Comment"},
+		}
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/comments?sort=newest&limit=1&after=7&since=2026-01-02T10:00:00Z").
+		AddParam("number", post.Number).
+		ExecuteAsJSON(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.ArrayLength()).Equals(1)
+	Expect(getComments.Sort).Equals("newest")
+	Expect(getComments.Limit).Equals(1)
+	Expect(getComments.After).Equals(7)
+	Expect(*getComments.Since).Equals(time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC))
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/comments?sort=newest&limit=1&after=7").
+		AddParam("number", post.Number).
+		Execute(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Link")).Equals(`<http://demo.test.fider.io/api/v1/posts/1/comments?after=6&limit=1&sort=newest>; rel="next"`)
+
+	code, response = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/comments?limit=2").
+		AddParam("number", post.Number).
+		Execute(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Link")).Equals("")
+}
+
+func TestListCommentHandler_UnknownCursor(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentsByPost) error {
+		return app.ErrNotFound
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/comments?after=999").
+		AddParam("number", post.Number).
+		ExecuteAsJSON(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestListCommentHandler_InvalidQuery(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/comments?sort=random&limit=1000&since=yesterday").
+		AddParam("number", post.Number).
+		ExecuteAsJSON(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusBadRequest)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/comments?limit=0").
+		AddParam("number", post.Number).
+		ExecuteAsJSON(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
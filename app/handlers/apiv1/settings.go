@@ -0,0 +1,25 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// GetSettings returns all settings of current tenant
+func GetSettings() web.HandlerFunc {
+	return func(c *web.Context) error {
+		tenant := c.Tenant()
+		return c.Ok(web.Map{
+			"title":                 tenant.Name,
+			"invitation":            tenant.Invitation,
+			"welcomeMessage":        tenant.WelcomeMessage,
+			"cname":                 tenant.CNAME,
+			"locale":                tenant.Locale,
+			"logoBlobKey":           tenant.LogoBlobKey,
+			"customCSS":             tenant.CustomCSS,
+			"prioritizationFormula": tenant.PrioritizationFormula,
+			"isPrivate":             tenant.IsPrivate,
+			"isEmailAuthAllowed":    tenant.IsEmailAuthAllowed,
+			"emailRules":            tenant.EmailRules,
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package apiv1_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestGetSettingsHandler(t *testing.T) {
+	RegisterT(t)
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecuteAsJSON(apiv1.GetSettings())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("title")).Equals(mock.DemoTenant.Name)
+	Expect(result.Contains("isPrivate")).IsTrue()
+	Expect(result.Contains("emailRules")).IsTrue()
+}
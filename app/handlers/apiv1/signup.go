@@ -0,0 +1,93 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
+)
+
+// ListTenantSignUps returns the most recent sign-ups of new sites, by default only those waiting for approval
+func ListTenantSignUps() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.ListTenantSignUps{Status: enum.TenantSignUpPending}
+		if c.QueryParam("status") != "" {
+			_ = q.Status.UnmarshalText([]byte(c.QueryParam("status")))
+			if q.Status == 0 {
+				result := validate.Success()
+				result.AddFieldFailure("status", "Status must be one of pending, approved or rejected.")
+				return c.HandleValidation(result)
+			}
+		}
+
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// ApproveTenantSignUp approves a new site, which is activated once its email is verified
+func ApproveTenantSignUp() web.HandlerFunc {
+	return reviewTenantSignUp(true)
+}
+
+// RejectTenantSignUp rejects a new site, which is then disabled
+func RejectTenantSignUp() web.HandlerFunc {
+	return reviewTenantSignUp(false)
+}
+
+func reviewTenantSignUp(approve bool) web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getSignUp := &query.GetTenantSignUpByID{SignUpID: id}
+		if err := bus.Dispatch(c, getSignUp); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		signUp := getSignUp.Result
+		if signUp.Status != enum.TenantSignUpPending {
+			return c.HandleValidation(validate.Failed("This sign-up has already been reviewed."))
+		}
+
+		if approve {
+			approveSignUp := &cmd.ApproveTenantSignUp{SignUpID: signUp.ID}
+			if err := bus.Dispatch(c, approveSignUp); err != nil {
+				if errors.Cause(err) == app.ErrSignUpAlreadyReviewed {
+					return c.HandleValidation(validate.Failed("This sign-up has already been reviewed."))
+				}
+				return c.Failure(err)
+			}
+			signUp.Status = enum.TenantSignUpApproved
+
+			if approveSignUp.Activated && signUp.Email != "" {
+				siteURL := web.TenantBaseURL(c, &entity.Tenant{Subdomain: signUp.TenantSubdomain})
+				c.Enqueue(tasks.SendWelcomeEmail(signUp.Name, signUp.Email, siteURL))
+			}
+		} else {
+			if err := bus.Dispatch(c, &cmd.RejectTenantSignUp{SignUpID: signUp.ID}); err != nil {
+				if errors.Cause(err) == app.ErrSignUpAlreadyReviewed {
+					return c.HandleValidation(validate.Failed("This sign-up has already been reviewed."))
+				}
+				return c.Failure(err)
+			}
+			signUp.Status = enum.TenantSignUpRejected
+		}
+
+		return c.Ok(signUp)
+	}
+}
@@ -0,0 +1,116 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestListTenantSignUpsHandler(t *testing.T) {
+	RegisterT(t)
+
+	var listQuery *query.ListTenantSignUps
+	bus.AddHandler(func(ctx context.Context, q *query.ListTenantSignUps) error {
+		listQuery = q
+		q.Result = []*entity.TenantSignUp{
+			{ID: 1, TenantName: "My Company", TenantSubdomain: "mycompany", Status: enum.TenantSignUpRejected},
+		}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/operator/signups?status=rejected").
+		ExecuteAsJSON(apiv1.ListTenantSignUps())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listQuery.Status).Equals(enum.TenantSignUpRejected)
+	Expect(response.ArrayLength()).Equals(1)
+}
+
+func TestListTenantSignUpsHandler_InvalidStatus(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/operator/signups?status=unknown").
+		ExecuteAsJSON(apiv1.ListTenantSignUps())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestApproveTenantSignUpHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByID) error {
+		q.Result = &entity.TenantSignUp{ID: q.SignUpID, TenantSubdomain: "mycompany", Status: enum.TenantSignUpPending}
+		return nil
+	})
+
+	var approveSignUp *cmd.ApproveTenantSignUp
+	bus.AddHandler(func(ctx context.Context, c *cmd.ApproveTenantSignUp) error {
+		approveSignUp = c
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePostAsJSON(apiv1.ApproveTenantSignUp(), `{}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(approveSignUp.SignUpID).Equals(5)
+	Expect(response.String("status")).Equals("approved")
+}
+
+func TestApproveTenantSignUpHandler_ReviewedConcurrently(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByID) error {
+		q.Result = &entity.TenantSignUp{ID: q.SignUpID, TenantSubdomain: "mycompany", Status: enum.TenantSignUpPending}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.ApproveTenantSignUp) error {
+		return app.ErrSignUpAlreadyReviewed
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePostAsJSON(apiv1.ApproveTenantSignUp(), `{}`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestRejectTenantSignUpHandler_AlreadyReviewed(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByID) error {
+		q.Result = &entity.TenantSignUp{ID: q.SignUpID, Status: enum.TenantSignUpApproved}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePostAsJSON(apiv1.RejectTenantSignUp(), `{}`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(bus.GetCallCount(&cmd.RejectTenantSignUp{})).Equals(0)
+}
@@ -0,0 +1,107 @@
+package apiv1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+const (
+	defaultStatsDays     = 30
+	defaultStatsPageSize = 20
+	maxStatsPageSize     = 100
+)
+
+// GetPostStats returns the daily views, referrers and conversion rate of a post
+func GetPostStats() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		since, result := statsSince(c)
+		if !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getStats := &query.GetPostStats{PostID: getPost.Result.ID, Since: since}
+		if err := bus.Dispatch(c, getStats); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getStats.Result)
+	}
+}
+
+// ListTopPostsByViews returns the most viewed posts
+func ListTopPostsByViews() web.HandlerFunc {
+	return func(c *web.Context) error {
+		since, result := statsSince(c)
+		limit := statsLimit(c, result)
+		if !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		q := &query.ListTopPostsByViews{Since: since, Limit: limit}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// ListTopReferrers returns the sites that sent most views to posts
+func ListTopReferrers() web.HandlerFunc {
+	return func(c *web.Context) error {
+		since, result := statsSince(c)
+		limit := statsLimit(c, result)
+		if !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		q := &query.ListTopReferrers{Since: since, Limit: limit}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// statsSince returns the start of the period given on the days parameter, which can't go past the retention period
+func statsSince(c *web.Context) (time.Time, *validate.Result) {
+	result := validate.Success()
+	days := defaultStatsDays
+	if c.QueryParam("days") != "" {
+		value, err := c.QueryParamAsInt("days")
+		if err != nil || value < 1 || value > env.Config.Analytics.RetentionDays {
+			result.AddFieldFailure("days", fmt.Sprintf("Days must be between 1 and %d.", env.Config.Analytics.RetentionDays))
+		}
+		days = value
+	}
+	return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days), result
+}
+
+func statsLimit(c *web.Context, result *validate.Result) int {
+	if c.QueryParam("limit") == "" {
+		return defaultStatsPageSize
+	}
+
+	limit, err := c.QueryParamAsInt("limit")
+	if err != nil || limit < 1 || limit > maxStatsPageSize {
+		result.AddFieldFailure("limit", fmt.Sprintf("Limit must be between 1 and %d.", maxStatsPageSize))
+	}
+	return limit
+}
@@ -0,0 +1,96 @@
+package apiv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestGetPostStatsHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 10, Number: 5, Title: "Add dark mode"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		if q.Number == post.Number {
+			q.Result = post
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	var statsQuery *query.GetPostStats
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostStats) error {
+		statsQuery = q
+		q.Result = &entity.PostStats{Views: 40, UniqueVisitors: 20, Votes: 5, ConversionRate: 0.25}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", "5").
+		WithURL("http://demo.test.fider.io/api/v1/posts/5/stats?days=7").
+		Execute(apiv1.GetPostStats())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(statsQuery.PostID).Equals(10)
+	Expect(statsQuery.Since).Equals(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -6))
+
+	stats := &entity.PostStats{}
+	Expect(json.NewDecoder(response.Body).Decode(stats)).IsNil()
+	Expect(stats.Views).Equals(40)
+	Expect(stats.UniqueVisitors).Equals(20)
+	Expect(stats.ConversionRate).Equals(0.25)
+}
+
+func TestListTopPostsByViewsHandler(t *testing.T) {
+	RegisterT(t)
+
+	var listQuery *query.ListTopPostsByViews
+	bus.AddHandler(func(ctx context.Context, q *query.ListTopPostsByViews) error {
+		listQuery = q
+		q.Result = []*entity.PostViewStats{
+			{PostNumber: 5, PostTitle: "Add dark mode", Views: 40, UniqueVisitors: 20},
+		}
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/stats/posts?limit=10").
+		ExecuteAsJSON(apiv1.ListTopPostsByViews())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listQuery.Limit).Equals(10)
+	Expect(listQuery.Since).Equals(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -29))
+	Expect(query.ArrayLength()).Equals(1)
+}
+
+func TestListTopReferrersHandler_InvalidParams(t *testing.T) {
+	RegisterT(t)
+
+	for _, url := range []string{
+		"http://demo.test.fider.io/api/v1/stats/referrers?days=0",
+		"http://demo.test.fider.io/api/v1/stats/referrers?days=1000",
+		"http://demo.test.fider.io/api/v1/stats/referrers?limit=500",
+		"http://demo.test.fider.io/api/v1/stats/referrers?limit=abc",
+	} {
+		code, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AsUser(mock.JonSnow).
+			WithURL(url).
+			ExecuteAsJSON(apiv1.ListTopReferrers())
+		Expect(code).Equals(http.StatusBadRequest)
+	}
+}
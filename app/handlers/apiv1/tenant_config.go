@@ -0,0 +1,75 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/tenantconfig"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ExportTenantConfig returns the configuration of current tenant as JSON or as YAML when ?format=yaml
+func ExportTenantConfig() web.HandlerFunc {
+	return func(c *web.Context) error {
+		format := c.QueryParam("format")
+		if format == "" {
+			format = "json"
+		}
+		if format != "json" && format != "yaml" {
+			return c.HandleValidation(validate.Failed("Format must be either 'json' or 'yaml'."))
+		}
+
+		config, err := tenantconfig.Export(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		content, err := tenantconfig.Marshal(config, format)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if format == "yaml" {
+			return c.Blob(http.StatusOK, "application/yaml; charset=utf-8", content)
+		}
+		return c.Blob(http.StatusOK, web.JSONContentType+"; charset=utf-8", content)
+	}
+}
+
+// ApplyTenantConfig changes current tenant to match the configuration sent as JSON or YAML
+// Changes are only listed and not applied when ?dryRun=true
+func ApplyTenantConfig() web.HandlerFunc {
+	return func(c *web.Context) error {
+		config, err := tenantconfig.Parse([]byte(c.Request.Body))
+		if err != nil {
+			return c.HandleValidation(validate.Failed(errors.Cause(err).Error()))
+		}
+
+		action := &actions.ApplyTenantConfig{Config: config}
+		if !action.IsAuthorized(c, c.User()) {
+			return c.HandleValidation(validate.Unauthorized())
+		}
+		if result := action.Validate(c, c.User()); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		changes, err := tenantconfig.Plan(c, config)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		dryRun := c.QueryParam("dryRun") == "true"
+		if !dryRun {
+			if err := tenantconfig.Apply(c, changes); err != nil {
+				return c.Failure(err)
+			}
+		}
+
+		return c.Ok(web.Map{
+			"dryRun":  dryRun,
+			"changes": changes,
+		})
+	}
+}
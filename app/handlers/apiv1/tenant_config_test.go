@@ -0,0 +1,139 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func setupTenantConfigTags() {
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		q.Result = []*entity.Tag{{ID: 1, Name: "Bug", Slug: "bug", Color: "FF0000", IsPublic: true}}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == "bug" {
+			q.Result = &entity.Tag{ID: 1, Name: "Bug", Slug: "bug", Color: "FF0000", IsPublic: true}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewTag) error {
+		return nil
+	})
+}
+
+func TestExportTenantConfigHandler_YAML(t *testing.T) {
+	RegisterT(t)
+	setupTenantConfigTags()
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListAllWebhooks) error {
+		q.Result = []*entity.Webhook{}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListCustomOAuthConfig) error {
+		q.Result = []*entity.OAuthConfig{}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/admin/config?format=yaml").
+		Execute(apiv1.ExportTenantConfig())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Type")).ContainsSubstring("application/yaml")
+	Expect(response.Body.String()).ContainsSubstring("- name: Bug")
+}
+
+func TestExportTenantConfigHandler_UnknownFormat(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/admin/config?format=xml").
+		Execute(apiv1.ExportTenantConfig())
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestApplyTenantConfigHandler_DryRun(t *testing.T) {
+	RegisterT(t)
+	setupTenantConfigTags()
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/admin/config?dryRun=true").
+		ExecutePostAsJSON(apiv1.ApplyTenantConfig(), "tags:\n  - name: Feature\n    color: 0000ff\n")
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("changes[0].action")).Equals("create")
+	Expect(result.String("changes[0].name")).Equals("Feature")
+	Expect(result.String("changes[1].action")).Equals("delete")
+	Expect(result.String("changes[1].name")).Equals("Bug")
+	Expect(bus.GetCallCount(&cmd.AddNewTag{})).Equals(0)
+}
+
+func TestApplyTenantConfigHandler(t *testing.T) {
+	RegisterT(t)
+	setupTenantConfigTags()
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(apiv1.ApplyTenantConfig(), `{ "tags": [{ "name": "Bug", "color": "FF0000", "isPublic": true }, { "name": "Feature", "color": "0000FF" }] }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("changes[0].action")).Equals("create")
+	Expect(bus.GetCallCount(&cmd.AddNewTag{})).Equals(1)
+}
+
+func TestApplyTenantConfigHandler_Invalid(t *testing.T) {
+	RegisterT(t)
+	setupTenantConfigTags()
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(apiv1.ApplyTenantConfig(), `{ "tags": [{ "name": "Feature", "color": "blue" }] }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(bus.GetCallCount(&cmd.AddNewTag{})).Equals(0)
+}
+
+func TestApplyTenantConfigHandler_UnknownField(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(apiv1.ApplyTenantConfig(), `{ "labels": [] }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestApplyTenantConfigHandler_NonAdmin(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.ApplyTenantConfig(), `{ "tags": [] }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+}
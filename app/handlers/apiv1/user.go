@@ -9,6 +9,7 @@ import (
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
@@ -73,3 +74,46 @@ func CreateUser() web.HandlerFunc {
 		})
 	}
 }
+
+// BlockUser blocks given user from using current tenant
+func BlockUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return blockOrUnblock(c, func(userID int) bus.Msg {
+			return &cmd.BlockUser{UserID: userID}
+		})
+	}
+}
+
+// UnblockUser allows a blocked user to use current tenant again
+func UnblockUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return blockOrUnblock(c, func(userID int) bus.Msg {
+			return &cmd.UnblockUser{UserID: userID}
+		})
+	}
+}
+
+func blockOrUnblock(c *web.Context, getCommand func(userID int) bus.Msg) error {
+	userID, err := c.ParamAsInt("userID")
+	if err != nil {
+		return c.NotFound()
+	}
+
+	if userID == c.User().ID {
+		return c.HandleValidation(validate.Failed("It is not allowed to block or unblock yourself."))
+	}
+
+	getUser := &query.GetUserByID{UserID: userID}
+	if err := bus.Dispatch(c, getUser); err != nil {
+		return c.Failure(err)
+	}
+	if getUser.Result.Tenant.ID != c.Tenant().ID {
+		return c.NotFound()
+	}
+
+	if err := bus.Dispatch(c, getCommand(getUser.Result.ID)); err != nil {
+		return c.Failure(err)
+	}
+
+	return c.Ok(web.Map{})
+}
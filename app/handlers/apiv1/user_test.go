@@ -186,3 +186,58 @@ func TestCreateUser_NewUser(t *testing.T) {
 	theOtherUserID := query.Int32("id")
 	Expect(theOtherUserID).Equals(userID)
 }
+
+func TestBlockUserHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.AryaStark
+		return nil
+	})
+
+	var blockUser *cmd.BlockUser
+	bus.AddHandler(func(ctx context.Context, c *cmd.BlockUser) error {
+		blockUser = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("userID", mock.AryaStark.ID).
+		ExecutePost(apiv1.BlockUser(), ``)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(blockUser.UserID).Equals(mock.AryaStark.ID)
+}
+
+func TestBlockUserHandler_Yourself(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("userID", mock.JonSnow.ID).
+		ExecutePost(apiv1.BlockUser(), ``)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(bus.GetCallCount(&cmd.BlockUser{})).Equals(0)
+}
+
+func TestUnblockUserHandler_OtherTenant(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = &entity.User{ID: q.UserID, Tenant: mock.AvengersTenant}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("userID", 9).
+		Execute(apiv1.UnblockUser())
+
+	Expect(code).Equals(http.StatusNotFound)
+	Expect(bus.GetCallCount(&cmd.UnblockUser{})).Equals(0)
+}
@@ -0,0 +1,155 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/webhook"
+)
+
+// GetWebhookSchema returns the JSON Schema of the standard payload sent by webhooks of given type
+func GetWebhookSchema() web.HandlerFunc {
+	return func(c *web.Context) error {
+		var webhookType enum.WebhookType
+		if err := webhookType.UnmarshalText([]byte(c.Param("type"))); err != nil || webhookType == 0 {
+			return c.NotFound()
+		}
+
+		return c.Ok(webhook.Schema(webhookType))
+	}
+}
+
+// getWebhookFromParam returns the webhook identified by the "id" route param, or nil if it doesn't exist
+func getWebhookFromParam(c *web.Context) (*entity.Webhook, error) {
+	id, err := c.ParamAsInt("id")
+	if err != nil {
+		return nil, nil
+	}
+
+	getWebhook := &query.GetWebhook{ID: id}
+	if err := bus.Dispatch(c, getWebhook); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return getWebhook.Result, nil
+}
+
+// ListWebhooks returns all webhooks of current tenant
+func ListWebhooks() web.HandlerFunc {
+	return func(c *web.Context) error {
+		allWebhooks := &query.ListAllWebhooks{}
+		if err := bus.Dispatch(c, allWebhooks); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(allWebhooks.Result)
+	}
+}
+
+// GetWebhook returns a single webhook of current tenant
+func GetWebhook() web.HandlerFunc {
+	return func(c *web.Context) error {
+		hook, err := getWebhookFromParam(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if hook == nil {
+			return c.NotFound()
+		}
+
+		return c.Ok(hook)
+	}
+}
+
+// CreateEditWebhook creates a new webhook or edit an existing one
+func CreateEditWebhook() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id := 0
+		if c.Param("id") != "" {
+			hook, err := getWebhookFromParam(c)
+			if err != nil {
+				return c.Failure(err)
+			}
+			if hook == nil {
+				return c.NotFound()
+			}
+			id = hook.ID
+		}
+
+		action := new(actions.CreateEditWebhook)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		createEditWebhook := &query.CreateEditWebhook{
+			ID:          id,
+			Name:        action.Name,
+			Type:        action.Type,
+			Status:      action.Status,
+			Format:      action.Format,
+			Url:         action.Url,
+			Content:     action.Content,
+			HttpMethod:  action.HttpMethod,
+			HttpHeaders: action.HttpHeaders,
+			Conditions:  action.Conditions,
+		}
+		if id > 0 && action.Status == enum.WebhookFailed {
+			createEditWebhook.Status = enum.WebhookDisabled
+		}
+		if err := bus.Dispatch(c, createEditWebhook); err != nil {
+			return c.Failure(err)
+		}
+
+		if id > 0 {
+			return c.Ok(web.Map{"id": id})
+		}
+		return c.Ok(web.Map{"id": createEditWebhook.Result})
+	}
+}
+
+// DeleteWebhook deletes an existing webhook
+func DeleteWebhook() web.HandlerFunc {
+	return func(c *web.Context) error {
+		hook, err := getWebhookFromParam(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if hook == nil {
+			return c.NotFound()
+		}
+
+		if err := bus.Dispatch(c, &query.DeleteWebhook{ID: hook.ID}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// TestWebhook triggers an existing webhook with sample data and returns the result
+func TestWebhook() web.HandlerFunc {
+	return func(c *web.Context) error {
+		hook, err := getWebhookFromParam(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if hook == nil {
+			return c.NotFound()
+		}
+
+		triggerWebhook := &cmd.TestWebhook{ID: hook.ID}
+		if err := bus.Dispatch(c, triggerWebhook); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(triggerWebhook.Result)
+	}
+}
@@ -0,0 +1,143 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestGetWebhookSchemaHandler(t *testing.T) {
+	RegisterT(t)
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("type", "change_status").
+		ExecuteAsJSON(apiv1.GetWebhookSchema())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.String("$schema")).Equals("https://json-schema.org/draft/2020-12/schema")
+	Expect(query.String("properties.event.const")).Equals("post.status_changed")
+	Expect(query.Contains("properties.post.properties.previous_status")).IsTrue()
+	Expect(query.Contains("properties.comment")).IsFalse()
+}
+
+func TestGetWebhookSchemaHandler_UnknownType(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("type", "new_vote").
+		Execute(apiv1.GetWebhookSchema())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestCreateWebhookHandler(t *testing.T) {
+	RegisterT(t)
+
+	var createWebhook *query.CreateEditWebhook
+	bus.AddHandler(func(ctx context.Context, q *query.CreateEditWebhook) error {
+		createWebhook = q
+		q.Result = 3
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(apiv1.CreateEditWebhook(), `{ "name": "Slack", "type": "new_post", "status": "disabled", "url": "https://hooks.slack.com/x", "http_method": "POST" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.Int32("id")).Equals(3)
+	Expect(createWebhook.ID).Equals(0)
+	Expect(createWebhook.Name).Equals("Slack")
+	Expect(createWebhook.Type).Equals(enum.WebhookNewPost)
+}
+
+func TestCreateWebhookHandler_RequiresAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreateEditWebhook(), `{ "name": "Slack", "type": "new_post", "status": "disabled", "url": "https://hooks.slack.com/x", "http_method": "POST" }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+	Expect(bus.GetCallCount(&query.CreateEditWebhook{})).Equals(0)
+}
+
+func TestUpdateWebhookHandler_FailedBecomesDisabled(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetWebhook) error {
+		q.Result = &entity.Webhook{ID: q.ID, Name: "Slack"}
+		return nil
+	})
+
+	var updateWebhook *query.CreateEditWebhook
+	bus.AddHandler(func(ctx context.Context, q *query.CreateEditWebhook) error {
+		updateWebhook = q
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePost(apiv1.CreateEditWebhook(), `{ "name": "Slack", "type": "new_post", "status": "failed", "url": "https://hooks.slack.com/x", "http_method": "POST" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateWebhook.ID).Equals(5)
+	Expect(updateWebhook.Status).Equals(enum.WebhookDisabled)
+}
+
+func TestDeleteWebhookHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetWebhook) error {
+		return app.ErrNotFound
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		Execute(apiv1.DeleteWebhook())
+
+	Expect(code).Equals(http.StatusNotFound)
+	Expect(bus.GetCallCount(&query.DeleteWebhook{})).Equals(0)
+}
+
+func TestTestWebhookHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetWebhook) error {
+		q.Result = &entity.Webhook{ID: q.ID, Name: "Slack"}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TestWebhook) error {
+		c.Result = &dto.WebhookTriggerResult{Success: true}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePost(apiv1.TestWebhook(), ``)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(bus.GetCallCount(&cmd.TestWebhook{})).Equals(1)
+}
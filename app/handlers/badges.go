@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/badge"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/crypto"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/ogimage"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// PostBadge returns an SVG badge with the number of votes and status of a post
+func PostBadge() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		post := getPost.Result
+		return renderBadge(c, &badge.Badge{
+			Label:   i18n.T(c, "badge.votes", i18n.Params{"count": post.VotesCount}),
+			Message: i18n.T(c, fmt.Sprintf("enum.poststatus.%s", post.Status.Name())),
+			Color:   ogimage.StatusColor(post.Status),
+		})
+	}
+}
+
+// OpenPostsBadge returns an SVG badge with the number of posts that are still open on the site
+func OpenPostsBadge() web.HandlerFunc {
+	return func(c *web.Context) error {
+		countPerStatus := &query.CountPostPerStatus{}
+		if err := bus.Dispatch(c, countPerStatus); err != nil {
+			return c.Failure(err)
+		}
+
+		count := countPerStatus.Result[enum.PostOpen] +
+			countPerStatus.Result[enum.PostStarted] +
+			countPerStatus.Result[enum.PostPlanned]
+
+		return renderBadge(c, &badge.Badge{
+			Label:   i18n.T(c, "badge.openposts"),
+			Message: strconv.Itoa(count),
+		})
+	}
+}
+
+func renderBadge(c *web.Context, b *badge.Badge) error {
+	svg, err := badge.Render(b, badge.ParseStyle(c.QueryParam("style")))
+	if err != nil {
+		return c.Failure(err)
+	}
+
+	etag := fmt.Sprintf(`"%s"`, crypto.MD5(string(svg)))
+	c.Response.Header().Set("ETag", etag)
+	if c.Request.GetHeader("If-None-Match") == etag {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Image("image/svg+xml", svg)
+}
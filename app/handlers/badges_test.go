@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+
+	"github.com/getfider/fider/app/pkg/mock"
+
+	"github.com/getfider/fider/app/handlers"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestPostBadgeHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "Add dark mode", VotesCount: 42, Status: enum.PostPlanned}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		WithURL("http://demo.test.fider.io/static/badges/posts/1?style=flat-square").
+		Execute(handlers.PostBadge())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Type")).Equals("image/svg+xml")
+	Expect(response.Header().Get("ETag")).IsNotEmpty()
+	Expect(response.Body.String()).ContainsSubstring(`aria-label="42 votes: Planned"`)
+}
+
+func TestPostBadgeHandler_NotModified(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "Add dark mode", VotesCount: 1}
+		return nil
+	})
+
+	_, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		Execute(handlers.PostBadge())
+	etag := response.Header().Get("ETag")
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		AddHeader("If-None-Match", etag).
+		Execute(handlers.PostBadge())
+
+	Expect(code).Equals(http.StatusNotModified)
+	Expect(response.Body.Len()).Equals(0)
+}
+
+func TestPostBadgeHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		return app.ErrNotFound
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 999).
+		Execute(handlers.PostBadge())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestOpenPostsBadgeHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.CountPostPerStatus) error {
+		q.Result = map[enum.PostStatus]int{
+			enum.PostOpen:      5,
+			enum.PostStarted:   2,
+			enum.PostPlanned:   1,
+			enum.PostCompleted: 10,
+			enum.PostDeclined:  3,
+		}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		Execute(handlers.OpenPostsBadge())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Type")).Equals("image/svg+xml")
+	Expect(response.Body.String()).ContainsSubstring(`aria-label="open posts: 8"`)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"bytes"
 	"fmt"
+	"image"
 	"image/color"
 	"image/png"
 	"net/http"
@@ -12,12 +13,18 @@ import (
 
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/services/blob"
 
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/crypto"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/i18n"
 	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/ogimage"
 	"github.com/getfider/fider/app/pkg/web"
 	"github.com/goenning/imagic"
 	"github.com/goenning/letteravatar"
@@ -190,3 +197,92 @@ func ViewUploadedImage() web.HandlerFunc {
 		return c.Image(q.Result.ContentType, bytes)
 	}
 }
+
+// PostOGImage returns the Open Graph image of given post, generating and caching it when needed
+func PostOGImage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		key := ogimage.PostBlobKey(getPost.Result, c.Tenant().LogoBlobKey)
+		getBlob := &query.GetBlobByKey{Key: key}
+		err = bus.Dispatch(c, getBlob)
+		if err == nil {
+			return c.Image(getBlob.Result.ContentType, getBlob.Result.Content)
+		}
+		if errors.Cause(err) != blob.ErrNotFound {
+			return c.Failure(err)
+		}
+
+		bytes, err := drawPostOGImage(c, getPost.Result)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.StoreBlob{Key: key, Content: bytes, ContentType: "image/png"}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Image("image/png", bytes)
+	}
+}
+
+func drawPostOGImage(c *web.Context, post *entity.Post) ([]byte, error) {
+	tenant := c.Tenant()
+
+	var logo image.Image
+	if tenant.LogoBlobKey != "" {
+		getLogo := &query.GetBlobByKey{Key: tenant.LogoBlobKey}
+		if err := bus.Dispatch(c, getLogo); err != nil {
+			return nil, err
+		}
+		resized, err := imagic.Apply(getLogo.Result.Content, imagic.Resize(96))
+		if err != nil {
+			return nil, err
+		}
+		logo, _, err = image.Decode(bytes.NewReader(resized))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode tenant logo")
+		}
+	} else {
+		var err error
+		logo, err = letteravatar.Draw(96, letteravatar.Extract(tenant.Name), &letteravatar.Options{
+			PaletteKey: tenant.Subdomain,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to draw tenant letter avatar")
+		}
+	}
+
+	votes := ogimage.VotesBucket(post.VotesCount)
+	caption := i18n.T(c, "ogimage.votes", i18n.Params{"count": votes})
+	if votes != post.VotesCount {
+		caption = i18n.T(c, "ogimage.votes.more", i18n.Params{"count": votes})
+	}
+
+	card := &ogimage.Card{
+		Logo:       logo,
+		SiteName:   tenant.Name,
+		Title:      post.Title,
+		Caption:    caption,
+		LabelColor: ogimage.StatusColor(post.Status),
+	}
+	if post.Status != enum.PostOpen {
+		card.Label = i18n.T(c, fmt.Sprintf("enum.poststatus.%s", post.Status.Name()))
+	}
+
+	return ogimage.Draw(card)
+}
+
+// postOGImageURL returns the URL of the Open Graph image of given post
+func postOGImageURL(c *web.Context, post *entity.Post) string {
+	version := ogimage.PostVersion(post, c.Tenant().LogoBlobKey)
+	return fmt.Sprintf("%s/static/og/posts/%d/%s", web.BaseURL(c), post.Number, version)
+}
@@ -1,15 +1,22 @@
 package handlers_test
 
 import (
+	"bytes"
 	"context"
+	"image/png"
 	"io"
 	"net/http"
 	"testing"
 
 	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/ogimage"
+	"github.com/getfider/fider/app/services/blob"
 	"github.com/getfider/fider/app/services/httpclient"
 
 	"github.com/getfider/fider/app/pkg/mock"
@@ -102,3 +109,63 @@ func TestUnknownUser_LetterAvatarHandler(t *testing.T) {
 	bytes, _ := io.ReadAll(response.Body)
 	Expect(bytes).Equals(expectedAvatar)
 }
+
+func TestPostOGImageHandler_Cached(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add dark mode", VotesCount: 4}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		Expect(q.Key).Equals(ogimage.PostBlobKey(post, mock.DemoTenant.LogoBlobKey))
+		q.Result = &dto.Blob{Content: []byte("cached"), ContentType: "image/png"}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		Execute(handlers.PostOGImage())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals("cached")
+	ExpectHandler(&cmd.StoreBlob{}).CalledTimes(0)
+}
+
+func TestPostOGImageHandler_Generate(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add dark mode", VotesCount: 12, Status: enum.PostPlanned}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		return blob.ErrNotFound
+	})
+
+	var stored *cmd.StoreBlob
+	bus.AddHandler(func(ctx context.Context, c *cmd.StoreBlob) error {
+		stored = c
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		Execute(handlers.PostOGImage())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Type")).Equals("image/png")
+	Expect(stored.Key).Equals(ogimage.PostBlobKey(post, mock.DemoTenant.LogoBlobKey))
+	Expect(stored.ContentType).Equals("image/png")
+
+	img, err := png.Decode(bytes.NewReader(stored.Content))
+	Expect(err).IsNil()
+	Expect(img.Bounds().Dx()).Equals(ogimage.Width)
+	Expect(response.Body.Bytes()).Equals(stored.Content)
+}
@@ -3,9 +3,11 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
@@ -73,3 +75,58 @@ func ReadAllNotifications() web.HandlerFunc {
 		return c.Ok(web.Map{})
 	}
 }
+
+// GetPushPublicKey returns the VAPID public key browsers need to subscribe to push notifications
+func GetPushPublicKey() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if !env.IsWebPushEnabled() {
+			return c.NotFound()
+		}
+
+		return c.Ok(web.Map{
+			"publicKey": env.Config.WebPush.VAPIDPublicKey,
+		})
+	}
+}
+
+// SubscribeToPush registers current browser to receive push notifications
+func SubscribeToPush() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if !env.IsWebPushEnabled() {
+			return c.NotFound()
+		}
+
+		action := new(actions.SubscribeToPush)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.SavePushSubscription{
+			Endpoint:  action.Endpoint,
+			P256dh:    action.Keys.P256dh,
+			Auth:      action.Keys.Auth,
+			UserAgent: c.Request.GetHeader("User-Agent"),
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// UnsubscribeFromPush stops sending push notifications to current browser
+func UnsubscribeFromPush() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UnsubscribeFromPush)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeletePushSubscription{UserID: c.User().ID, Endpoint: action.Endpoint}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 
 	"github.com/getfider/fider/app/handlers"
 	. "github.com/getfider/fider/app/pkg/assert"
@@ -108,3 +109,86 @@ func TestReadAllNotificationsHandler(t *testing.T) {
 	Expect(code).Equals(http.StatusOK)
 	Expect(called).IsTrue()
 }
+
+func enableWebPush() func() {
+	env.Config.WebPush.VAPIDPublicKey = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	env.Config.WebPush.VAPIDPrivateKey = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	return func() {
+		env.Config.WebPush.VAPIDPublicKey = ""
+		env.Config.WebPush.VAPIDPrivateKey = ""
+	}
+}
+
+func TestGetPushPublicKeyHandler(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecuteAsJSON(handlers.GetPushPublicKey())
+	Expect(code).Equals(http.StatusNotFound)
+
+	defer enableWebPush()()
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecuteAsJSON(handlers.GetPushPublicKey())
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.String("publicKey")).Equals(env.Config.WebPush.VAPIDPublicKey)
+}
+
+func TestSubscribeToPushHandler(t *testing.T) {
+	RegisterT(t)
+	defer enableWebPush()()
+
+	var savePushSubscription *cmd.SavePushSubscription
+	bus.AddHandler(func(ctx context.Context, c *cmd.SavePushSubscription) error {
+		savePushSubscription = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddHeader("User-Agent", "Firefox").
+		ExecutePost(handlers.SubscribeToPush(), `{
+			"endpoint": "https://updates.push.services.mozilla.com/wpush/v2/abc",
+			"keys": {
+				"p256dh": "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+				"auth": "BTBZMqHH6r4Tts7J_aSIgg"
+			}
+		}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(savePushSubscription.Endpoint).Equals("https://updates.push.services.mozilla.com/wpush/v2/abc")
+	Expect(savePushSubscription.Auth).Equals("BTBZMqHH6r4Tts7J_aSIgg")
+	Expect(savePushSubscription.UserAgent).Equals("Firefox")
+}
+
+func TestSubscribeToPushHandler_InvalidInput(t *testing.T) {
+	RegisterT(t)
+	defer enableWebPush()()
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.SubscribeToPush(), `{
+			"endpoint": "http://example.com/push",
+			"keys": { "p256dh": "abc", "auth": "def" }
+		}`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectHandler(&cmd.SavePushSubscription{}).CalledTimes(0)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.SubscribeToPush(), `{
+			"endpoint": "https://10.0.0.1/admin",
+			"keys": { "p256dh": "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", "auth": "BTBZMqHH6r4Tts7J_aSIgg" }
+		}`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectHandler(&cmd.SavePushSubscription{}).CalledTimes(0)
+}
@@ -102,6 +102,9 @@ func OAuthToken() web.HandlerFunc {
 			err = bus.Dispatch(c, userByEmail)
 			user = userByEmail.Result
 		}
+		if err == nil && !user.IsAdministrator() && !c.Tenant().EmailRules.Allows(user.Email) {
+			return c.Redirect("/not-invited")
+		}
 		if err != nil {
 			if errors.Cause(err) == app.ErrNotFound {
 				isTrusted := isTrustedOAuthProvider(c, provider)
@@ -109,6 +112,10 @@ func OAuthToken() web.HandlerFunc {
 					return c.Redirect("/not-invited")
 				}
 
+				if !c.Tenant().EmailRules.Allows(oauthUser.Result.Email) {
+					return c.Redirect("/not-invited")
+				}
+
 				user = &entity.User{
 					Name:   oauthUser.Result.Name,
 					Tenant: c.Tenant(),
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+const oauthAuthorizationCodeDuration = 10 * time.Minute
+
+// oauthAuthorizeRequest is an authorization request made by a third-party application, as defined by RFC 6749 and RFC 7636
+type oauthAuthorizeRequest struct {
+	ClientID            string `json:"clientID"`
+	RedirectURI         string `json:"redirectURI"`
+	ResponseType        string `json:"responseType"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+	Approved            bool   `json:"approved"`
+}
+
+// getClient returns the application of the request, or nil if the client or its redirect URI are unknown
+// In this case, the user must not be redirected back to the application
+func (r *oauthAuthorizeRequest) getClient(c *web.Context) (*entity.OAuthApp, error) {
+	if r.ClientID == "" {
+		return nil, nil
+	}
+
+	getApp := &query.GetOAuthAppByClientID{ClientID: r.ClientID}
+	if err := bus.Dispatch(c, getApp); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !getApp.Result.HasRedirectURI(r.RedirectURI) {
+		return nil, nil
+	}
+	return getApp.Result, nil
+}
+
+// validate returns an OAuth error code and description if the request can't be authorized
+func (r *oauthAuthorizeRequest) validate(oauthApp *entity.OAuthApp) (string, string) {
+	if r.ResponseType != "code" {
+		return "unsupported_response_type", "Only the authorization code flow is supported."
+	}
+
+	if r.CodeChallenge == "" || len(r.CodeChallenge) > 128 || r.CodeChallengeMethod != "S256" {
+		return "invalid_request", "A code_challenge with the S256 method is required."
+	}
+
+	scopes := entity.ParseOAuthScopes(r.Scope)
+	if len(scopes) == 0 {
+		return "invalid_scope", "At least one scope is required."
+	}
+	for _, scope := range scopes {
+		if !entity.IsValidOAuthScope(scope) {
+			return "invalid_scope", "Scope '" + scope + "' is not valid."
+		}
+	}
+	if !oauthApp.AllowsScopes(scopes) {
+		return "invalid_scope", "This application is not allowed to request the given scopes."
+	}
+
+	return "", ""
+}
+
+func (r *oauthAuthorizeRequest) redirectURL(params url.Values) string {
+	if r.State != "" {
+		params.Set("state", r.State)
+	}
+
+	separator := "?"
+	if strings.Contains(r.RedirectURI, "?") {
+		separator = "&"
+	}
+	return r.RedirectURI + separator + params.Encode()
+}
+
+// OAuthAuthorizePage is the consent page where users authorize third-party applications
+func OAuthAuthorizePage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		request := &oauthAuthorizeRequest{
+			ClientID:            c.QueryParam("client_id"),
+			RedirectURI:         c.QueryParam("redirect_uri"),
+			ResponseType:        c.QueryParam("response_type"),
+			Scope:               c.QueryParam("scope"),
+			State:               c.QueryParam("state"),
+			CodeChallenge:       c.QueryParam("code_challenge"),
+			CodeChallengeMethod: c.QueryParam("code_challenge_method"),
+		}
+
+		oauthApp, err := request.getClient(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if oauthApp == nil {
+			return c.Page(http.StatusBadRequest, web.Props{
+				Page:  "OAuthAuthorize/OAuthAuthorize.page",
+				Title: "Authorize application",
+				Data: web.Map{
+					"error": "The application is unknown or has requested an invalid redirect URI.",
+				},
+			})
+		}
+
+		if code, description := request.validate(oauthApp); code != "" {
+			return c.Redirect(request.redirectURL(url.Values{
+				"error":             {code},
+				"error_description": {description},
+			}))
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "OAuthAuthorize/OAuthAuthorize.page",
+			Title: "Authorize " + oauthApp.Name,
+			Data: web.Map{
+				"app":     web.Map{"name": oauthApp.Name},
+				"scopes":  entity.ParseOAuthScopes(request.Scope),
+				"request": request,
+			},
+		})
+	}
+}
+
+// OAuthAuthorize records the decision of current user and returns the URL the user is redirected to
+func OAuthAuthorize() web.HandlerFunc {
+	return func(c *web.Context) error {
+		request := &oauthAuthorizeRequest{}
+		if err := c.Bind(request); err != nil {
+			return c.Failure(err)
+		}
+
+		oauthApp, err := request.getClient(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if oauthApp == nil {
+			return c.NotFound()
+		}
+
+		if code, description := request.validate(oauthApp); code != "" {
+			return c.Ok(web.Map{
+				"redirectURL": request.redirectURL(url.Values{
+					"error":             {code},
+					"error_description": {description},
+				}),
+			})
+		}
+
+		if !request.Approved {
+			return c.Ok(web.Map{
+				"redirectURL": request.redirectURL(url.Values{
+					"error":             {"access_denied"},
+					"error_description": {"The user has denied the authorization request."},
+				}),
+			})
+		}
+
+		addCode := &cmd.AddOAuthAuthorizationCode{
+			AppID:         oauthApp.ID,
+			RedirectURI:   request.RedirectURI,
+			CodeChallenge: request.CodeChallenge,
+			Scopes:        entity.ParseOAuthScopes(request.Scope),
+			ExpiresIn:     oauthAuthorizationCodeDuration,
+		}
+		if err := bus.Dispatch(c, addCode); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"redirectURL": request.redirectURL(url.Values{"code": {addCode.Result}}),
+		})
+	}
+}
+
+func oauthTokenError(c *web.Context, status int, code, description string) error {
+	c.Response.Header().Set("Cache-Control", "no-store")
+	return c.JSON(status, web.Map{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// authenticateOAuthClient verifies the credentials sent with HTTP Basic authentication or in the request body
+func authenticateOAuthClient(c *web.Context, form url.Values) (*entity.OAuthApp, error) {
+	clientID, clientSecret := form.Get("client_id"), form.Get("client_secret")
+	if auth := c.Request.GetHeader("Authorization"); strings.HasPrefix(auth, "Basic ") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "Basic "))
+		if err != nil {
+			return nil, nil
+		}
+		credentials := strings.SplitN(string(decoded), ":", 2)
+		if len(credentials) != 2 {
+			return nil, nil
+		}
+		clientID, _ = url.QueryUnescape(credentials[0])
+		clientSecret, _ = url.QueryUnescape(credentials[1])
+	}
+
+	if clientID == "" || clientSecret == "" {
+		return nil, nil
+	}
+
+	verify := &query.VerifyOAuthAppSecret{ClientID: clientID, ClientSecret: clientSecret}
+	if err := bus.Dispatch(c, verify); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return verify.Result, nil
+}
+
+// verifyCodeChallenge checks the PKCE code verifier against the S256 challenge sent on the authorization request
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	hash := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(hash[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// OAuthIssueToken exchanges an authorization code or a refresh token for new tokens
+func OAuthIssueToken() web.HandlerFunc {
+	return func(c *web.Context) error {
+		form, err := url.ParseQuery(c.Request.Body)
+		if err != nil {
+			return oauthTokenError(c, http.StatusBadRequest, "invalid_request", "Request body must be form encoded.")
+		}
+
+		oauthApp, err := authenticateOAuthClient(c, form)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if oauthApp == nil {
+			return oauthTokenError(c, http.StatusUnauthorized, "invalid_client", "Client authentication failed.")
+		}
+
+		var tokens *entity.OAuthTokens
+		switch form.Get("grant_type") {
+		case "authorization_code":
+			consume := &cmd.ConsumeOAuthAuthorizationCode{Code: form.Get("code")}
+			if err := bus.Dispatch(c, consume); err != nil {
+				if errors.Cause(err) == app.ErrNotFound {
+					return oauthTokenError(c, http.StatusBadRequest, "invalid_grant", "Authorization code is invalid or has already been used.")
+				}
+				return c.Failure(err)
+			}
+
+			code := consume.Result
+			if code.AppID != oauthApp.ID || code.RedirectURI != form.Get("redirect_uri") || time.Now().After(code.ExpiresAt) {
+				return oauthTokenError(c, http.StatusBadRequest, "invalid_grant", "Authorization code is invalid or has expired.")
+			}
+			if !verifyCodeChallenge(form.Get("code_verifier"), code.CodeChallenge) {
+				return oauthTokenError(c, http.StatusBadRequest, "invalid_grant", "Code verifier does not match the code challenge.")
+			}
+
+			issue := &cmd.IssueOAuthTokens{AppID: oauthApp.ID, UserID: code.UserID, Scopes: code.Scopes}
+			if err := bus.Dispatch(c, issue); err != nil {
+				return c.Failure(err)
+			}
+			tokens = issue.Result
+		case "refresh_token":
+			refresh := &cmd.RefreshOAuthTokens{AppID: oauthApp.ID, RefreshToken: form.Get("refresh_token")}
+			if err := bus.Dispatch(c, refresh); err != nil {
+				if errors.Cause(err) == app.ErrNotFound {
+					return oauthTokenError(c, http.StatusBadRequest, "invalid_grant", "Refresh token is invalid or has expired.")
+				}
+				return c.Failure(err)
+			}
+			tokens = refresh.Result
+		default:
+			return oauthTokenError(c, http.StatusBadRequest, "unsupported_grant_type", "Grant type must be authorization_code or refresh_token.")
+		}
+
+		c.Response.Header().Set("Cache-Control", "no-store")
+		return c.JSON(http.StatusOK, web.Map{
+			"access_token":  tokens.AccessToken,
+			"token_type":    "Bearer",
+			"expires_in":    tokens.ExpiresIn,
+			"refresh_token": tokens.RefreshToken,
+			"scope":         strings.Join(tokens.Scopes, " "),
+		})
+	}
+}
+
+// RevokeOAuthAuthorization removes the access of an application authorized by current user
+func RevokeOAuthAuthorization() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		if err := bus.Dispatch(c, &cmd.RevokeOAuthAuthorization{ID: id}); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
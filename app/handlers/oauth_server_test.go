@@ -0,0 +1,301 @@
+package handlers_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+const oauthCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+var zapierApp = &entity.OAuthApp{
+	ID:           1,
+	Name:         "Zapier",
+	ClientID:     "zapier-client-id",
+	RedirectURIs: []string{"https://zapier.com/callback"},
+	Scopes:       []string{entity.OAuthScopeRead, entity.OAuthScopeWrite},
+}
+
+func oauthCodeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func mockOAuthApps() {
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthAppByClientID) error {
+		if q.ClientID == zapierApp.ClientID {
+			q.Result = zapierApp
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.VerifyOAuthAppSecret) error {
+		if q.ClientID == zapierApp.ClientID && q.ClientSecret == "zapier-secret" {
+			q.Result = zapierApp
+			return nil
+		}
+		return app.ErrNotFound
+	})
+}
+
+func TestOAuthAuthorizePage_InvalidClient(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+
+	for _, rawQuery := range []string{
+		"client_id=unknown&redirect_uri=https%3A%2F%2Fzapier.com%2Fcallback",
+		"client_id=zapier-client-id&redirect_uri=https%3A%2F%2Fevil.com%2Fcallback",
+	} {
+		code, page := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			WithURL("http://demo.test.fider.io/oauth2/authorize?" + rawQuery).
+			ExecuteAsPage(handlers.OAuthAuthorizePage())
+
+		Expect(code).Equals(http.StatusBadRequest)
+		Expect(page.Data["error"]).Equals("The application is unknown or has requested an invalid redirect URI.")
+	}
+}
+
+func TestOAuthAuthorizePage_InvalidRequest(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/oauth2/authorize?client_id=zapier-client-id&redirect_uri=https%3A%2F%2Fzapier.com%2Fcallback&response_type=code&scope=read&state=xyz").
+		Execute(handlers.OAuthAuthorizePage())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	location, _ := url.Parse(response.Header().Get("Location"))
+	Expect(location.Host).Equals("zapier.com")
+	Expect(location.Query().Get("error")).Equals("invalid_request")
+	Expect(location.Query().Get("state")).Equals("xyz")
+
+	code, response = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/oauth2/authorize?client_id=zapier-client-id&redirect_uri=https%3A%2F%2Fzapier.com%2Fcallback&response_type=code&scope=read+admin&code_challenge=abc&code_challenge_method=S256").
+		Execute(handlers.OAuthAuthorizePage())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	location, _ = url.Parse(response.Header().Get("Location"))
+	Expect(location.Query().Get("error")).Equals("invalid_scope")
+}
+
+func TestOAuthAuthorizePage_ValidRequest(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+
+	code, page := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://demo.test.fider.io/oauth2/authorize?client_id=zapier-client-id&redirect_uri=https%3A%2F%2Fzapier.com%2Fcallback&response_type=code&scope=read+write&code_challenge=abc&code_challenge_method=S256").
+		ExecuteAsPage(handlers.OAuthAuthorizePage())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(page.Page).Equals("OAuthAuthorize/OAuthAuthorize.page")
+	Expect(page.Data["app"]).Equals(map[string]any{"name": "Zapier"})
+	Expect(page.Data["scopes"]).Equals([]any{"read", "write"})
+}
+
+func TestOAuthAuthorizeHandler_Approve(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+
+	var addCode *cmd.AddOAuthAuthorizationCode
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddOAuthAuthorizationCode) error {
+		addCode = c
+		c.Result = "my-code"
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePostAsJSON(handlers.OAuthAuthorize(), `{
+			"clientID": "zapier-client-id",
+			"redirectURI": "https://zapier.com/callback",
+			"responseType": "code",
+			"scope": "read",
+			"state": "xyz",
+			"codeChallenge": "abc",
+			"codeChallengeMethod": "S256",
+			"approved": true
+		}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("redirectURL")).Equals("https://zapier.com/callback?code=my-code&state=xyz")
+	Expect(addCode.AppID).Equals(zapierApp.ID)
+	Expect(addCode.CodeChallenge).Equals("abc")
+	Expect(addCode.Scopes).Equals([]string{"read"})
+}
+
+func TestOAuthAuthorizeHandler_Deny(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePostAsJSON(handlers.OAuthAuthorize(), `{
+			"clientID": "zapier-client-id",
+			"redirectURI": "https://zapier.com/callback",
+			"responseType": "code",
+			"scope": "read",
+			"codeChallenge": "abc",
+			"codeChallengeMethod": "S256",
+			"approved": false
+		}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("redirectURL")).ContainsSubstring("https://zapier.com/callback?error=access_denied")
+	Expect(bus.GetCallCount(&cmd.AddOAuthAuthorizationCode{})).Equals(0)
+}
+
+func mockOAuthAuthorizationCode() {
+	bus.AddHandler(func(ctx context.Context, c *cmd.ConsumeOAuthAuthorizationCode) error {
+		if c.Code != "my-code" {
+			return app.ErrNotFound
+		}
+		c.Result = &entity.OAuthAuthorizationCode{
+			AppID:         zapierApp.ID,
+			UserID:        mock.AryaStark.ID,
+			RedirectURI:   "https://zapier.com/callback",
+			CodeChallenge: oauthCodeChallenge(oauthCodeVerifier),
+			Scopes:        []string{entity.OAuthScopeRead},
+			ExpiresAt:     time.Now().Add(5 * time.Minute),
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.IssueOAuthTokens) error {
+		c.Result = &entity.OAuthTokens{
+			AccessToken:  entity.OAuthAccessTokenPrefix + "access",
+			RefreshToken: entity.OAuthRefreshTokenPrefix + "refresh",
+			ExpiresIn:    3600,
+			Scopes:       c.Scopes,
+		}
+		return nil
+	})
+}
+
+func TestOAuthIssueTokenHandler_AuthorizationCode(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+	mockOAuthAuthorizationCode()
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("zapier-client-id:zapier-secret"))).
+		ExecutePostAsJSON(handlers.OAuthIssueToken(), url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {"my-code"},
+			"redirect_uri":  {"https://zapier.com/callback"},
+			"code_verifier": {oauthCodeVerifier},
+		}.Encode())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("access_token")).Equals(entity.OAuthAccessTokenPrefix + "access")
+	Expect(result.String("refresh_token")).Equals(entity.OAuthRefreshTokenPrefix + "refresh")
+	Expect(result.String("token_type")).Equals("Bearer")
+	Expect(result.Int32("expires_in")).Equals(3600)
+	Expect(result.String("scope")).Equals("read")
+}
+
+func TestOAuthIssueTokenHandler_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+	mockOAuthAuthorizationCode()
+
+	testCases := []struct {
+		form   url.Values
+		status int
+		error  string
+	}{
+		{
+			form:   url.Values{"grant_type": {"authorization_code"}, "code": {"my-code"}, "client_id": {"zapier-client-id"}, "client_secret": {"wrong"}},
+			status: http.StatusUnauthorized,
+			error:  "invalid_client",
+		},
+		{
+			form:   url.Values{"grant_type": {"password"}, "client_id": {"zapier-client-id"}, "client_secret": {"zapier-secret"}},
+			status: http.StatusBadRequest,
+			error:  "unsupported_grant_type",
+		},
+		{
+			form:   url.Values{"grant_type": {"authorization_code"}, "code": {"other-code"}, "client_id": {"zapier-client-id"}, "client_secret": {"zapier-secret"}},
+			status: http.StatusBadRequest,
+			error:  "invalid_grant",
+		},
+		{
+			form: url.Values{
+				"grant_type": {"authorization_code"}, "code": {"my-code"}, "redirect_uri": {"https://zapier.com/callback"},
+				"code_verifier": {"wrong-verifier-wrong-verifier-wrong-verifier"}, "client_id": {"zapier-client-id"}, "client_secret": {"zapier-secret"},
+			},
+			status: http.StatusBadRequest,
+			error:  "invalid_grant",
+		},
+		{
+			form: url.Values{
+				"grant_type": {"authorization_code"}, "code": {"my-code"}, "redirect_uri": {"https://zapier.com/other"},
+				"code_verifier": {oauthCodeVerifier}, "client_id": {"zapier-client-id"}, "client_secret": {"zapier-secret"},
+			},
+			status: http.StatusBadRequest,
+			error:  "invalid_grant",
+		},
+	}
+
+	for _, testCase := range testCases {
+		code, result := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			ExecutePostAsJSON(handlers.OAuthIssueToken(), testCase.form.Encode())
+
+		Expect(code).Equals(testCase.status)
+		Expect(result.String("error")).Equals(testCase.error)
+	}
+	Expect(bus.GetCallCount(&cmd.IssueOAuthTokens{})).Equals(0)
+}
+
+func TestOAuthIssueTokenHandler_RefreshToken(t *testing.T) {
+	RegisterT(t)
+	mockOAuthApps()
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.RefreshOAuthTokens) error {
+		if c.AppID != zapierApp.ID || c.RefreshToken != entity.OAuthRefreshTokenPrefix+"refresh" {
+			return app.ErrNotFound
+		}
+		c.Result = &entity.OAuthTokens{
+			AccessToken:  entity.OAuthAccessTokenPrefix + "new-access",
+			RefreshToken: entity.OAuthRefreshTokenPrefix + "new-refresh",
+			ExpiresIn:    3600,
+			Scopes:       []string{entity.OAuthScopeRead},
+		}
+		return nil
+	})
+
+	code, result := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		ExecutePostAsJSON(handlers.OAuthIssueToken(), url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {entity.OAuthRefreshTokenPrefix + "refresh"},
+			"client_id":     {"zapier-client-id"},
+			"client_secret": {"zapier-secret"},
+		}.Encode())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(result.String("access_token")).Equals(entity.OAuthAccessTokenPrefix + "new-access")
+	Expect(result.String("refresh_token")).Equals(entity.OAuthRefreshTokenPrefix + "new-refresh")
+}
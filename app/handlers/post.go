@@ -3,12 +3,17 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/analytics"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/csv"
 	"github.com/getfider/fider/app/pkg/markdown"
 	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
 )
 
 // Index is the default home page
@@ -74,10 +79,13 @@ func PostDetails() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		recordPostView(c, getPost.Result)
+
 		return c.Page(http.StatusOK, web.Props{
 			Page:        "ShowPost/ShowPost.page",
 			Title:       getPost.Result.Title,
 			Description: markdown.PlainText(getPost.Result.Description),
+			Image:       postOGImageURL(c, getPost.Result),
 			Data: web.Map{
 				"comments":    getComments.Result,
 				"subscribed":  isSubscribed.Result,
@@ -90,6 +98,30 @@ func PostDetails() web.HandlerFunc {
 	}
 }
 
+// recordPostView counts a view of given post, unless it comes from a bot, a prefetch or a staff member
+func recordPostView(c *web.Context, post *entity.Post) {
+	userAgent := c.Request.GetHeader("User-Agent")
+	if c.Request.IsCrawler() || analytics.IsBot(userAgent) {
+		return
+	}
+
+	if c.Request.GetHeader("Purpose") == "prefetch" || c.Request.GetHeader("Sec-Purpose") == "prefetch" {
+		return
+	}
+
+	if c.IsAuthenticated() && c.User().IsCollaborator() {
+		return
+	}
+
+	now := time.Now()
+	c.Enqueue(tasks.RecordPostView(&cmd.RecordPostView{
+		PostID:         post.ID,
+		ViewedAt:       now,
+		VisitorHash:    analytics.VisitorHash(analytics.Day(now), c.Tenant().ID, post.ID, c.Request.ClientIP, userAgent),
+		ReferrerDomain: analytics.ReferrerDomain(c.Request.GetHeader("Referer"), c.Request.URL.Hostname()),
+	}))
+}
+
 // ExportPostsToCSV returns a CSV with all posts
 func ExportPostsToCSV() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -107,3 +139,21 @@ func ExportPostsToCSV() web.HandlerFunc {
 		return c.Attachment("posts.csv", "text/csv", bytes)
 	}
 }
+
+// ExportVotesToCSV returns a CSV with all votes, including the reason and importance given by voters
+func ExportVotesToCSV() web.HandlerFunc {
+	return func(c *web.Context) error {
+
+		allVotes := &query.ListAllVotes{}
+		if err := bus.Dispatch(c, allVotes); err != nil {
+			return c.Failure(err)
+		}
+
+		bytes, err := csv.FromVotes(allVotes.Result)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Attachment("votes.csv", "text/csv", bytes)
+	}
+}
@@ -78,7 +78,8 @@ func VerifyChangeEmailKey() web.HandlerFunc {
 func UserSettings() web.HandlerFunc {
 	return func(c *web.Context) error {
 		settings := &query.GetCurrentUserSettings{}
-		if err := bus.Dispatch(c, settings); err != nil {
+		authorizedApps := &query.ListOAuthAuthorizations{}
+		if err := bus.Dispatch(c, settings, authorizedApps); err != nil {
 			return err
 		}
 
@@ -86,7 +87,8 @@ func UserSettings() web.HandlerFunc {
 			Page:  "MySettings/MySettings.page",
 			Title: "Settings",
 			Data: web.Map{
-				"userSettings": settings.Result,
+				"userSettings":   settings.Result,
+				"authorizedApps": authorizedApps.Result,
 			},
 		})
 	}
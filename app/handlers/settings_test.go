@@ -29,6 +29,10 @@ func TestSettingsHandler(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.ListOAuthAuthorizations) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, _ := server.
 		AsUser(mock.JonSnow).
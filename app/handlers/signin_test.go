@@ -18,6 +18,7 @@ import (
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/jwt"
 	"github.com/getfider/fider/app/pkg/mock"
 	"github.com/getfider/fider/app/pkg/web"
@@ -493,6 +494,54 @@ func TestVerifySignUpKeyHandler_PendingTenant(t *testing.T) {
 	Expect(verified).IsTrue()
 }
 
+func TestVerifySignUpKeyHandler_AwaitingApproval(t *testing.T) {
+	RegisterT(t)
+	env.Config.SignUp.ApprovalRequired = true
+
+	server := mock.NewServer()
+	mock.DemoTenant.Status = enum.TenantPending
+
+	var newUser *entity.User
+	bus.AddHandler(func(ctx context.Context, c *cmd.RegisterUser) error {
+		newUser = c.User
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.ActivateTenant) error {
+		panic("Should not activate the tenant")
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByTenantID) error {
+		q.Result = &entity.TenantSignUp{TenantID: q.TenantID, Status: enum.TenantSignUpPending}
+		return nil
+	})
+
+	key := "1234567890"
+	bus.AddHandler(func(ctx context.Context, q *query.GetVerificationByKey) error {
+		q.Result = &entity.EmailVerification{
+			Key:       q.Key,
+			Kind:      q.Kind,
+			ExpiresAt: time.Now().Add(5 * time.Minute),
+			Name:      "Hot Pie",
+			Email:     "hot.pie@got.com",
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetKeyAsVerified) error {
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/signup/verify?k=" + key).
+		Execute(handlers.VerifySignUpKey())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("http://demo.test.fider.io")
+	Expect(newUser.Email).Equals("hot.pie@got.com")
+}
+
 func TestCompleteSignInProfileHandler_UnknownKey(t *testing.T) {
 	RegisterT(t)
 
@@ -22,6 +22,7 @@ import (
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/jwt"
 	"github.com/getfider/fider/app/pkg/validate"
 	"github.com/getfider/fider/app/pkg/web"
 )
@@ -54,10 +55,24 @@ func CreateTenant() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
+		if !env.IsSingleHostMode() && env.Config.SignUp.MaxPerIPPerDay > 0 {
+			countByIP := &query.CountTenantSignUpsByIPAddress{
+				IPAddress: c.Request.ClientIP,
+				Since:     time.Now().Add(-24 * time.Hour),
+			}
+			if err := bus.Dispatch(c, countByIP); err != nil {
+				return c.Failure(err)
+			}
+			if countByIP.Result >= env.Config.SignUp.MaxPerIPPerDay {
+				return c.HandleValidation(validate.Failed("Too many sites have been created from your network. Please try again later."))
+			}
+		}
+
 		socialSignUp := action.Token != ""
+		approvalRequired := env.IsSignUpApprovalRequired()
 
 		status := enum.TenantPending
-		if socialSignUp {
+		if socialSignUp && !approvalRequired {
 			status = enum.TenantActive
 		}
 
@@ -117,6 +132,30 @@ func CreateTenant() web.HandlerFunc {
 			c.Enqueue(tasks.SendSignUpEmail(action, siteURL))
 		}
 
+		if !env.IsSingleHostMode() {
+			signUp := &entity.TenantSignUp{
+				TenantID:        createTenant.Result.ID,
+				TenantName:      createTenant.Result.Name,
+				TenantSubdomain: createTenant.Result.Subdomain,
+				Name:            user.Name,
+				Email:           user.Email,
+				IPAddress:       c.Request.ClientIP,
+				Status:          enum.TenantSignUpApproved,
+				CreatedAt:       time.Now(),
+			}
+			if approvalRequired {
+				signUp.Status = enum.TenantSignUpPending
+			}
+
+			if err := bus.Dispatch(c, &cmd.AddTenantSignUp{SignUp: signUp}); err != nil {
+				return c.Failure(err)
+			}
+
+			if approvalRequired {
+				c.Enqueue(tasks.NotifyOperatorsAboutSignUp(signUp, siteURL, web.OAuthBaseURL(c)))
+			}
+		}
+
 		if status == enum.TenantActive && user.Email != "" {
 			c.Enqueue(tasks.SendWelcomeEmail(user.Name, user.Email, siteURL))
 		}
@@ -175,8 +214,21 @@ func VerifySignUpKey() web.HandlerFunc {
 			return err
 		}
 
-		if err = bus.Dispatch(c, &cmd.ActivateTenant{TenantID: c.Tenant().ID}); err != nil {
-			return c.Failure(err)
+		// Sites waiting for approval are only activated once an operator approves them
+		activate := true
+		if env.IsSignUpApprovalRequired() {
+			signUp := &query.GetTenantSignUpByTenantID{TenantID: c.Tenant().ID}
+			err := bus.Dispatch(c, signUp)
+			if err != nil && errors.Cause(err) != app.ErrNotFound {
+				return c.Failure(err)
+			}
+			activate = signUp.Result == nil || signUp.Result.Status == enum.TenantSignUpApproved
+		}
+
+		if activate {
+			if err = bus.Dispatch(c, &cmd.ActivateTenant{TenantID: c.Tenant().ID}); err != nil {
+				return c.Failure(err)
+			}
 		}
 
 		user := &entity.User{
@@ -197,8 +249,108 @@ func VerifySignUpKey() web.HandlerFunc {
 
 		webutil.AddAuthUserCookie(c, user)
 
-		c.Enqueue(tasks.SendWelcomeEmail(user.Name, user.Email, c.BaseURL()))
+		if activate {
+			c.Enqueue(tasks.SendWelcomeEmail(user.Name, user.Email, c.BaseURL()))
+		}
 
 		return c.Redirect(c.BaseURL())
 	}
 }
+
+// ReviewSignUp shows the confirmation page for the links sent to operators.
+// Mail scanners follow links, so the review itself only happens on ConfirmSignUpReview
+func ReviewSignUp() web.HandlerFunc {
+	return func(c *web.Context) error {
+		key := c.QueryParam("k")
+		claims, signUp, err := getSignUpToReview(c, key)
+		if signUp == nil {
+			return err
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "SignUp/SignUpReviewed.page",
+			Title: "Sign-up " + signUp.Status.Name(),
+			Data: web.Map{
+				"tenantName": signUp.TenantName,
+				"tenantURL":  web.TenantBaseURL(c, &entity.Tenant{Subdomain: signUp.TenantSubdomain}),
+				"status":     signUp.Status,
+				"approve":    claims.Approve,
+				"reviewKey":  key,
+			},
+		})
+	}
+}
+
+type signUpReviewRequest struct {
+	Key string `json:"key"`
+}
+
+// ConfirmSignUpReview approves or rejects a new site once the operator confirms it
+func ConfirmSignUpReview() web.HandlerFunc {
+	return func(c *web.Context) error {
+		request := &signUpReviewRequest{}
+		if err := c.Bind(request); err != nil {
+			return c.Failure(err)
+		}
+
+		claims, signUp, err := getSignUpToReview(c, request.Key)
+		if signUp == nil {
+			return err
+		}
+
+		// Links of other operators might have been used already, so the first review wins
+		if signUp.Status == enum.TenantSignUpPending {
+			if err := reviewSignUp(c, signUp, claims.Approve); err != nil {
+				if errors.Cause(err) == app.ErrSignUpAlreadyReviewed {
+					return c.HandleValidation(validate.Failed("This sign-up has already been reviewed."))
+				}
+				return c.Failure(err)
+			}
+		}
+
+		return c.Ok(web.Map{
+			"status": signUp.Status,
+		})
+	}
+}
+
+// getSignUpToReview returns the sign-up referenced by the review key, or the response to send when it can't be used
+func getSignUpToReview(c *web.Context, key string) (*jwt.SignUpReviewClaims, *entity.TenantSignUp, error) {
+	claims, err := jwt.DecodeSignUpReviewClaims(key)
+	// Review keys are only sent to operators, so the email is checked to revoke the keys of removed operators
+	if err != nil || !env.IsOperatorEmail(claims.Operator) {
+		return nil, nil, c.NotFound()
+	}
+
+	getSignUp := &query.GetTenantSignUpByID{SignUpID: claims.SignUpID}
+	if err := bus.Dispatch(c, getSignUp); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, nil, c.NotFound()
+		}
+		return nil, nil, c.Failure(err)
+	}
+
+	return claims, getSignUp.Result, nil
+}
+
+func reviewSignUp(c *web.Context, signUp *entity.TenantSignUp, approve bool) error {
+	if !approve {
+		if err := bus.Dispatch(c, &cmd.RejectTenantSignUp{SignUpID: signUp.ID}); err != nil {
+			return err
+		}
+		signUp.Status = enum.TenantSignUpRejected
+		return nil
+	}
+
+	approveSignUp := &cmd.ApproveTenantSignUp{SignUpID: signUp.ID}
+	if err := bus.Dispatch(c, approveSignUp); err != nil {
+		return err
+	}
+	signUp.Status = enum.TenantSignUpApproved
+
+	if approveSignUp.Activated && signUp.Email != "" {
+		siteURL := web.TenantBaseURL(c, &entity.Tenant{Subdomain: signUp.TenantSubdomain})
+		c.Enqueue(tasks.SendWelcomeEmail(signUp.Name, signUp.Email, siteURL))
+	}
+	return nil
+}
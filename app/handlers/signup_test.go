@@ -17,6 +17,7 @@ import (
 	"github.com/getfider/fider/app/handlers"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/jwt"
 	"github.com/getfider/fider/app/pkg/mock"
 	"github.com/getfider/fider/app/pkg/web"
@@ -147,7 +148,13 @@ func TestCreateTenantHandler_WithSocialAccount(t *testing.T) {
 		return nil
 	})
 
-	server := mock.NewServer()
+	var signUp *entity.TenantSignUp
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddTenantSignUp) error {
+		signUp = c.SignUp
+		return nil
+	})
+
+	server := mock.NewServer().WithClientIP("203.0.113.7")
 	token, _ := jwt.Encode(jwt.OAuthClaims{
 		OAuthID:       "123",
 		OAuthName:     "Jon Snow",
@@ -174,6 +181,11 @@ func TestCreateTenantHandler_WithSocialAccount(t *testing.T) {
 	Expect(newUser.Email).Equals("jon.snow@got.com")
 	Expect(newUser.Role).Equals(enum.RoleAdministrator)
 
+	Expect(signUp.TenantID).Equals(1)
+	Expect(signUp.Email).Equals("jon.snow@got.com")
+	Expect(signUp.IPAddress).Equals("203.0.113.7")
+	Expect(signUp.Status).Equals(enum.TenantSignUpApproved)
+
 	cookie := web.ParseCookie(response.Header().Get("Set-Cookie"))
 	Expect(cookie.Name).Equals(web.CookieSignUpAuthName)
 	ExpectFiderToken(cookie.Value, newUser)
@@ -268,6 +280,10 @@ func TestCreateTenantHandler_WithEmailAndName(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddTenantSignUp) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, response := server.ExecutePost(
 		handlers.CreateTenant(),
@@ -294,3 +310,197 @@ func TestCreateTenantHandler_WithEmailAndName(t *testing.T) {
 	Expect(saveKeyCmd.Request.GetEmail()).Equals("jon.snow@got.com")
 	Expect(saveKeyCmd.Request.GetName()).Equals("Jon Snow")
 }
+
+func TestCreateTenantHandler_ApprovalRequired(t *testing.T) {
+	RegisterT(t)
+	env.Config.SignUp.ApprovalRequired = true
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.RegisterUser) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	var newTenant *cmd.CreateTenant
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateTenant) error {
+		newTenant = c
+		c.Result = &entity.Tenant{ID: 1, Name: c.Name, Subdomain: c.Subdomain, Status: c.Status}
+		return nil
+	})
+
+	var signUp *entity.TenantSignUp
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddTenantSignUp) error {
+		signUp = c.SignUp
+		return nil
+	})
+
+	token, _ := jwt.Encode(jwt.OAuthClaims{
+		OAuthID:       "123",
+		OAuthName:     "Jon Snow",
+		OAuthEmail:    "jon.snow@got.com",
+		OAuthProvider: "facebook",
+	})
+	code, _ := mock.NewServer().ExecutePost(
+		handlers.CreateTenant(),
+		fmt.Sprintf(`{
+			"token": "%s",
+			"tenantName": "My Company",
+			"subdomain": "mycompany",
+			"legalAgreement": true
+		}`, token),
+	)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(newTenant.Status).Equals(enum.TenantPending)
+	Expect(signUp.TenantName).Equals("My Company")
+	Expect(signUp.Status).Equals(enum.TenantSignUpPending)
+}
+
+func TestCreateTenantHandler_TooManySignUpsFromIP(t *testing.T) {
+	RegisterT(t)
+	env.Config.SignUp.MaxPerIPPerDay = 3
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.CountTenantSignUpsByIPAddress) error {
+		Expect(q.IPAddress).Equals("203.0.113.7")
+		q.Result = 3
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateTenant) error {
+		panic("Should not create any tenant")
+	})
+
+	code, _ := mock.NewServer().
+		WithClientIP("203.0.113.7").
+		ExecutePost(
+			handlers.CreateTenant(),
+			`{
+				"name": "Jon Snow",
+				"email": "jon.snow@got.com",
+				"tenantName": "My Company",
+				"subdomain": "mycompany",
+				"legalAgreement": true
+			}`,
+		)
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestReviewSignUpHandler_OnlyConfirms(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByID) error {
+		q.Result = &entity.TenantSignUp{ID: q.SignUpID, TenantName: "My Company", TenantSubdomain: "mycompany", Status: enum.TenantSignUpPending}
+		return nil
+	})
+
+	token, _ := jwt.Encode(jwt.SignUpReviewClaims{SignUpID: 5, Approve: true, Operator: "jon.snow@got.com"})
+	code, page := mock.NewServer().
+		WithURL("http://login.test.fider.io/signup/review?k=" + token).
+		ExecuteAsPage(handlers.ReviewSignUp())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(page.Page).Equals("SignUp/SignUpReviewed.page")
+	Expect(page.Data["status"]).Equals("pending")
+	Expect(page.Data["approve"]).IsTrue()
+	Expect(page.Data["reviewKey"]).Equals(token)
+	Expect(page.Data["tenantURL"]).Equals("http://mycompany.test.fider.io")
+}
+
+func TestConfirmSignUpReviewHandler_Approve(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByID) error {
+		q.Result = &entity.TenantSignUp{ID: q.SignUpID, TenantName: "My Company", TenantSubdomain: "mycompany", Status: enum.TenantSignUpPending}
+		return nil
+	})
+
+	var approveSignUp *cmd.ApproveTenantSignUp
+	bus.AddHandler(func(ctx context.Context, c *cmd.ApproveTenantSignUp) error {
+		approveSignUp = c
+		return nil
+	})
+
+	token, _ := jwt.Encode(jwt.SignUpReviewClaims{SignUpID: 5, Approve: true, Operator: "jon.snow@got.com"})
+	code, json := mock.NewServer().
+		WithURL("http://login.test.fider.io/_api/signup/review").
+		ExecutePostAsJSON(handlers.ConfirmSignUpReview(), `{ "key": "`+token+`" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(approveSignUp.SignUpID).Equals(5)
+	Expect(json.String("status")).Equals("approved")
+}
+
+func TestConfirmSignUpReviewHandler_AlreadyReviewed(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByID) error {
+		q.Result = &entity.TenantSignUp{ID: q.SignUpID, TenantName: "My Company", TenantSubdomain: "mycompany", Status: enum.TenantSignUpRejected}
+		return nil
+	})
+
+	token, _ := jwt.Encode(jwt.SignUpReviewClaims{SignUpID: 5, Approve: true, Operator: "jon.snow@got.com"})
+	code, json := mock.NewServer().
+		WithURL("http://login.test.fider.io/_api/signup/review").
+		ExecutePostAsJSON(handlers.ConfirmSignUpReview(), `{ "key": "`+token+`" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(json.String("status")).Equals("rejected")
+}
+
+func TestConfirmSignUpReviewHandler_ReviewedConcurrently(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByID) error {
+		q.Result = &entity.TenantSignUp{ID: q.SignUpID, TenantName: "My Company", TenantSubdomain: "mycompany", Status: enum.TenantSignUpPending}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.RejectTenantSignUp) error {
+		return app.ErrSignUpAlreadyReviewed
+	})
+
+	token, _ := jwt.Encode(jwt.SignUpReviewClaims{SignUpID: 5, Approve: false, Operator: "jon.snow@got.com"})
+	code, json := mock.NewServer().
+		WithURL("http://login.test.fider.io/_api/signup/review").
+		ExecutePostAsJSON(handlers.ConfirmSignUpReview(), `{ "key": "`+token+`" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(json.String("errors[0].message")).Equals("This sign-up has already been reviewed.")
+}
+
+func TestReviewSignUpHandler_NotOperator(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+
+	token, _ := jwt.Encode(jwt.SignUpReviewClaims{SignUpID: 5, Approve: true, Operator: "arya.stark@got.com"})
+	code, _ := mock.NewServer().
+		WithURL("http://login.test.fider.io/signup/review?k=" + token).
+		Execute(handlers.ReviewSignUp())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestConfirmSignUpReviewHandler_NotOperator(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+
+	token, _ := jwt.Encode(jwt.SignUpReviewClaims{SignUpID: 5, Approve: true, Operator: "arya.stark@got.com"})
+	code, _ := mock.NewServer().
+		WithURL("http://login.test.fider.io/_api/signup/review").
+		ExecutePost(handlers.ConfirmSignUpReview(), `{ "key": "`+token+`" }`)
+
+	Expect(code).Equals(http.StatusNotFound)
+}
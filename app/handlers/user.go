@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/web"
 )
@@ -39,3 +41,44 @@ func UnblockUser() web.HandlerFunc {
 		return c.Ok(web.Map{})
 	}
 }
+
+// PreviewUserMerge returns what will be changed when merging a user into another
+func PreviewUserMerge() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.MergeUsers)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		preview := &query.PreviewUserMerge{
+			SourceUserID: action.SourceUserID,
+			TargetUserID: action.TargetUserID,
+		}
+		if err := bus.Dispatch(c, preview); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(preview.Result)
+	}
+}
+
+// MergeUsers moves all content of a duplicate user into another user, then removes the duplicate
+func MergeUsers() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.MergeUsers)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		merge := &cmd.MergeUsers{
+			SourceUserID: action.SourceUserID,
+			TargetUserID: action.TargetUserID,
+			BlockSource:  action.BlockSource,
+		}
+		if err := bus.Dispatch(c, merge); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(merge.Result)
+	}
+}
@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestPreviewUserMergeHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = &entity.User{ID: q.UserID, Tenant: mock.DemoTenant, Status: enum.UserActive}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.PreviewUserMerge) error {
+		Expect(q.SourceUserID).Equals(3)
+		Expect(q.TargetUserID).Equals(mock.AryaStark.ID)
+		q.Result = &entity.UserMergeSummary{
+			Votes: entity.UserMergeCount{Moved: 4, Dropped: 1},
+		}
+		return nil
+	})
+
+	code, json := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(handlers.PreviewUserMerge(), `{ "sourceUserID": 3, "targetUserID": 2 }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(json.Int32("votes.moved")).Equals(4)
+	Expect(json.Int32("votes.dropped")).Equals(1)
+	ExpectHandler(&cmd.MergeUsers{}).CalledTimes(0)
+}
+
+func TestMergeUsersHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = &entity.User{ID: q.UserID, Tenant: mock.DemoTenant, Status: enum.UserActive}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.MergeUsers) error {
+		Expect(c.SourceUserID).Equals(3)
+		Expect(c.TargetUserID).Equals(mock.AryaStark.ID)
+		Expect(c.BlockSource).IsTrue()
+		c.Result = &entity.UserMergeSummary{}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.MergeUsers(), `{ "sourceUserID": 3, "targetUserID": 2, "blockSource": true }`)
+
+	Expect(code).Equals(http.StatusOK)
+	ExpectHandler(&cmd.MergeUsers{}).CalledOnce()
+}
+
+func TestMergeUsersHandler_SameUser(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = &entity.User{ID: q.UserID, Tenant: mock.DemoTenant, Status: enum.UserActive}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.MergeUsers) error {
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(handlers.MergeUsers(), `{ "sourceUserID": 2, "targetUserID": 2 }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectHandler(&cmd.MergeUsers{}).CalledTimes(0)
+}
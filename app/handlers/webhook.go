@@ -42,10 +42,12 @@ func CreateWebhook() web.HandlerFunc {
 			Name:        action.Name,
 			Type:        action.Type,
 			Status:      action.Status,
+			Format:      action.Format,
 			Url:         action.Url,
 			Content:     action.Content,
 			HttpMethod:  action.HttpMethod,
 			HttpHeaders: action.HttpHeaders,
+			Conditions:  action.Conditions,
 		}
 		if err := bus.Dispatch(c, createWebhook); err != nil {
 			return c.Failure(err)
@@ -73,10 +75,12 @@ func UpdateWebhook() web.HandlerFunc {
 			Name:        action.Name,
 			Type:        action.Type,
 			Status:      action.Status,
+			Format:      action.Format,
 			Url:         action.Url,
 			Content:     action.Content,
 			HttpMethod:  action.HttpMethod,
 			HttpHeaders: action.HttpHeaders,
+			Conditions:  action.Conditions,
 		}
 		if action.Status == enum.WebhookFailed {
 			updateWebhook.Status = enum.WebhookDisabled
@@ -131,9 +135,11 @@ func PreviewWebhook() web.HandlerFunc {
 		}
 
 		previewWebhook := &cmd.PreviewWebhook{
-			Type:    action.Type,
-			Url:     action.Url,
-			Content: action.Content,
+			Type:       action.Type,
+			Format:     action.Format,
+			Url:        action.Url,
+			Content:    action.Content,
+			Conditions: action.Conditions,
 		}
 		if err := bus.Dispatch(c, previewWebhook); err != nil {
 			return c.Failure(err)
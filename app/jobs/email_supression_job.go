@@ -43,6 +43,7 @@ func (e EmailSupressionJobHandler) Run(ctx Context) error {
 	log.Debugf(ctx, "@{Count} account(s) marked with supressed email", dto.Props{
 		"Count": c.NumOfSupressedEmailAddresses,
 	})
+	ctx.Report("supressed_emails", c.NumOfSupressedEmailAddresses)
 
 	return nil
 }
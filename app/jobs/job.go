@@ -2,14 +2,18 @@ package jobs
 
 import (
 	"context"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/robfig/cron"
 )
 
 type Handler interface {
@@ -18,31 +22,122 @@ type Handler interface {
 }
 
 type fiderJob struct {
-	Name    string
-	Handler Handler
+	Name     string
+	Schedule string
+	Handler  Handler
 }
 
-func NewJob(ctx context.Context, name string, handler Handler) (string, fiderJob) {
-	schedule := handler.Schedule()
+var (
+	registry   = map[string]fiderJob{}
+	registryMu sync.RWMutex
+)
+
+// Schedule adds a job with given name to given scheduler, which runs on the schedule set on JOBS_SCHEDULES
+// or on the default schedule of the handler.
+// Jobs with an invalid schedule are not registered, so that they're never listed as scheduled
+func Schedule(ctx context.Context, c *cron.Cron, name string, handler Handler) error {
+	job := newJob(name, handler)
+	if err := c.AddJob(job.Schedule, job); err != nil {
+		return errors.Wrap(err, "failed to schedule job '%s' to run '%s'", name, job.Schedule)
+	}
+	register(ctx, job)
+	return nil
+}
+
+func newJob(name string, handler Handler) fiderJob {
+	schedule, ok := env.JobSchedule(name)
+	if !ok {
+		schedule = handler.Schedule()
+	}
+	return fiderJob{Name: name, Schedule: schedule, Handler: handler}
+}
+
+func register(ctx context.Context, job fiderJob) {
 	log.Debugf(ctx, "Job '@{JobName}' scheduled to run '@{Schedule}'", dto.Props{
-		"JobName":  name,
-		"Schedule": schedule,
+		"JobName":  job.Name,
+		"Schedule": job.Schedule,
 	})
-	return schedule, fiderJob{Name: name, Handler: handler}
+
+	registryMu.Lock()
+	registry[job.Name] = job
+	registryMu.Unlock()
+}
+
+// Get returns the job registered with given name
+func Get(name string) (fiderJob, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	job, ok := registry[name]
+	return job, ok
 }
 
+// List returns all registered jobs ordered by name
+func List() []fiderJob {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	list := make([]fiderJob, 0, len(registry))
+	for _, job := range registry {
+		list = append(list, job)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Run is called by the scheduler, paused jobs are skipped
 func (j fiderJob) Run() {
-	locked := false
-	unlock := func() {}
+	// Errors are already logged when the job context is created
+	if execute, err := j.lock(false); err == nil && execute != nil {
+		execute()
+	}
+}
 
+// RunNow starts the job in the background, even if it's paused
+// Returns false when the job is already running somewhere else, in which case nothing is started
+func (j fiderJob) RunNow() (bool, error) {
+	execute, err := j.lock(true)
+	if err != nil || execute == nil {
+		return false, err
+	}
+	go execute()
+	return true, nil
+}
+
+// lock acquires the lock of the job and returns the function that executes it and releases the lock
+// The function is nil when the job is skipped
+func (j fiderJob) lock(manual bool) (func(), error) {
 	ctx, trx, err := newJobContext()
 	if err != nil {
-		log.Error(ctx, err)
-		return
+		return nil, err
+	}
+
+	if !manual && IsPaused(ctx, j.Name) {
+		log.Debugf(ctx, "Job '@{JobName}' skipped, it's paused", dto.Props{
+			"JobName": j.Name,
+		})
+		trx.MustCommit()
+		return nil, nil
 	}
 
 	start := time.Now()
+	locked, unlock := dbx.TryLock(ctx, trx, j.Name)
+	if !locked {
+		log.Debugf(ctx, "Job '@{JobName}' skipped, could not acquire lock", dto.Props{
+			"JobName": j.Name,
+		})
+		trx.MustCommit()
+		return nil, nil
+	}
+
+	return func() {
+		j.execute(ctx, trx, manual, start, unlock)
+	}, nil
+}
+
+func (j fiderJob) execute(ctx Context, trx *dbx.Trx, manual bool, start time.Time, unlock func()) {
 	ctx.LastSuccessfulRun = getLastSuccessfulRun(ctx, j.Name)
+	ctx.summary = dto.Props{}
 
 	logFinish := func() {
 		elapsedMs := time.Since(start).Nanoseconds() / int64(time.Millisecond)
@@ -62,8 +157,10 @@ func (j fiderJob) Run() {
 	defer func() {
 		if r := recover(); r != nil {
 			logFinish()
-			log.Error(ctx, errors.Panicked(r))
+			err := errors.Panicked(r)
+			log.Error(ctx, err)
 			setLastFailedRun(j.Name, start)
+			addRun(j.Name, manual, start, ctx.summary, err)
 			trx.MustRollback()
 		}
 	}()
@@ -72,23 +169,16 @@ func (j fiderJob) Run() {
 		"JobName": j.Name,
 	})
 
-	locked, unlock = dbx.TryLock(ctx, trx, j.Name)
-	if !locked {
-		log.Debugf(ctx, "Job '@{JobName}' skipped, could not acquire lock", dto.Props{
-			"JobName": j.Name,
-		})
-		trx.MustCommit()
-		return
-	}
-
 	defer logFinish()
 
 	if err := j.Handler.Run(ctx); err != nil {
 		log.Error(ctx, err)
 		setLastFailedRun(j.Name, start)
+		addRun(j.Name, manual, start, ctx.summary, err)
 		trx.MustRollback()
 	} else {
 		setLastSuccessfulRun(j.Name, start)
+		addRun(j.Name, manual, start, ctx.summary, nil)
 		trx.MustCommit()
 	}
 }
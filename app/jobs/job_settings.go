@@ -3,21 +3,68 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
 )
 
 type Context struct {
 	context.Context
 	LastSuccessfulRun *time.Time
+	summary           dto.Props
+}
+
+// Report adds given value to the summary kept on the history of current run
+func (ctx Context) Report(key string, value any) {
+	if ctx.summary != nil {
+		ctx.summary[key] = value
+	}
+}
+
+// IsPaused returns true if scheduled runs of given job are being skipped
+func IsPaused(ctx context.Context, jobName string) bool {
+	get := &query.GetSystemSettings{
+		Key: fmt.Sprintf("jobs.%s.paused", jobName),
+	}
+	if err := bus.Dispatch(ctx, get); err != nil {
+		log.Error(ctx, err)
+		return false
+	}
+	return get.Value == "true"
+}
+
+// SetPaused pauses or resumes scheduled runs of given job
+func SetPaused(ctx context.Context, jobName string, paused bool) error {
+	return bus.Dispatch(ctx, &cmd.SetSystemSettings{
+		Key:   fmt.Sprintf("jobs.%s.paused", jobName),
+		Value: strconv.FormatBool(paused),
+	})
+}
+
+// GetState returns given job along with its current state
+func GetState(ctx context.Context, job fiderJob) *entity.Job {
+	return &entity.Job{
+		Name:              job.Name,
+		Schedule:          job.Schedule,
+		Paused:            IsPaused(ctx, job.Name),
+		LastSuccessfulRun: getLastSuccessfulRun(ctx, job.Name),
+		LastFailedRun:     getLastRun(ctx, fmt.Sprintf("jobs.%s.last_failed_run", job.Name)),
+	}
 }
 
 func getLastSuccessfulRun(ctx context.Context, jobName string) *time.Time {
-	key := fmt.Sprintf("jobs.%s.last_successful_run", jobName)
+	return getLastRun(ctx, fmt.Sprintf("jobs.%s.last_successful_run", jobName))
+}
+
+func getLastRun(ctx context.Context, key string) *time.Time {
 	get := &query.GetSystemSettings{
 		Key: key,
 	}
@@ -61,3 +108,31 @@ func setLastRun(key string, t time.Time) {
 		log.Error(ctx, err)
 	}
 }
+
+func addRun(jobName string, manual bool, start time.Time, summary dto.Props, runErr error) {
+	ctx, trx, err := newJobContext()
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	defer trx.MustCommit()
+
+	finish := time.Now()
+	run := &entity.JobRun{
+		JobName:    jobName,
+		Status:     enum.JobRunSuccess,
+		Manual:     manual,
+		StartedAt:  start,
+		FinishedAt: finish,
+		DurationMs: finish.Sub(start).Milliseconds(),
+		Summary:    summary,
+	}
+	if runErr != nil {
+		run.Status = enum.JobRunFailed
+		run.Error = errors.Cause(runErr).Error()
+	}
+
+	if err = bus.Dispatch(ctx, &cmd.AddJobRun{Run: run}); err != nil {
+		log.Error(ctx, err)
+	}
+}
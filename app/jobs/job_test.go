@@ -9,9 +9,13 @@ import (
 
 	"github.com/getfider/fider/app/jobs"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/robfig/cron"
 )
 
 type MockJobHandler struct {
@@ -34,11 +38,23 @@ func (e MockJobHandler) Run(ctx jobs.Context) error {
 	return nil
 }
 
+type runner interface {
+	Run()
+	RunNow() (bool, error)
+}
+
+func scheduleJob(name string, handler jobs.Handler) (string, runner) {
+	err := jobs.Schedule(context.Background(), cron.New(), name, handler)
+	Expect(err).IsNil()
+	job, _ := jobs.Get(name)
+	return job.Schedule, job
+}
+
 func TestJob_WhenSuccessful_ShouldUpdateLastSuccessfulRun(t *testing.T) {
 	RegisterT(t)
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
-		Expect(q.Key).Equals("jobs.Test.last_successful_run")
+		Expect(q.Key == "jobs.Test.paused" || q.Key == "jobs.Test.last_successful_run").IsTrue()
 		return nil
 	})
 
@@ -48,7 +64,14 @@ func TestJob_WhenSuccessful_ShouldUpdateLastSuccessfulRun(t *testing.T) {
 		return nil
 	})
 
-	schedule, job := jobs.NewJob(context.Background(), "Test", MockJobHandler{
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddJobRun) error {
+		Expect(c.Run.JobName).Equals("Test")
+		Expect(c.Run.Status).Equals(enum.JobRunSuccess)
+		Expect(c.Run.Manual).IsFalse()
+		return nil
+	})
+
+	schedule, job := scheduleJob("Test", MockJobHandler{
 		ShouldFail: false,
 	})
 	Expect(schedule).Equals("0 * * * * *")
@@ -60,7 +83,7 @@ func TestJob_WhenError_ShouldUpdateLastFailedRun(t *testing.T) {
 	RegisterT(t)
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
-		Expect(q.Key).Equals("jobs.Test.last_successful_run")
+		Expect(q.Key == "jobs.Test.paused" || q.Key == "jobs.Test.last_successful_run").IsTrue()
 		return nil
 	})
 
@@ -70,7 +93,13 @@ func TestJob_WhenError_ShouldUpdateLastFailedRun(t *testing.T) {
 		return nil
 	})
 
-	schedule, job := jobs.NewJob(context.Background(), "Test", MockJobHandler{
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddJobRun) error {
+		Expect(c.Run.Status).Equals(enum.JobRunFailed)
+		Expect(c.Run.Error).Equals("Failed")
+		return nil
+	})
+
+	schedule, job := scheduleJob("Test", MockJobHandler{
 		ShouldFail: true,
 	})
 	Expect(schedule).Equals("0 * * * * *")
@@ -91,10 +120,14 @@ func TestJob_WhenTwoConcurrentRuns_ShouldExecuteOnce(t *testing.T) {
 		return nil
 	})
 
-	_, job1 := jobs.NewJob(context.Background(), "Test", MockJobHandler{
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddJobRun) error {
+		return nil
+	})
+
+	_, job1 := scheduleJob("Test", MockJobHandler{
 		WaitTime: 1 * time.Second,
 	})
-	_, job2 := jobs.NewJob(context.Background(), "Test", MockJobHandler{
+	_, job2 := scheduleJob("Test", MockJobHandler{
 		WaitTime: 1 * time.Second,
 	})
 
@@ -114,3 +147,59 @@ func TestJob_WhenTwoConcurrentRuns_ShouldExecuteOnce(t *testing.T) {
 
 	Expect(counter).Equals(1)
 }
+
+func TestJob_WhenPaused_ShouldOnlyRunManually(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetSystemSettings) error {
+		if q.Key == "jobs.Test.paused" {
+			q.Value = "true"
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetSystemSettings) error {
+		return nil
+	})
+
+	runs := make([]*entity.JobRun, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddJobRun) error {
+		runs = append(runs, c.Run)
+		return nil
+	})
+
+	_, job := scheduleJob("Test", MockJobHandler{})
+
+	job.Run()
+	Expect(runs).HasLen(0)
+
+	started, err := job.RunNow()
+	Expect(err).IsNil()
+	Expect(started).IsTrue()
+	for i := 0; started && i < 50 && len(runs) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	Expect(len(runs) == 1 && runs[0].Manual).IsTrue()
+}
+
+func TestSchedule_InvalidSchedule(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Jobs.Schedules = "BrokenJob=every minute"
+	defer func() {
+		env.Config.Jobs.Schedules = ""
+	}()
+
+	c := cron.New()
+	err := jobs.Schedule(context.Background(), c, "BrokenJob", MockJobHandler{})
+	Expect(err).IsNotNil()
+	_, ok := jobs.Get("BrokenJob")
+	Expect(ok).IsFalse()
+
+	err = jobs.Schedule(context.Background(), c, "ValidJob", MockJobHandler{})
+	Expect(err).IsNil()
+	job, ok := jobs.Get("ValidJob")
+	Expect(ok).IsTrue()
+	Expect(job.Schedule).Equals("0 * * * * *")
+	Expect(c.Entries()).HasLen(1)
+}
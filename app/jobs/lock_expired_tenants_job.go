@@ -26,6 +26,7 @@ func (e LockExpiredTenantsJobHandler) Run(ctx Context) error {
 	log.Debugf(ctx, "@{Count} tenants marked as locked", dto.Props{
 		"Count": c.NumOfTenantsLocked,
 	})
+	ctx.Report("locked_tenants", c.NumOfTenantsLocked)
 
 	// Handle userlist
 	if env.Config.UserList.Enabled && c.NumOfTenantsLocked > 0 {
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+type PurgeExpiredAnnouncementsJobHandler struct {
+}
+
+func (e PurgeExpiredAnnouncementsJobHandler) Schedule() string {
+	return "0 30 * * * *" // every hour at minute 30
+}
+
+func (e PurgeExpiredAnnouncementsJobHandler) Run(ctx Context) error {
+	log.Debug(ctx, "deleting announcements that have ended")
+
+	c := &cmd.PurgeExpiredAnnouncements{}
+	err := bus.Dispatch(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf(ctx, "@{RowsDeleted} announcements were deleted", dto.Props{
+		"RowsDeleted": c.NumOfDeletedAnnouncements,
+	})
+	ctx.Report("deleted_announcements", c.NumOfDeletedAnnouncements)
+
+	return nil
+}
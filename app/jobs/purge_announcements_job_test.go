@@ -0,0 +1,34 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestPurgeExpiredAnnouncementsJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.PurgeExpiredAnnouncementsJobHandler{}
+	Expect(job.Schedule()).Equals("0 30 * * * *")
+}
+
+func TestPurgeExpiredAnnouncementsJob_ShouldJustDispatchCommand(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.PurgeExpiredAnnouncements) error {
+		c.NumOfDeletedAnnouncements = 2
+		return nil
+	})
+
+	job := &jobs.PurgeExpiredAnnouncementsJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(bus.GetCallCount(&cmd.PurgeExpiredAnnouncements{})).Equals(1)
+}
@@ -26,6 +26,7 @@ func (e PurgeExpiredNotificationsJobHandler) Run(ctx Context) error {
 	log.Debugf(ctx, "@{RowsDeleted} notifications were deleted", dto.Props{
 		"RowsDeleted": c.NumOfDeletedNotifications,
 	})
+	ctx.Report("deleted_notifications", c.NumOfDeletedNotifications)
 
 	return nil
 }
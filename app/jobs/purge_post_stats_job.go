@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+type PurgeExpiredPostStatsJobHandler struct {
+}
+
+func (e PurgeExpiredPostStatsJobHandler) Schedule() string {
+	return "0 15 * * * *" // every hour at minute 15
+}
+
+func (e PurgeExpiredPostStatsJobHandler) Run(ctx Context) error {
+	log.Debugf(ctx, "deleting post stats older than @{RetentionDays} days", dto.Props{
+		"RetentionDays": env.Config.Analytics.RetentionDays,
+	})
+
+	c := &cmd.PurgeExpiredPostStats{
+		Before: time.Now().AddDate(0, 0, -env.Config.Analytics.RetentionDays),
+	}
+	err := bus.Dispatch(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf(ctx, "@{RowsDeleted} post stats rows were deleted", dto.Props{
+		"RowsDeleted": c.NumOfDeletedRows,
+	})
+	ctx.Report("deleted_rows", c.NumOfDeletedRows)
+
+	return nil
+}
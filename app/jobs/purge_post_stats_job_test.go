@@ -0,0 +1,40 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+func TestPurgeExpiredPostStatsJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.PurgeExpiredPostStatsJobHandler{}
+	Expect(job.Schedule()).Equals("0 15 * * * *")
+}
+
+func TestPurgeExpiredPostStatsJob_ShouldUseRetentionPeriod(t *testing.T) {
+	RegisterT(t)
+	env.Config.Analytics.RetentionDays = 90
+
+	var purge *cmd.PurgeExpiredPostStats
+	bus.AddHandler(func(ctx context.Context, c *cmd.PurgeExpiredPostStats) error {
+		purge = c
+		c.NumOfDeletedRows = 12
+		return nil
+	})
+
+	job := &jobs.PurgeExpiredPostStatsJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(bus.GetCallCount(&cmd.PurgeExpiredPostStats{})).Equals(1)
+	Expect(purge.Before).TemporarilySimilar(time.Now().AddDate(0, 0, -90), 5*time.Second)
+}
@@ -43,6 +43,7 @@ func (e TrialReminderJobHandler) Run(ctx Context) error {
 			"name": contact.Name,
 		}))
 	}
+	ctx.Report("reminders_sent", len(to))
 
 	if len(to) > 0 {
 		bus.Publish(ctx, &cmd.SendMail{
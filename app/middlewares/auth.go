@@ -1,7 +1,9 @@
 package middlewares
 
 import (
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
@@ -31,3 +33,29 @@ func IsAuthorized(roles ...enum.Role) web.MiddlewareFunc {
 		}
 	}
 }
+
+// BlockOAuthAccessTokens blocks requests made by third-party applications, regardless of the scopes they were granted
+// It's used on operations that users must do themselves, such as deleting their account
+func BlockOAuthAccessTokens() web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			if c.Value(app.OAuthAccessTokenCtxKey) != nil {
+				return c.Forbidden()
+			}
+			return next(c)
+		}
+	}
+}
+
+// IsOperator blocks requests from users that cannot manage the whole instance
+func IsOperator() web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			user := c.User()
+			if user == nil || user.Tenant == nil || !user.IsAdministrator() || !env.IsOperator(user.Tenant.ID, user.Email) {
+				return c.Forbidden()
+			}
+			return next(c)
+		}
+	}
+}
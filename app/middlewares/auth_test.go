@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/getfider/fider/app/middlewares"
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/mock"
 	"github.com/getfider/fider/app/pkg/web"
 )
@@ -59,3 +61,59 @@ func TestIsAuthenticated_WithoutUser(t *testing.T) {
 
 	Expect(status).Equals(http.StatusUnauthorized)
 }
+
+func TestIsOperator_WithOperator(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+	env.Config.Operator.TenantID = mock.DemoTenant.ID
+
+	server := mock.NewServer()
+	server.Use(middlewares.IsOperator())
+	status, _ := server.AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestIsOperator_WithAdministratorOnMultiHost(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.IsOperator())
+	status, _ := server.AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestIsOperator_WithAdministratorOfOtherTenant(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "jon.snow@got.com"
+	env.Config.Operator.TenantID = mock.DemoTenant.ID
+
+	// Any tenant can have an administrator with the email of an operator
+	impostor := &entity.User{ID: 10, Name: "Jon Snow", Email: "jon.snow@got.com", Role: enum.RoleAdministrator, Tenant: mock.AvengersTenant}
+
+	server := mock.NewServer()
+	server.Use(middlewares.IsOperator())
+	status, _ := server.OnTenant(mock.AvengersTenant).AsUser(impostor).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestIsOperator_WithVisitor(t *testing.T) {
+	RegisterT(t)
+	env.Config.Operator.Emails = "arya.stark@got.com"
+
+	server := mock.NewServer()
+	server.Use(middlewares.IsOperator())
+	status, _ := server.AsUser(mock.AryaStark).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusForbidden)
+}
@@ -16,3 +16,18 @@ func ClientCache(d time.Duration) web.MiddlewareFunc {
 		}
 	}
 }
+
+// TenantCache adds Cache-Control header for X seconds to resources of current tenant
+// Resources of private tenants are only visible to its users, so shared caches must not store them
+func TenantCache(d time.Duration) web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			visibility := "public"
+			if c.Tenant() != nil && c.Tenant().IsPrivate {
+				visibility = "private"
+			}
+			c.Response.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%.f", visibility, d.Seconds()))
+			return next(c)
+		}
+	}
+}
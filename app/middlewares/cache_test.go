@@ -40,3 +40,50 @@ func TestCache_StatusNotFound(t *testing.T) {
 	Expect(status).Equals(http.StatusNotFound)
 	Expect(response.Header().Get("Cache-Control")).Equals("no-cache, no-store")
 }
+
+func TestTenantCache_PublicTenant(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.TenantCache(5 * time.Minute))
+	handler := func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	status, response := server.OnTenant(mock.DemoTenant).Execute(handler)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Header().Get("Cache-Control")).Equals("public, max-age=300")
+}
+
+func TestTenantCache_PrivateTenant(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsPrivate = true
+	server.Use(middlewares.TenantCache(5 * time.Minute))
+	handler := func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	status, response := server.OnTenant(mock.DemoTenant).AsUser(mock.AryaStark).Execute(handler)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Header().Get("Cache-Control")).Equals("private, max-age=300")
+}
+
+func TestTenantCache_PrivateTenant_LongLived(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.IsPrivate = true
+	server.Use(middlewares.TenantCache(30 * 24 * time.Hour))
+	handler := func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	status, response := server.OnTenant(mock.DemoTenant).AsUser(mock.AryaStark).Execute(handler)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Header().Get("Cache-Control")).Equals("private, max-age=2592000")
+}
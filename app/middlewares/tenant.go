@@ -91,6 +91,25 @@ func BlockPendingTenants() web.MiddlewareFunc {
 	return func(next web.HandlerFunc) web.HandlerFunc {
 		return func(c *web.Context) error {
 			if c.Tenant().Status == enum.TenantPending {
+				if env.IsSignUpApprovalRequired() {
+					signUp := &query.GetTenantSignUpByTenantID{TenantID: c.Tenant().ID}
+					err := bus.Dispatch(c, signUp)
+					if err != nil && errors.Cause(err) != app.ErrNotFound {
+						return c.Failure(err)
+					}
+
+					if signUp.Result != nil && signUp.Result.Status == enum.TenantSignUpPending {
+						return c.Page(http.StatusOK, web.Props{
+							Page:        "SignUp/PendingActivation.page",
+							Title:       "Pending Approval",
+							Description: "Our team is reviewing your site and will activate it shortly.",
+							Data: web.Map{
+								"awaitingApproval": true,
+							},
+						})
+					}
+				}
+
 				return c.Page(http.StatusOK, web.Props{
 					Page:        "SignUp/PendingActivation.page",
 					Title:       "Pending Activation",
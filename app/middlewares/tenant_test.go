@@ -13,6 +13,7 @@ import (
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/mock"
 	"github.com/getfider/fider/app/pkg/web"
 )
@@ -275,6 +276,27 @@ func TestBlockPendingTenants_Pending(t *testing.T) {
 	Expect(status).Equals(http.StatusOK)
 }
 
+func TestBlockPendingTenants_AwaitingApproval(t *testing.T) {
+	RegisterT(t)
+	env.Config.SignUp.ApprovalRequired = true
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantSignUpByTenantID) error {
+		q.Result = &entity.TenantSignUp{TenantID: q.TenantID, Status: enum.TenantSignUpPending}
+		return nil
+	})
+
+	server := mock.NewServer()
+	mock.DemoTenant.Status = enum.TenantPending
+
+	server.Use(middlewares.BlockPendingTenants())
+	status, response := server.OnTenant(mock.DemoTenant).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusTeapot)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).ContainsSubstring("Pending Approval")
+}
+
 func TestCheckTenantPrivacy_Private_Unauthenticated(t *testing.T) {
 	RegisterT(t)
 
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -60,6 +61,10 @@ func User() web.MiddlewareFunc {
 				parts := strings.Split(authHeader, "Bearer")
 				if len(parts) == 2 {
 					apiKey := strings.TrimSpace(parts[1])
+					if strings.HasPrefix(apiKey, entity.OAuthAccessTokenPrefix) {
+						return oauthTokenUser(next, c, apiKey)
+					}
+
 					getUserByAPIKey := &query.GetUserByAPIKey{APIKey: apiKey}
 					err = bus.Dispatch(c, getUserByAPIKey)
 					if err != nil {
@@ -109,3 +114,55 @@ func User() web.MiddlewareFunc {
 		}
 	}
 }
+
+// oauthTokenUser authenticates requests made by third-party applications on behalf of a user
+// Applications are limited to the scopes granted by the user and only act with staff permissions when granted the admin scope
+func oauthTokenUser(next web.HandlerFunc, c *web.Context, accessToken string) error {
+	getAccessToken := &query.GetOAuthAccessToken{Token: accessToken}
+	err := bus.Dispatch(c, getAccessToken)
+	if err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			c.Response.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			return c.JSON(http.StatusUnauthorized, web.Map{
+				"error":             "invalid_token",
+				"error_description": "Access token is invalid or has expired",
+			})
+		}
+		return err
+	}
+	token := getAccessToken.Result
+
+	requiredScope := entity.OAuthScopeWrite
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		requiredScope = entity.OAuthScopeRead
+	}
+	if !token.HasScope(requiredScope) {
+		c.Response.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, requiredScope))
+		return c.JSON(http.StatusForbidden, web.Map{
+			"error":             "insufficient_scope",
+			"error_description": fmt.Sprintf("Access token requires the '%s' scope", requiredScope),
+		})
+	}
+
+	getUser := &query.GetUserByID{UserID: token.UserID}
+	if err := bus.Dispatch(c, getUser); err != nil {
+		return err
+	}
+
+	user := getUser.Result
+	if user.Status == enum.UserBlocked {
+		return c.Unauthorized()
+	}
+
+	if user.IsCollaborator() && !token.HasScope(entity.OAuthScopeAdmin) {
+		member := *user
+		member.Role = enum.RoleVisitor
+		user = &member
+	}
+
+	if c.Tenant() != nil && user.Tenant.ID == c.Tenant().ID {
+		c.SetUser(user)
+		c.Set(app.OAuthAccessTokenCtxKey, token)
+	}
+	return next(c)
+}
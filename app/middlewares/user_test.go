@@ -426,3 +426,129 @@ func TestUser_Impersonation_ValidUser(t *testing.T) {
 	Expect(status).Equals(http.StatusOK)
 	Expect(response.Body.String()).Equals("Arya Stark")
 }
+
+func mockOAuthAccessToken(scopes ...string) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthAccessToken) error {
+		if q.Token == entity.OAuthAccessTokenPrefix+"1234" {
+			q.Result = &entity.OAuthAccessToken{AuthorizationID: 1, AppID: 1, UserID: mock.JonSnow.ID, Scopes: scopes}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == mock.JonSnow.ID {
+			q.Result = mock.JonSnow
+			return nil
+		}
+		return app.ErrNotFound
+	})
+}
+
+func TestUser_ValidOAuthToken(t *testing.T) {
+	RegisterT(t)
+	mockOAuthAccessToken(entity.OAuthScopeRead, entity.OAuthScopeAdmin)
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer "+entity.OAuthAccessTokenPrefix+"1234").
+		Execute(func(c *web.Context) error {
+			return c.String(http.StatusOK, c.User().Name+" "+c.User().Role.String())
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals("Jon Snow administrator")
+	Expect(bus.GetCallCount(&query.GetUserByAPIKey{})).Equals(0)
+}
+
+func TestUser_OAuthToken_WithoutAdminScope(t *testing.T) {
+	RegisterT(t)
+	mockOAuthAccessToken(entity.OAuthScopeRead)
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer "+entity.OAuthAccessTokenPrefix+"1234").
+		Execute(func(c *web.Context) error {
+			return c.String(http.StatusOK, c.User().Name+" "+c.User().Role.String())
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals("Jon Snow visitor")
+	Expect(mock.JonSnow.Role).Equals(enum.RoleAdministrator)
+}
+
+func TestUser_OAuthToken_InsufficientScope(t *testing.T) {
+	RegisterT(t)
+	mockOAuthAccessToken(entity.OAuthScopeRead)
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer "+entity.OAuthAccessTokenPrefix+"1234").
+		ExecutePost(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		}, `{ "title": "My post" }`)
+
+	Expect(status).Equals(http.StatusForbidden)
+	Expect(response.Header().Get("WWW-Authenticate")).Equals(`Bearer error="insufficient_scope", scope="write"`)
+}
+
+func TestUser_OAuthToken_BlockedOnAccountOperations(t *testing.T) {
+	RegisterT(t)
+	mockOAuthAccessToken(entity.OAuthScopeRead, entity.OAuthScopeWrite)
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	server.Use(middlewares.BlockOAuthAccessTokens())
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/me").
+		AddHeader("Authorization", "Bearer "+entity.OAuthAccessTokenPrefix+"1234").
+		ExecutePost(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		}, `{ }`)
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestUser_Cookie_AllowedOnAccountOperations(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.BlockOAuthAccessTokens())
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithURL("http://example.com/api/v1/me").
+		ExecutePost(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		}, `{ }`)
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestUser_InvalidOAuthToken(t *testing.T) {
+	RegisterT(t)
+	mockOAuthAccessToken(entity.OAuthScopeRead)
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer "+entity.OAuthAccessTokenPrefix+"expired").
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusUnauthorized)
+	Expect(query.String("error")).Equals("invalid_token")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddNewAnnouncement struct {
+	Text        string
+	Severity    enum.AnnouncementSeverity
+	Audience    enum.AnnouncementAudience
+	StartsAt    time.Time
+	EndsAt      *time.Time
+	Dismissible bool
+
+	Result *entity.Announcement
+}
+
+type UpdateAnnouncement struct {
+	AnnouncementID int
+	Text           string
+	Severity       enum.AnnouncementSeverity
+	Audience       enum.AnnouncementAudience
+	StartsAt       time.Time
+	EndsAt         *time.Time
+	Dismissible    bool
+
+	Result *entity.Announcement
+}
+
+type DeleteAnnouncement struct {
+	Announcement *entity.Announcement
+}
+
+type PurgeExpiredAnnouncements struct {
+	NumOfDeletedAnnouncements int
+}
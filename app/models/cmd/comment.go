@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/getfider/fider/app/models/entity"
 )
 
@@ -19,3 +21,8 @@ type UpdateComment struct {
 type DeleteComment struct {
 	CommentID int
 }
+
+type BackdateComment struct {
+	Comment   *entity.Comment
+	CreatedAt time.Time
+}
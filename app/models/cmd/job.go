@@ -0,0 +1,7 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/entity"
+
+type AddJobRun struct {
+	Run *entity.JobRun
+}
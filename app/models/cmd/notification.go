@@ -14,6 +14,10 @@ type MarkNotificationAsRead struct {
 	ID int
 }
 
+type MarkNotificationAsUnread struct {
+	ID int
+}
+
 type AddNewNotification struct {
 	User   *entity.User
 	Title  string
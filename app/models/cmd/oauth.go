@@ -22,6 +22,10 @@ type SaveCustomOAuthConfig struct {
 	JSONUserEmailPath string
 }
 
+type DeleteCustomOAuthConfig struct {
+	Provider string
+}
+
 type ParseOAuthRawProfile struct {
 	Provider string
 	Body     string
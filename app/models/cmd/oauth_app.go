@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type CreateOAuthApp struct {
+	Name         string
+	RedirectURIs []string
+	Scopes       []string
+
+	Result *entity.OAuthApp
+	// ClientSecret is only available when the app is created, as only its hash is stored
+	ClientSecret string
+}
+
+type UpdateOAuthApp struct {
+	ID           int
+	Name         string
+	RedirectURIs []string
+	Scopes       []string
+}
+
+type DeleteOAuthApp struct {
+	ID int
+}
+
+// AddOAuthAuthorizationCode records the consent of current user and returns a single-use code
+type AddOAuthAuthorizationCode struct {
+	AppID         int
+	RedirectURI   string
+	CodeChallenge string
+	Scopes        []string
+	ExpiresIn     time.Duration
+
+	Result string
+}
+
+// ConsumeOAuthAuthorizationCode removes given code and returns it, so that it can't be used again
+type ConsumeOAuthAuthorizationCode struct {
+	Code string
+
+	Result *entity.OAuthAuthorizationCode
+}
+
+type IssueOAuthTokens struct {
+	AppID  int
+	UserID int
+	Scopes []string
+
+	Result *entity.OAuthTokens
+}
+
+// RefreshOAuthTokens replaces the tokens of given refresh token with new ones
+type RefreshOAuthTokens struct {
+	AppID        int
+	RefreshToken string
+
+	Result *entity.OAuthTokens
+}
+
+// RevokeOAuthAuthorization removes the authorization of current user and all tokens issued with it
+type RevokeOAuthAuthorization struct {
+	ID int
+}
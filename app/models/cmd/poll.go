@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddNewPoll struct {
+	Post              *entity.Post
+	Question          string
+	Options           []string
+	IsMultipleChoice  bool
+	ResultsVisibility enum.PollResultsVisibility
+	ClosesAt          *time.Time
+
+	Result *entity.Poll
+}
+
+type DeletePoll struct {
+	Poll *entity.Poll
+}
+
+// SetPollVote replaces the choices of current user on given poll
+type SetPollVote struct {
+	Poll      *entity.Poll
+	OptionIDs []int
+}
+
+type RemovePollVote struct {
+	Poll *entity.Poll
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 )
@@ -20,8 +22,33 @@ type UpdatePost struct {
 	Result *entity.Post
 }
 
+type SetPostScore struct {
+	Post       *entity.Post
+	Reach      *float64
+	Impact     *float64
+	Confidence *float64
+	Effort     *float64
+}
+
+type SetPostTarget struct {
+	Post   *entity.Post
+	Target *entity.PostTarget
+}
+
+type ReorderPost struct {
+	Post   *entity.Post
+	Before *entity.Post
+	After  *entity.Post
+}
+
 type SetPostResponse struct {
 	Post   *entity.Post
 	Text   string
 	Status enum.PostStatus
 }
+
+type BackdatePost struct {
+	Post        *entity.Post
+	CreatedAt   time.Time
+	RespondedAt time.Time
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import "time"
+
+type RecordPostView struct {
+	PostID         int
+	ViewedAt       time.Time
+	VisitorHash    string
+	ReferrerDomain string
+}
+
+// PurgeExpiredPostStats deletes counters older than given time
+// and visitor hashes of past days, which are only needed to count unique visitors of the current day
+type PurgeExpiredPostStats struct {
+	Before time.Time
+
+	NumOfDeletedRows int
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type AddNewPostTemplate struct {
+	Name             string
+	Content          string
+	RequiredSections []string
+	IsDefault        bool
+	Tags             []*entity.Tag
+
+	Result *entity.PostTemplate
+}
+
+type UpdatePostTemplate struct {
+	TemplateID       int
+	Name             string
+	Content          string
+	RequiredSections []string
+	IsDefault        bool
+	Tags             []*entity.Tag
+
+	Result *entity.PostTemplate
+}
+
+type DeletePostTemplate struct {
+	Template *entity.PostTemplate
+}
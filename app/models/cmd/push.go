@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type SavePushSubscription struct {
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	UserAgent string
+}
+
+type DeletePushSubscription struct {
+	UserID   int
+	Endpoint string
+}
+
+type SendWebPush struct {
+	User  *entity.User
+	Title string
+	Link  string
+}
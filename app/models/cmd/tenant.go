@@ -24,6 +24,10 @@ type UpdateTenantEmailAuthAllowedSettings struct {
 	IsEmailAuthAllowed bool
 }
 
+type UpdateTenantEmailRules struct {
+	Rules entity.TenantEmailRules
+}
+
 type UpdateTenantSettings struct {
 	Logo           *dto.ImageUpload
 	Title          string
@@ -34,7 +38,8 @@ type UpdateTenantSettings struct {
 }
 
 type UpdateTenantAdvancedSettings struct {
-	CustomCSS string
+	CustomCSS             string
+	PrioritizationFormula enum.PrioritizationFormula
 }
 
 type ActivateTenant struct {
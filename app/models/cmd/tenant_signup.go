@@ -0,0 +1,19 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/entity"
+
+type AddTenantSignUp struct {
+	SignUp *entity.TenantSignUp
+}
+
+type ApproveTenantSignUp struct {
+	SignUpID int
+
+	// Activated is true when the site was activated by the approval.
+	// Sites created by email are only activated after the email is verified
+	Activated bool
+}
+
+type RejectTenantSignUp struct {
+	SignUpID int
+}
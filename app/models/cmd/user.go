@@ -50,3 +50,11 @@ type UpdateCurrentUser struct {
 	AvatarType enum.AvatarType
 	Avatar     *dto.ImageUpload
 }
+
+type MergeUsers struct {
+	SourceUserID int
+	TargetUserID int
+	BlockSource  bool
+
+	Result *entity.UserMergeSummary
+}
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 )
 
 type AddVote struct {
-	Post *entity.Post
-	User *entity.User
+	Post       *entity.Post
+	User       *entity.User
+	Reason     string
+	Importance enum.VoteImportance
 }
 
 type RemoveVote struct {
@@ -18,3 +23,9 @@ type MarkPostAsDuplicate struct {
 	Post     *entity.Post
 	Original *entity.Post
 }
+
+type BackdateVote struct {
+	Post      *entity.Post
+	User      *entity.User
+	CreatedAt time.Time
+}
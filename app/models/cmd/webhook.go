@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/pkg/webhook"
 )
@@ -18,9 +19,11 @@ type TriggerWebhooks struct {
 }
 
 type PreviewWebhook struct {
-	Type    enum.WebhookType
-	Url     string
-	Content string
+	Type       enum.WebhookType
+	Format     enum.WebhookFormat
+	Url        string
+	Content    string
+	Conditions entity.WebhookConditions
 
 	Result *dto.WebhookPreviewResult
 }
@@ -17,8 +17,14 @@ type WebhookTriggerResult struct {
 }
 
 type WebhookPreviewResult struct {
-	Url     PreviewedField `json:"url"`
-	Content PreviewedField `json:"content"`
+	Url     PreviewedField   `json:"url"`
+	Content PreviewedField   `json:"content"`
+	Trigger PreviewedTrigger `json:"trigger"`
+}
+
+type PreviewedTrigger struct {
+	WouldTrigger bool   `json:"would_trigger"`
+	Reason       string `json:"reason,omitempty"`
 }
 
 type PreviewedField struct {
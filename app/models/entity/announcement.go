@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Announcement is a message displayed on all pages of a site for a period of time
+type Announcement struct {
+	ID          int                       `json:"id"`
+	Text        string                    `json:"text"`
+	Severity    enum.AnnouncementSeverity `json:"severity"`
+	Audience    enum.AnnouncementAudience `json:"audience"`
+	StartsAt    time.Time                 `json:"startsAt"`
+	EndsAt      *time.Time                `json:"endsAt"`
+	Dismissible bool                      `json:"dismissible"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+}
+
+// IsActive returns true if the announcement should be displayed at given time
+func (a *Announcement) IsActive(now time.Time) bool {
+	return !a.StartsAt.After(now) && (a.EndsAt == nil || a.EndsAt.After(now))
+}
+
+// IsVisibleTo returns true if given user is part of the announcement audience
+func (a *Announcement) IsVisibleTo(user *User) bool {
+	switch a.Audience {
+	case enum.AnnouncementEveryone:
+		return true
+	case enum.AnnouncementMembers:
+		return user != nil
+	case enum.AnnouncementStaff:
+		return user != nil && user.IsCollaborator()
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestAnnouncement_IsActive(t *testing.T) {
+	RegisterT(t)
+
+	now := time.Date(2026, time.October, 16, 10, 0, 0, 0, time.UTC)
+	endsAt := now.Add(time.Hour)
+	announcement := &entity.Announcement{StartsAt: now.Add(-time.Hour), EndsAt: &endsAt}
+
+	Expect(announcement.IsActive(now)).IsTrue()
+	Expect(announcement.IsActive(now.Add(-2 * time.Hour))).IsFalse()
+	Expect(announcement.IsActive(endsAt)).IsFalse()
+
+	announcement.EndsAt = nil
+	Expect(announcement.IsActive(now.AddDate(1, 0, 0))).IsTrue()
+}
+
+func TestAnnouncement_IsVisibleTo(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{Role: enum.RoleVisitor}
+	collaborator := &entity.User{Role: enum.RoleCollaborator}
+
+	everyone := &entity.Announcement{Audience: enum.AnnouncementEveryone}
+	Expect(everyone.IsVisibleTo(nil)).IsTrue()
+	Expect(everyone.IsVisibleTo(visitor)).IsTrue()
+
+	members := &entity.Announcement{Audience: enum.AnnouncementMembers}
+	Expect(members.IsVisibleTo(nil)).IsFalse()
+	Expect(members.IsVisibleTo(visitor)).IsTrue()
+
+	staff := &entity.Announcement{Audience: enum.AnnouncementStaff}
+	Expect(staff.IsVisibleTo(nil)).IsFalse()
+	Expect(staff.IsVisibleTo(visitor)).IsFalse()
+	Expect(staff.IsVisibleTo(collaborator)).IsTrue()
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Job is a scheduled job and its current state
+type Job struct {
+	Name              string     `json:"name"`
+	Schedule          string     `json:"schedule"`
+	Paused            bool       `json:"paused"`
+	LastSuccessfulRun *time.Time `json:"lastSuccessfulRun,omitempty"`
+	LastFailedRun     *time.Time `json:"lastFailedRun,omitempty"`
+}
+
+// JobRun is a single execution of a scheduled job
+type JobRun struct {
+	ID         int               `json:"id"`
+	JobName    string            `json:"jobName"`
+	Status     enum.JobRunStatus `json:"status"`
+	Manual     bool              `json:"manual"`
+	StartedAt  time.Time         `json:"startedAt"`
+	FinishedAt time.Time         `json:"finishedAt"`
+	DurationMs int64             `json:"durationMs"`
+	Error      string            `json:"error,omitempty"`
+	Summary    map[string]any    `json:"summary,omitempty"`
+}
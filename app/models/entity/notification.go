@@ -4,9 +4,68 @@ import "time"
 
 // Notification is the system generated notification entity
 type Notification struct {
-	ID        int       `json:"id" db:"id"`
-	Title     string    `json:"title" db:"title"`
-	Link      string    `json:"link" db:"link"`
-	Read      bool      `json:"read" db:"read"`
-	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	ID        int               `json:"id" db:"id"`
+	Title     string            `json:"title" db:"title"`
+	Link      string            `json:"link" db:"link"`
+	Read      bool              `json:"read" db:"read"`
+	CreatedAt time.Time         `json:"createdAt" db:"created_at"`
+	Post      *NotificationPost `json:"post,omitempty"`
+}
+
+// NotificationPost is the post a notification refers to
+type NotificationPost struct {
+	ID     int    `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+}
+
+// NotificationGroup is a set of notifications that refer to the same post
+type NotificationGroup struct {
+	Post          *NotificationPost `json:"post"`
+	Title         string            `json:"title"`
+	Link          string            `json:"link"`
+	Count         int               `json:"count"`
+	UnreadCount   int               `json:"unreadCount"`
+	LatestAt      time.Time         `json:"latestAt"`
+	Notifications []*Notification   `json:"notifications"`
+}
+
+// GroupNotificationsByPost groups given notifications by their post, keeping the original order of first appearance
+func GroupNotificationsByPost(notifications []*Notification) []*NotificationGroup {
+	groups := make([]*NotificationGroup, 0)
+	byPost := make(map[int]*NotificationGroup)
+
+	for _, n := range notifications {
+		var group *NotificationGroup
+		ok := false
+		if n.Post != nil {
+			group, ok = byPost[n.Post.ID]
+		}
+
+		if !ok {
+			group = &NotificationGroup{
+				Post:          n.Post,
+				Title:         n.Title,
+				Link:          n.Link,
+				LatestAt:      n.CreatedAt,
+				Notifications: make([]*Notification, 0),
+			}
+			if n.Post != nil {
+				byPost[n.Post.ID] = group
+			}
+			groups = append(groups, group)
+		}
+
+		group.Count++
+		if !n.Read {
+			group.UnreadCount++
+		}
+		if n.CreatedAt.After(group.LatestAt) {
+			group.LatestAt = n.CreatedAt
+		}
+		group.Notifications = append(group.Notifications, n)
+	}
+
+	return groups
 }
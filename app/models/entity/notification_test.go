@@ -0,0 +1,37 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestGroupNotificationsByPost(t *testing.T) {
+	RegisterT(t)
+
+	now := time.Now()
+	post1 := &entity.NotificationPost{ID: 1, Number: 1, Title: "Post #1"}
+	post2 := &entity.NotificationPost{ID: 2, Number: 2, Title: "Post #2"}
+
+	groups := entity.GroupNotificationsByPost([]*entity.Notification{
+		{ID: 5, Title: "Comment on Post #1", Post: post1, Read: false, CreatedAt: now},
+		{ID: 4, Title: "Comment on Post #2", Post: post2, Read: true, CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: 3, Title: "Comment on Post #1", Post: post1, Read: true, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: 2, Title: "Something else", CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: 1, Title: "Comment on Post #1", Post: post1, Read: false, CreatedAt: now.Add(-4 * time.Hour)},
+	})
+
+	Expect(groups).HasLen(3)
+	Expect(groups[0].Post).Equals(post1)
+	Expect(groups[0].Count).Equals(3)
+	Expect(groups[0].UnreadCount).Equals(2)
+	Expect(groups[0].LatestAt).Equals(now)
+	Expect(groups[0].Notifications).HasLen(3)
+	Expect(groups[1].Post).Equals(post2)
+	Expect(groups[1].Count).Equals(1)
+	Expect(groups[1].UnreadCount).Equals(0)
+	Expect(groups[2].Post).IsNil()
+	Expect(groups[2].Title).Equals("Something else")
+}
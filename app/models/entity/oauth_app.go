@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// OAuth scopes that can be granted to third-party applications
+const (
+	// OAuthScopeRead allows reading data through the API
+	OAuthScopeRead = "read"
+	// OAuthScopeWrite allows creating and changing content through the API, such as posts, comments and votes
+	OAuthScopeWrite = "write"
+	// OAuthScopeAdmin allows using the API operations reserved to collaborators and administrators
+	OAuthScopeAdmin = "admin"
+)
+
+// Prefixes of the tokens issued to third-party applications, used to tell them apart from API keys
+const (
+	OAuthAccessTokenPrefix  = "fider_at_"
+	OAuthRefreshTokenPrefix = "fider_rt_"
+)
+
+// OAuthScopes are all the scopes that can be granted to third-party applications
+var OAuthScopes = []string{OAuthScopeRead, OAuthScopeWrite, OAuthScopeAdmin}
+
+// IsValidOAuthScope returns true if given scope is known
+func IsValidOAuthScope(scope string) bool {
+	return containsString(OAuthScopes, scope)
+}
+
+// ParseOAuthScopes splits a space-delimited list of scopes
+func ParseOAuthScopes(scope string) []string {
+	return strings.Fields(scope)
+}
+
+// OAuthApp is a third-party application registered by a tenant to access the API on behalf of its users
+type OAuthApp struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	ClientID     string    `json:"clientID"`
+	RedirectURIs []string  `json:"redirectURIs"`
+	Scopes       []string  `json:"scopes"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// HasRedirectURI returns true if given URI is registered on the app
+// URIs must match exactly, as recommended by the OAuth 2.0 Security Best Current Practice
+func (a *OAuthApp) HasRedirectURI(uri string) bool {
+	for _, u := range a.RedirectURIs {
+		if u == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsScopes returns true if the app is allowed to request all given scopes
+func (a *OAuthApp) AllowsScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !containsString(a.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// OAuthAuthorization is the consent given by a user to a third-party application
+type OAuthAuthorization struct {
+	ID         int        `json:"id"`
+	App        *OAuthApp  `json:"app"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// OAuthAuthorizationCode is the short-lived code exchanged by an application for its tokens
+type OAuthAuthorizationCode struct {
+	AppID         int
+	UserID        int
+	RedirectURI   string
+	CodeChallenge string
+	Scopes        []string
+	ExpiresAt     time.Time
+}
+
+// OAuthTokens are the tokens issued to an application
+type OAuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
+	Scopes       []string
+}
+
+// OAuthAccessToken is what an access token grants to the application using it
+type OAuthAccessToken struct {
+	AuthorizationID int
+	AppID           int
+	UserID          int
+	Scopes          []string
+}
+
+// HasScope returns true if the token was granted given scope
+func (t *OAuthAccessToken) HasScope(scope string) bool {
+	return containsString(t.Scopes, scope)
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
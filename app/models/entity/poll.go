@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Poll is a follow-up question asked by the staff on a post
+type Poll struct {
+	ID                int                        `json:"id"`
+	Question          string                     `json:"question"`
+	IsMultipleChoice  bool                       `json:"isMultipleChoice"`
+	ResultsVisibility enum.PollResultsVisibility `json:"resultsVisibility"`
+	ClosesAt          *time.Time                 `json:"closesAt,omitempty"`
+	CreatedAt         time.Time                  `json:"createdAt"`
+	Options           []*PollOption              `json:"options"`
+	VotersCount       int                        `json:"votersCount"`
+	HasResults        bool                       `json:"hasResults"`
+	MyVotes           []int                      `json:"myVotes"`
+}
+
+// PollOption is one of the answers of a poll
+type PollOption struct {
+	ID         int    `json:"id"`
+	Text       string `json:"text"`
+	VotesCount int    `json:"votesCount"`
+}
+
+// PollVote is the choice of a user on a poll option
+type PollVote struct {
+	User      *VoteUser `json:"user"`
+	OptionID  int       `json:"optionId"`
+	Option    string    `json:"option"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// IsClosed returns true if the poll no longer accepts votes
+func (p *Poll) IsClosed() bool {
+	return p.ClosesAt != nil && !p.ClosesAt.After(time.Now())
+}
+
+// HasOption returns true if given option belongs to this poll
+func (p *Poll) HasOption(optionID int) bool {
+	for _, option := range p.Options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// CanSeeResults returns true if given user is allowed to see the results of the poll
+func (p *Poll) CanSeeResults(user *User) bool {
+	return p.ResultsVisibility == enum.PollResultsPublic || (user != nil && user.IsCollaborator())
+}
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/getfider/fider/app/models/enum"
@@ -22,6 +24,9 @@ type Post struct {
 	Status        enum.PostStatus `json:"status"`
 	Response      *PostResponse   `json:"response,omitempty"`
 	Tags          []string        `json:"tags"`
+	Score         *PostScore      `json:"score,omitempty"`
+	Target        *PostTarget     `json:"target,omitempty"`
+	Rank          *float64        `json:"rank,omitempty"`
 }
 
 // CanBeVoted returns true if this post can have its vote changed
@@ -33,6 +38,76 @@ func (i *Post) Url(baseURL string) string {
 	return fmt.Sprintf("%s/posts/%d/%s", baseURL, i.Number, i.Slug)
 }
 
+//PostScore holds the staff-only prioritization fields of a post
+type PostScore struct {
+	Reach      *float64 `json:"reach"`
+	Impact     *float64 `json:"impact"`
+	Confidence *float64 `json:"confidence"`
+	Effort     *float64 `json:"effort"`
+	Value      *float64 `json:"value"`
+}
+
+//PostTarget is when the staff expects a post to be released
+type PostTarget struct {
+	Kind     enum.PostTargetKind `json:"kind"`
+	Value    string              `json:"value"`
+	IsPublic bool                `json:"isPublic"`
+}
+
+// StartsAt returns the first day of the target quarter or month, or nil for milestones and invalid values
+func (t *PostTarget) StartsAt() *time.Time {
+	var (
+		startsAt time.Time
+		err      error
+	)
+
+	switch t.Kind {
+	case enum.PostTargetMonth:
+		startsAt, err = time.Parse("2006-01", t.Value)
+	case enum.PostTargetQuarter:
+		year, quarter, found := strings.Cut(t.Value, "-Q")
+		if !found {
+			return nil
+		}
+		startsAt, err = time.Parse("2006", year)
+		if err == nil {
+			var q int
+			q, err = strconv.Atoi(quarter)
+			if q < 1 || q > 4 {
+				return nil
+			}
+			startsAt = startsAt.AddDate(0, (q-1)*3, 0)
+		}
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return nil
+	}
+	return &startsAt
+}
+
+// Equals returns true if both targets are the same, including their visibility
+func (t *PostTarget) Equals(other *PostTarget) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return *t == *other
+}
+
+// String returns the target as displayed to users, such as "Q3 2026", "September 2026" or "v2.0"
+func (t *PostTarget) String() string {
+	startsAt := t.StartsAt()
+	if startsAt == nil {
+		return t.Value
+	}
+	if t.Kind == enum.PostTargetQuarter {
+		return fmt.Sprintf("Q%d %d", (int(startsAt.Month())+2)/3, startsAt.Year())
+	}
+	return startsAt.Format("January 2006")
+}
+
 //PostResponse is a staff response to a given post
 type PostResponse struct {
 	Text        string        `json:"text"`
@@ -52,3 +127,12 @@ type OriginalPost struct {
 func (i *OriginalPost) Url(baseURL string) string {
 	return fmt.Sprintf("%s/posts/%d/%s", baseURL, i.Number, i.Slug)
 }
+
+//PostSubscription is a post the user has subscribed to
+type PostSubscription struct {
+	Number       int             `json:"number"`
+	Title        string          `json:"title"`
+	Slug         string          `json:"slug"`
+	Status       enum.PostStatus `json:"status"`
+	SubscribedAt time.Time       `json:"subscribedAt"`
+}
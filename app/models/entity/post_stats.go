@@ -0,0 +1,35 @@
+package entity
+
+// PostStats is the aggregated traffic of a post over a period
+type PostStats struct {
+	Views          int               `json:"views"`
+	UniqueVisitors int               `json:"uniqueVisitors"`
+	Votes          int               `json:"votes"`
+	ConversionRate float64           `json:"conversionRate"`
+	Daily          []*DailyPostViews `json:"daily"`
+	Referrers      []*ReferrerStats  `json:"referrers"`
+}
+
+// DailyPostViews is the number of views of a post on a single day
+type DailyPostViews struct {
+	Day            string `json:"day"`
+	Views          int    `json:"views"`
+	UniqueVisitors int    `json:"uniqueVisitors"`
+}
+
+// PostViewStats is the number of views of a post over a period, used to rank posts
+type PostViewStats struct {
+	PostNumber     int     `json:"postNumber"`
+	PostTitle      string  `json:"postTitle"`
+	PostSlug       string  `json:"postSlug"`
+	Views          int     `json:"views"`
+	UniqueVisitors int     `json:"uniqueVisitors"`
+	Votes          int     `json:"votes"`
+	ConversionRate float64 `json:"conversionRate"`
+}
+
+// ReferrerStats is the number of views coming from another site over a period
+type ReferrerStats struct {
+	Domain string `json:"domain"`
+	Views  int    `json:"views"`
+}
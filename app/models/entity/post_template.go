@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"strings"
+)
+
+// PostTemplate is a markdown skeleton used as the initial description of new posts
+type PostTemplate struct {
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	Content          string   `json:"content"`
+	RequiredSections []string `json:"requiredSections"`
+	IsDefault        bool     `json:"isDefault"`
+	Tags             []string `json:"tags"`
+}
+
+// MissingSections returns the required sections that are either absent from given description
+// or have been left empty or unchanged from the template skeleton
+func (t *PostTemplate) MissingSections(description string) []string {
+	missing := make([]string, 0)
+	if len(t.RequiredSections) == 0 {
+		return missing
+	}
+
+	skeleton := markdownSections(t.Content)
+	sections := markdownSections(description)
+	for _, required := range t.RequiredSections {
+		key := sectionKey(required)
+		content, ok := sections[key]
+		if !ok || content == "" || content == skeleton[key] {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
+
+// HasSection returns true if content has a heading with given name
+func (t *PostTemplate) HasSection(name string) bool {
+	_, ok := markdownSections(t.Content)[sectionKey(name)]
+	return ok
+}
+
+// markdownSections splits a markdown text by its headings
+// and returns the trimmed content of each section keyed by its heading
+func markdownSections(text string) map[string]string {
+	sections := make(map[string]string)
+
+	current := ""
+	inSection := false
+	lines := make([]string, 0)
+	flush := func() {
+		if inSection {
+			sections[current] = strings.TrimSpace(strings.Join(lines, "\n"))
+		}
+		lines = lines[:0]
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if heading, ok := parseHeading(line); ok {
+			flush()
+			current = sectionKey(heading)
+			inSection = true
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return sections
+}
+
+func parseHeading(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level == 0 || level > 6 {
+		return "", false
+	}
+	rest := trimmed[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimRight(rest, "# ")), true
+}
+
+func sectionKey(heading string) string {
+	return strings.ToLower(strings.TrimSpace(heading))
+}
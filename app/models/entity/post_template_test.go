@@ -0,0 +1,55 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+var bugReportTemplate = &entity.PostTemplate{
+	Name: "Bug Report",
+	Content: `## Steps to reproduce
+Describe how to reproduce the bug
+
+## Expected behavior
+
+## Additional context`,
+	RequiredSections: []string{"Steps to reproduce", "Expected behavior"},
+}
+
+func TestPostTemplate_HasSection(t *testing.T) {
+	RegisterT(t)
+
+	Expect(bugReportTemplate.HasSection("Steps to reproduce")).IsTrue()
+	Expect(bugReportTemplate.HasSection("expected BEHAVIOR")).IsTrue()
+	Expect(bugReportTemplate.HasSection("Screenshots")).IsFalse()
+}
+
+func TestPostTemplate_MissingSections(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		description string
+		missing     []string
+	}{
+		{"", []string{"Steps to reproduce", "Expected behavior"}},
+		{bugReportTemplate.Content, []string{"Steps to reproduce", "Expected behavior"}},
+		{"## Steps to reproduce\nClick the button\n\n## Expected behavior\n", []string{"Expected behavior"}},
+		{"## Steps to reproduce\nClick the button\n\n### Expected behavior ###\nIt works", []string{}},
+		{"# steps to reproduce\r\nClick the button\r\n## Expected Behavior\r\nIt works\r\n## Additional context\r\n", []string{}},
+		{"##Steps to reproduce\nClick the button\n## Expected behavior\nIt works", []string{"Steps to reproduce"}},
+	}
+
+	for _, testCase := range testCases {
+		missing := bugReportTemplate.MissingSections(testCase.description)
+		Expect(missing).Equals(testCase.missing)
+	}
+}
+
+func TestPostTemplate_MissingSections_NoneRequired(t *testing.T) {
+	RegisterT(t)
+
+	template := &entity.PostTemplate{Content: "## Context"}
+	Expect(template.MissingSections("")).HasLen(0)
+}
@@ -0,0 +1,66 @@
+package entity_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestPostTarget_StartsAt(t *testing.T) {
+	RegisterT(t)
+
+	quarter := &entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q3"}
+	Expect(*quarter.StartsAt()).Equals(time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC))
+	Expect(quarter.String()).Equals("Q3 2026")
+
+	month := &entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09"}
+	Expect(*month.StartsAt()).Equals(time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC))
+	Expect(month.String()).Equals("September 2026")
+
+	milestone := &entity.PostTarget{Kind: enum.PostTargetMilestone, Value: "v2.0"}
+	Expect(milestone.StartsAt()).IsNil()
+	Expect(milestone.String()).Equals("v2.0")
+
+	invalid := &entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q5"}
+	Expect(invalid.StartsAt()).IsNil()
+	Expect(invalid.String()).Equals("2026-Q5")
+}
+
+func TestPostTarget_Equals(t *testing.T) {
+	RegisterT(t)
+
+	var none *entity.PostTarget
+	target := &entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: true}
+
+	Expect(none.Equals(nil)).IsTrue()
+	Expect(none.Equals(target)).IsFalse()
+	Expect(target.Equals(nil)).IsFalse()
+	Expect(target.Equals(&entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: true})).IsTrue()
+	Expect(target.Equals(&entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: false})).IsFalse()
+}
+
+func TestComparePostTargets(t *testing.T) {
+	RegisterT(t)
+
+	targets := []*entity.PostTarget{
+		nil,
+		{Kind: enum.PostTargetMilestone, Value: "v2.0"},
+		{Kind: enum.PostTargetMonth, Value: "2026-07"},
+		{Kind: enum.PostTargetMilestone, Value: "Beta"},
+		{Kind: enum.PostTargetMonth, Value: "2026-05"},
+		{Kind: enum.PostTargetQuarter, Value: "2026-Q3"},
+	}
+	slices.SortFunc(targets, entity.ComparePostTargets)
+
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		if target != nil {
+			names[i] = target.String()
+		}
+	}
+	Expect(names).Equals([]string{"May 2026", "Q3 2026", "July 2026", "Beta", "v2.0", ""})
+}
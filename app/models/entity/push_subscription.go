@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PushSubscription is a browser/device registered to receive Web Push notifications
+type PushSubscription struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"-"`
+	Endpoint  string    `json:"endpoint"`
+	P256dh    string    `json:"-"`
+	Auth      string    `json:"-"`
+	UserAgent string    `json:"userAgent"`
+	CreatedAt time.Time `json:"createdAt"`
+}
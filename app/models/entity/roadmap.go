@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"cmp"
+	"strings"
+)
+
+// RoadmapGroup is a list of posts expected on the same target
+// Posts without target are grouped together, with a nil Target
+type RoadmapGroup struct {
+	Target *PostTarget `json:"target"`
+	Posts  []*Post     `json:"posts"`
+}
+
+// ComparePostTargets orders targets by date, then milestones by name and finally posts without target
+func ComparePostTargets(a, b *PostTarget) int {
+	if a == nil || b == nil {
+		return compareMissing(a == nil, b == nil)
+	}
+
+	aStartsAt, bStartsAt := a.StartsAt(), b.StartsAt()
+	if aStartsAt == nil || bStartsAt == nil {
+		if c := compareMissing(aStartsAt == nil, bStartsAt == nil); c != 0 {
+			return c
+		}
+	} else if c := aStartsAt.Compare(*bStartsAt); c != 0 {
+		return c
+	}
+
+	return cmp.Or(cmp.Compare(a.Kind, b.Kind), strings.Compare(strings.ToLower(a.Value), strings.ToLower(b.Value)))
+}
+
+// compareMissing puts missing values last
+func compareMissing(aMissing, bMissing bool) int {
+	switch {
+	case aMissing == bMissing:
+		return 0
+	case aMissing:
+		return 1
+	}
+	return -1
+}
@@ -1,21 +1,31 @@
 package entity
 
-import "github.com/getfider/fider/app/models/enum"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/disposable"
+	"github.com/getfider/fider/app/pkg/errors"
+)
 
 // Tenant represents a tenant
 type Tenant struct {
-	ID                 int               `json:"id"`
-	Name               string            `json:"name"`
-	Subdomain          string            `json:"subdomain"`
-	Invitation         string            `json:"invitation"`
-	WelcomeMessage     string            `json:"welcomeMessage"`
-	CNAME              string            `json:"cname"`
-	Status             enum.TenantStatus `json:"status"`
-	Locale             string            `json:"locale"`
-	IsPrivate          bool              `json:"isPrivate"`
-	LogoBlobKey        string            `json:"logoBlobKey"`
-	CustomCSS          string            `json:"-"`
-	IsEmailAuthAllowed bool              `json:"isEmailAuthAllowed"`
+	ID                    int                        `json:"id"`
+	Name                  string                     `json:"name"`
+	Subdomain             string                     `json:"subdomain"`
+	Invitation            string                     `json:"invitation"`
+	WelcomeMessage        string                     `json:"welcomeMessage"`
+	CNAME                 string                     `json:"cname"`
+	Status                enum.TenantStatus          `json:"status"`
+	Locale                string                     `json:"locale"`
+	IsPrivate             bool                       `json:"isPrivate"`
+	LogoBlobKey           string                     `json:"logoBlobKey"`
+	CustomCSS             string                     `json:"-"`
+	IsEmailAuthAllowed    bool                       `json:"isEmailAuthAllowed"`
+	PrioritizationFormula enum.PrioritizationFormula `json:"-"`
+	EmailRules            TenantEmailRules           `json:"-"`
 }
 
 func (t *Tenant) IsDisabled() bool {
@@ -28,3 +38,57 @@ type TenantContact struct {
 	Email     string `json:"email"`
 	Subdomain string `json:"subdomain"`
 }
+
+// TenantEmailRules restricts the email addresses that can be used on a site.
+// Domains match themselves and all of their subdomains
+type TenantEmailRules struct {
+	AllowedDomains  []string `json:"allowedDomains"`
+	BlockedDomains  []string `json:"blockedDomains"`
+	BlockDisposable bool     `json:"blockDisposable"`
+}
+
+// Allows returns true if given email address satisfies all rules
+func (r TenantEmailRules) Allows(email string) bool {
+	_, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+
+	for _, blocked := range r.BlockedDomains {
+		if matchesDomain(domain, blocked) {
+			return false
+		}
+	}
+
+	if r.BlockDisposable && disposable.IsDisposableDomain(domain) {
+		return false
+	}
+
+	if len(r.AllowedDomains) > 0 {
+		for _, allowed := range r.AllowedDomains {
+			if matchesDomain(domain, allowed) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func matchesDomain(domain, rule string) bool {
+	rule = strings.ToLower(rule)
+	return domain != "" && (domain == rule || strings.HasSuffix(domain, "."+rule))
+}
+
+func (r TenantEmailRules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *TenantEmailRules) Scan(src any) error {
+	if src == nil {
+		return nil
+	}
+	rules, ok := src.([]byte)
+	if !ok {
+		return errors.New("Invalid data stored in database")
+	}
+	return json.Unmarshal(rules, r)
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// TenantSignUp is the request of someone to create a new site
+type TenantSignUp struct {
+	ID              int                     `json:"id"`
+	TenantID        int                     `json:"tenantId"`
+	TenantName      string                  `json:"tenantName"`
+	TenantSubdomain string                  `json:"tenantSubdomain"`
+	Name            string                  `json:"name"`
+	Email           string                  `json:"email"`
+	IPAddress       string                  `json:"ipAddress"`
+	Status          enum.TenantSignUpStatus `json:"status"`
+	CreatedAt       time.Time               `json:"createdAt"`
+	ReviewedAt      *time.Time              `json:"reviewedAt,omitempty"`
+}
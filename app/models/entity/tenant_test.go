@@ -0,0 +1,28 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestTenantEmailRules_Allows(t *testing.T) {
+	RegisterT(t)
+
+	Expect(entity.TenantEmailRules{}.Allows("jon.snow@got.com")).IsTrue()
+
+	rules := entity.TenantEmailRules{
+		AllowedDomains: []string{"got.com"},
+		BlockedDomains: []string{"kingslanding.got.com"},
+	}
+	Expect(rules.Allows("jon.snow@got.com")).IsTrue()
+	Expect(rules.Allows("jon.snow@North.GOT.com")).IsTrue()
+	Expect(rules.Allows("cersei@kingslanding.got.com")).IsFalse()
+	Expect(rules.Allows("jon.snow@notgot.com")).IsFalse()
+	Expect(rules.Allows("")).IsFalse()
+
+	rules = entity.TenantEmailRules{BlockDisposable: true}
+	Expect(rules.Allows("jon.snow@got.com")).IsTrue()
+	Expect(rules.Allows("jon.snow@yopmail.com")).IsFalse()
+}
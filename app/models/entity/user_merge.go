@@ -0,0 +1,20 @@
+package entity
+
+// UserMergeCount is the number of records of one kind affected by a user merge
+type UserMergeCount struct {
+	Moved   int `json:"moved"`
+	Dropped int `json:"dropped"`
+}
+
+// UserMergeSummary describes what is (or will be) changed when merging a user into another
+// Records are dropped instead of moved when the target user already has an equivalent one
+type UserMergeSummary struct {
+	Posts         UserMergeCount `json:"posts"`
+	Comments      UserMergeCount `json:"comments"`
+	Votes         UserMergeCount `json:"votes"`
+	PollVotes     UserMergeCount `json:"pollVotes"`
+	Subscriptions UserMergeCount `json:"subscriptions"`
+	Notifications UserMergeCount `json:"notifications"`
+	Providers     UserMergeCount `json:"providers"`
+	Settings      UserMergeCount `json:"settings"`
+}
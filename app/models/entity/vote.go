@@ -2,6 +2,8 @@ package entity
 
 import (
 	"time"
+
+	"github.com/getfider/fider/app/models/enum"
 )
 
 //VoteUser represents a user that voted on a post
@@ -12,8 +14,17 @@ type VoteUser struct {
 	AvatarURL string `json:"avatarURL,omitempty"`
 }
 
+//VotePost is the post a vote was given to
+type VotePost struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
 //Vote represents a vote given by a user on a post
 type Vote struct {
-	User      *VoteUser `json:"user"`
-	CreatedAt time.Time `json:"createdAt"`
+	User       *VoteUser           `json:"user"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	Reason     string              `json:"reason,omitempty"`
+	Importance enum.VoteImportance `json:"importance,omitempty"`
+	Post       *VotePost           `json:"post,omitempty"`
 }
@@ -14,10 +14,12 @@ type Webhook struct {
 	Name        string             `json:"name" db:"name"`
 	Type        enum.WebhookType   `json:"type" db:"type"`
 	Status      enum.WebhookStatus `json:"status" db:"status"`
+	Format      enum.WebhookFormat `json:"format" db:"format"`
 	Url         string             `json:"url" db:"url"`
 	Content     string             `json:"content" db:"content"`
 	HttpMethod  string             `json:"http_method" db:"http_method"`
 	HttpHeaders HttpHeaders        `json:"http_headers" db:"http_headers"`
+	Conditions  WebhookConditions  `json:"conditions" db:"conditions"`
 }
 
 type HttpHeaders map[string]string
@@ -36,3 +38,30 @@ func (h *HttpHeaders) Scan(src any) error {
 	}
 	return json.Unmarshal(headers, &h)
 }
+
+// WebhookConditions restricts the events that trigger a webhook.
+// Empty conditions are ignored, so a webhook without conditions is triggered by every event of its type.
+// AuthorRoles are matched against the role of the post author
+type WebhookConditions struct {
+	IncludeTags []string `json:"include_tags,omitempty"`
+	ExcludeTags []string `json:"exclude_tags,omitempty"`
+	FromStatus  []string `json:"from_status,omitempty"`
+	ToStatus    []string `json:"to_status,omitempty"`
+	AuthorRoles []string `json:"author_roles,omitempty"`
+	MinVotes    int      `json:"min_votes,omitempty"`
+}
+
+func (c WebhookConditions) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *WebhookConditions) Scan(src any) error {
+	if src == nil {
+		return nil
+	}
+	conditions, ok := src.([]byte)
+	if !ok {
+		return errors.New("Invalid data stored in database")
+	}
+	return json.Unmarshal(conditions, c)
+}
@@ -0,0 +1,89 @@
+package enum
+
+// AnnouncementSeverity is how important an announcement is, which defines how it's displayed
+type AnnouncementSeverity int
+
+const (
+	// AnnouncementInfo is used for general information
+	AnnouncementInfo AnnouncementSeverity = 1
+	// AnnouncementWarning is used for things visitors should be aware of
+	AnnouncementWarning AnnouncementSeverity = 2
+	// AnnouncementCritical is used for disruptive events, like a downtime
+	AnnouncementCritical AnnouncementSeverity = 3
+)
+
+var announcementSeverityIDs = map[AnnouncementSeverity]string{
+	AnnouncementInfo:     "info",
+	AnnouncementWarning:  "warning",
+	AnnouncementCritical: "critical",
+}
+
+var announcementSeverityName = map[string]AnnouncementSeverity{
+	"info":     AnnouncementInfo,
+	"warning":  AnnouncementWarning,
+	"critical": AnnouncementCritical,
+}
+
+// MarshalText returns the Text version of the announcement severity
+func (severity AnnouncementSeverity) MarshalText() ([]byte, error) {
+	return []byte(announcementSeverityIDs[severity]), nil
+}
+
+// UnmarshalText parse string into an announcement severity
+func (severity *AnnouncementSeverity) UnmarshalText(text []byte) error {
+	*severity = announcementSeverityName[string(text)]
+	return nil
+}
+
+// Name returns the name of an announcement severity
+func (severity AnnouncementSeverity) Name() string {
+	name, ok := announcementSeverityIDs[severity]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
+
+// AnnouncementAudience is who an announcement is shown to
+type AnnouncementAudience int
+
+const (
+	// AnnouncementEveryone is shown to all visitors, including anonymous ones
+	AnnouncementEveryone AnnouncementAudience = 1
+	// AnnouncementMembers is only shown to signed in users
+	AnnouncementMembers AnnouncementAudience = 2
+	// AnnouncementStaff is only shown to collaborators and administrators
+	AnnouncementStaff AnnouncementAudience = 3
+)
+
+var announcementAudienceIDs = map[AnnouncementAudience]string{
+	AnnouncementEveryone: "everyone",
+	AnnouncementMembers:  "members",
+	AnnouncementStaff:    "staff",
+}
+
+var announcementAudienceName = map[string]AnnouncementAudience{
+	"everyone": AnnouncementEveryone,
+	"members":  AnnouncementMembers,
+	"staff":    AnnouncementStaff,
+}
+
+// MarshalText returns the Text version of the announcement audience
+func (audience AnnouncementAudience) MarshalText() ([]byte, error) {
+	return []byte(announcementAudienceIDs[audience]), nil
+}
+
+// UnmarshalText parse string into an announcement audience
+func (audience *AnnouncementAudience) UnmarshalText(text []byte) error {
+	*audience = announcementAudienceName[string(text)]
+	return nil
+}
+
+// Name returns the name of an announcement audience
+func (audience AnnouncementAudience) Name() string {
+	name, ok := announcementAudienceIDs[audience]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,41 @@
+package enum
+
+// JobRunStatus is the outcome of a job run
+type JobRunStatus int
+
+const (
+	// JobRunSuccess means the job finished without errors
+	JobRunSuccess JobRunStatus = 1
+	// JobRunFailed means the job returned an error or panicked
+	JobRunFailed JobRunStatus = 2
+)
+
+var jobRunStatusIDs = map[JobRunStatus]string{
+	JobRunSuccess: "success",
+	JobRunFailed:  "failed",
+}
+
+var jobRunStatusName = map[string]JobRunStatus{
+	"success": JobRunSuccess,
+	"failed":  JobRunFailed,
+}
+
+// MarshalText returns the Text version of the job run status
+func (status JobRunStatus) MarshalText() ([]byte, error) {
+	return []byte(jobRunStatusIDs[status]), nil
+}
+
+// UnmarshalText parse string into a job run status
+func (status *JobRunStatus) UnmarshalText(text []byte) error {
+	*status = jobRunStatusName[string(text)]
+	return nil
+}
+
+// Name returns the name of a job run status
+func (status JobRunStatus) Name() string {
+	name, ok := jobRunStatusIDs[status]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
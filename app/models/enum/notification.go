@@ -12,8 +12,26 @@ var (
 	NotificationChannelWeb NotificationChannel = 1
 	//NotificationChannelEmail is an email notification
 	NotificationChannelEmail NotificationChannel = 2
+	//NotificationChannelPush is a browser push notification
+	NotificationChannelPush NotificationChannel = 4
 )
 
+//NotificationRetentionSettingsKeyName is the user setting that defines for how many days notifications are kept
+const NotificationRetentionSettingsKeyName = "notification_retention_days"
+
+//NotificationRetentionOptions are the number of days a user can choose to keep notifications for
+var NotificationRetentionOptions = []int{7, 30, 90, 180, 365}
+
+//IsValidNotificationRetention returns true if given value is one of NotificationRetentionOptions
+func IsValidNotificationRetention(v string) bool {
+	for _, days := range NotificationRetentionOptions {
+		if strconv.Itoa(days) == v {
+			return true
+		}
+	}
+	return false
+}
+
 //NotificationEvent represents all possible notification events
 type NotificationEvent struct {
 	UserSettingsKeyName           string
@@ -24,7 +42,8 @@ type NotificationEvent struct {
 }
 
 func notificationEventValidation(v string) bool {
-	return v == "0" || v == "1" || v == "2" || v == "3"
+	n, err := strconv.Atoi(v)
+	return err == nil && strconv.Itoa(n) == v && n >= 0 && n <= int(NotificationChannelWeb|NotificationChannelEmail|NotificationChannelPush)
 }
 
 var (
@@ -66,10 +85,25 @@ var (
 		},
 		Validate: notificationEventValidation,
 	}
+	//NotificationEventNewPoll is triggered when a new poll is opened on a post
+	NotificationEventNewPoll = NotificationEvent{
+		UserSettingsKeyName: "event_notification_new_poll",
+		DefaultSettingValue: strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
+		RequiresSubscriptionUserRoles: []Role{
+			RoleVisitor,
+		},
+		DefaultEnabledUserRoles: []Role{
+			RoleAdministrator,
+			RoleCollaborator,
+			RoleVisitor,
+		},
+		Validate: notificationEventValidation,
+	}
 	//AllNotificationEvents contains all possible notification events
 	AllNotificationEvents = []NotificationEvent{
 		NotificationEventNewPost,
 		NotificationEventNewComment,
 		NotificationEventChangeStatus,
+		NotificationEventNewPoll,
 	}
 )
@@ -0,0 +1,41 @@
+package enum
+
+// PollResultsVisibility defines who can see the results of a poll
+type PollResultsVisibility int
+
+const (
+	// PollResultsPublic means that everyone can see the results
+	PollResultsPublic PollResultsVisibility = 1
+	// PollResultsStaff means that only collaborators and administrators can see the results
+	PollResultsStaff PollResultsVisibility = 2
+)
+
+var pollResultsVisibilityIDs = map[PollResultsVisibility]string{
+	PollResultsPublic: "public",
+	PollResultsStaff:  "staff",
+}
+
+var pollResultsVisibilityName = map[string]PollResultsVisibility{
+	"public": PollResultsPublic,
+	"staff":  PollResultsStaff,
+}
+
+// MarshalText returns the Text version of the poll results visibility
+func (visibility PollResultsVisibility) MarshalText() ([]byte, error) {
+	return []byte(pollResultsVisibilityIDs[visibility]), nil
+}
+
+// UnmarshalText parse string into a poll results visibility
+func (visibility *PollResultsVisibility) UnmarshalText(text []byte) error {
+	*visibility = pollResultsVisibilityName[string(text)]
+	return nil
+}
+
+// Name returns the name of a poll results visibility
+func (visibility PollResultsVisibility) Name() string {
+	name, ok := pollResultsVisibilityIDs[visibility]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,45 @@
+package enum
+
+// PostTargetKind is how the target release of a post is expressed
+type PostTargetKind int
+
+const (
+	// PostTargetQuarter means the post is expected on a quarter, such as 2026-Q3
+	PostTargetQuarter PostTargetKind = 1
+	// PostTargetMonth means the post is expected on a month, such as 2026-09
+	PostTargetMonth PostTargetKind = 2
+	// PostTargetMilestone means the post is expected on a named milestone, such as v2.0
+	PostTargetMilestone PostTargetKind = 3
+)
+
+var postTargetKindIDs = map[PostTargetKind]string{
+	PostTargetQuarter:   "quarter",
+	PostTargetMonth:     "month",
+	PostTargetMilestone: "milestone",
+}
+
+var postTargetKindName = map[string]PostTargetKind{
+	"quarter":   PostTargetQuarter,
+	"month":     PostTargetMonth,
+	"milestone": PostTargetMilestone,
+}
+
+// MarshalText returns the Text version of the post target kind
+func (kind PostTargetKind) MarshalText() ([]byte, error) {
+	return []byte(postTargetKindIDs[kind]), nil
+}
+
+// UnmarshalText parse string into a post target kind
+func (kind *PostTargetKind) UnmarshalText(text []byte) error {
+	*kind = postTargetKindName[string(text)]
+	return nil
+}
+
+// Name returns the name of a post target kind
+func (kind PostTargetKind) Name() string {
+	name, ok := postTargetKindIDs[kind]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,41 @@
+package enum
+
+// PrioritizationFormula is the formula used to compute the staff score of posts
+type PrioritizationFormula int
+
+const (
+	// PrioritizationRICE is Reach × Impact × Confidence ÷ Effort
+	PrioritizationRICE PrioritizationFormula = 1
+	// PrioritizationICE is Impact × Confidence ÷ Effort, ignoring Reach
+	PrioritizationICE PrioritizationFormula = 2
+)
+
+var prioritizationFormulaIDs = map[PrioritizationFormula]string{
+	PrioritizationRICE: "rice",
+	PrioritizationICE:  "ice",
+}
+
+var prioritizationFormulaName = map[string]PrioritizationFormula{
+	"rice": PrioritizationRICE,
+	"ice":  PrioritizationICE,
+}
+
+// MarshalText returns the Text version of the prioritization formula
+func (formula PrioritizationFormula) MarshalText() ([]byte, error) {
+	return []byte(prioritizationFormulaIDs[formula]), nil
+}
+
+// UnmarshalText parse string into a prioritization formula
+func (formula *PrioritizationFormula) UnmarshalText(text []byte) error {
+	*formula = prioritizationFormulaName[string(text)]
+	return nil
+}
+
+// Name returns the name of a prioritization formula
+func (formula PrioritizationFormula) Name() string {
+	name, ok := prioritizationFormulaIDs[formula]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
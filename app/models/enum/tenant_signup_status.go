@@ -0,0 +1,45 @@
+package enum
+
+// TenantSignUpStatus is the review status of a site sign-up
+type TenantSignUpStatus int
+
+const (
+	// TenantSignUpPending means the sign-up is waiting for an operator to review it
+	TenantSignUpPending TenantSignUpStatus = 1
+	// TenantSignUpApproved means the sign-up was approved and the site can be activated
+	TenantSignUpApproved TenantSignUpStatus = 2
+	// TenantSignUpRejected means the sign-up was rejected and the site is disabled
+	TenantSignUpRejected TenantSignUpStatus = 3
+)
+
+var tenantSignUpStatusIDs = map[TenantSignUpStatus]string{
+	TenantSignUpPending:  "pending",
+	TenantSignUpApproved: "approved",
+	TenantSignUpRejected: "rejected",
+}
+
+var tenantSignUpStatusName = map[string]TenantSignUpStatus{
+	"pending":  TenantSignUpPending,
+	"approved": TenantSignUpApproved,
+	"rejected": TenantSignUpRejected,
+}
+
+// MarshalText returns the Text version of the sign-up status
+func (status TenantSignUpStatus) MarshalText() ([]byte, error) {
+	return []byte(tenantSignUpStatusIDs[status]), nil
+}
+
+// UnmarshalText parse string into a sign-up status
+func (status *TenantSignUpStatus) UnmarshalText(text []byte) error {
+	*status = tenantSignUpStatusName[string(text)]
+	return nil
+}
+
+// Name returns the name of a sign-up status
+func (status TenantSignUpStatus) Name() string {
+	name, ok := tenantSignUpStatusIDs[status]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
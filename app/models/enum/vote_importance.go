@@ -0,0 +1,45 @@
+package enum
+
+// VoteImportance is how important a post is to the user who voted on it
+type VoteImportance int
+
+const (
+	// VoteImportanceNiceToHave means the user would like to have it
+	VoteImportanceNiceToHave VoteImportance = 1
+	// VoteImportanceImportant means the user really needs it
+	VoteImportanceImportant VoteImportance = 2
+	// VoteImportanceCritical means the user is blocked without it
+	VoteImportanceCritical VoteImportance = 3
+)
+
+var voteImportanceIDs = map[VoteImportance]string{
+	VoteImportanceNiceToHave: "nice_to_have",
+	VoteImportanceImportant:  "important",
+	VoteImportanceCritical:   "critical",
+}
+
+var voteImportanceName = map[string]VoteImportance{
+	"nice_to_have": VoteImportanceNiceToHave,
+	"important":    VoteImportanceImportant,
+	"critical":     VoteImportanceCritical,
+}
+
+// MarshalText returns the Text version of the vote importance
+func (importance VoteImportance) MarshalText() ([]byte, error) {
+	return []byte(voteImportanceIDs[importance]), nil
+}
+
+// UnmarshalText parse string into a vote importance
+func (importance *VoteImportance) UnmarshalText(text []byte) error {
+	*importance = voteImportanceName[string(text)]
+	return nil
+}
+
+// Name returns the name of a vote importance
+func (importance VoteImportance) Name() string {
+	name, ok := voteImportanceIDs[importance]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,41 @@
+package enum
+
+// WebhookFormat is how the content of a webhook is built
+type WebhookFormat int
+
+const (
+	// WebhookFormatTemplate renders the content from a custom template
+	WebhookFormatTemplate WebhookFormat = 1
+	// WebhookFormatStandard sends the standard JSON payload
+	WebhookFormatStandard WebhookFormat = 2
+)
+
+var webhookFormatIDs = map[WebhookFormat]string{
+	WebhookFormatTemplate: "template",
+	WebhookFormatStandard: "standard",
+}
+
+var webhookFormatName = map[string]WebhookFormat{
+	"template": WebhookFormatTemplate,
+	"standard": WebhookFormatStandard,
+}
+
+// MarshalText returns the Text version of the webhook format
+func (format WebhookFormat) MarshalText() ([]byte, error) {
+	return []byte(webhookFormatIDs[format]), nil
+}
+
+// UnmarshalText parse string into a webhook format
+func (format *WebhookFormat) UnmarshalText(text []byte) error {
+	*format = webhookFormatName[string(text)]
+	return nil
+}
+
+// Name returns the name of a webhook format
+func (format WebhookFormat) Name() string {
+	name, ok := webhookFormatIDs[format]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
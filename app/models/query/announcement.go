@@ -0,0 +1,19 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetAnnouncementByID struct {
+	AnnouncementID int
+
+	Result *entity.Announcement
+}
+
+// ListAnnouncements returns the announcements of current tenant.
+// When ActiveOnly is set, only those currently displayed to current user are returned
+type ListAnnouncements struct {
+	ActiveOnly bool
+
+	Result []*entity.Announcement
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"time"
+
 	"github.com/getfider/fider/app/models/entity"
 )
 
@@ -13,5 +15,26 @@ type GetCommentByID struct {
 type GetCommentsByPost struct {
 	Post *entity.Post
 
+	// Sort is either "oldest" (default) or "newest"
+	Sort string
+	// Since only includes comments created after given time
+	Since *time.Time
+	// After is the ID of the last comment of previous page
+	After int
+	// Limit is the maximum number of comments returned, 0 means no limit
+	Limit int
+
 	Result []*entity.Comment
 }
+
+// GetCommentsByPosts loads the comments of many posts at once, indexed by post ID
+type GetCommentsByPosts struct {
+	PostIDs []int
+
+	// Sort is either "oldest" (default) or "newest"
+	Sort string
+	// Limit is the maximum number of comments returned for each post, 0 means no limit
+	Limit int
+
+	Result map[int][]*entity.Comment
+}
@@ -0,0 +1,10 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+type ListJobRuns struct {
+	JobName string
+	Limit   int
+
+	Result []*entity.JobRun
+}
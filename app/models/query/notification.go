@@ -18,6 +18,16 @@ type GetActiveNotifications struct {
 	Result []*entity.Notification
 }
 
+type ListNotifications struct {
+	// UnreadOnly excludes notifications that have already been read
+	UnreadOnly bool
+	// After is the ID of the last notification of previous page
+	After int
+	Limit int
+
+	Result []*entity.Notification
+}
+
 type GetActiveSubscribers struct {
 	Number  int
 	Channel enum.NotificationChannel
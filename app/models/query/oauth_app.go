@@ -0,0 +1,38 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+type ListOAuthApps struct {
+	Result []*entity.OAuthApp
+}
+
+type GetOAuthAppByID struct {
+	ID int
+
+	Result *entity.OAuthApp
+}
+
+type GetOAuthAppByClientID struct {
+	ClientID string
+
+	Result *entity.OAuthApp
+}
+
+// VerifyOAuthAppSecret checks the credentials of an app
+type VerifyOAuthAppSecret struct {
+	ClientID     string
+	ClientSecret string
+
+	Result *entity.OAuthApp
+}
+
+type GetOAuthAccessToken struct {
+	Token string
+
+	Result *entity.OAuthAccessToken
+}
+
+// ListOAuthAuthorizations returns the apps authorized by current user
+type ListOAuthAuthorizations struct {
+	Result []*entity.OAuthAuthorization
+}
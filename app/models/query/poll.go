@@ -0,0 +1,24 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetPollsByPost struct {
+	Post *entity.Post
+
+	Result []*entity.Poll
+}
+
+type GetPollByID struct {
+	Post   *entity.Post
+	PollID int
+
+	Result *entity.Poll
+}
+
+type ListPollVotes struct {
+	Poll *entity.Poll
+
+	Result []*entity.PollVote
+}
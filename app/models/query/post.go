@@ -18,6 +18,9 @@ type CountPostPerStatus struct {
 type GetPostByID struct {
 	PostID int
 
+	// IncludeStaffFields returns the score, private target and private tags even if current user is not a collaborator
+	IncludeStaffFields bool
+
 	Result *entity.Post
 }
 
@@ -33,6 +36,13 @@ type GetPostByNumber struct {
 	Result *entity.Post
 }
 
+// GetPostsByNumbers loads many posts at once, indexed by number. Numbers without a post are left out
+type GetPostsByNumbers struct {
+	Numbers []int
+
+	Result map[int]*entity.Post
+}
+
 type SearchPosts struct {
 	Query string
 	View  string
@@ -45,3 +55,10 @@ type SearchPosts struct {
 type GetAllPosts struct {
 	Result []*entity.Post
 }
+
+type GetRoadmap struct {
+	Statuses []enum.PostStatus
+	View     string
+
+	Result []*entity.RoadmapGroup
+}
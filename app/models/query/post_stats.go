@@ -0,0 +1,28 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetPostStats struct {
+	PostID int
+	Since  time.Time
+
+	Result *entity.PostStats
+}
+
+type ListTopPostsByViews struct {
+	Since time.Time
+	Limit int
+
+	Result []*entity.PostViewStats
+}
+
+type ListTopReferrers struct {
+	Since time.Time
+	Limit int
+
+	Result []*entity.ReferrerStats
+}
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetPostTemplateByID struct {
+	TemplateID int
+
+	Result *entity.PostTemplate
+}
+
+// GetPostTemplateForTag returns the template attached to given tag
+// or the default template if Tag is nil or has no template attached
+type GetPostTemplateForTag struct {
+	Tag *entity.Tag
+
+	Result *entity.PostTemplate
+}
+
+type GetAllPostTemplates struct {
+	Result []*entity.PostTemplate
+}
@@ -0,0 +1,9 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+type ListPushSubscriptions struct {
+	UserID int
+
+	Result []*entity.PushSubscription
+}
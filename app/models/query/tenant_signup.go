@@ -0,0 +1,33 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type GetTenantSignUpByID struct {
+	SignUpID int
+
+	Result *entity.TenantSignUp
+}
+
+type GetTenantSignUpByTenantID struct {
+	TenantID int
+
+	Result *entity.TenantSignUp
+}
+
+type ListTenantSignUps struct {
+	Status enum.TenantSignUpStatus
+
+	Result []*entity.TenantSignUp
+}
+
+type CountTenantSignUpsByIPAddress struct {
+	IPAddress string
+	Since     time.Time
+
+	Result int
+}
@@ -22,6 +22,10 @@ type GetCurrentUserSettings struct {
 	Result map[string]string
 }
 
+type ListCurrentUserSubscriptions struct {
+	Result []*entity.PostSubscription
+}
+
 type GetUserByID struct {
 	UserID int
 
@@ -44,3 +48,10 @@ type GetUserByProvider struct {
 type GetAllUsers struct {
 	Result []*entity.User
 }
+
+type PreviewUserMerge struct {
+	SourceUserID int
+	TargetUserID int
+
+	Result *entity.UserMergeSummary
+}
@@ -3,9 +3,25 @@ package query
 import "github.com/getfider/fider/app/models/entity"
 
 type ListPostVotes struct {
-	PostID       int
-	Limit        int
-	IncludeEmail bool
+	PostID        int
+	Limit         int
+	IncludeEmail  bool
+	IncludeReason bool
 
 	Result []*entity.Vote
 }
+
+// ListVotesByPosts loads the votes of many posts at once, indexed by post ID
+type ListVotesByPosts struct {
+	PostIDs []int
+	// Limit is the maximum number of votes returned for each post, 0 means no limit
+	Limit         int
+	IncludeEmail  bool
+	IncludeReason bool
+
+	Result map[int][]*entity.Vote
+}
+
+type ListAllVotes struct {
+	Result []*entity.Vote
+}
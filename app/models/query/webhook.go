@@ -32,10 +32,12 @@ type CreateEditWebhook struct {
 	Name        string
 	Type        enum.WebhookType
 	Status      enum.WebhookStatus
+	Format      enum.WebhookFormat
 	Url         string
 	Content     string
 	HttpMethod  string
 	HttpHeaders entity.HttpHeaders
+	Conditions  entity.WebhookConditions
 
 	Result int
 }
@@ -0,0 +1,50 @@
+package analytics
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/pkg/env"
+)
+
+// Day returns the UTC day of given time, which is the unit of all counters
+func Day(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// VisitorHash returns an anonymous identifier of a visitor of a post on given day.
+// IP addresses are never stored, and the hash is different on each day and post,
+// so it can't be used to track visitors over time
+func VisitorHash(day string, tenantID, postID int, ip, userAgent string) string {
+	mac := hmac.New(sha256.New, []byte(env.Config.JWTSecret))
+	fmt.Fprintf(mac, "%s|%d|%d|%s|%s", day, tenantID, postID, ip, userAgent)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var botRegex = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|preview|headless|lighthouse|facebookexternalhit|curl|wget|python|java/|go-http-client|okhttp|httpclient`)
+
+// IsBot returns true if given user agent belongs to a bot, crawler or any other non-browser client
+func IsBot(userAgent string) bool {
+	return strings.TrimSpace(userAgent) == "" || botRegex.MatchString(userAgent)
+}
+
+// ReferrerDomain returns the domain of given referrer URL.
+// It's empty when the referrer is invalid or is the site itself
+func ReferrerDomain(referrer, host string) string {
+	u, err := url.Parse(referrer)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ""
+	}
+
+	domain := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if domain == strings.TrimPrefix(strings.ToLower(host), "www.") {
+		return ""
+	}
+	return domain
+}
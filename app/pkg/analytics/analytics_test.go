@@ -0,0 +1,50 @@
+package analytics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/pkg/analytics"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestDay(t *testing.T) {
+	RegisterT(t)
+
+	brt := time.FixedZone("BRT", -3*60*60)
+	Expect(analytics.Day(time.Date(2026, time.October, 16, 22, 0, 0, 0, brt))).Equals("2026-10-17")
+	Expect(analytics.Day(time.Date(2026, time.October, 16, 10, 0, 0, 0, time.UTC))).Equals("2026-10-16")
+}
+
+func TestVisitorHash(t *testing.T) {
+	RegisterT(t)
+
+	hash := analytics.VisitorHash("2026-10-16", 1, 10, "203.0.113.7", "Mozilla/5.0")
+	Expect(hash).HasLen(64)
+	Expect(analytics.VisitorHash("2026-10-16", 1, 10, "203.0.113.7", "Mozilla/5.0")).Equals(hash)
+	Expect(analytics.VisitorHash("2026-10-17", 1, 10, "203.0.113.7", "Mozilla/5.0")).NotEquals(hash)
+	Expect(analytics.VisitorHash("2026-10-16", 1, 11, "203.0.113.7", "Mozilla/5.0")).NotEquals(hash)
+	Expect(analytics.VisitorHash("2026-10-16", 1, 10, "203.0.113.8", "Mozilla/5.0")).NotEquals(hash)
+}
+
+func TestIsBot(t *testing.T) {
+	RegisterT(t)
+
+	Expect(analytics.IsBot("")).IsTrue()
+	Expect(analytics.IsBot("Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)")).IsTrue()
+	Expect(analytics.IsBot("Mozilla/5.0 (Linux; Android 6.0.1; Nexus 5X Build/MMB29P) HeadlessChrome/120.0")).IsTrue()
+	Expect(analytics.IsBot("curl/8.4.0")).IsTrue()
+	Expect(analytics.IsBot("python-requests/2.31.0")).IsTrue()
+	Expect(analytics.IsBot("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15")).IsFalse()
+}
+
+func TestReferrerDomain(t *testing.T) {
+	RegisterT(t)
+
+	Expect(analytics.ReferrerDomain("https://www.google.com/search?q=fider", "demo.test.fider.io")).Equals("google.com")
+	Expect(analytics.ReferrerDomain("https://News.YCombinator.com/item?id=1", "demo.test.fider.io")).Equals("news.ycombinator.com")
+	Expect(analytics.ReferrerDomain("http://demo.test.fider.io/", "demo.test.fider.io")).Equals("")
+	Expect(analytics.ReferrerDomain("android-app://com.slack", "demo.test.fider.io")).Equals("")
+	Expect(analytics.ReferrerDomain("", "demo.test.fider.io")).Equals("")
+	Expect(analytics.ReferrerDomain("not a url", "demo.test.fider.io")).Equals("")
+}
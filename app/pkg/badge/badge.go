@@ -0,0 +1,212 @@
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/getfider/fider/app/pkg/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// Style is the visual variant of a badge
+type Style string
+
+var (
+	//StyleFlat is a small badge with rounded corners and a subtle gradient
+	StyleFlat Style = "flat"
+	//StyleFlatSquare is a small badge with square corners and no gradient
+	StyleFlatSquare Style = "flat-square"
+	//StyleForTheBadge is a larger badge with uppercase bold text
+	StyleForTheBadge Style = "for-the-badge"
+)
+
+// ParseStyle returns the style with given name, falling back to StyleFlat for unknown names
+func ParseStyle(name string) Style {
+	switch Style(name) {
+	case StyleFlatSquare, StyleForTheBadge:
+		return Style(name)
+	}
+	return StyleFlat
+}
+
+// DefaultColor is used for the message when a badge has no color
+var DefaultColor color.Color = color.RGBA{0x00, 0x7e, 0xc6, 0xff}
+
+var labelColor color.Color = color.RGBA{0x55, 0x55, 0x55, 0xff}
+
+// Badge is the content rendered into a badge
+type Badge struct {
+	Label   string
+	Message string
+	Color   color.Color
+}
+
+type metrics struct {
+	height   int
+	padding  int
+	fontSize float64
+	radius   int
+	bold     bool
+	upper    bool
+	spacing  float64
+	gradient bool
+	shadow   bool
+}
+
+var styles = map[Style]metrics{
+	StyleFlat:        {height: 20, padding: 6, fontSize: 11, radius: 3, gradient: true, shadow: true},
+	StyleFlatSquare:  {height: 20, padding: 6, fontSize: 11},
+	StyleForTheBadge: {height: 28, padding: 12, fontSize: 10, bold: true, upper: true, spacing: 1.25},
+}
+
+var (
+	loadFontsOnce         sync.Once
+	regularFont, boldFont *opentype.Font
+	fontsErr              error
+)
+
+func loadFonts() error {
+	loadFontsOnce.Do(func() {
+		if regularFont, fontsErr = opentype.Parse(goregular.TTF); fontsErr != nil {
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	if fontsErr != nil {
+		return errors.Wrap(fontsErr, "failed to parse fonts")
+	}
+	return nil
+}
+
+// measure returns the width of given text, which is used to size the badge
+// as SVG viewers cannot do it themselves
+func measure(m metrics, text string) (int, error) {
+	f := regularFont
+	if m.bold {
+		f = boldFont
+	}
+
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: m.fontSize, DPI: 72})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create font face")
+	}
+	defer face.Close()
+
+	width := float64(font.MeasureString(face, text).Ceil())
+	if count := len([]rune(text)); count > 1 {
+		width += m.spacing * float64(count-1)
+	}
+	return int(width + 0.5), nil
+}
+
+type segment struct {
+	X         float64
+	Width     int
+	TextWidth int
+	Text      string
+	Fill      string
+}
+
+var tpl = template.Must(template.New("badge").Parse(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" role="img" aria-label="{{.Title}}">` +
+		`<title>{{.Title}}</title>` +
+		`{{if .Gradient}}<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>{{end}}` +
+		`<clipPath id="r"><rect width="{{.Width}}" height="{{.Height}}" rx="{{.Radius}}" fill="#fff"/></clipPath>` +
+		`<g clip-path="url(#r)">` +
+		`{{range .Segments}}<rect x="{{.X}}" width="{{.Width}}" height="{{$.Height}}" fill="{{.Fill}}"/>{{end}}` +
+		`{{if .Gradient}}<rect width="{{.Width}}" height="{{.Height}}" fill="url(#s)"/>{{end}}` +
+		`</g>` +
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" text-rendering="geometricPrecision" font-size="{{.FontSize}}"{{if .Bold}} font-weight="bold"{{end}}{{if .Spacing}} letter-spacing="{{.Spacing}}"{{end}}>` +
+		`{{range .Segments}}{{if $.Shadow}}<text x="{{.Center}}" y="{{$.ShadowY}}" fill="#010101" fill-opacity=".3" textLength="{{.TextWidth}}">{{.Text}}</text>{{end}}` +
+		`<text x="{{.Center}}" y="{{$.TextY}}" textLength="{{.TextWidth}}">{{.Text}}</text>{{end}}` +
+		`</g>` +
+		`</svg>`,
+))
+
+// Center returns the horizontal position where the text of the segment is anchored
+func (s segment) Center() float64 {
+	return s.X + float64(s.Width)/2
+}
+
+// Render returns given badge drawn as an SVG image on given style
+func Render(b *Badge, style Style) ([]byte, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+
+	m, ok := styles[style]
+	if !ok {
+		m = styles[StyleFlat]
+	}
+
+	fill := b.Color
+	if fill == nil {
+		fill = DefaultColor
+	}
+
+	texts := []string{b.Label, b.Message}
+	fills := []string{hex(labelColor), hex(fill)}
+	if b.Label == "" {
+		texts, fills = texts[1:], fills[1:]
+	}
+
+	x := 0
+	segments := make([]segment, 0, len(texts))
+	for i, text := range texts {
+		if m.upper {
+			text = strings.ToUpper(text)
+		}
+		textWidth, err := measure(m, text)
+		if err != nil {
+			return nil, err
+		}
+		width := textWidth + 2*m.padding
+		segments = append(segments, segment{
+			X:         float64(x),
+			Width:     width,
+			TextWidth: textWidth,
+			Text:      html.EscapeString(text),
+			Fill:      fills[i],
+		})
+		x += width
+	}
+
+	textY := m.height/2 + int(m.fontSize/2) - 1
+	title := b.Message
+	if b.Label != "" {
+		title = b.Label + ": " + b.Message
+	}
+
+	buf := new(bytes.Buffer)
+	err := tpl.Execute(buf, map[string]any{
+		"Width":    x,
+		"Height":   m.height,
+		"Radius":   m.radius,
+		"Title":    html.EscapeString(title),
+		"Gradient": m.gradient,
+		"Shadow":   m.shadow,
+		"FontSize": m.fontSize,
+		"Bold":     m.bold,
+		"Spacing":  m.spacing,
+		"TextY":    textY,
+		"ShadowY":  textY + 1,
+		"Segments": segments,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render badge")
+	}
+	return buf.Bytes(), nil
+}
+
+func hex(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
@@ -0,0 +1,69 @@
+package badge_test
+
+import (
+	"encoding/xml"
+	"image/color"
+	"strings"
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/badge"
+)
+
+func TestParseStyle(t *testing.T) {
+	RegisterT(t)
+
+	Expect(badge.ParseStyle("flat")).Equals(badge.StyleFlat)
+	Expect(badge.ParseStyle("flat-square")).Equals(badge.StyleFlatSquare)
+	Expect(badge.ParseStyle("for-the-badge")).Equals(badge.StyleForTheBadge)
+	Expect(badge.ParseStyle("")).Equals(badge.StyleFlat)
+	Expect(badge.ParseStyle("plastic")).Equals(badge.StyleFlat)
+}
+
+func TestRender(t *testing.T) {
+	RegisterT(t)
+
+	for _, style := range []badge.Style{badge.StyleFlat, badge.StyleFlatSquare, badge.StyleForTheBadge} {
+		svg, err := badge.Render(&badge.Badge{
+			Label:   "42 votes",
+			Message: "Planned",
+			Color:   color.RGBA{0x7c, 0x3a, 0xed, 0xff},
+		}, style)
+		Expect(err).IsNil()
+
+		content := string(svg)
+		Expect(xml.Unmarshal(svg, new(any))).IsNil()
+		Expect(strings.HasPrefix(content, "<svg ")).IsTrue()
+		Expect(content).ContainsSubstring(`aria-label="42 votes: Planned"`)
+		Expect(content).ContainsSubstring(`fill="#7c3aed"`)
+	}
+}
+
+func TestRender_ForTheBadge(t *testing.T) {
+	RegisterT(t)
+
+	svg, err := badge.Render(&badge.Badge{Label: "open posts", Message: "12"}, badge.StyleForTheBadge)
+	Expect(err).IsNil()
+	Expect(string(svg)).ContainsSubstring(`height="28"`)
+	Expect(string(svg)).ContainsSubstring(">OPEN POSTS</text>")
+	Expect(string(svg)).ContainsSubstring(`fill="#007ec6"`)
+}
+
+func TestRender_EscapesText(t *testing.T) {
+	RegisterT(t)
+
+	svg, err := badge.Render(&badge.Badge{Label: "<script>", Message: "a & b"}, badge.StyleFlat)
+	Expect(err).IsNil()
+	Expect(strings.Contains(string(svg), "<script>")).IsFalse()
+	Expect(string(svg)).ContainsSubstring("&lt;script&gt;")
+	Expect(string(svg)).ContainsSubstring("a &amp; b")
+}
+
+func TestRender_WithoutLabel(t *testing.T) {
+	RegisterT(t)
+
+	svg, err := badge.Render(&badge.Badge{Message: "Planned"}, badge.StyleFlatSquare)
+	Expect(err).IsNil()
+	Expect(strings.Count(string(svg), "<rect x=")).Equals(1)
+	Expect(string(svg)).ContainsSubstring(`aria-label="Planned"`)
+}
@@ -30,6 +30,14 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 		"original_number",
 		"original_title",
 		"tags",
+		"reach",
+		"impact",
+		"confidence",
+		"effort",
+		"score",
+		"target_kind",
+		"target_value",
+		"target_public",
 	}
 	if err := writer.Write(header); err != nil {
 		return nil, err
@@ -42,6 +50,8 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 			respondedBy    string
 			respondedAt    string
 			response       string
+			score          = make([]string, 5)
+			target         = make([]string, 3)
 		)
 
 		if post.Response != nil {
@@ -54,6 +64,18 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 			}
 		}
 
+		if post.Score != nil {
+			for i, value := range []*float64{post.Score.Reach, post.Score.Impact, post.Score.Confidence, post.Score.Effort, post.Score.Value} {
+				if value != nil {
+					score[i] = strconv.FormatFloat(*value, 'f', -1, 64)
+				}
+			}
+		}
+
+		if post.Target != nil {
+			target = []string{post.Target.Kind.Name(), post.Target.Value, strconv.FormatBool(post.Target.IsPublic)}
+		}
+
 		record := []string{
 			strconv.Itoa(post.Number),
 			post.Title,
@@ -70,6 +92,107 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 			originalTitle,
 			strings.Join(post.Tags, ", "),
 		}
+		record = append(record, score...)
+		record = append(record, target...)
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+//FromPollVotes return a byte array of CSV file containing all votes of a poll
+func FromPollVotes(votes []*entity.PollVote) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := gocsv.NewWriter(buffer)
+
+	header := []string{
+		"option_id",
+		"option",
+		"user_id",
+		"user_name",
+		"user_email",
+		"voted_at",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, vote := range votes {
+		record := []string{
+			strconv.Itoa(vote.OptionID),
+			vote.Option,
+			strconv.Itoa(vote.User.ID),
+			vote.User.Name,
+			vote.User.Email,
+			vote.CreatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+//FromVotes return a byte array of CSV file containing all votes
+func FromVotes(votes []*entity.Vote) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := gocsv.NewWriter(buffer)
+
+	header := []string{
+		"post_number",
+		"post_title",
+		"user_id",
+		"user_name",
+		"user_email",
+		"voted_at",
+		"importance",
+		"reason",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, vote := range votes {
+		var (
+			postNumber string
+			postTitle  string
+			importance string
+		)
+
+		if vote.Post != nil {
+			postNumber = strconv.Itoa(vote.Post.Number)
+			postTitle = vote.Post.Title
+		}
+
+		if vote.Importance > 0 {
+			importance = vote.Importance.Name()
+		}
+
+		record := []string{
+			postNumber,
+			postTitle,
+			strconv.Itoa(vote.User.ID),
+			vote.User.Name,
+			vote.User.Email,
+			vote.CreatedAt.Format(time.RFC3339),
+			importance,
+			vote.Reason,
+		}
 		if err := writer.Write(record); err != nil {
 			return nil, err
 		}
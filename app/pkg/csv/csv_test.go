@@ -84,6 +84,18 @@ var openPost = &entity.Post{
 	VotesCount:    4,
 	CommentsCount: 2,
 	Status:        enum.PostOpen,
+	Score: &entity.PostScore{
+		Reach:      floatPtr(1000),
+		Impact:     floatPtr(2),
+		Confidence: floatPtr(80),
+		Effort:     floatPtr(4),
+		Value:      floatPtr(400),
+	},
+	Target: &entity.PostTarget{
+		Kind:     enum.PostTargetMonth,
+		Value:    "2018-06",
+		IsPublic: false,
+	},
 }
 
 var duplicatePost = &entity.Post{
@@ -110,3 +122,57 @@ var duplicatePost = &entity.Post{
 	},
 	Tags: []string{"this-tag-has,comma"},
 }
+
+func TestExportVotesToCSV(t *testing.T) {
+	RegisterT(t)
+
+	votes := []*entity.Vote{
+		{
+			Post:       &entity.VotePost{Number: 10, Title: "Go is fast"},
+			User:       &entity.VoteUser{ID: 3, Name: "Jon Snow", Email: "jon.snow@got.com"},
+			CreatedAt:  time.Date(2018, 3, 23, 19, 33, 22, 0, time.UTC),
+			Importance: enum.VoteImportanceCritical,
+			Reason:     "We deploy 50 services, every second counts",
+		},
+		{
+			Post:      &entity.VotePost{Number: 10, Title: "Go is fast"},
+			User:      &entity.VoteUser{ID: 4, Name: "Arya Stark", Email: "arya.stark@got.com"},
+			CreatedAt: time.Date(2018, 3, 24, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	expected, err := os.ReadFile("./testdata/votes.csv")
+	Expect(err).IsNil()
+	actual, err := csv.FromVotes(votes)
+	Expect(err).IsNil()
+	Expect(actual).Equals(expected)
+}
+
+func TestExportPollVotesToCSV(t *testing.T) {
+	RegisterT(t)
+
+	votes := []*entity.PollVote{
+		{
+			OptionID:  1,
+			Option:    "Windows",
+			CreatedAt: time.Date(2018, 3, 23, 19, 33, 22, 0, time.UTC),
+			User:      &entity.VoteUser{ID: 3, Name: "Jon Snow", Email: "jon.snow@got.com"},
+		},
+		{
+			OptionID:  2,
+			Option:    "Linux, macOS",
+			CreatedAt: time.Date(2018, 3, 24, 10, 0, 0, 0, time.UTC),
+			User:      &entity.VoteUser{ID: 4, Name: "Arya Stark", Email: "arya.stark@got.com"},
+		},
+	}
+
+	expected, err := os.ReadFile("./testdata/poll-votes.csv")
+	Expect(err).IsNil()
+	actual, err := csv.FromPollVotes(votes)
+	Expect(err).IsNil()
+	Expect(actual).Equals(expected)
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,152 @@
+package demo
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+var firstNames = []string{
+	"Alice", "Bruno", "Carla", "Daniel", "Elena", "Felix", "Grace", "Hugo", "Ines", "Jonas",
+	"Kira", "Liam", "Maya", "Noah", "Olga", "Pedro", "Quinn", "Rosa", "Samir", "Tara",
+	"Umar", "Vera", "Wei", "Ximena", "Yusuf", "Zoe",
+}
+
+var lastNames = []string{
+	"Almeida", "Becker", "Chen", "Dubois", "Evans", "Fischer", "Garcia", "Hansen", "Ito", "Jensen",
+	"Kowalski", "Lopez", "Moreau", "Nakamura", "Olsen", "Petrov", "Rossi", "Silva", "Tanaka", "Weber",
+}
+
+var tagNames = []string{
+	"Bug", "Feature Request", "UI", "Performance", "Integrations", "Mobile",
+	"API", "Documentation", "Security", "Billing", "Accessibility", "Reporting",
+}
+
+var tagColors = []string{
+	"E53935", "8E24AA", "3949AB", "039BE5", "00897B", "7CB342",
+	"FDD835", "FB8C00", "6D4C41", "546E7A", "D81B60", "1E88E5",
+}
+
+var titleTemplates = []string{
+	"Add %s",
+	"Support %s",
+	"Allow exporting %s",
+	"Improve %s",
+	"Make %s faster",
+	"Show %s on the dashboard",
+	"Let admins configure %s",
+	"Notify me about %s",
+	"Fix %s on mobile",
+	"Search by %s",
+}
+
+var titleSubjects = []string{
+	"dark mode", "two-factor authentication", "custom fields", "recurring reports", "bulk editing",
+	"keyboard shortcuts", "calendar sync", "Slack notifications", "CSV imports", "team permissions",
+	"audit logs", "saved filters", "single sign-on", "offline access", "file attachments",
+	"webhooks", "multiple languages", "public roadmaps", "usage analytics", "comment reactions",
+	"weekly digests", "time tracking", "custom domains", "archived projects", "API rate limits",
+}
+
+var sentences = []string{
+	"Our team relies on this every day and the current workaround is painful.",
+	"It would save us a lot of time, especially at the end of each month.",
+	"Most tools we use already support this, so it feels like a missing piece.",
+	"Right now we have to copy everything by hand into a spreadsheet.",
+	"This is the main reason some of our colleagues still use the old system.",
+	"I'd be happy to help test an early version of this.",
+	"The current behavior is confusing for new users.",
+	"Ideally this would also be available through the API.",
+	"We have around fifty people who would benefit from this.",
+	"It doesn't need to be perfect, even a simple version would help.",
+	"Please consider making this configurable per project.",
+	"We ran into this again during our last release.",
+}
+
+var bulletPoints = []string{
+	"Works for every project",
+	"Can be turned off by admins",
+	"Keeps the existing data intact",
+	"Available on mobile",
+	"Shows up in the activity feed",
+	"Respects user permissions",
+	"Can be exported later",
+}
+
+var comments = []string{
+	"+1, we need this too.",
+	"This would be a game changer for our team.",
+	"Any update on this one?",
+	"We worked around it with a small script, but a built-in option would be much better.",
+	"Agreed! It's the feature our customers ask about the most.",
+	"Could this also cover **archived** items?",
+	"Same here, it blocks our migration.",
+	"I think this is related to another request about notifications.",
+	"Thanks for considering it!",
+	"It would be great if it also worked with `keyboard shortcuts`.",
+	"Our workaround:\n\n1. Export everything\n2. Edit it by hand\n3. Import it back\n\nNot fun.",
+}
+
+var staffComments = []string{
+	"Thanks for the feedback, we're looking into it.",
+	"Could you share a bit more about how you'd use this?",
+	"Good point, we'll take it into account when we plan the next quarter.",
+	"We've shared this with the team, keep the votes coming!",
+}
+
+var responses = map[enum.PostStatus][]string{
+	enum.PostPlanned: {
+		"This is on our roadmap for the next few months.",
+		"Thanks everyone, we've planned this for an upcoming release.",
+	},
+	enum.PostStarted: {
+		"We've started working on this, stay tuned!",
+		"Work is in progress, we'll share a preview soon.",
+	},
+	enum.PostCompleted: {
+		"This is now available to everyone. Thanks for the feedback!",
+		"Shipped! Let us know what you think.",
+	},
+	enum.PostDeclined: {
+		"We've decided not to move forward with this for now.",
+		"This doesn't fit our plans at the moment, but thanks for suggesting it.",
+	},
+}
+
+var voteReasons = []string{
+	"We need it for our audit",
+	"It would save us hours every week",
+	"Our customers keep asking for it",
+	"Blocks our rollout to the whole company",
+}
+
+func pick(rnd *rand.Rand, values []string) string {
+	return values[rnd.Intn(len(values))]
+}
+
+func randomTitle(rnd *rand.Rand) string {
+	return fmt.Sprintf(pick(rnd, titleTemplates), pick(rnd, titleSubjects))
+}
+
+func randomDescription(rnd *rand.Rand) string {
+	paragraphs := make([]string, 0)
+	for i := 0; i < 1+rnd.Intn(3); i++ {
+		paragraph := make([]string, 0)
+		for j := 0; j < 1+rnd.Intn(3); j++ {
+			paragraph = append(paragraph, pick(rnd, sentences))
+		}
+		paragraphs = append(paragraphs, strings.Join(paragraph, " "))
+	}
+
+	if rnd.Intn(3) == 0 {
+		list := make([]string, 0)
+		for _, idx := range rnd.Perm(len(bulletPoints))[:2+rnd.Intn(3)] {
+			list = append(list, "- "+bulletPoints[idx])
+		}
+		paragraphs = append(paragraphs, "**What we'd expect:**\n\n"+strings.Join(list, "\n"))
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,392 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/gosimple/slug"
+)
+
+// Options controls how much demo data is generated
+// The same seed always generates the same data, relative to Now, which defaults to the current time
+type Options struct {
+	Seed     int64
+	Users    int
+	Tags     int
+	Posts    int
+	Comments int
+	Votes    int
+	Days     int
+	Now      time.Time
+}
+
+// DefaultOptions returns the options used when none are given
+func DefaultOptions() Options {
+	return Options{
+		Seed:     1,
+		Users:    50,
+		Tags:     6,
+		Posts:    100,
+		Comments: 300,
+		Votes:    1500,
+		Days:     180,
+	}
+}
+
+// Summary holds how many records were generated
+type Summary struct {
+	Users    int
+	Tags     int
+	Posts    int
+	Comments int
+	Votes    int
+}
+
+type generator struct {
+	ctx     context.Context
+	rnd     *rand.Rand
+	opts    Options
+	staff   *entity.User
+	users   []*entity.User
+	tags    []*entity.Tag
+	summary *Summary
+}
+
+type generatedPost struct {
+	post      *entity.Post
+	author    *entity.User
+	createdAt time.Time
+	weight    float64
+}
+
+// Generate populates the tenant in the context with users, tags, posts, comments and votes
+// Everything goes through the same commands used by the application, but notifications are not sent
+func Generate(ctx context.Context, opts Options) (*Summary, error) {
+	if _, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); !ok {
+		return nil, errors.New("tenant is required to generate demo data")
+	}
+	if opts.Users < 1 || opts.Days < 1 {
+		return nil, errors.New("at least one user and one day are required to generate demo data")
+	}
+	if opts.Tags < 0 || opts.Posts < 0 || opts.Comments < 0 || opts.Votes < 0 {
+		return nil, errors.New("number of tags, posts, comments and votes can't be negative")
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	g := &generator{
+		ctx:     ctx,
+		rnd:     rand.New(rand.NewSource(opts.Seed)),
+		opts:    opts,
+		summary: &Summary{},
+	}
+
+	steps := []func() error{g.generateStaff, g.generateUsers, g.generateTags}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	posts := make([]*generatedPost, 0, opts.Posts)
+	for i := 0; i < opts.Posts; i++ {
+		post, err := g.generatePost()
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+
+	if err := g.generateVotes(posts); err != nil {
+		return nil, err
+	}
+	if err := g.generateComments(posts); err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		if err := g.generateResponse(post); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.summary, nil
+}
+
+func (g *generator) as(user *entity.User) context.Context {
+	return context.WithValue(g.ctx, app.UserCtxKey, user)
+}
+
+// after returns a random time after given time, most likely within the first days, but never after now
+func (g *generator) after(t time.Time, meanDays float64) time.Time {
+	delay := time.Duration(g.rnd.ExpFloat64() * meanDays * float64(24*time.Hour))
+	result := t.Add(delay)
+	if result.After(g.opts.Now) {
+		return t.Add(time.Duration(g.rnd.Float64() * float64(g.opts.Now.Sub(t))))
+	}
+	return result
+}
+
+// randomUser favors the first users, so that a few of them are a lot more active than the others
+func (g *generator) randomUser() *entity.User {
+	return g.users[int(float64(len(g.users))*math.Pow(g.rnd.Float64(), 2))]
+}
+
+func (g *generator) generateStaff() error {
+	allUsers := &query.GetAllUsers{}
+	if err := bus.Dispatch(g.ctx, allUsers); err != nil {
+		return err
+	}
+
+	for _, user := range allUsers.Result {
+		if user.IsAdministrator() && user.Status == enum.UserActive {
+			g.staff = user
+			return nil
+		}
+	}
+
+	staff, err := g.registerUser("Demo Administrator", "demo.admin@example.com", enum.RoleAdministrator)
+	g.staff = staff
+	return err
+}
+
+func (g *generator) generateUsers() error {
+	for i := 0; i < g.opts.Users; i++ {
+		first, last := pick(g.rnd, firstNames), pick(g.rnd, lastNames)
+		email := fmt.Sprintf("%s.%s.%d.%d@example.com", strings.ToLower(first), strings.ToLower(last), g.opts.Seed, i)
+		user, err := g.registerUser(first+" "+last, email, enum.RoleVisitor)
+		if err != nil {
+			return err
+		}
+		g.users = append(g.users, user)
+	}
+	return nil
+}
+
+// registerUser reuses the existing user with given email, so that a seed can be generated more than once
+func (g *generator) registerUser(name, email string, role enum.Role) (*entity.User, error) {
+	byEmail := &query.GetUserByEmail{Email: email}
+	err := bus.Dispatch(g.ctx, byEmail)
+	if err == nil {
+		return byEmail.Result, nil
+	}
+	if errors.Cause(err) != app.ErrNotFound {
+		return nil, err
+	}
+
+	user := &entity.User{
+		Name:   name,
+		Email:  email,
+		Tenant: g.ctx.Value(app.TenantCtxKey).(*entity.Tenant),
+		Role:   role,
+	}
+	if err := bus.Dispatch(g.ctx, &cmd.RegisterUser{User: user}); err != nil {
+		return nil, err
+	}
+	g.summary.Users++
+	return user, nil
+}
+
+func (g *generator) generateTags() error {
+	names := slices.Clone(tagNames)
+	g.rnd.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+
+	for i := 0; i < g.opts.Tags && i < len(names); i++ {
+		bySlug := &query.GetTagBySlug{Slug: slug.Make(names[i])}
+		err := bus.Dispatch(g.ctx, bySlug)
+		if err == nil {
+			g.tags = append(g.tags, bySlug.Result)
+			continue
+		}
+		if errors.Cause(err) != app.ErrNotFound {
+			return err
+		}
+
+		addTag := &cmd.AddNewTag{
+			Name:     names[i],
+			Color:    tagColors[g.rnd.Intn(len(tagColors))],
+			IsPublic: g.rnd.Intn(5) > 0,
+		}
+		if err := bus.Dispatch(g.as(g.staff), addTag); err != nil {
+			return err
+		}
+		g.tags = append(g.tags, addTag.Result)
+		g.summary.Tags++
+	}
+	return nil
+}
+
+func (g *generator) generatePost() (*generatedPost, error) {
+	author := g.randomUser()
+
+	// Recent posts are more common than old ones
+	age := math.Pow(g.rnd.Float64(), 1.5) * float64(g.opts.Days) * float64(24*time.Hour)
+	createdAt := g.opts.Now.Add(-time.Duration(age))
+
+	newPost := &cmd.AddNewPost{
+		Title:       randomTitle(g.rnd),
+		Description: randomDescription(g.rnd),
+	}
+	if err := bus.Dispatch(g.as(author), newPost); err != nil {
+		return nil, err
+	}
+	if err := bus.Dispatch(g.as(author), &cmd.AddVote{Post: newPost.Result, User: author}); err != nil {
+		return nil, err
+	}
+	g.summary.Posts++
+	g.summary.Votes++
+
+	if len(g.tags) > 0 {
+		for _, idx := range g.rnd.Perm(len(g.tags))[:g.rnd.Intn(min(3, len(g.tags)+1))] {
+			if err := bus.Dispatch(g.as(g.staff), &cmd.AssignTag{Tag: g.tags[idx], Post: newPost.Result}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := bus.Dispatch(g.ctx,
+		&cmd.BackdatePost{Post: newPost.Result, CreatedAt: createdAt},
+		&cmd.BackdateVote{Post: newPost.Result, User: author, CreatedAt: createdAt},
+	); err != nil {
+		return nil, err
+	}
+
+	// A few posts get most of the attention
+	return &generatedPost{
+		post:      newPost.Result,
+		author:    author,
+		createdAt: createdAt,
+		weight:    math.Min(1/math.Pow(1-g.rnd.Float64(), 0.8), 50),
+	}, nil
+}
+
+// distribute splits total among posts proportionally to their weight
+func (g *generator) distribute(posts []*generatedPost, total int, weight func(p *generatedPost) float64) []int {
+	sum := 0.0
+	for _, post := range posts {
+		sum += weight(post)
+	}
+
+	counts := make([]int, len(posts))
+	for i, post := range posts {
+		counts[i] = int(math.Round(float64(total) * weight(post) / sum))
+	}
+	return counts
+}
+
+func (g *generator) generateVotes(posts []*generatedPost) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	// Author votes are already counted
+	remaining := max(g.opts.Votes-len(posts), 0)
+	counts := g.distribute(posts, remaining, func(p *generatedPost) float64 { return p.weight })
+	for i, post := range posts {
+		voters := 0
+		for _, idx := range g.rnd.Perm(len(g.users)) {
+			if voters >= counts[i] {
+				break
+			}
+
+			voter := g.users[idx]
+			if voter.ID == post.author.ID {
+				continue
+			}
+
+			addVote := &cmd.AddVote{Post: post.post, User: voter}
+			if g.rnd.Intn(10) == 0 {
+				addVote.Reason = pick(g.rnd, voteReasons)
+			}
+			if g.rnd.Intn(3) == 0 {
+				addVote.Importance = enum.VoteImportance(1 + g.rnd.Intn(3))
+			}
+
+			if err := bus.Dispatch(g.as(voter), addVote, &cmd.BackdateVote{
+				Post:      post.post,
+				User:      voter,
+				CreatedAt: g.after(post.createdAt, 7),
+			}); err != nil {
+				return err
+			}
+			voters++
+			g.summary.Votes++
+		}
+	}
+	return nil
+}
+
+func (g *generator) generateComments(posts []*generatedPost) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	counts := g.distribute(posts, g.opts.Comments, func(p *generatedPost) float64 { return math.Sqrt(p.weight) })
+	for i, post := range posts {
+		times := make([]time.Time, counts[i])
+		for j := range times {
+			times[j] = g.after(post.createdAt, 10)
+		}
+		slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+
+		for _, createdAt := range times {
+			author, content := g.randomUser(), pick(g.rnd, comments)
+			if g.rnd.Intn(6) == 0 {
+				author, content = g.staff, pick(g.rnd, staffComments)
+			}
+
+			addComment := &cmd.AddNewComment{Post: post.post, Content: content}
+			if err := bus.Dispatch(g.as(author), addComment); err != nil {
+				return err
+			}
+			if err := bus.Dispatch(g.ctx, &cmd.BackdateComment{Comment: addComment.Result, CreatedAt: createdAt}); err != nil {
+				return err
+			}
+			g.summary.Comments++
+		}
+	}
+	return nil
+}
+
+// generateResponse sets the status of a post after its votes, because closed posts cannot be voted
+// Older posts are more likely to have been answered
+func (g *generator) generateResponse(post *generatedPost) error {
+	age := g.opts.Now.Sub(post.createdAt).Hours() / 24 / float64(g.opts.Days)
+	if g.rnd.Float64() > 0.2+age*0.6 {
+		return nil
+	}
+
+	statuses := []enum.PostStatus{
+		enum.PostPlanned, enum.PostPlanned, enum.PostPlanned,
+		enum.PostStarted, enum.PostStarted,
+		enum.PostCompleted, enum.PostCompleted, enum.PostCompleted,
+		enum.PostDeclined, enum.PostDeclined,
+	}
+	status := statuses[g.rnd.Intn(len(statuses))]
+
+	if err := bus.Dispatch(g.as(g.staff), &cmd.SetPostResponse{
+		Post:   post.post,
+		Text:   pick(g.rnd, responses[status]),
+		Status: status,
+	}); err != nil {
+		return err
+	}
+
+	return bus.Dispatch(g.ctx, &cmd.BackdatePost{
+		Post:        post.post,
+		CreatedAt:   post.createdAt,
+		RespondedAt: g.after(post.createdAt, 30),
+	})
+}
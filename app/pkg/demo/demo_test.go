@@ -0,0 +1,191 @@
+package demo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/demo"
+)
+
+var now = time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+type generated struct {
+	posts     []string
+	comments  []string
+	statuses  []enum.PostStatus
+	postDates []time.Time
+	voteDates []time.Time
+}
+
+func setupDemo() (context.Context, *generated) {
+	result := &generated{}
+	lastID := 0
+	nextID := func() int {
+		lastID++
+		return lastID
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllUsers) error {
+		q.Result = []*entity.User{{ID: nextID(), Name: "Jon Snow", Role: enum.RoleAdministrator, Status: enum.UserActive}}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.RegisterUser) error {
+		c.User.ID = nextID()
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewTag) error {
+		c.Result = &entity.Tag{ID: nextID(), Name: c.Name, Color: c.Color, IsPublic: c.IsPublic}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
+		user := ctx.Value(app.UserCtxKey).(*entity.User)
+		c.Result = &entity.Post{ID: nextID(), Title: c.Title, Description: c.Description, User: user, Status: enum.PostOpen}
+		result.posts = append(result.posts, c.Title)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error {
+		Expect(c.Post.CanBeVoted()).IsTrue()
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignTag) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewComment) error {
+		c.Result = &entity.Comment{ID: nextID(), Content: c.Content}
+		result.comments = append(result.comments, c.Content)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostResponse) error {
+		user := ctx.Value(app.UserCtxKey).(*entity.User)
+		Expect(user.IsAdministrator()).IsTrue()
+		c.Post.Status = c.Status
+		c.Post.Response = &entity.PostResponse{Text: c.Text}
+		result.statuses = append(result.statuses, c.Status)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.BackdatePost) error {
+		Expect(c.CreatedAt.After(now)).IsFalse()
+		Expect(c.RespondedAt.After(now)).IsFalse()
+		result.postDates = append(result.postDates, c.CreatedAt)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.BackdateComment) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.BackdateVote) error {
+		Expect(c.CreatedAt.After(now)).IsFalse()
+		result.voteDates = append(result.voteDates, c.CreatedAt)
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{ID: 1})
+	return ctx, result
+}
+
+func demoOptions(seed int64) demo.Options {
+	return demo.Options{
+		Seed:     seed,
+		Users:    20,
+		Tags:     4,
+		Posts:    30,
+		Comments: 60,
+		Votes:    200,
+		Days:     90,
+		Now:      now,
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	RegisterT(t)
+	ctx, result := setupDemo()
+
+	summary, err := demo.Generate(ctx, demoOptions(42))
+	Expect(err).IsNil()
+	Expect(summary.Users).Equals(20)
+	Expect(summary.Tags).Equals(4)
+	Expect(summary.Posts).Equals(30)
+	Expect(summary.Comments).Equals(len(result.comments))
+	Expect(summary.Votes).Equals(len(result.voteDates))
+	Expect(summary.Votes > 30 && summary.Votes <= 200).IsTrue()
+	Expect(len(result.statuses) > 0).IsTrue()
+
+	oldest := now
+	for _, createdAt := range result.postDates {
+		if createdAt.Before(oldest) {
+			oldest = createdAt
+		}
+	}
+	Expect(oldest.Before(now.AddDate(0, 0, -30))).IsTrue()
+	Expect(oldest.Before(now.AddDate(0, 0, -90))).IsFalse()
+}
+
+func TestGenerate_SameSeed_SameData(t *testing.T) {
+	RegisterT(t)
+
+	ctx, first := setupDemo()
+	_, err := demo.Generate(ctx, demoOptions(7))
+	Expect(err).IsNil()
+
+	ctx, second := setupDemo()
+	_, err = demo.Generate(ctx, demoOptions(7))
+	Expect(err).IsNil()
+
+	Expect(second).Equals(first)
+
+	ctx, third := setupDemo()
+	_, err = demo.Generate(ctx, demoOptions(8))
+	Expect(err).IsNil()
+
+	Expect(third.posts).NotEquals(first.posts)
+}
+
+func TestGenerate_WithoutTenant(t *testing.T) {
+	RegisterT(t)
+
+	summary, err := demo.Generate(context.Background(), demoOptions(1))
+	Expect(err).IsNotNil()
+	Expect(summary).IsNil()
+}
+
+func TestGenerate_NegativeOptions(t *testing.T) {
+	RegisterT(t)
+	ctx, _ := setupDemo()
+
+	opts := demoOptions(1)
+	opts.Comments = -1
+	summary, err := demo.Generate(ctx, opts)
+	Expect(err).IsNotNil()
+	Expect(summary).IsNil()
+
+	opts = demoOptions(1)
+	opts.Posts = -5
+	summary, err = demo.Generate(ctx, opts)
+	Expect(err).IsNotNil()
+	Expect(summary).IsNil()
+}
@@ -0,0 +1,46 @@
+package disposable
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed domains.txt
+var domainsFile string
+
+var domains = parse(domainsFile)
+
+func parse(content string) map[string]bool {
+	result := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.ToLower(strings.TrimSpace(line)); line != "" && !strings.HasPrefix(line, "#") {
+			result[line] = true
+		}
+	}
+	return result
+}
+
+// IsDisposableDomain returns true if given domain, or any of its parent domains, is known to provide disposable email addresses
+func IsDisposableDomain(domain string) bool {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	for domain != "" {
+		if domains[domain] {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			return false
+		}
+		domain = parent
+	}
+	return false
+}
+
+// IsDisposableEmail returns true if given email address belongs to a disposable email provider
+func IsDisposableEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	return IsDisposableDomain(email[at+1:])
+}
@@ -0,0 +1,25 @@
+package disposable_test
+
+import (
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/disposable"
+)
+
+func TestIsDisposableEmail(t *testing.T) {
+	RegisterT(t)
+
+	for email, expected := range map[string]bool{
+		"jon.snow@got.com":          false,
+		"jon.snow@mailinator.com":   true,
+		"jon.snow@MailInator.com":   true,
+		"jon.snow@eu.yopmail.com":   true,
+		"jon.snow@notyopmail.com":   false,
+		"jon.snow":                  false,
+		"":                          false,
+		"jon@snow@guerrillamail.de": true,
+	} {
+		Expect(disposable.IsDisposableEmail(email)).Equals(expected)
+	}
+}
@@ -41,6 +41,8 @@ type config struct {
 		ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT,default=5s,strict"`
 		WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT,default=10s,strict"`
 		IdleTimeout  time.Duration `env:"HTTP_IDLE_TIMEOUT,default=120s,strict"`
+		// comma separated list of IPs or CIDRs of the reverse proxies allowed to set X-Forwarded-For
+		TrustedProxies string `env:"HTTP_TRUSTED_PROXIES"`
 	}
 	Port       string `env:"PORT,default=3000"`
 	HostMode   string `env:"HOST_MODE,default=single"`
@@ -130,6 +132,27 @@ type config struct {
 			Path string `env:"BLOB_STORAGE_FS_PATH"`
 		}
 	}
+	WebPush struct {
+		VAPIDPublicKey  string `env:"WEBPUSH_VAPID_PUBLIC_KEY"`
+		VAPIDPrivateKey string `env:"WEBPUSH_VAPID_PRIVATE_KEY"`
+		Subject         string `env:"WEBPUSH_SUBJECT"`
+	}
+	Operator struct {
+		Emails   string `env:"OPERATOR_EMAILS"`    // comma separated list of administrators allowed to manage the instance on multi host mode
+		TenantID int    `env:"OPERATOR_TENANT_ID"` // tenant the operators must be administrators of, as any tenant can have a user with any email
+	}
+	SignUp struct {
+		ApprovalRequired      bool   `env:"SIGNUP_APPROVAL_REQUIRED,default=false"`
+		BlockDisposableEmails bool   `env:"SIGNUP_BLOCK_DISPOSABLE_EMAILS,default=false"`
+		BlockedSubdomains     string `env:"SIGNUP_BLOCKED_SUBDOMAINS"`                  // comma separated list of words that new subdomains cannot contain
+		MaxPerIPPerDay        int    `env:"SIGNUP_MAX_PER_IP_PER_DAY,default=0,strict"` // 0 means unlimited
+	}
+	Analytics struct {
+		RetentionDays int `env:"ANALYTICS_RETENTION_DAYS,default=365,strict"` // how long daily counters of post views are kept
+	}
+	Jobs struct {
+		Schedules string `env:"JOBS_SCHEDULES"` // semicolon separated list of JobName=cron expression, e.g: EmailSupressionJob=0 */10 * * * *
+	}
 	Maintenance struct {
 		Enabled bool   `env:"MAINTENANCE,default=false,strict"`
 		Message string `env:"MAINTENANCE_MESSAGE"`
@@ -196,6 +219,14 @@ func Reload() {
 	} else if bsType == "fs" {
 		mustBeSet("BLOB_STORAGE_FS_PATH")
 	}
+
+	if Config.WebPush.VAPIDPublicKey != "" || Config.WebPush.VAPIDPrivateKey != "" {
+		mustBeSet("WEBPUSH_VAPID_PUBLIC_KEY")
+		mustBeSet("WEBPUSH_VAPID_PRIVATE_KEY")
+		if Config.WebPush.Subject == "" {
+			Config.WebPush.Subject = "mailto:" + Config.Email.NoReply
+		}
+	}
 }
 
 func mustBeSet(name string) {
@@ -210,6 +241,70 @@ func IsSingleHostMode() bool {
 	return Config.HostMode == "single"
 }
 
+// IsOperator returns true if the administrator with given email on given tenant can manage the whole instance.
+// On single host mode every administrator is an operator, otherwise only the ones of OPERATOR_TENANT_ID are
+func IsOperator(tenantID int, email string) bool {
+	if IsSingleHostMode() {
+		return true
+	}
+	if Config.Operator.TenantID == 0 || Config.Operator.TenantID != tenantID {
+		return false
+	}
+	return IsOperatorEmail(email)
+}
+
+// IsOperatorEmail returns true if given email is set on OPERATOR_EMAILS, or on single host mode
+func IsOperatorEmail(email string) bool {
+	if IsSingleHostMode() {
+		return true
+	}
+	for _, operator := range OperatorEmails() {
+		if strings.EqualFold(operator, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// OperatorEmails returns the emails of the administrators set on OPERATOR_EMAILS
+func OperatorEmails() []string {
+	emails := make([]string, 0)
+	for _, operator := range strings.Split(Config.Operator.Emails, ",") {
+		if operator = strings.TrimSpace(operator); operator != "" {
+			emails = append(emails, operator)
+		}
+	}
+	return emails
+}
+
+// IsSignUpApprovalRequired returns true if new sites must be approved by an operator before being activated.
+// Only available on multi host mode
+func IsSignUpApprovalRequired() bool {
+	return !IsSingleHostMode() && Config.SignUp.ApprovalRequired
+}
+
+// IsBlockedSubdomain returns true if given subdomain contains any of the words set on SIGNUP_BLOCKED_SUBDOMAINS
+func IsBlockedSubdomain(subdomain string) bool {
+	subdomain = strings.ToLower(subdomain)
+	for _, word := range strings.Split(Config.SignUp.BlockedSubdomains, ",") {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" && strings.Contains(subdomain, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// JobSchedule returns the schedule of given job set on JOBS_SCHEDULES, if any
+func JobSchedule(jobName string) (string, bool) {
+	for _, entry := range strings.Split(Config.Jobs.Schedules, ";") {
+		name, schedule, ok := strings.Cut(entry, "=")
+		if ok && strings.TrimSpace(name) == jobName && strings.TrimSpace(schedule) != "" {
+			return strings.TrimSpace(schedule), true
+		}
+	}
+	return "", false
+}
+
 var hasLegal *bool
 
 // HasLegal returns true if current instance contains legal documents: privacy.md and terms.md
@@ -236,6 +331,11 @@ func IsBillingEnabled() bool {
 	return Config.Paddle.VendorID != "" && Config.Paddle.VendorAuthCode != ""
 }
 
+// IsWebPushEnabled returns true if VAPID keys are configured
+func IsWebPushEnabled() bool {
+	return Config.WebPush.VAPIDPublicKey != "" && Config.WebPush.VAPIDPrivateKey != ""
+}
+
 // IsProduction returns true on Fider production environment
 func IsProduction() bool {
 	return Config.Environment == "production" || (!IsTest() && !IsDevelopment())
@@ -66,3 +66,70 @@ func TestSubdomain(t *testing.T) {
 	Expect(env.Subdomain("test.fidercdn.com")).Equals("")
 	Expect(env.Subdomain("helloworld.com")).Equals("")
 }
+
+func TestIsOperator(t *testing.T) {
+	RegisterT(t)
+
+	Expect(env.IsOperator(1, "jon.snow@got.com")).IsFalse()
+
+	env.Config.Operator.Emails = "arya.stark@got.com, Jon.Snow@got.com"
+	Expect(env.IsOperatorEmail("jon.snow@got.com")).IsTrue()
+	Expect(env.IsOperatorEmail("sansa.stark@got.com")).IsFalse()
+	Expect(env.IsOperatorEmail("")).IsFalse()
+
+	// Operators must belong to the operator tenant
+	Expect(env.IsOperator(1, "jon.snow@got.com")).IsFalse()
+	env.Config.Operator.TenantID = 1
+	Expect(env.IsOperator(1, "jon.snow@got.com")).IsTrue()
+	Expect(env.IsOperator(2, "jon.snow@got.com")).IsFalse()
+	Expect(env.IsOperator(1, "sansa.stark@got.com")).IsFalse()
+
+	env.Config.Operator.Emails = ""
+	env.Config.Operator.TenantID = 0
+	env.Config.HostMode = "single"
+	Expect(env.IsOperator(2, "sansa.stark@got.com")).IsTrue()
+	Expect(env.IsOperatorEmail("sansa.stark@got.com")).IsTrue()
+}
+
+func TestIsSignUpApprovalRequired(t *testing.T) {
+	RegisterT(t)
+
+	Expect(env.IsSignUpApprovalRequired()).IsFalse()
+
+	env.Config.SignUp.ApprovalRequired = true
+	Expect(env.IsSignUpApprovalRequired()).IsTrue()
+
+	env.Config.HostMode = "single"
+	Expect(env.IsSignUpApprovalRequired()).IsFalse()
+}
+
+func TestIsBlockedSubdomain(t *testing.T) {
+	RegisterT(t)
+
+	Expect(env.IsBlockedSubdomain("casino-online")).IsFalse()
+
+	env.Config.SignUp.BlockedSubdomains = "casino, Loans,"
+	Expect(env.IsBlockedSubdomain("casino-online")).IsTrue()
+	Expect(env.IsBlockedSubdomain("cheaploans")).IsTrue()
+	Expect(env.IsBlockedSubdomain("feedback")).IsFalse()
+}
+
+func TestJobSchedule(t *testing.T) {
+	RegisterT(t)
+
+	env.Config.Jobs.Schedules = "PurgeExpiredNotificationsJob=0 0 3 * * *; EmailSupressionJob = 0 */5 * * * *;Broken"
+
+	schedule, ok := env.JobSchedule("PurgeExpiredNotificationsJob")
+	Expect(ok).IsTrue()
+	Expect(schedule).Equals("0 0 3 * * *")
+
+	schedule, ok = env.JobSchedule("EmailSupressionJob")
+	Expect(ok).IsTrue()
+	Expect(schedule).Equals("0 */5 * * * *")
+
+	_, ok = env.JobSchedule("Broken")
+	Expect(ok).IsFalse()
+
+	_, ok = env.JobSchedule("LockExpiredTenantsJob")
+	Expect(ok).IsFalse()
+}
@@ -0,0 +1,472 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Request is a GraphQL request as sent by clients
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Response is the result of a GraphQL request
+type Response struct {
+	Data   *Map     `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error is an error found while validating or executing a request
+// Path only includes the names of the fields, even when the error happens inside a list
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Limits restricts the size of the queries that can be executed
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Map is a JSON object that keeps the order in which its keys were set
+type Map struct {
+	keys   []string
+	values map[string]any
+}
+
+func newMap() *Map {
+	return &Map{values: make(map[string]any)}
+}
+
+// Get returns the value of given key
+func (m *Map) Get(key string) any {
+	return m.values[key]
+}
+
+// Keys returns the keys in the order they were set
+func (m *Map) Keys() []string {
+	return m.keys
+}
+
+func (m *Map) set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON writes the keys in the order they were set
+func (m *Map) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+type fieldGroup struct {
+	key    string
+	fields []*FieldSelection
+}
+
+type executor struct {
+	schema    *Schema
+	limits    Limits
+	document  *Document
+	variables map[string]any
+	errors    []*Error
+}
+
+// Execute validates and runs a read-only query against given schema
+func Execute(ctx context.Context, schema *Schema, request Request, limits Limits) *Response {
+	doc, err := Parse(request.Query)
+	if err != nil {
+		return failed(err)
+	}
+
+	operation, err := getOperation(doc, request.OperationName)
+	if err != nil {
+		return failed(err)
+	}
+	if operation.Type != "query" {
+		return failed(fmt.Errorf("Only queries are supported."))
+	}
+
+	variables := make(map[string]any)
+	for _, definition := range operation.Variables {
+		if definition.DefaultValue != nil {
+			variables[definition.Name] = definition.DefaultValue
+		}
+	}
+	for name, value := range request.Variables {
+		variables[name] = value
+	}
+
+	e := &executor{
+		schema:    schema,
+		limits:    limits,
+		document:  doc,
+		variables: variables,
+	}
+
+	if err := e.validateFragments(); err != nil {
+		return failed(err)
+	}
+
+	complexity, err := e.analyze(schema.Query, operation.SelectionSet, 1)
+	if err != nil {
+		return failed(err)
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return failed(fmt.Errorf("Query is too complex, its complexity is %d and maximum allowed is %d.", complexity, limits.MaxComplexity))
+	}
+
+	data := e.executeSelectionSet(ctx, schema.Query, []any{nil}, operation.SelectionSet, nil)
+	return &Response{Data: data[0], Errors: e.errors}
+}
+
+func failed(err error) *Response {
+	return &Response{Errors: []*Error{{Message: err.Error()}}}
+}
+
+func getOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("Operation name is required when document has multiple operations.")
+		}
+		return doc.Operations[0], nil
+	}
+
+	for _, operation := range doc.Operations {
+		if operation.Name == name {
+			return operation, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown operation named '%s'.", name)
+}
+
+// validateFragments ensures that all spreads refer to existing fragments and that fragments don't spread themselves
+func (e *executor) validateFragments() error {
+	var visit func(selections []Selection, stack map[string]bool) error
+	visit = func(selections []Selection, stack map[string]bool) error {
+		for _, selection := range selections {
+			switch s := selection.(type) {
+			case *FieldSelection:
+				if err := visit(s.SelectionSet, stack); err != nil {
+					return err
+				}
+			case *InlineFragment:
+				if err := visit(s.SelectionSet, stack); err != nil {
+					return err
+				}
+			case *FragmentSpread:
+				fragment, ok := e.document.Fragments[s.Name]
+				if !ok {
+					return fmt.Errorf("Unknown fragment '%s'.", s.Name)
+				}
+				if stack[s.Name] {
+					return fmt.Errorf("Cannot spread fragment '%s' within itself.", s.Name)
+				}
+				stack[s.Name] = true
+				if err := visit(fragment.SelectionSet, stack); err != nil {
+					return err
+				}
+				delete(stack, s.Name)
+			}
+		}
+		return nil
+	}
+
+	for name, fragment := range e.document.Fragments {
+		if err := visit(fragment.SelectionSet, map[string]bool{name: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// analyze validates the selection set against the schema and returns its complexity
+func (e *executor) analyze(objectType *Object, selections []Selection, depth int) (int, error) {
+	if e.limits.MaxDepth > 0 && depth > e.limits.MaxDepth {
+		return 0, fmt.Errorf("Query is too deep, maximum allowed depth is %d.", e.limits.MaxDepth)
+	}
+
+	groups, err := e.collectFields(objectType, selections)
+	if err != nil {
+		return 0, err
+	}
+
+	complexity := 0
+	for _, group := range groups {
+		for _, field := range group.fields {
+			if field.Name == "__typename" {
+				if len(field.SelectionSet) > 0 {
+					return 0, fmt.Errorf("Field '__typename' must not have a selection.")
+				}
+				continue
+			}
+
+			definition, ok := objectType.Fields[field.Name]
+			if !ok {
+				return 0, fmt.Errorf("Cannot query field '%s' on type '%s'.", field.Name, objectType.Name)
+			}
+
+			args, err := coerceArguments(definition.Args, field.Arguments, e.variables)
+			if err != nil {
+				return 0, fmt.Errorf("Field '%s': %s", field.Name, err.Error())
+			}
+
+			childComplexity := 0
+			if childType := objectOf(definition.Type); childType != nil {
+				if len(field.SelectionSet) == 0 {
+					return 0, fmt.Errorf("Field '%s' of type '%s' must have a selection of subfields.", field.Name, definition.Type.String())
+				}
+				if childComplexity, err = e.analyze(childType, field.SelectionSet, depth+1); err != nil {
+					return 0, err
+				}
+			} else if len(field.SelectionSet) > 0 {
+				return 0, fmt.Errorf("Field '%s' must not have a selection since type '%s' has no subfields.", field.Name, definition.Type.String())
+			}
+
+			if definition.Complexity != nil {
+				complexity += definition.Complexity(args, childComplexity)
+			} else {
+				complexity += 1 + childComplexity
+			}
+		}
+	}
+	return complexity, nil
+}
+
+// collectFields flattens fragments into the list of fields to be resolved, grouped by their response key
+func (e *executor) collectFields(objectType *Object, selections []Selection) ([]*fieldGroup, error) {
+	var groups []*fieldGroup
+	byKey := make(map[string]*fieldGroup)
+	visited := make(map[string]bool)
+
+	var collect func(selections []Selection) error
+	collect = func(selections []Selection) error {
+		for _, selection := range selections {
+			switch s := selection.(type) {
+			case *FieldSelection:
+				include, err := e.shouldInclude(s.Directives)
+				if err != nil {
+					return err
+				}
+				if !include {
+					continue
+				}
+				key := s.ResponseKey()
+				group, ok := byKey[key]
+				if !ok {
+					group = &fieldGroup{key: key}
+					byKey[key] = group
+					groups = append(groups, group)
+				} else if group.fields[0].Name != s.Name {
+					return fmt.Errorf("Fields '%s' and '%s' conflict because they are both named '%s' on the response.", group.fields[0].Name, s.Name, key)
+				}
+				group.fields = append(group.fields, s)
+			case *InlineFragment:
+				include, err := e.shouldInclude(s.Directives)
+				if err != nil {
+					return err
+				}
+				if !include {
+					continue
+				}
+				if err := e.checkTypeCondition(objectType, s.TypeCondition); err != nil {
+					return err
+				}
+				if err := collect(s.SelectionSet); err != nil {
+					return err
+				}
+			case *FragmentSpread:
+				include, err := e.shouldInclude(s.Directives)
+				if err != nil {
+					return err
+				}
+				if !include {
+					continue
+				}
+				if visited[s.Name] {
+					continue
+				}
+				visited[s.Name] = true
+				fragment, ok := e.document.Fragments[s.Name]
+				if !ok {
+					return fmt.Errorf("Unknown fragment '%s'.", s.Name)
+				}
+				if err := e.checkTypeCondition(objectType, fragment.TypeCondition); err != nil {
+					return err
+				}
+				if err := collect(fragment.SelectionSet); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	return groups, collect(selections)
+}
+
+func (e *executor) checkTypeCondition(objectType *Object, typeCondition string) error {
+	if typeCondition != "" && typeCondition != objectType.Name {
+		return fmt.Errorf("Fragment on type '%s' can never be spread on type '%s'.", typeCondition, objectType.Name)
+	}
+	return nil
+}
+
+func (e *executor) shouldInclude(directives []*Directive) (bool, error) {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			return false, fmt.Errorf("Unknown directive '@%s'.", directive.Name)
+		}
+
+		args, err := coerceArguments(map[string]*Argument{"if": {Type: Boolean}}, directive.Arguments, e.variables)
+		if err != nil {
+			return false, fmt.Errorf("Directive '@%s': %s", directive.Name, err.Error())
+		}
+		condition, ok := args["if"].(bool)
+		if !ok {
+			return false, fmt.Errorf("Directive '@%s' requires argument 'if'.", directive.Name)
+		}
+		if (directive.Name == "skip" && condition) || (directive.Name == "include" && !condition) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// executeSelectionSet resolves the selection set of all sources at once, which are all of the same type
+func (e *executor) executeSelectionSet(ctx context.Context, objectType *Object, sources []any, selections []Selection, path []any) []*Map {
+	results := make([]*Map, len(sources))
+	for i := range results {
+		results[i] = newMap()
+	}
+
+	// Selection sets have already been validated by analyze
+	groups, _ := e.collectFields(objectType, selections)
+	for _, group := range groups {
+		field := group.fields[0]
+		if field.Name == "__typename" {
+			for _, result := range results {
+				result.set(group.key, objectType.Name)
+			}
+			continue
+		}
+
+		fieldPath := append(append([]any{}, path...), group.key)
+		definition := objectType.Fields[field.Name]
+		args, _ := coerceArguments(definition.Args, field.Arguments, e.variables)
+
+		values, err := definition.Resolve(ctx, ResolveParams{Sources: sources, Args: args})
+		if err == nil && len(values) != len(sources) {
+			err = fmt.Errorf("Field '%s' resolved %d values for %d sources.", field.Name, len(values), len(sources))
+		}
+		if err != nil {
+			e.errors = append(e.errors, &Error{Message: err.Error(), Path: fieldPath})
+			for _, result := range results {
+				result.set(group.key, nil)
+			}
+			continue
+		}
+
+		var childSelections []Selection
+		for _, f := range group.fields {
+			childSelections = append(childSelections, f.SelectionSet...)
+		}
+
+		completed := e.completeValues(ctx, definition.Type, values, childSelections, fieldPath)
+		for i, result := range results {
+			result.set(group.key, completed[i])
+		}
+	}
+	return results
+}
+
+// completeValues resolves the selection sets of objects, including the ones inside lists,
+// so that all objects of the same level are resolved together
+func (e *executor) completeValues(ctx context.Context, t Type, values []any, selections []Selection, path []any) []any {
+	completed := make([]any, len(values))
+
+	switch t := t.(type) {
+	case *Object:
+		indexes := make([]int, 0, len(values))
+		sources := make([]any, 0, len(values))
+		for i, value := range values {
+			if !isNil(value) {
+				indexes = append(indexes, i)
+				sources = append(sources, value)
+			}
+		}
+		for j, result := range e.executeSelectionSet(ctx, t, sources, selections, path) {
+			completed[indexes[j]] = result
+		}
+	case *List:
+		counts := make([]int, len(values))
+		items := make([]any, 0)
+		for i, value := range values {
+			if isNil(value) {
+				counts[i] = -1
+				continue
+			}
+			list := reflect.ValueOf(value)
+			if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+				list = reflect.ValueOf([]any{value})
+			}
+			counts[i] = list.Len()
+			for k := 0; k < list.Len(); k++ {
+				items = append(items, list.Index(k).Interface())
+			}
+		}
+
+		completedItems := e.completeValues(ctx, t.OfType, items, selections, path)
+		offset := 0
+		for i, count := range counts {
+			if count < 0 {
+				continue
+			}
+			completed[i] = completedItems[offset : offset+count]
+			offset += count
+		}
+	default:
+		for i, value := range values {
+			if !isNil(value) {
+				completed[i] = value
+			}
+		}
+	}
+	return completed
+}
+
+func objectOf(t Type) *Object {
+	switch t := t.(type) {
+	case *Object:
+		return t
+	case *List:
+		return objectOf(t.OfType)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/graphql"
+)
+
+type book struct {
+	ID       int
+	Title    string
+	AuthorID int
+}
+
+type author struct {
+	ID   int
+	Name string
+}
+
+var books = []*book{
+	{ID: 1, Title: "A Game of Thrones", AuthorID: 1},
+	{ID: 2, Title: "A Clash of Kings", AuthorID: 1},
+	{ID: 3, Title: "The Hobbit", AuthorID: 2},
+}
+
+var authors = map[int]*author{
+	1: {ID: 1, Name: "George R. R. Martin"},
+	2: {ID: 2, Name: "J. R. R. Tolkien"},
+}
+
+func newSchema(authorBatches *[][]int) *graphql.Schema {
+	var bookType *graphql.Object
+	authorType := &graphql.Object{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"id":   {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*author).ID })},
+			"name": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*author).Name })},
+		},
+	}
+	bookType = &graphql.Object{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id":    {Type: graphql.Int, Resolve: graphql.Property(func(s any) any { return s.(*book).ID })},
+			"title": {Type: graphql.String, Resolve: graphql.Property(func(s any) any { return s.(*book).Title })},
+			"author": {
+				Type: authorType,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) ([]any, error) {
+					ids := make([]int, 0)
+					values := make([]any, len(p.Sources))
+					for i, s := range p.Sources {
+						ids = append(ids, s.(*book).AuthorID)
+						values[i] = authors[s.(*book).AuthorID]
+					}
+					*authorBatches = append(*authorBatches, ids)
+					return values, nil
+				},
+			},
+			"secret": {
+				Type: graphql.String,
+				Resolve: graphql.Resolve(func(ctx context.Context, s any, args map[string]any) (any, error) {
+					return nil, errors.New("Not allowed.")
+				}),
+			},
+		},
+	}
+
+	return &graphql.Schema{
+		Query: &graphql.Object{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"books": {
+					Type: graphql.ListOf(bookType),
+					Args: map[string]*graphql.Argument{
+						"limit": {Type: graphql.Int, DefaultValue: 10},
+					},
+					Resolve: graphql.Resolve(func(ctx context.Context, s any, args map[string]any) (any, error) {
+						limit := args["limit"].(int)
+						if limit > len(books) {
+							limit = len(books)
+						}
+						return books[:limit], nil
+					}),
+					Complexity: func(args map[string]any, childComplexity int) int {
+						return 1 + args["limit"].(int)*childComplexity
+					},
+				},
+				"book": {
+					Type: bookType,
+					Args: map[string]*graphql.Argument{
+						"id": {Type: graphql.Int},
+					},
+					Resolve: graphql.Resolve(func(ctx context.Context, s any, args map[string]any) (any, error) {
+						for _, b := range books {
+							if b.ID == args["id"] {
+								return b, nil
+							}
+						}
+						return nil, nil
+					}),
+				},
+			},
+		},
+	}
+}
+
+func execute(request graphql.Request, limits graphql.Limits) (string, [][]int) {
+	var batches [][]int
+	response := graphql.Execute(context.Background(), newSchema(&batches), request, limits)
+	content, _ := json.Marshal(response)
+	return string(content), batches
+}
+
+func TestExecute_BatchesEachLevel(t *testing.T) {
+	RegisterT(t)
+
+	result, batches := execute(graphql.Request{
+		Query: `{ books { title author { name } } }`,
+	}, graphql.Limits{})
+
+	Expect(result).Equals(`{"data":{"books":[` +
+		`{"title":"A Game of Thrones","author":{"name":"George R. R. Martin"}},` +
+		`{"title":"A Clash of Kings","author":{"name":"George R. R. Martin"}},` +
+		`{"title":"The Hobbit","author":{"name":"J. R. R. Tolkien"}}]}}`)
+	Expect(batches).Equals([][]int{{1, 1, 2}})
+}
+
+func TestExecute_AliasesVariablesAndFragments(t *testing.T) {
+	RegisterT(t)
+
+	result, _ := execute(graphql.Request{
+		Query: `
+			query GetBooks($limit: Int, $id: Int = 3, $withAuthor: Boolean!) {
+				first: books(limit: $limit) { ...BookFields }
+				hobbit: book(id: $id) {
+					__typename
+					... on Book { title }
+					author @include(if: $withAuthor) { name }
+				}
+				missing: book(id: 10) { title }
+			}
+
+			fragment BookFields on Book { id title }
+		`,
+		Variables: map[string]any{"limit": float64(1), "withAuthor": false},
+	}, graphql.Limits{})
+
+	Expect(result).Equals(`{"data":{` +
+		`"first":[{"id":1,"title":"A Game of Thrones"}],` +
+		`"hobbit":{"__typename":"Book","title":"The Hobbit"},` +
+		`"missing":null}}`)
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	RegisterT(t)
+
+	result, _ := execute(graphql.Request{
+		Query: `{ book(id: 1) { title secret } }`,
+	}, graphql.Limits{})
+
+	Expect(result).Equals(`{"data":{"book":{"title":"A Game of Thrones","secret":null}},"errors":[{"message":"Not allowed.","path":["book","secret"]}]}`)
+}
+
+func TestExecute_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		query   string
+		message string
+	}{
+		{`{ books { title `, "Unexpected end of document."},
+		{`{ books { isbn } }`, "Cannot query field 'isbn' on type 'Book'."},
+		{`{ books }`, "Field 'books' of type '[Book]' must have a selection of subfields."},
+		{`{ books { title { name } } }`, "Field 'title' must not have a selection since type 'String' has no subfields."},
+		{`{ books(limit: "ten") { title } }`, "Field 'books': Argument 'limit' must be of type Int."},
+		{`{ books(order: "title") { title } }`, "Field 'books': Unknown argument 'order'."},
+		{`mutation { books { title } }`, "Only queries are supported."},
+		{`{ books { ...Missing } }`, "Unknown fragment 'Missing'."},
+		{`{ books { ...A } } fragment A on Book { ...B } fragment B on Book { ...A }`, "Cannot spread fragment"},
+		{`{ books { ... on Author { name } } }`, "Fragment on type 'Author' can never be spread on type 'Book'."},
+		{`{ books { title @deprecated } }`, "Unknown directive '@deprecated'."},
+		{`query A { books { id } } query B { books { id } }`, "Operation name is required when document has multiple operations."},
+	}
+
+	for _, testCase := range testCases {
+		result, _ := execute(graphql.Request{Query: testCase.query}, graphql.Limits{})
+		Expect(result).ContainsSubstring(testCase.message)
+		Expect(result).ContainsSubstring(`{"errors":[`)
+	}
+}
+
+func TestExecute_Limits(t *testing.T) {
+	RegisterT(t)
+
+	result, _ := execute(graphql.Request{
+		Query: `{ books { author { name } } }`,
+	}, graphql.Limits{MaxDepth: 2})
+	Expect(result).Equals(`{"errors":[{"message":"Query is too deep, maximum allowed depth is 2."}]}`)
+
+	result, _ = execute(graphql.Request{
+		Query: `{ books(limit: 10) { id title author { name } } }`,
+	}, graphql.Limits{MaxComplexity: 30})
+	Expect(result).Equals(`{"errors":[{"message":"Query is too complex, its complexity is 41 and maximum allowed is 30."}]}`)
+
+	result, _ = execute(graphql.Request{
+		Query: `{ books(limit: 2) { id title author { name } } }`,
+	}, graphql.Limits{MaxDepth: 3, MaxComplexity: 30})
+	Expect(result).ContainsSubstring(`{"data":{"books":[`)
+}
+
+func TestExecute_OperationName(t *testing.T) {
+	RegisterT(t)
+
+	result, _ := execute(graphql.Request{
+		Query:         `query A { book(id: 1) { title } } query B { book(id: 2) { title } }`,
+		OperationName: "B",
+	}, graphql.Limits{})
+	Expect(result).Equals(`{"data":{"book":{"title":"A Clash of Kings"}}}`)
+}
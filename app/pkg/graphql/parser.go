@@ -0,0 +1,670 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Document is a parsed GraphQL request
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query, mutation or subscription of a document
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+// VariableDefinition declares a variable used by an operation
+type VariableDefinition struct {
+	Name         string
+	Type         string
+	DefaultValue Value
+}
+
+// Fragment is a named set of fields that can be spread into selection sets
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+}
+
+// Selection is either a *FieldSelection, *FragmentSpread or *InlineFragment
+type Selection any
+
+// FieldSelection selects a field of an object
+type FieldSelection struct {
+	Alias        string
+	Name         string
+	Arguments    map[string]Value
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+// ResponseKey is the name of the field on the response
+func (f *FieldSelection) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread includes a named fragment into a selection set
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+// InlineFragment includes a set of fields, optionally restricted to a type
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+// Directive is an annotation such as @skip or @include
+type Directive struct {
+	Name      string
+	Arguments map[string]Value
+}
+
+// Value is a literal or a variable reference
+type Value any
+
+// Variable is a reference to a variable of the operation
+type Variable struct {
+	Name string
+}
+
+// EnumValue is an unquoted name used as a value
+type EnumValue string
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type parser struct {
+	source string
+	pos    int
+	token  token
+}
+
+// Parse reads a GraphQL document from source
+func Parse(source string) (*Document, error) {
+	p := &parser{source: source}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"):
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: selections})
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			operation, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, operation)
+		case p.peek(tokenName, "fragment"):
+			fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				return nil, fmt.Errorf("There can be only one fragment named '%s'.", fragment.Name)
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("Document must contain at least one operation.")
+	}
+	return doc, nil
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	operation := &Operation{Type: p.token.value}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenName {
+		operation.Name = p.token.value
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek(tokenPunctuator, "(") {
+		variables, err := p.parseVariableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		operation.Variables = variables
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	operation.SelectionSet = selections
+	return operation, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	var variables []*VariableDefinition
+	for !p.peek(tokenPunctuator, ")") {
+		if err := p.expect(tokenPunctuator, "$"); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		typeRef, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+
+		variable := &VariableDefinition{Name: name, Type: typeRef}
+		if p.peek(tokenPunctuator, "=") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if variable.DefaultValue, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		variables = append(variables, variable)
+	}
+	return variables, p.next()
+}
+
+func (p *parser) parseTypeRef() (string, error) {
+	var typeRef string
+	if p.peek(tokenPunctuator, "[") {
+		if err := p.next(); err != nil {
+			return "", err
+		}
+		ofType, err := p.parseTypeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect(tokenPunctuator, "]"); err != nil {
+			return "", err
+		}
+		typeRef = "[" + ofType + "]"
+	} else {
+		name, err := p.parseName()
+		if err != nil {
+			return "", err
+		}
+		typeRef = name
+	}
+
+	if p.peek(tokenPunctuator, "!") {
+		typeRef += "!"
+		return typeRef, p.next()
+	}
+	return typeRef, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, fmt.Errorf("Fragment can't be named 'on'.")
+	}
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+	typeCondition, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &Fragment{Name: name, TypeCondition: typeCondition, SelectionSet: selections}, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	var selections []Selection
+	for !p.peek(tokenPunctuator, "}") {
+		selection, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("Selection set at position %d can't be empty.", p.token.pos)
+	}
+	return selections, p.next()
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	if p.peek(tokenPunctuator, "...") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		if p.token.kind == tokenName && p.token.value != "on" {
+			spread := &FragmentSpread{Name: p.token.value}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			directives, err := p.parseDirectives()
+			if err != nil {
+				return nil, err
+			}
+			spread.Directives = directives
+			return spread, nil
+		}
+
+		fragment := &InlineFragment{}
+		if p.peek(tokenName, "on") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			typeCondition, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			fragment.TypeCondition = typeCondition
+		}
+		directives, err := p.parseDirectives()
+		if err != nil {
+			return nil, err
+		}
+		fragment.Directives = directives
+		if fragment.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+		return fragment, nil
+	}
+
+	field := &FieldSelection{}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunctuator, ":") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		field.Alias = name
+		if name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	field.Name = name
+
+	if p.peek(tokenPunctuator, "(") {
+		if field.Arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+	if field.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunctuator, "{") {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) parseArguments() (map[string]Value, error) {
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	arguments := make(map[string]Value)
+	for !p.peek(tokenPunctuator, ")") {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := arguments[name]; ok {
+			return nil, fmt.Errorf("There can be only one argument named '%s'.", name)
+		}
+		arguments[name] = value
+	}
+	return arguments, p.next()
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.peek(tokenPunctuator, "@") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		directive := &Directive{Name: name}
+		if p.peek(tokenPunctuator, "(") {
+			if directive.Arguments, err = p.parseArguments(); err != nil {
+				return nil, err
+			}
+		}
+		directives = append(directives, directive)
+	}
+	return directives, nil
+}
+
+func (p *parser) parseValue(isConst bool) (Value, error) {
+	tok := p.token
+	switch tok.kind {
+	case tokenPunctuator:
+		switch tok.value {
+		case "$":
+			if isConst {
+				return nil, p.unexpected()
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			return &Variable{Name: name}, nil
+		case "[":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			list := make([]any, 0)
+			for !p.peek(tokenPunctuator, "]") {
+				item, err := p.parseValue(isConst)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			return list, p.next()
+		case "{":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			object := make(map[string]any)
+			for !p.peek(tokenPunctuator, "}") {
+				name, err := p.parseName()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(tokenPunctuator, ":"); err != nil {
+					return nil, err
+				}
+				if object[name], err = p.parseValue(isConst); err != nil {
+					return nil, err
+				}
+			}
+			return object, p.next()
+		}
+	case tokenInt:
+		value, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid integer '%s' at position %d.", tok.value, tok.pos)
+		}
+		return value, p.next()
+	case tokenFloat:
+		value, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid number '%s' at position %d.", tok.value, tok.pos)
+		}
+		return value, p.next()
+	case tokenString:
+		return tok.value, p.next()
+	case tokenName:
+		var value Value
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			value = EnumValue(tok.value)
+		}
+		return value, p.next()
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) parseName() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.token.value
+	return name, p.next()
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.token.kind == kind && p.token.value == value
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return p.unexpected()
+	}
+	return p.next()
+}
+
+func (p *parser) unexpected() error {
+	if p.token.kind == tokenEOF {
+		return fmt.Errorf("Unexpected end of document.")
+	}
+	return fmt.Errorf("Unexpected '%s' at position %d.", p.token.value, p.token.pos)
+}
+
+// next moves to the next token, skipping whitespaces, commas and comments
+func (p *parser) next() error {
+	for p.pos < len(p.source) {
+		ch := p.source[p.pos]
+		if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',' {
+			p.pos++
+		} else if ch == '#' {
+			for p.pos < len(p.source) && p.source[p.pos] != '\n' {
+				p.pos++
+			}
+		} else if strings.HasPrefix(p.source[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+		} else {
+			break
+		}
+	}
+
+	start := p.pos
+	if p.pos >= len(p.source) {
+		p.token = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	ch := p.source[p.pos]
+	switch {
+	case strings.HasPrefix(p.source[p.pos:], "..."):
+		p.pos += 3
+		p.token = token{kind: tokenPunctuator, value: "...", pos: start}
+	case strings.ContainsRune("!$():=@[]{}|&", rune(ch)):
+		p.pos++
+		p.token = token{kind: tokenPunctuator, value: string(ch), pos: start}
+	case ch == '_' || isLetter(ch):
+		for p.pos < len(p.source) && (p.source[p.pos] == '_' || isLetter(p.source[p.pos]) || isDigit(p.source[p.pos])) {
+			p.pos++
+		}
+		p.token = token{kind: tokenName, value: p.source[start:p.pos], pos: start}
+	case ch == '-' || isDigit(ch):
+		return p.readNumber()
+	case ch == '"':
+		return p.readString()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.source[p.pos:])
+		return fmt.Errorf("Unexpected character '%c' at position %d.", r, start)
+	}
+	return nil
+}
+
+func (p *parser) readNumber() error {
+	start := p.pos
+	kind := tokenInt
+	if p.source[p.pos] == '-' {
+		p.pos++
+	}
+	p.readDigits()
+	if p.pos < len(p.source) && p.source[p.pos] == '.' {
+		kind = tokenFloat
+		p.pos++
+		p.readDigits()
+	}
+	if p.pos < len(p.source) && (p.source[p.pos] == 'e' || p.source[p.pos] == 'E') {
+		kind = tokenFloat
+		p.pos++
+		if p.pos < len(p.source) && (p.source[p.pos] == '+' || p.source[p.pos] == '-') {
+			p.pos++
+		}
+		p.readDigits()
+	}
+	p.token = token{kind: kind, value: p.source[start:p.pos], pos: start}
+	return nil
+}
+
+func (p *parser) readDigits() {
+	for p.pos < len(p.source) && isDigit(p.source[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) readString() error {
+	start := p.pos
+	if strings.HasPrefix(p.source[p.pos:], `"""`) {
+		end := strings.Index(p.source[p.pos+3:], `"""`)
+		if end < 0 {
+			return fmt.Errorf("Unterminated string at position %d.", start)
+		}
+		value := p.source[p.pos+3 : p.pos+3+end]
+		p.pos += end + 6
+		p.token = token{kind: tokenString, value: strings.TrimSpace(value), pos: start}
+		return nil
+	}
+
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.source) {
+		ch := p.source[p.pos]
+		switch {
+		case ch == '"':
+			p.pos++
+			p.token = token{kind: tokenString, value: sb.String(), pos: start}
+			return nil
+		case ch == '\n':
+			return fmt.Errorf("Unterminated string at position %d.", start)
+		case ch == '\\' && p.pos+1 < len(p.source):
+			escaped := p.source[p.pos+1]
+			p.pos += 2
+			switch escaped {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case '"', '\\', '/':
+				sb.WriteByte(escaped)
+			case 'u':
+				if p.pos+4 > len(p.source) {
+					return fmt.Errorf("Invalid escape sequence at position %d.", p.pos-2)
+				}
+				code, err := strconv.ParseUint(p.source[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return fmt.Errorf("Invalid escape sequence at position %d.", p.pos-2)
+				}
+				sb.WriteRune(rune(code))
+				p.pos += 4
+			default:
+				return fmt.Errorf("Invalid escape sequence at position %d.", p.pos-2)
+			}
+		default:
+			sb.WriteByte(ch)
+			p.pos++
+		}
+	}
+	return fmt.Errorf("Unterminated string at position %d.", start)
+}
+
+func isLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
@@ -0,0 +1,93 @@
+package graphql_test
+
+import (
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/graphql"
+)
+
+func TestParse(t *testing.T) {
+	RegisterT(t)
+
+	doc, err := graphql.Parse(`
+		# Lists the posts with their tags
+		query Posts($tags: [String!], $limit: Int = 10) {
+			top: posts(view: "most-wanted", tags: $tags, limit: $limit, search: "dark \"mode\"\n") {
+				number
+				...PostFields @skip(if: false)
+			}
+		}
+
+		fragment PostFields on Post {
+			title
+			tags { slug }
+		}
+	`)
+	Expect(err).IsNil()
+	Expect(doc.Operations).HasLen(1)
+
+	operation := doc.Operations[0]
+	Expect(operation.Type).Equals("query")
+	Expect(operation.Name).Equals("Posts")
+	Expect(operation.Variables).HasLen(2)
+	Expect(operation.Variables[0].Type).Equals("[String!]")
+	Expect(operation.Variables[1].DefaultValue).Equals(10)
+
+	field := operation.SelectionSet[0].(*graphql.FieldSelection)
+	Expect(field.ResponseKey()).Equals("top")
+	Expect(field.Name).Equals("posts")
+	Expect(field.Arguments["view"]).Equals("most-wanted")
+	Expect(field.Arguments["tags"]).Equals(&graphql.Variable{Name: "tags"})
+	Expect(field.Arguments["search"]).Equals("dark \"mode\"\n")
+	Expect(field.SelectionSet).HasLen(2)
+
+	spread := field.SelectionSet[1].(*graphql.FragmentSpread)
+	Expect(spread.Name).Equals("PostFields")
+	Expect(spread.Directives[0].Name).Equals("skip")
+	Expect(spread.Directives[0].Arguments["if"]).Equals(false)
+
+	fragment := doc.Fragments["PostFields"]
+	Expect(fragment.TypeCondition).Equals("Post")
+	Expect(fragment.SelectionSet).HasLen(2)
+}
+
+func TestParse_Values(t *testing.T) {
+	RegisterT(t)
+
+	doc, err := graphql.Parse(`{ f(a: -12, b: 1.5e2, c: true, d: null, e: OPEN, g: [1, "two"], h: """ block """, i: "é") }`)
+	Expect(err).IsNil()
+
+	args := doc.Operations[0].SelectionSet[0].(*graphql.FieldSelection).Arguments
+	Expect(args["a"]).Equals(-12)
+	Expect(args["b"]).Equals(150.0)
+	Expect(args["c"]).Equals(true)
+	Expect(args["d"]).IsNil()
+	Expect(args["e"]).Equals(graphql.EnumValue("OPEN"))
+	Expect(args["g"]).Equals([]any{1, "two"})
+	Expect(args["h"]).Equals("block")
+	Expect(args["i"]).Equals("é")
+}
+
+func TestParse_Errors(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		source  string
+		message string
+	}{
+		{``, "Document must contain at least one operation."},
+		{`{ }`, "Selection set at position 2 can't be empty."},
+		{`{ posts(limit: ) }`, "Unexpected ')' at position 15."},
+		{`{ posts(query: "open) }`, "Unterminated string at position 15."},
+		{`{ posts ~ }`, "Unexpected character '~' at position 8."},
+		{`fragment on on Post { id }`, "Fragment can't be named 'on'."},
+		{`query ($a: Int = $b) { id }`, "Unexpected '$' at position 17."},
+	}
+
+	for _, testCase := range testCases {
+		_, err := graphql.Parse(testCase.source)
+		Expect(err).IsNotNil()
+		Expect(err.Error()).Equals(testCase.message)
+	}
+}
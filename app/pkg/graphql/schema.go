@@ -0,0 +1,245 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Type is the type of a field or an argument
+type Type interface {
+	String() string
+}
+
+// Scalar is a leaf type, which is sent as is on the response
+type Scalar struct {
+	Name string
+}
+
+func (s *Scalar) String() string {
+	return s.Name
+}
+
+// Built-in scalar types
+var (
+	Int     = &Scalar{Name: "Int"}
+	Float   = &Scalar{Name: "Float"}
+	String  = &Scalar{Name: "String"}
+	Boolean = &Scalar{Name: "Boolean"}
+	ID      = &Scalar{Name: "ID"}
+)
+
+// Object is a type with a set of fields, which must be selected on queries
+type Object struct {
+	Name   string
+	Fields Fields
+}
+
+func (o *Object) String() string {
+	return o.Name
+}
+
+// Fields of an object by name
+type Fields map[string]*Field
+
+// List is a list of values of given type
+type List struct {
+	OfType Type
+}
+
+func (l *List) String() string {
+	return "[" + l.OfType.String() + "]"
+}
+
+// ListOf returns a list type of given type
+func ListOf(ofType Type) *List {
+	return &List{OfType: ofType}
+}
+
+// Field is a field of an object
+type Field struct {
+	Type Type
+	Args map[string]*Argument
+
+	// Resolve returns the values of the field for all the sources at once,
+	// which allows fields to load the data of a whole level of the response on a single batch
+	Resolve BatchResolveFunc
+
+	// Complexity returns the cost of the field given its arguments and the cost of its selection set
+	// When not set, the cost is 1 plus the cost of its selection set
+	Complexity func(args map[string]any, childComplexity int) int
+}
+
+// Argument is an argument accepted by a field
+type Argument struct {
+	Type         Type
+	DefaultValue any
+}
+
+// ResolveParams are the inputs of a resolver
+type ResolveParams struct {
+	Sources []any
+	Args    map[string]any
+}
+
+// BatchResolveFunc returns one value per source, in the same order
+type BatchResolveFunc func(ctx context.Context, p ResolveParams) ([]any, error)
+
+// ResolveFunc returns the value of a field of a single source
+type ResolveFunc func(ctx context.Context, source any, args map[string]any) (any, error)
+
+// Resolve creates a batch resolver that resolves each source independently
+func Resolve(fn ResolveFunc) BatchResolveFunc {
+	return func(ctx context.Context, p ResolveParams) ([]any, error) {
+		values := make([]any, len(p.Sources))
+		for i, source := range p.Sources {
+			value, err := fn(ctx, source, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+}
+
+// Property creates a batch resolver that reads a value from each source
+func Property(fn func(source any) any) BatchResolveFunc {
+	return func(ctx context.Context, p ResolveParams) ([]any, error) {
+		values := make([]any, len(p.Sources))
+		for i, source := range p.Sources {
+			values[i] = fn(source)
+		}
+		return values, nil
+	}
+}
+
+// Schema is the entry point of queries
+type Schema struct {
+	Query *Object
+}
+
+func coerceArguments(definitions map[string]*Argument, values map[string]Value, variables map[string]any) (map[string]any, error) {
+	for name := range values {
+		if _, ok := definitions[name]; !ok {
+			return nil, fmt.Errorf("Unknown argument '%s'.", name)
+		}
+	}
+
+	args := make(map[string]any, len(definitions))
+	for name, definition := range definitions {
+		value, ok := values[name]
+		if ok {
+			if v, isVariable := value.(*Variable); isVariable {
+				value, ok = variables[v.Name]
+			} else {
+				resolved, err := resolveVariables(value, variables)
+				if err != nil {
+					return nil, err
+				}
+				value = resolved
+			}
+		}
+
+		if !ok || value == nil {
+			args[name] = definition.DefaultValue
+			continue
+		}
+
+		coerced, err := coerceValue(definition.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("Argument '%s' %s", name, err.Error())
+		}
+		args[name] = coerced
+	}
+	return args, nil
+}
+
+func resolveVariables(value Value, variables map[string]any) (any, error) {
+	switch v := value.(type) {
+	case *Variable:
+		return variables[v.Name], nil
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := resolveVariables(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = resolved
+		}
+		return list, nil
+	case map[string]any:
+		return nil, fmt.Errorf("Input objects are not supported.")
+	}
+	return value, nil
+}
+
+func coerceValue(t Type, value any) (any, error) {
+	if list, ok := t.(*List); ok {
+		items, isList := value.([]any)
+		if !isList {
+			items = []any{value}
+		}
+		result := make([]any, 0, len(items))
+		for _, item := range items {
+			coerced, err := coerceValue(list.OfType, item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, coerced)
+		}
+		return result, nil
+	}
+
+	switch t {
+	case Int:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case float64:
+			if v == math.Trunc(v) && v >= math.MinInt32 && v <= math.MaxInt32 {
+				return int(v), nil
+			}
+		}
+	case Float:
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case String:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case EnumValue:
+			return string(v), nil
+		}
+	case ID:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int:
+			return fmt.Sprint(v), nil
+		}
+	case Boolean:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("must be of type %s.", t.String())
+}
+
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
@@ -50,6 +50,14 @@ type OAuthStateClaims struct {
 	Metadata
 }
 
+// SignUpReviewClaims represents what goes into JWT tokens used by operators to approve or reject new sites
+type SignUpReviewClaims struct {
+	SignUpID int    `json:"signupreview/id"`
+	Approve  bool   `json:"signupreview/approve"`
+	Operator string `json:"signupreview/operator"`
+	Metadata
+}
+
 // Encode creates new JWT token with given claims
 func Encode(claims jwtgo.Claims) (string, error) {
 	jwtToken := jwtgo.NewWithClaims(jwtgo.GetSigningMethod("HS256"), claims)
@@ -90,6 +98,16 @@ func DecodeOAuthStateClaims(token string) (*OAuthStateClaims, error) {
 	return claims, nil
 }
 
+// DecodeSignUpReviewClaims extract SignUpReviewClaims from given JWT token
+func DecodeSignUpReviewClaims(token string) (*SignUpReviewClaims, error) {
+	claims := &SignUpReviewClaims{}
+	err := decode(token, claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode SignUpReview claims")
+	}
+	return claims, nil
+}
+
 func decode(token string, claims jwtgo.Claims) error {
 	jwtToken, err := jwtgo.ParseWithClaims(token, claims, func(t *jwtgo.Token) (any, error) {
 		if _, ok := t.Method.(*jwtgo.SigningMethodHMAC); !ok {
@@ -31,6 +31,9 @@ func createServer() *Server {
 	bus.AddHandler(func(ctx context.Context, q *query.ListActiveOAuthProviders) error {
 		return nil
 	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListAnnouncements) error {
+		return nil
+	})
 
 	engine := web.New()
 
@@ -102,6 +105,12 @@ func (s *Server) WithURL(fullURL string) *Server {
 	return s
 }
 
+// WithClientIP set current context Request client IP address
+func (s *Server) WithClientIP(ip string) *Server {
+	s.context.Request.ClientIP = ip
+	return s
+}
+
 // Execute given handler and return response
 func (s *Server) Execute(handler web.HandlerFunc) (int, *httptest.ResponseRecorder) {
 	next := handler
@@ -0,0 +1,197 @@
+package ogimage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"github.com/getfider/fider/app/pkg/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Width and Height are the dimensions recommended by most social networks for Open Graph images
+const (
+	Width  = 1200
+	Height = 630
+)
+
+const (
+	padding       = 80
+	logoSize      = 96
+	maxTitleLines = 3
+)
+
+var (
+	backgroundColor = color.White
+	titleColor      = color.RGBA{0x1f, 0x29, 0x37, 0xff}
+	textColor       = color.RGBA{0x4b, 0x55, 0x63, 0xff}
+	labelTextColor  = color.White
+)
+
+// Card is the content rendered into an Open Graph image
+type Card struct {
+	Logo       image.Image
+	SiteName   string
+	Title      string
+	Caption    string
+	Label      string
+	LabelColor color.Color
+}
+
+var (
+	loadFontsOnce         sync.Once
+	regularFont, boldFont *opentype.Font
+	fontsErr              error
+)
+
+func loadFonts() error {
+	loadFontsOnce.Do(func() {
+		if regularFont, fontsErr = opentype.Parse(goregular.TTF); fontsErr != nil {
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	if fontsErr != nil {
+		return errors.Wrap(fontsErr, "failed to parse fonts")
+	}
+	return nil
+}
+
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create font face")
+	}
+	return face, nil
+}
+
+// Draw renders given card as a PNG image
+func Draw(card *Card) ([]byte, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+
+	siteFace, err := newFace(regularFont, 40)
+	if err != nil {
+		return nil, err
+	}
+	titleFace, err := newFace(boldFont, 64)
+	if err != nil {
+		return nil, err
+	}
+	captionFace, err := newFace(regularFont, 40)
+	if err != nil {
+		return nil, err
+	}
+	labelFace, err := newFace(boldFont, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+
+	if card.LabelColor != nil {
+		draw.Draw(dst, image.Rect(0, 0, 16, Height), image.NewUniform(card.LabelColor), image.Point{}, draw.Src)
+	}
+
+	siteX := padding
+	if card.Logo != nil {
+		logo := image.Rect(padding, 64, padding+logoSize, 64+logoSize)
+		draw.Draw(dst, logo, card.Logo, card.Logo.Bounds().Min, draw.Over)
+		siteX += logoSize + 32
+	}
+	siteName := truncate(siteFace, card.SiteName, Width-padding-siteX)
+	drawText(dst, siteFace, textColor, siteName, siteX, 64+logoSize/2+14)
+
+	for i, line := range wrap(titleFace, card.Title, Width-2*padding, maxTitleLines) {
+		drawText(dst, titleFace, titleColor, line, padding, 300+i*80)
+	}
+
+	baseline := Height - padding
+	drawText(dst, captionFace, textColor, card.Caption, padding, baseline)
+
+	if card.Label != "" {
+		labelWidth := font.MeasureString(labelFace, card.Label).Ceil()
+		labelX := Width - padding - labelWidth - 48
+		background := image.Rect(labelX, baseline-44, Width-padding, baseline+16)
+		labelColor := card.LabelColor
+		if labelColor == nil {
+			labelColor = textColor
+		}
+		draw.Draw(dst, background, image.NewUniform(labelColor), image.Point{}, draw.Src)
+		drawText(dst, labelFace, labelTextColor, card.Label, labelX+24, baseline)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, dst); err != nil {
+		return nil, errors.Wrap(err, "failed to encode image")
+	}
+	return buf.Bytes(), nil
+}
+
+func drawText(dst draw.Image, face font.Face, c color.Color, text string, x, y int) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// wrap breaks text into lines that fit in given width, truncating it if there are more than maxLines
+func wrap(face font.Face, text string, width, maxLines int) []string {
+	lines := make([]string, 0)
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && font.MeasureString(face, candidate).Ceil() > width {
+			lines = append(lines, line)
+			line = word
+		} else {
+			line = candidate
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = truncate(face, lines[maxLines-1]+"…", width)
+	}
+
+	for i := range lines {
+		lines[i] = truncate(face, lines[i], width)
+	}
+	return lines
+}
+
+// truncate removes characters from the end of text until it fits in given width
+func truncate(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+
+	runes := []rune(strings.TrimSuffix(text, "…"))
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+	}
+	return ""
+}
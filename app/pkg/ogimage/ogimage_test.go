@@ -0,0 +1,91 @@
+package ogimage
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"golang.org/x/image/font"
+)
+
+func TestVotesBucket(t *testing.T) {
+	RegisterT(t)
+
+	Expect(VotesBucket(0)).Equals(0)
+	Expect(VotesBucket(9)).Equals(9)
+	Expect(VotesBucket(10)).Equals(10)
+	Expect(VotesBucket(24)).Equals(10)
+	Expect(VotesBucket(25)).Equals(25)
+	Expect(VotesBucket(999)).Equals(500)
+	Expect(VotesBucket(123456)).Equals(10000)
+}
+
+func TestPostBlobKey(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 4, Title: "Add dark mode", Status: enum.PostOpen, VotesCount: 11}
+	key := PostBlobKey(post, "")
+	Expect(strings.HasPrefix(key, "og/posts/4/")).IsTrue()
+	Expect(strings.HasSuffix(key, ".png")).IsTrue()
+
+	post.VotesCount = 12
+	Expect(PostBlobKey(post, "")).Equals(key)
+
+	post.VotesCount = 25
+	Expect(PostBlobKey(post, "")).NotEquals(key)
+
+	post.VotesCount = 11
+	post.Status = enum.PostPlanned
+	Expect(PostBlobKey(post, "")).NotEquals(key)
+
+	post.Status = enum.PostOpen
+	post.Title = "Add a dark mode"
+	Expect(PostBlobKey(post, "")).NotEquals(key)
+
+	post.Title = "Add dark mode"
+	Expect(PostBlobKey(post, "logos/new.png")).NotEquals(key)
+}
+
+func TestDraw(t *testing.T) {
+	RegisterT(t)
+
+	content, err := Draw(&Card{
+		SiteName:   "Demo",
+		Title:      "Add support for dark mode on all pages",
+		Caption:    "10+ votes",
+		Label:      "Planned",
+		LabelColor: StatusColor(enum.PostPlanned),
+	})
+	Expect(err).IsNil()
+
+	img, err := png.Decode(bytes.NewReader(content))
+	Expect(err).IsNil()
+	Expect(img.Bounds().Dx()).Equals(Width)
+	Expect(img.Bounds().Dy()).Equals(Height)
+}
+
+func TestWrap(t *testing.T) {
+	RegisterT(t)
+
+	Expect(loadFonts()).IsNil()
+	face, err := newFace(boldFont, 64)
+	Expect(err).IsNil()
+
+	lines := wrap(face, "Short title", 1040, 3)
+	Expect(lines).Equals([]string{"Short title"})
+
+	lines = wrap(face, strings.Repeat("very long title ", 50), 1040, 3)
+	Expect(lines).HasLen(3)
+	Expect(strings.HasSuffix(lines[2], "…")).IsTrue()
+	for _, line := range lines {
+		Expect(font.MeasureString(face, line).Ceil() <= 1040).IsTrue()
+	}
+
+	lines = wrap(face, strings.Repeat("x", 200), 1040, 3)
+	Expect(lines).HasLen(1)
+	Expect(strings.HasSuffix(lines[0], "…")).IsTrue()
+}
@@ -0,0 +1,49 @@
+package ogimage
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/crypto"
+)
+
+// voteBuckets are the thresholds shown on images once a post has more votes than the first one.
+// Images only need to be regenerated when a post moves into another bucket.
+var voteBuckets = []int{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// VotesBucket returns the vote count shown on the image of a post with given number of votes
+func VotesBucket(count int) int {
+	bucket := count
+	for _, threshold := range voteBuckets {
+		if count >= threshold {
+			bucket = threshold
+		}
+	}
+	return bucket
+}
+
+// PostVersion returns a fingerprint of everything rendered into the image of given post,
+// so that a new image is generated whenever any of it changes
+func PostVersion(post *entity.Post, logoBlobKey string) string {
+	return crypto.MD5(fmt.Sprintf("%s|%d|%d|%s", post.Title, post.Status, VotesBucket(post.VotesCount), logoBlobKey))[:12]
+}
+
+// PostBlobKey returns the key used to cache the image of given post in blob storage
+func PostBlobKey(post *entity.Post, logoBlobKey string) string {
+	return fmt.Sprintf("og/posts/%d/%s.png", post.ID, PostVersion(post, logoBlobKey))
+}
+
+var statusColors = map[enum.PostStatus]color.Color{
+	enum.PostStarted:   color.RGBA{0x25, 0x63, 0xeb, 0xff},
+	enum.PostCompleted: color.RGBA{0x16, 0xa3, 0x4a, 0xff},
+	enum.PostDeclined:  color.RGBA{0xdc, 0x26, 0x26, 0xff},
+	enum.PostPlanned:   color.RGBA{0x7c, 0x3a, 0xed, 0xff},
+	enum.PostDuplicate: color.RGBA{0x6b, 0x72, 0x80, 0xff},
+}
+
+// StatusColor returns the color used to highlight given post status, or nil for open posts
+func StatusColor(status enum.PostStatus) color.Color {
+	return statusColors[status]
+}
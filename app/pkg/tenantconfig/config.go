@@ -0,0 +1,160 @@
+package tenantconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the declarative configuration of a tenant. It does not include any content, like posts or users.
+// Sections that are omitted are left untouched when the configuration is applied,
+// while items missing from a section are deleted
+type Config struct {
+	Settings       *Settings        `json:"settings" yaml:"settings"`
+	Tags           []*Tag           `json:"tags" yaml:"tags"`
+	Webhooks       []*Webhook       `json:"webhooks" yaml:"webhooks"`
+	OAuthProviders []*OAuthProvider `json:"oauthProviders" yaml:"oauthProviders"`
+}
+
+// Settings are the site settings of a tenant
+// Custom domain and logo are environment specific and are not part of the configuration
+type Settings struct {
+	Title                 string                     `json:"title" yaml:"title"`
+	Invitation            string                     `json:"invitation" yaml:"invitation"`
+	WelcomeMessage        string                     `json:"welcomeMessage" yaml:"welcomeMessage"`
+	Locale                string                     `json:"locale" yaml:"locale"`
+	CustomCSS             string                     `json:"customCSS" yaml:"customCSS"`
+	PrioritizationFormula enum.PrioritizationFormula `json:"prioritizationFormula" yaml:"prioritizationFormula"`
+	IsPrivate             bool                       `json:"isPrivate" yaml:"isPrivate"`
+	IsEmailAuthAllowed    bool                       `json:"isEmailAuthAllowed" yaml:"isEmailAuthAllowed"`
+	EmailRules            EmailRules                 `json:"emailRules" yaml:"emailRules"`
+}
+
+// EmailRules restricts the email addresses that can be used on a tenant
+type EmailRules struct {
+	AllowedDomains  []string `json:"allowedDomains" yaml:"allowedDomains"`
+	BlockedDomains  []string `json:"blockedDomains" yaml:"blockedDomains"`
+	BlockDisposable bool     `json:"blockDisposable" yaml:"blockDisposable"`
+}
+
+// Tag is identified by the slug of its name
+type Tag struct {
+	Name     string `json:"name" yaml:"name"`
+	Color    string `json:"color" yaml:"color"`
+	IsPublic bool   `json:"isPublic" yaml:"isPublic"`
+}
+
+// Webhook is identified by its name
+// Values of HTTP headers are never exported, as they often hold credentials, and the current ones are kept when they're empty
+type Webhook struct {
+	Name        string             `json:"name" yaml:"name"`
+	Type        enum.WebhookType   `json:"type" yaml:"type"`
+	Status      enum.WebhookStatus `json:"status" yaml:"status"`
+	Format      enum.WebhookFormat `json:"format" yaml:"format"`
+	URL         string             `json:"url" yaml:"url"`
+	Content     string             `json:"content" yaml:"content"`
+	HTTPMethod  string             `json:"httpMethod" yaml:"httpMethod"`
+	HTTPHeaders map[string]string  `json:"httpHeaders,omitempty" yaml:"httpHeaders,omitempty"`
+	Conditions  WebhookConditions  `json:"conditions" yaml:"conditions"`
+}
+
+// WebhookConditions restricts the events that trigger a webhook
+type WebhookConditions struct {
+	IncludeTags []string `json:"includeTags,omitempty" yaml:"includeTags,omitempty"`
+	ExcludeTags []string `json:"excludeTags,omitempty" yaml:"excludeTags,omitempty"`
+	FromStatus  []string `json:"fromStatus,omitempty" yaml:"fromStatus,omitempty"`
+	ToStatus    []string `json:"toStatus,omitempty" yaml:"toStatus,omitempty"`
+	AuthorRoles []string `json:"authorRoles,omitempty" yaml:"authorRoles,omitempty"`
+	MinVotes    int      `json:"minVotes,omitempty" yaml:"minVotes,omitempty"`
+}
+
+// ToModel returns the conditions in the format stored on webhooks
+func (c WebhookConditions) ToModel() entity.WebhookConditions {
+	return entity.WebhookConditions{
+		IncludeTags: c.IncludeTags,
+		ExcludeTags: c.ExcludeTags,
+		FromStatus:  c.FromStatus,
+		ToStatus:    c.ToStatus,
+		AuthorRoles: c.AuthorRoles,
+		MinVotes:    c.MinVotes,
+	}
+}
+
+// OAuthProvider is a custom OAuth provider, identified by its display name
+// Client secret is never exported and the current one is kept when it's omitted
+type OAuthProvider struct {
+	DisplayName       string `json:"displayName" yaml:"displayName"`
+	Enabled           bool   `json:"enabled" yaml:"enabled"`
+	IsTrusted         bool   `json:"isTrusted" yaml:"isTrusted"`
+	ClientID          string `json:"clientID" yaml:"clientID"`
+	ClientSecret      string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	AuthorizeURL      string `json:"authorizeURL" yaml:"authorizeURL"`
+	TokenURL          string `json:"tokenURL" yaml:"tokenURL"`
+	ProfileURL        string `json:"profileURL" yaml:"profileURL"`
+	Scope             string `json:"scope" yaml:"scope"`
+	JSONUserIDPath    string `json:"jsonUserIDPath" yaml:"jsonUserIDPath"`
+	JSONUserNamePath  string `json:"jsonUserNamePath" yaml:"jsonUserNamePath"`
+	JSONUserEmailPath string `json:"jsonUserEmailPath" yaml:"jsonUserEmailPath"`
+}
+
+// Status returns the status of the provider as stored on custom OAuth configs
+func (p *OAuthProvider) Status() int {
+	if p.Enabled {
+		return enum.OAuthConfigEnabled
+	}
+	return enum.OAuthConfigDisabled
+}
+
+// Parse reads a configuration written in YAML or JSON
+// Unknown fields are rejected so that typos are not silently ignored
+func Parse(data []byte) (*Config, error) {
+	config := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant configuration")
+	}
+
+	if config.Settings != nil {
+		rules := &config.Settings.EmailRules
+		for i := range rules.AllowedDomains {
+			rules.AllowedDomains[i] = strings.ToLower(rules.AllowedDomains[i])
+		}
+		for i := range rules.BlockedDomains {
+			rules.BlockedDomains[i] = strings.ToLower(rules.BlockedDomains[i])
+		}
+	}
+	for _, tag := range config.Tags {
+		if tag != nil {
+			tag.Color = strings.ToUpper(tag.Color)
+		}
+	}
+	for _, webhook := range config.Webhooks {
+		if webhook != nil && webhook.Format == 0 {
+			webhook.Format = enum.WebhookFormatTemplate
+		}
+	}
+	return config, nil
+}
+
+// Marshal writes given configuration as "json" or "yaml"
+func Marshal(config *Config, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(config, "", "  ")
+	case "yaml":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(config); err != nil {
+			return nil, errors.Wrap(err, "failed to write tenant configuration")
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, errors.New("unknown format '%s'", format)
+}
@@ -0,0 +1,91 @@
+package tenantconfig_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/tenantconfig"
+)
+
+func TestParse_YAML(t *testing.T) {
+	RegisterT(t)
+
+	config, err := tenantconfig.Parse([]byte(`
+settings:
+  title: Feedback
+  locale: en
+  prioritizationFormula: ice
+  emailRules:
+    allowedDomains: [Example.COM]
+tags:
+  - name: Bug
+    color: ff0000
+    isPublic: true
+webhooks:
+  - name: Slack
+    type: new_post
+    status: enabled
+    url: https://example.com
+    httpMethod: POST
+`))
+	Expect(err).IsNil()
+	Expect(config.Settings.Title).Equals("Feedback")
+	Expect(config.Settings.PrioritizationFormula).Equals(enum.PrioritizationICE)
+	Expect(config.Settings.EmailRules.AllowedDomains).Equals([]string{"example.com"})
+	Expect(config.Tags).HasLen(1)
+	Expect(config.Tags[0].Color).Equals("FF0000")
+	Expect(config.Webhooks[0].Type).Equals(enum.WebhookNewPost)
+	Expect(config.Webhooks[0].Format).Equals(enum.WebhookFormatTemplate)
+	Expect(config.OAuthProviders).IsNil()
+}
+
+func TestParse_JSON(t *testing.T) {
+	RegisterT(t)
+
+	config, err := tenantconfig.Parse([]byte(`{ "tags": [], "oauthProviders": [{ "displayName": "Okta", "enabled": true }] }`))
+	Expect(err).IsNil()
+	Expect(config.Settings).IsNil()
+	Expect(config.Tags).HasLen(0)
+	Expect(config.Tags).IsNotNil()
+	Expect(config.OAuthProviders[0].DisplayName).Equals("Okta")
+	Expect(config.OAuthProviders[0].Status()).Equals(enum.OAuthConfigEnabled)
+}
+
+func TestParse_UnknownField(t *testing.T) {
+	RegisterT(t)
+
+	config, err := tenantconfig.Parse([]byte(`{ "tgas": [] }`))
+	Expect(err).IsNotNil()
+	Expect(config).IsNil()
+}
+
+func TestMarshal(t *testing.T) {
+	RegisterT(t)
+
+	config := &tenantconfig.Config{
+		Settings: &tenantconfig.Settings{
+			Title:                 "Feedback",
+			PrioritizationFormula: enum.PrioritizationRICE,
+			EmailRules:            tenantconfig.EmailRules{AllowedDomains: []string{}, BlockedDomains: []string{"example.com"}},
+		},
+		Tags:     []*tenantconfig.Tag{{Name: "Bug", Color: "FF0000", IsPublic: true}},
+		Webhooks: []*tenantconfig.Webhook{},
+		OAuthProviders: []*tenantconfig.OAuthProvider{
+			{DisplayName: "Okta", Enabled: true},
+		},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		content, err := tenantconfig.Marshal(config, format)
+		Expect(err).IsNil()
+
+		parsed, err := tenantconfig.Parse(content)
+		Expect(err).IsNil()
+		Expect(parsed).Equals(config)
+	}
+
+	content, err := tenantconfig.Marshal(config, "xml")
+	Expect(err).IsNotNil()
+	Expect(content).IsNil()
+}
@@ -0,0 +1,123 @@
+package tenantconfig
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// Export returns the current configuration of the tenant in the context
+func Export(ctx context.Context) (*Config, error) {
+	tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if !ok {
+		return nil, errors.New("tenant is required to export its configuration")
+	}
+
+	getTags := &query.GetAllTags{}
+	listWebhooks := &query.ListAllWebhooks{}
+	listOAuthConfigs := &query.ListCustomOAuthConfig{}
+	if err := bus.Dispatch(ctx, getTags, listWebhooks, listOAuthConfigs); err != nil {
+		return nil, err
+	}
+
+	config := &Config{
+		Settings:       exportSettings(tenant),
+		Tags:           make([]*Tag, 0, len(getTags.Result)),
+		Webhooks:       make([]*Webhook, 0, len(listWebhooks.Result)),
+		OAuthProviders: make([]*OAuthProvider, 0, len(listOAuthConfigs.Result)),
+	}
+
+	for _, tag := range getTags.Result {
+		config.Tags = append(config.Tags, exportTag(tag))
+	}
+
+	for _, webhook := range listWebhooks.Result {
+		config.Webhooks = append(config.Webhooks, exportWebhook(webhook))
+	}
+
+	for _, oauthConfig := range listOAuthConfigs.Result {
+		config.OAuthProviders = append(config.OAuthProviders, exportOAuthProvider(oauthConfig))
+	}
+
+	return config, nil
+}
+
+func exportSettings(tenant *entity.Tenant) *Settings {
+	return &Settings{
+		Title:                 tenant.Name,
+		Invitation:            tenant.Invitation,
+		WelcomeMessage:        tenant.WelcomeMessage,
+		Locale:                tenant.Locale,
+		CustomCSS:             tenant.CustomCSS,
+		PrioritizationFormula: tenant.PrioritizationFormula,
+		IsPrivate:             tenant.IsPrivate,
+		IsEmailAuthAllowed:    tenant.IsEmailAuthAllowed,
+		EmailRules: EmailRules{
+			AllowedDomains:  nonNil(tenant.EmailRules.AllowedDomains),
+			BlockedDomains:  nonNil(tenant.EmailRules.BlockedDomains),
+			BlockDisposable: tenant.EmailRules.BlockDisposable,
+		},
+	}
+}
+
+func exportTag(tag *entity.Tag) *Tag {
+	return &Tag{
+		Name:     tag.Name,
+		Color:    tag.Color,
+		IsPublic: tag.IsPublic,
+	}
+}
+
+func exportWebhook(webhook *entity.Webhook) *Webhook {
+	headers := make(map[string]string, len(webhook.HttpHeaders))
+	for name := range webhook.HttpHeaders {
+		headers[name] = ""
+	}
+
+	return &Webhook{
+		Name:        webhook.Name,
+		Type:        webhook.Type,
+		Status:      webhook.Status,
+		Format:      webhook.Format,
+		URL:         webhook.Url,
+		Content:     webhook.Content,
+		HTTPMethod:  webhook.HttpMethod,
+		HTTPHeaders: headers,
+		Conditions: WebhookConditions{
+			IncludeTags: webhook.Conditions.IncludeTags,
+			ExcludeTags: webhook.Conditions.ExcludeTags,
+			FromStatus:  webhook.Conditions.FromStatus,
+			ToStatus:    webhook.Conditions.ToStatus,
+			AuthorRoles: webhook.Conditions.AuthorRoles,
+			MinVotes:    webhook.Conditions.MinVotes,
+		},
+	}
+}
+
+func exportOAuthProvider(config *entity.OAuthConfig) *OAuthProvider {
+	return &OAuthProvider{
+		DisplayName:       config.DisplayName,
+		Enabled:           config.Status == enum.OAuthConfigEnabled,
+		IsTrusted:         config.IsTrusted,
+		ClientID:          config.ClientID,
+		AuthorizeURL:      config.AuthorizeURL,
+		TokenURL:          config.TokenURL,
+		ProfileURL:        config.ProfileURL,
+		Scope:             config.Scope,
+		JSONUserIDPath:    config.JSONUserIDPath,
+		JSONUserNamePath:  config.JSONUserNamePath,
+		JSONUserEmailPath: config.JSONUserEmailPath,
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,433 @@
+package tenantconfig
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/gosimple/slug"
+)
+
+// Actions used on a Change
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Kinds of objects used on a Change
+const (
+	KindSettings      = "settings"
+	KindTag           = "tag"
+	KindWebhook       = "webhook"
+	KindOAuthProvider = "oauthProvider"
+)
+
+// Change is a single operation needed to bring a tenant to a desired configuration
+type Change struct {
+	Action string   `json:"action"`
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Fields []string `json:"fields,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// String returns a human readable description of the change, like "~ tag bug (color)"
+func (c *Change) String() string {
+	symbol := map[string]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[c.Action]
+	text := fmt.Sprintf("%s %s %s", symbol, c.Kind, c.Name)
+	if len(c.Fields) > 0 {
+		text += fmt.Sprintf(" (%s)", strings.Join(c.Fields, ", "))
+	}
+	return text
+}
+
+// Plan compares given configuration with the current configuration of the tenant in the context
+// and returns the changes needed to make them equal. Nothing is changed until the plan is applied.
+// Configuration is expected to be validated before it's planned
+func Plan(ctx context.Context, config *Config) ([]*Change, error) {
+	tenant := currentTenant(ctx)
+	if tenant == nil {
+		return nil, errors.New("tenant is required to plan its configuration")
+	}
+
+	changes := make([]*Change, 0)
+	if config.Settings != nil {
+		changes = append(changes, planSettings(exportSettings(tenant), config.Settings)...)
+	}
+
+	if config.Tags != nil {
+		tagChanges, err := planTags(ctx, config.Tags)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, tagChanges...)
+	}
+
+	if config.Webhooks != nil {
+		webhookChanges, err := planWebhooks(ctx, config.Webhooks)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, webhookChanges...)
+	}
+
+	if config.OAuthProviders != nil {
+		oauthChanges, err := planOAuthProviders(ctx, config.OAuthProviders)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, oauthChanges...)
+	}
+
+	return changes, nil
+}
+
+// Apply executes all given changes in order and stops on the first error
+// It should be called within a transaction, so that a failure doesn't leave the tenant half configured
+func Apply(ctx context.Context, changes []*Change) error {
+	for _, change := range changes {
+		if err := change.apply(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func planSettings(current, desired *Settings) []*Change {
+	changes := make([]*Change, 0)
+
+	fields := diff(
+		"title", current.Title == desired.Title,
+		"invitation", current.Invitation == desired.Invitation,
+		"welcomeMessage", current.WelcomeMessage == desired.WelcomeMessage,
+		"locale", current.Locale == desired.Locale,
+	)
+	if len(fields) > 0 {
+		changes = append(changes, &Change{
+			Action: ActionUpdate, Kind: KindSettings, Name: "general", Fields: fields,
+			apply: func(ctx context.Context) error {
+				tenant := currentTenant(ctx)
+				return bus.Dispatch(ctx, &cmd.UpdateTenantSettings{
+					Logo:           &dto.ImageUpload{BlobKey: tenant.LogoBlobKey},
+					Title:          desired.Title,
+					Invitation:     desired.Invitation,
+					WelcomeMessage: desired.WelcomeMessage,
+					CNAME:          tenant.CNAME,
+					Locale:         desired.Locale,
+				})
+			},
+		})
+	}
+
+	fields = diff(
+		"customCSS", current.CustomCSS == desired.CustomCSS,
+		"prioritizationFormula", current.PrioritizationFormula == desired.PrioritizationFormula,
+	)
+	if len(fields) > 0 {
+		changes = append(changes, &Change{
+			Action: ActionUpdate, Kind: KindSettings, Name: "advanced", Fields: fields,
+			apply: func(ctx context.Context) error {
+				return bus.Dispatch(ctx, &cmd.UpdateTenantAdvancedSettings{
+					CustomCSS:             desired.CustomCSS,
+					PrioritizationFormula: desired.PrioritizationFormula,
+				})
+			},
+		})
+	}
+
+	if current.IsPrivate != desired.IsPrivate {
+		changes = append(changes, &Change{
+			Action: ActionUpdate, Kind: KindSettings, Name: "privacy", Fields: []string{"isPrivate"},
+			apply: func(ctx context.Context) error {
+				return bus.Dispatch(ctx, &cmd.UpdateTenantPrivacySettings{IsPrivate: desired.IsPrivate})
+			},
+		})
+	}
+
+	if current.IsEmailAuthAllowed != desired.IsEmailAuthAllowed {
+		changes = append(changes, &Change{
+			Action: ActionUpdate, Kind: KindSettings, Name: "emailAuth", Fields: []string{"isEmailAuthAllowed"},
+			apply: func(ctx context.Context) error {
+				return bus.Dispatch(ctx, &cmd.UpdateTenantEmailAuthAllowedSettings{IsEmailAuthAllowed: desired.IsEmailAuthAllowed})
+			},
+		})
+	}
+
+	fields = diff(
+		"allowedDomains", slices.Equal(current.EmailRules.AllowedDomains, desired.EmailRules.AllowedDomains),
+		"blockedDomains", slices.Equal(current.EmailRules.BlockedDomains, desired.EmailRules.BlockedDomains),
+		"blockDisposable", current.EmailRules.BlockDisposable == desired.EmailRules.BlockDisposable,
+	)
+	if len(fields) > 0 {
+		changes = append(changes, &Change{
+			Action: ActionUpdate, Kind: KindSettings, Name: "emailRules", Fields: fields,
+			apply: func(ctx context.Context) error {
+				return bus.Dispatch(ctx, &cmd.UpdateTenantEmailRules{
+					Rules: entity.TenantEmailRules{
+						AllowedDomains:  desired.EmailRules.AllowedDomains,
+						BlockedDomains:  desired.EmailRules.BlockedDomains,
+						BlockDisposable: desired.EmailRules.BlockDisposable,
+					},
+				})
+			},
+		})
+	}
+
+	return changes
+}
+
+func planTags(ctx context.Context, desired []*Tag) ([]*Change, error) {
+	getTags := &query.GetAllTags{}
+	if err := bus.Dispatch(ctx, getTags); err != nil {
+		return nil, err
+	}
+
+	changes := make([]*Change, 0)
+	for _, tag := range desired {
+		idx := slices.IndexFunc(getTags.Result, func(t *entity.Tag) bool { return t.Slug == slug.Make(tag.Name) })
+		if idx < 0 {
+			changes = append(changes, &Change{
+				Action: ActionCreate, Kind: KindTag, Name: tag.Name,
+				apply: func(ctx context.Context) error {
+					return bus.Dispatch(ctx, &cmd.AddNewTag{Name: tag.Name, Color: tag.Color, IsPublic: tag.IsPublic})
+				},
+			})
+			continue
+		}
+
+		existing := getTags.Result[idx]
+		fields := diff(
+			"name", existing.Name == tag.Name,
+			"color", existing.Color == tag.Color,
+			"isPublic", existing.IsPublic == tag.IsPublic,
+		)
+		if len(fields) > 0 {
+			changes = append(changes, &Change{
+				Action: ActionUpdate, Kind: KindTag, Name: tag.Name, Fields: fields,
+				apply: func(ctx context.Context) error {
+					return bus.Dispatch(ctx, &cmd.UpdateTag{TagID: existing.ID, Name: tag.Name, Color: tag.Color, IsPublic: tag.IsPublic})
+				},
+			})
+		}
+	}
+
+	for _, existing := range getTags.Result {
+		if !slices.ContainsFunc(desired, func(t *Tag) bool { return slug.Make(t.Name) == existing.Slug }) {
+			changes = append(changes, &Change{
+				Action: ActionDelete, Kind: KindTag, Name: existing.Name,
+				apply: func(ctx context.Context) error {
+					return bus.Dispatch(ctx, &cmd.DeleteTag{Tag: existing})
+				},
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func planWebhooks(ctx context.Context, desired []*Webhook) ([]*Change, error) {
+	listWebhooks := &query.ListAllWebhooks{}
+	if err := bus.Dispatch(ctx, listWebhooks); err != nil {
+		return nil, err
+	}
+
+	changes := make([]*Change, 0)
+	for _, webhook := range desired {
+		var existing *entity.Webhook
+		if idx := slices.IndexFunc(listWebhooks.Result, func(w *entity.Webhook) bool { return w.Name == webhook.Name }); idx >= 0 {
+			existing = listWebhooks.Result[idx]
+		}
+
+		headers := webhook.HTTPHeaders
+		if existing != nil {
+			headers = WebhookHeaders(webhook.HTTPHeaders, existing.HttpHeaders)
+		}
+
+		save := func(id int) func(ctx context.Context) error {
+			return func(ctx context.Context) error {
+				return bus.Dispatch(ctx, &query.CreateEditWebhook{
+					ID:          id,
+					Name:        webhook.Name,
+					Type:        webhook.Type,
+					Status:      webhook.Status,
+					Format:      webhook.Format,
+					Url:         webhook.URL,
+					Content:     webhook.Content,
+					HttpMethod:  webhook.HTTPMethod,
+					HttpHeaders: headers,
+					Conditions:  webhook.Conditions.ToModel(),
+				})
+			}
+		}
+
+		if existing == nil {
+			changes = append(changes, &Change{Action: ActionCreate, Kind: KindWebhook, Name: webhook.Name, apply: save(0)})
+			continue
+		}
+
+		fields := diff(
+			"type", existing.Type == webhook.Type,
+			"status", existing.Status == webhook.Status,
+			"format", existing.Format == webhook.Format,
+			"url", existing.Url == webhook.URL,
+			"content", existing.Content == webhook.Content,
+			"httpMethod", existing.HttpMethod == webhook.HTTPMethod,
+			"httpHeaders", maps.Equal(existing.HttpHeaders, headers),
+			"conditions", equalConditions(existing.Conditions, webhook.Conditions.ToModel()),
+		)
+		if len(fields) > 0 {
+			changes = append(changes, &Change{Action: ActionUpdate, Kind: KindWebhook, Name: webhook.Name, Fields: fields, apply: save(existing.ID)})
+		}
+	}
+
+	for _, existing := range listWebhooks.Result {
+		if !slices.ContainsFunc(desired, func(w *Webhook) bool { return w.Name == existing.Name }) {
+			changes = append(changes, &Change{
+				Action: ActionDelete, Kind: KindWebhook, Name: existing.Name,
+				apply: func(ctx context.Context) error {
+					return bus.Dispatch(ctx, &query.DeleteWebhook{ID: existing.ID})
+				},
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// WebhookHeaders keeps the current value of the headers without one, and all current headers when none is given
+func WebhookHeaders(desired, existing map[string]string) map[string]string {
+	if desired == nil {
+		return existing
+	}
+
+	headers := make(map[string]string, len(desired))
+	for name, value := range desired {
+		if value == "" {
+			value = existing[name]
+		}
+		headers[name] = value
+	}
+	return headers
+}
+
+func planOAuthProviders(ctx context.Context, desired []*OAuthProvider) ([]*Change, error) {
+	listConfigs := &query.ListCustomOAuthConfig{}
+	if err := bus.Dispatch(ctx, listConfigs); err != nil {
+		return nil, err
+	}
+
+	changes := make([]*Change, 0)
+	for _, provider := range desired {
+		idx := slices.IndexFunc(listConfigs.Result, func(c *entity.OAuthConfig) bool { return c.DisplayName == provider.DisplayName })
+		if idx < 0 {
+			changes = append(changes, &Change{
+				Action: ActionCreate, Kind: KindOAuthProvider, Name: provider.DisplayName,
+				apply: func(ctx context.Context) error {
+					return bus.Dispatch(ctx, saveOAuthProvider(provider, &entity.OAuthConfig{
+						Provider: "_" + strings.ToLower(rand.String(10)),
+					}))
+				},
+			})
+			continue
+		}
+
+		existing := listConfigs.Result[idx]
+		fields := diff(
+			"enabled", existing.Status == provider.Status(),
+			"isTrusted", existing.IsTrusted == provider.IsTrusted,
+			"clientID", existing.ClientID == provider.ClientID,
+			"clientSecret", provider.ClientSecret == "" || existing.ClientSecret == provider.ClientSecret,
+			"authorizeURL", existing.AuthorizeURL == provider.AuthorizeURL,
+			"tokenURL", existing.TokenURL == provider.TokenURL,
+			"profileURL", existing.ProfileURL == provider.ProfileURL,
+			"scope", existing.Scope == provider.Scope,
+			"jsonUserIDPath", existing.JSONUserIDPath == provider.JSONUserIDPath,
+			"jsonUserNamePath", existing.JSONUserNamePath == provider.JSONUserNamePath,
+			"jsonUserEmailPath", existing.JSONUserEmailPath == provider.JSONUserEmailPath,
+		)
+		if len(fields) > 0 {
+			changes = append(changes, &Change{
+				Action: ActionUpdate, Kind: KindOAuthProvider, Name: provider.DisplayName, Fields: fields,
+				apply: func(ctx context.Context) error {
+					return bus.Dispatch(ctx, saveOAuthProvider(provider, existing))
+				},
+			})
+		}
+	}
+
+	for _, existing := range listConfigs.Result {
+		if !slices.ContainsFunc(desired, func(p *OAuthProvider) bool { return p.DisplayName == existing.DisplayName }) {
+			changes = append(changes, &Change{
+				Action: ActionDelete, Kind: KindOAuthProvider, Name: existing.DisplayName,
+				apply: func(ctx context.Context) error {
+					return bus.Dispatch(ctx, &cmd.DeleteCustomOAuthConfig{Provider: existing.Provider})
+				},
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// saveOAuthProvider keeps the provider key, logo and client secret of the existing config
+func saveOAuthProvider(provider *OAuthProvider, existing *entity.OAuthConfig) *cmd.SaveCustomOAuthConfig {
+	secret := provider.ClientSecret
+	if secret == "" {
+		secret = existing.ClientSecret
+	}
+
+	return &cmd.SaveCustomOAuthConfig{
+		ID:                existing.ID,
+		Logo:              &dto.ImageUpload{BlobKey: existing.LogoBlobKey},
+		Provider:          existing.Provider,
+		Status:            provider.Status(),
+		DisplayName:       provider.DisplayName,
+		ClientID:          provider.ClientID,
+		ClientSecret:      secret,
+		AuthorizeURL:      provider.AuthorizeURL,
+		TokenURL:          provider.TokenURL,
+		Scope:             provider.Scope,
+		ProfileURL:        provider.ProfileURL,
+		IsTrusted:         provider.IsTrusted,
+		JSONUserIDPath:    provider.JSONUserIDPath,
+		JSONUserNamePath:  provider.JSONUserNamePath,
+		JSONUserEmailPath: provider.JSONUserEmailPath,
+	}
+}
+
+func equalConditions(a, b entity.WebhookConditions) bool {
+	return slices.Equal(a.IncludeTags, b.IncludeTags) &&
+		slices.Equal(a.ExcludeTags, b.ExcludeTags) &&
+		slices.Equal(a.FromStatus, b.FromStatus) &&
+		slices.Equal(a.ToStatus, b.ToStatus) &&
+		slices.Equal(a.AuthorRoles, b.AuthorRoles) &&
+		a.MinVotes == b.MinVotes
+}
+
+// diff receives pairs of field name and whether it's unchanged and returns the names of changed fields
+func diff(pairs ...any) []string {
+	fields := make([]string, 0)
+	for i := 0; i < len(pairs); i += 2 {
+		if equal := pairs[i+1].(bool); !equal {
+			fields = append(fields, pairs[i].(string))
+		}
+	}
+	return fields
+}
+
+func currentTenant(ctx context.Context) *entity.Tenant {
+	tenant, _ := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	return tenant
+}
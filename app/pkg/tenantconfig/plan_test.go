@@ -0,0 +1,206 @@
+package tenantconfig_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/tenantconfig"
+)
+
+func setupCurrentConfig() context.Context {
+	tenant := &entity.Tenant{
+		ID:                    1,
+		Name:                  "Demonstration",
+		Locale:                "en",
+		CNAME:                 "feedback.demo.com",
+		LogoBlobKey:           "logos/demo.png",
+		PrioritizationFormula: enum.PrioritizationRICE,
+		IsEmailAuthAllowed:    true,
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		q.Result = []*entity.Tag{
+			{ID: 1, Name: "Bug", Slug: "bug", Color: "FF0000", IsPublic: true},
+			{ID: 2, Name: "Legacy", Slug: "legacy", Color: "CCCCCC", IsPublic: false},
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListAllWebhooks) error {
+		q.Result = []*entity.Webhook{
+			{ID: 5, Name: "Slack", Type: enum.WebhookNewPost, Status: enum.WebhookEnabled, Format: enum.WebhookFormatTemplate, Url: "https://hooks.slack.com/1", HttpMethod: "POST", HttpHeaders: entity.HttpHeaders{"Authorization": "Bearer s3cr3t"}},
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListCustomOAuthConfig) error {
+		q.Result = []*entity.OAuthConfig{
+			{ID: 3, Provider: "_abc", DisplayName: "Okta", Status: enum.OAuthConfigEnabled, ClientID: "id", ClientSecret: "secret", LogoBlobKey: "logos/okta.png"},
+		}
+		return nil
+	})
+
+	return context.WithValue(context.Background(), app.TenantCtxKey, tenant)
+}
+
+func TestPlan_ExportedConfig_NoChanges(t *testing.T) {
+	RegisterT(t)
+	ctx := setupCurrentConfig()
+
+	config, err := tenantconfig.Export(ctx)
+	Expect(err).IsNil()
+	Expect(config.Settings.Title).Equals("Demonstration")
+	Expect(config.Tags).HasLen(2)
+	Expect(config.OAuthProviders[0].ClientSecret).Equals("")
+	Expect(config.Webhooks[0].HTTPHeaders).Equals(map[string]string{"Authorization": ""})
+
+	changes, err := tenantconfig.Plan(ctx, config)
+	Expect(err).IsNil()
+	Expect(changes).HasLen(0)
+}
+
+func TestPlan_OmittedSections_NoChanges(t *testing.T) {
+	RegisterT(t)
+	ctx := setupCurrentConfig()
+
+	changes, err := tenantconfig.Plan(ctx, &tenantconfig.Config{})
+	Expect(err).IsNil()
+	Expect(changes).HasLen(0)
+}
+
+func TestPlan_CreateUpdateDelete(t *testing.T) {
+	RegisterT(t)
+	ctx := setupCurrentConfig()
+
+	config, err := tenantconfig.Export(ctx)
+	Expect(err).IsNil()
+
+	config.Settings.Title = "Feedback"
+	config.Settings.IsPrivate = true
+	config.Tags = []*tenantconfig.Tag{
+		{Name: "Bug", Color: "00FF00", IsPublic: true},
+		{Name: "Feature", Color: "0000FF", IsPublic: true},
+	}
+	config.Webhooks = []*tenantconfig.Webhook{}
+	config.OAuthProviders[0].ClientSecret = "new-secret"
+
+	changes, err := tenantconfig.Plan(ctx, config)
+	Expect(err).IsNil()
+
+	descriptions := make([]string, len(changes))
+	for i, change := range changes {
+		descriptions[i] = change.String()
+	}
+	Expect(descriptions).Equals([]string{
+		"~ settings general (title)",
+		"~ settings privacy (isPrivate)",
+		"~ tag Bug (color)",
+		"+ tag Feature",
+		"- tag Legacy",
+		"- webhook Slack",
+		"~ oauthProvider Okta (clientSecret)",
+	})
+}
+
+func TestApply(t *testing.T) {
+	RegisterT(t)
+	ctx := setupCurrentConfig()
+
+	var updateSettings *cmd.UpdateTenantSettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTenantSettings) error {
+		updateSettings = c
+		return nil
+	})
+
+	var updateTag *cmd.UpdateTag
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTag) error {
+		updateTag = c
+		return nil
+	})
+
+	var deleteWebhook *query.DeleteWebhook
+	bus.AddHandler(func(ctx context.Context, q *query.DeleteWebhook) error {
+		deleteWebhook = q
+		return nil
+	})
+
+	var saveOAuth *cmd.SaveCustomOAuthConfig
+	bus.AddHandler(func(ctx context.Context, c *cmd.SaveCustomOAuthConfig) error {
+		saveOAuth = c
+		return nil
+	})
+
+	var deleteOAuth *cmd.DeleteCustomOAuthConfig
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteCustomOAuthConfig) error {
+		deleteOAuth = c
+		return nil
+	})
+
+	config, _ := tenantconfig.Export(ctx)
+	config.Settings.Title = "Feedback"
+	config.Tags[0].Color = "00FF00"
+	config.Webhooks = []*tenantconfig.Webhook{}
+	config.OAuthProviders = []*tenantconfig.OAuthProvider{
+		{DisplayName: "Auth0", Enabled: true, ClientID: "auth0", ClientSecret: "s3cr3t"},
+	}
+
+	changes, err := tenantconfig.Plan(ctx, config)
+	Expect(err).IsNil()
+	Expect(changes).HasLen(5)
+
+	err = tenantconfig.Apply(ctx, changes)
+	Expect(err).IsNil()
+
+	Expect(updateSettings.Title).Equals("Feedback")
+	Expect(updateSettings.CNAME).Equals("feedback.demo.com")
+	Expect(updateSettings.Logo.BlobKey).Equals("logos/demo.png")
+	Expect(updateTag.TagID).Equals(1)
+	Expect(updateTag.Color).Equals("00FF00")
+	Expect(deleteWebhook.ID).Equals(5)
+	Expect(saveOAuth.ID).Equals(0)
+	Expect(saveOAuth.DisplayName).Equals("Auth0")
+	Expect(saveOAuth.ClientSecret).Equals("s3cr3t")
+	Expect(saveOAuth.Provider).IsNotEmpty()
+	Expect(deleteOAuth.Provider).Equals("_abc")
+}
+
+func TestApply_WebhookHeaders(t *testing.T) {
+	RegisterT(t)
+	ctx := setupCurrentConfig()
+
+	var saveWebhook *query.CreateEditWebhook
+	bus.AddHandler(func(ctx context.Context, q *query.CreateEditWebhook) error {
+		saveWebhook = q
+		return nil
+	})
+
+	config, _ := tenantconfig.Export(ctx)
+	config.Webhooks[0].URL = "https://hooks.slack.com/2"
+	config.Webhooks[0].HTTPHeaders["X-Team"] = "core"
+
+	changes, err := tenantconfig.Plan(ctx, config)
+	Expect(err).IsNil()
+	Expect(changes).HasLen(1)
+	Expect(changes[0].String()).Equals("~ webhook Slack (url, httpHeaders)")
+
+	err = tenantconfig.Apply(ctx, changes)
+	Expect(err).IsNil()
+	Expect(saveWebhook.ID).Equals(5)
+	Expect(saveWebhook.HttpHeaders).Equals(entity.HttpHeaders{"Authorization": "Bearer s3cr3t", "X-Team": "core"})
+
+	config.Webhooks[0].HTTPHeaders = nil
+	changes, err = tenantconfig.Plan(ctx, config)
+	Expect(err).IsNil()
+	Expect(changes).HasLen(1)
+
+	err = tenantconfig.Apply(ctx, changes)
+	Expect(err).IsNil()
+	Expect(saveWebhook.HttpHeaders).Equals(entity.HttpHeaders{"Authorization": "Bearer s3cr3t"})
+}
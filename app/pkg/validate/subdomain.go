@@ -9,6 +9,7 @@ import (
 
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 )
 
 var domainRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9-]+[a-zA-Z0-9]$")
@@ -38,6 +39,10 @@ func Subdomain(ctx context.Context, subdomain string) ([]string, error) {
 		return []string{fmt.Sprintf("%s is a reserved subdomain.", subdomain)}, nil
 	}
 
+	if env.IsBlockedSubdomain(subdomain) {
+		return []string{"This subdomain is not allowed."}, nil
+	}
+
 	isAvailable := &query.IsSubdomainAvailable{Subdomain: subdomain}
 	if err := bus.Dispatch(ctx, isAvailable); err != nil {
 		return nil, err
@@ -8,6 +8,7 @@ import (
 
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/validate"
 )
 
@@ -59,3 +60,21 @@ func TestValidSubdomains_Availability(t *testing.T) {
 		Expect(err).IsNil()
 	}
 }
+
+func TestBlockedSubdomains(t *testing.T) {
+	RegisterT(t)
+	env.Config.SignUp.BlockedSubdomains = "casino"
+
+	bus.AddHandler(func(ctx context.Context, q *query.IsSubdomainAvailable) error {
+		q.Result = true
+		return nil
+	})
+
+	messages, err := validate.Subdomain(context.Background(), "best-casino")
+	Expect(messages).Equals([]string{"This subdomain is not allowed."})
+	Expect(err).IsNil()
+
+	messages, err = validate.Subdomain(context.Background(), "my-company")
+	Expect(messages).HasLen(0)
+	Expect(err).IsNil()
+}
@@ -38,6 +38,7 @@ type StringMap map[string]string
 type Props struct {
 	Title       string
 	Description string
+	Image       string
 	Page        string
 	Data        Map
 }
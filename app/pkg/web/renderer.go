@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
 
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
@@ -165,6 +166,9 @@ func (r *Renderer) Render(w io.Writer, statusCode int, props Props, ctx *Context
 
 	private["assets"] = r.assets
 	private["logo"] = LogoURL(ctx)
+	if props.Image != "" {
+		private["image"] = props.Image
+	}
 
 	locale := i18n.GetLocale(ctx)
 	localeChunkName := fmt.Sprintf("locale-%s-client-json", locale)
@@ -197,6 +201,18 @@ func (r *Renderer) Render(w io.Writer, statusCode int, props Props, ctx *Context
 		}
 	}
 
+	if tenant != nil && statusCode >= 200 && statusCode < 300 {
+		announcements := &query.ListAnnouncements{
+			ActiveOnly: true,
+			Result:     make([]*entity.Announcement, 0),
+		}
+		err = bus.Dispatch(ctx, announcements)
+		if err != nil {
+			panic(errors.Wrap(err, "failed to get list of announcements"))
+		}
+		public["announcements"] = announcements.Result
+	}
+
 	public["page"] = props.Page
 	public["contextID"] = ctx.ContextID()
 	public["sessionID"] = ctx.SessionID()
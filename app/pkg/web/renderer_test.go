@@ -62,6 +62,13 @@ func TestRenderer_Tenant(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.ListAnnouncements) error {
+		q.Result = []*entity.Announcement{
+			{ID: 1, Text: "We're migrating on **Friday**", Severity: enum.AnnouncementWarning, Audience: enum.AnnouncementEveryone, Dismissible: true},
+		}
+		return nil
+	})
+
 	buf := new(bytes.Buffer)
 	ctx := newGetContext("https://demo.test.fider.io:3000/", nil)
 	ctx.SetTenant(&entity.Tenant{Name: "Game of Thrones"})
@@ -115,6 +122,10 @@ func TestRenderer_Home_SSR(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.ListAnnouncements) error {
+		return nil
+	})
+
 	buf := new(bytes.Buffer)
 	ctx := newGetContext("https://demo.test.fider.io:3000/", map[string]string{
 		"User-Agent": "Googlebot",
@@ -2,6 +2,7 @@ package web
 
 import (
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -21,6 +22,7 @@ type Request struct {
 	IsSecure      bool
 	StartTime     time.Time
 	URL           *url.URL
+	ClientIP      string
 }
 
 // WrapRequest returns Fider wrapper of HTTP Request
@@ -57,9 +59,55 @@ func WrapRequest(request *http.Request) Request {
 		URL:           u,
 		IsSecure:      protocol == "https",
 		StartTime:     time.Now(),
+		ClientIP:      clientIP(request),
 	}
 }
 
+// clientIP returns the address of the client. X-Forwarded-For is only used when
+// the request comes from a trusted proxy, in which case the rightmost address
+// that is not a trusted proxy is the client
+func clientIP(request *http.Request) string {
+	remoteIP, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		remoteIP = request.RemoteAddr
+	}
+
+	if !isTrustedProxy(remoteIP) {
+		return remoteIP
+	}
+
+	forwardedFor := strings.Split(strings.Join(request.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(forwardedFor[i])
+		if ip != "" && !isTrustedProxy(ip) {
+			return ip
+		}
+	}
+	return remoteIP
+}
+
+func isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range strings.Split(env.Config.HTTP.TrustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if proxyIP := net.ParseIP(proxy); proxyIP != nil && proxyIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetHeader returns the value of HTTP header from given key
 func (r *Request) GetHeader(key string) string {
 	return r.instance.Header.Get(key)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/web"
 )
 
@@ -78,6 +79,41 @@ func TestRequest_BehindTLSTerminationProxy(t *testing.T) {
 	Expect(req.IsAPI()).IsFalse()
 }
 
+func TestRequest_ClientIP(t *testing.T) {
+	RegisterT(t)
+
+	wrap := func(remoteAddr, forwardedFor string) web.Request {
+		header := make(http.Header)
+		if forwardedFor != "" {
+			header.Set("X-Forwarded-For", forwardedFor)
+		}
+		return web.WrapRequest(
+			&http.Request{
+				Method:     "GET",
+				Header:     header,
+				Host:       "demo.test.fider.io",
+				RemoteAddr: remoteAddr,
+			},
+		)
+	}
+
+	trustedProxies := env.Config.HTTP.TrustedProxies
+	defer func() {
+		env.Config.HTTP.TrustedProxies = trustedProxies
+	}()
+
+	env.Config.HTTP.TrustedProxies = ""
+	Expect(wrap("10.0.0.1:54321", "").ClientIP).Equals("10.0.0.1")
+	Expect(wrap("10.0.0.1:54321", "203.0.113.7").ClientIP).Equals("10.0.0.1")
+
+	env.Config.HTTP.TrustedProxies = "10.0.0.0/8, 192.168.1.1"
+	Expect(wrap("10.0.0.1:54321", "").ClientIP).Equals("10.0.0.1")
+	Expect(wrap("10.0.0.1:54321", "203.0.113.7").ClientIP).Equals("203.0.113.7")
+	Expect(wrap("10.0.0.1:54321", "1.1.1.1, 203.0.113.7").ClientIP).Equals("203.0.113.7")
+	Expect(wrap("10.0.0.1:54321", "1.1.1.1, 203.0.113.7, 192.168.1.1").ClientIP).Equals("203.0.113.7")
+	Expect(wrap("203.0.113.9:54321", "1.1.1.1").ClientIP).Equals("203.0.113.9")
+}
+
 func TestIsCustomDomain(t *testing.T) {
 	RegisterT(t)
 
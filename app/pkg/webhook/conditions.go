@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+// Match returns true if the event described by these props satisfies all conditions.
+// When it doesn't, the reason of the first unmet condition is also returned
+func (p Props) Match(conditions entity.WebhookConditions) (bool, string) {
+	tags := p.getStrings("post_tags")
+
+	if len(conditions.IncludeTags) > 0 && !slices.ContainsFunc(conditions.IncludeTags, func(tag string) bool {
+		return slices.Contains(tags, tag)
+	}) {
+		return false, fmt.Sprintf("Post has none of the tags: %s.", strings.Join(conditions.IncludeTags, ", "))
+	}
+
+	for _, tag := range conditions.ExcludeTags {
+		if slices.Contains(tags, tag) {
+			return false, fmt.Sprintf("Post has the excluded tag '%s'.", tag)
+		}
+	}
+
+	if len(conditions.FromStatus) > 0 {
+		status := p.getString("post_old_status")
+		if !slices.Contains(conditions.FromStatus, status) {
+			return false, fmt.Sprintf("Previous status '%s' is not one of: %s.", status, strings.Join(conditions.FromStatus, ", "))
+		}
+	}
+
+	if len(conditions.ToStatus) > 0 {
+		status := p.getString("post_status")
+		if !slices.Contains(conditions.ToStatus, status) {
+			return false, fmt.Sprintf("New status '%s' is not one of: %s.", status, strings.Join(conditions.ToStatus, ", "))
+		}
+	}
+
+	// The role is the one of the post author, not of whoever triggered the event (e.g. the staff changing a status).
+	// New post events only have the author of the event, who is the post author
+	if len(conditions.AuthorRoles) > 0 {
+		role := p.getString("post_author_role")
+		if role == "" {
+			role = p.getString("author_role")
+		}
+		if !slices.Contains(conditions.AuthorRoles, role) {
+			return false, fmt.Sprintf("Post author role '%s' is not one of: %s.", role, strings.Join(conditions.AuthorRoles, ", "))
+		}
+	}
+
+	if conditions.MinVotes > 0 {
+		votes := p.getInt("post_votes")
+		if votes < conditions.MinVotes {
+			return false, fmt.Sprintf("Post has %d votes, less than the minimum of %d.", votes, conditions.MinVotes)
+		}
+	}
+
+	return true, ""
+}
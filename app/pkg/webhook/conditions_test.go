@@ -0,0 +1,88 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/webhook"
+)
+
+func TestProps_Match_NoConditions(t *testing.T) {
+	RegisterT(t)
+
+	ok, reason := webhook.Props{}.Match(entity.WebhookConditions{})
+	Expect(ok).IsTrue()
+	Expect(reason).Equals("")
+}
+
+func TestProps_Match_Tags(t *testing.T) {
+	RegisterT(t)
+
+	props := webhook.Props{"post_tags": []string{"mobile", "bug"}}
+
+	ok, _ := props.Match(entity.WebhookConditions{IncludeTags: []string{"ios", "mobile"}})
+	Expect(ok).IsTrue()
+
+	ok, reason := props.Match(entity.WebhookConditions{IncludeTags: []string{"desktop"}})
+	Expect(ok).IsFalse()
+	Expect(reason).Equals("Post has none of the tags: desktop.")
+
+	ok, reason = props.Match(entity.WebhookConditions{ExcludeTags: []string{"bug"}})
+	Expect(ok).IsFalse()
+	Expect(reason).Equals("Post has the excluded tag 'bug'.")
+
+	ok, _ = webhook.Props{}.Match(entity.WebhookConditions{ExcludeTags: []string{"bug"}})
+	Expect(ok).IsTrue()
+
+	ok, _ = webhook.Props{"post_tags": []any{"mobile"}}.Match(entity.WebhookConditions{IncludeTags: []string{"mobile"}})
+	Expect(ok).IsTrue()
+}
+
+func TestProps_Match_Status(t *testing.T) {
+	RegisterT(t)
+
+	props := webhook.Props{"post_old_status": "open", "post_status": "planned"}
+
+	ok, _ := props.Match(entity.WebhookConditions{FromStatus: []string{"open"}, ToStatus: []string{"planned", "started"}})
+	Expect(ok).IsTrue()
+
+	ok, reason := props.Match(entity.WebhookConditions{FromStatus: []string{"started"}})
+	Expect(ok).IsFalse()
+	Expect(reason).Equals("Previous status 'open' is not one of: started.")
+
+	ok, reason = props.Match(entity.WebhookConditions{ToStatus: []string{"completed"}})
+	Expect(ok).IsFalse()
+	Expect(reason).Equals("New status 'planned' is not one of: completed.")
+}
+
+func TestProps_Match_AuthorRoleAndVotes(t *testing.T) {
+	RegisterT(t)
+
+	props := webhook.Props{"author_role": "visitor", "post_votes": 7}
+
+	ok, _ := props.Match(entity.WebhookConditions{AuthorRoles: []string{"visitor"}, MinVotes: 7})
+	Expect(ok).IsTrue()
+
+	ok, reason := props.Match(entity.WebhookConditions{AuthorRoles: []string{"administrator", "collaborator"}})
+	Expect(ok).IsFalse()
+	Expect(reason).Equals("Post author role 'visitor' is not one of: administrator, collaborator.")
+
+	ok, reason = props.Match(entity.WebhookConditions{MinVotes: 10})
+	Expect(ok).IsFalse()
+	Expect(reason).Equals("Post has 7 votes, less than the minimum of 10.")
+}
+
+func TestProps_Match_PostAuthorRole(t *testing.T) {
+	RegisterT(t)
+
+	// A staff member changing the status of a post created by a visitor
+	props := webhook.Props{"author_role": "administrator", "post_author_role": "visitor"}
+
+	ok, _ := props.Match(entity.WebhookConditions{AuthorRoles: []string{"visitor"}})
+	Expect(ok).IsTrue()
+
+	ok, reason := props.Match(entity.WebhookConditions{AuthorRoles: []string{"administrator"}})
+	Expect(ok).IsFalse()
+	Expect(reason).Equals("Post author role 'visitor' is not one of: administrator.")
+}
@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// PayloadVersion is the version of the standard payload, which is increased on every breaking change
+const PayloadVersion = "1"
+
+var events = map[enum.WebhookType]string{
+	enum.WebhookNewPost:      "post.created",
+	enum.WebhookNewComment:   "comment.created",
+	enum.WebhookChangeStatus: "post.status_changed",
+	enum.WebhookDeletePost:   "post.deleted",
+}
+
+// EventName returns the name of the event sent on the standard payload of given webhook type
+func EventName(webhookType enum.WebhookType) string {
+	return events[webhookType]
+}
+
+// Payload is the standard JSON content sent by webhooks
+type Payload struct {
+	Version string          `json:"version" doc:"Version of the payload schema."`
+	Event   string          `json:"event" doc:"Name of the event that triggered the webhook."`
+	Tenant  PayloadTenant   `json:"tenant" doc:"Site where the event happened."`
+	Actor   *PayloadUser    `json:"actor" doc:"User who triggered the event."`
+	Post    PayloadPost     `json:"post" doc:"Post the event is about."`
+	Comment *PayloadComment `json:"comment,omitempty" doc:"Comment that was added. Only sent on comment.created events."`
+}
+
+// PayloadTenant describes a site on the standard payload
+type PayloadTenant struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Subdomain string `json:"subdomain"`
+	Locale    string `json:"locale"`
+	URL       string `json:"url" doc:"Base URL of the site."`
+	LogoURL   string `json:"logo_url"`
+}
+
+// PayloadUser describes a user on the standard payload
+type PayloadUser struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Role      string `json:"role" doc:"One of visitor, collaborator or administrator."`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// PayloadPost describes a post on the standard payload
+type PayloadPost struct {
+	ID             int              `json:"id"`
+	Number         int              `json:"number"`
+	Title          string           `json:"title"`
+	Slug           string           `json:"slug"`
+	Description    string           `json:"description" doc:"Description of the post in Markdown."`
+	URL            string           `json:"url"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Status         string           `json:"status" doc:"One of open, started, completed, declined, planned, duplicate or deleted."`
+	PreviousStatus string           `json:"previous_status,omitempty" doc:"Status before the change. Only sent on post.status_changed events."`
+	Tags           []string         `json:"tags" doc:"Slugs of the tags assigned to the post."`
+	Votes          int              `json:"votes"`
+	Comments       int              `json:"comments"`
+	Author         *PayloadUser     `json:"author" doc:"User who created the post."`
+	Response       *PayloadResponse `json:"response" doc:"Response of the staff to the post, if any."`
+	Target         *PayloadTarget   `json:"target" doc:"When the post is expected to be released, if any."`
+}
+
+// PayloadTarget describes the target release of a post on the standard payload
+type PayloadTarget struct {
+	Kind     string `json:"kind" doc:"One of quarter, month or milestone."`
+	Value    string `json:"value" doc:"Such as 2026-Q3, 2026-09 or the name of the milestone."`
+	Name     string `json:"name" doc:"Target as displayed to users, such as Q3 2026 or September 2026."`
+	IsPublic bool   `json:"is_public" doc:"False when only the staff can see the target."`
+}
+
+// PayloadResponse describes the response of a post on the standard payload
+type PayloadResponse struct {
+	Text        string               `json:"text" doc:"Text of the response in Markdown."`
+	RespondedAt time.Time            `json:"responded_at"`
+	Author      *PayloadUser         `json:"author"`
+	Original    *PayloadOriginalPost `json:"original,omitempty" doc:"Post this one is a duplicate of. Only sent when status is duplicate."`
+}
+
+// PayloadOriginalPost describes the post a duplicate refers to on the standard payload
+type PayloadOriginalPost struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+// PayloadComment describes a comment on the standard payload
+type PayloadComment struct {
+	Content string `json:"content" doc:"Content of the comment in Markdown."`
+}
+
+// NewPayload builds the standard payload of given webhook type from the props of the event
+func NewPayload(webhookType enum.WebhookType, props Props) *Payload {
+	payload := &Payload{
+		Version: PayloadVersion,
+		Event:   EventName(webhookType),
+		Tenant: PayloadTenant{
+			ID:        props.getInt("tenant_id"),
+			Name:      props.getString("tenant_name"),
+			Subdomain: props.getString("tenant_subdomain"),
+			Locale:    props.getString("tenant_locale"),
+			URL:       props.getString("tenant_url"),
+			LogoURL:   props.getString("tenant_logo"),
+		},
+		Actor: props.getUser("author"),
+		Post: PayloadPost{
+			ID:          props.getInt("post_id"),
+			Number:      props.getInt("post_number"),
+			Title:       props.getString("post_title"),
+			Slug:        props.getString("post_slug"),
+			Description: props.getString("post_description"),
+			URL:         props.getString("post_url"),
+			CreatedAt:   props.getTime("post_created_at"),
+			Status:      props.getString("post_status"),
+			Tags:        props.getStrings("post_tags"),
+			Votes:       props.getInt("post_votes"),
+			Comments:    props.getInt("post_comments"),
+			Author:      props.getUser("post_author"),
+		},
+	}
+
+	if payload.Post.Tags == nil {
+		payload.Post.Tags = []string{}
+	}
+
+	// Props of each event only describe what changed, the rest is implied by the event itself
+	switch webhookType {
+	case enum.WebhookNewPost:
+		payload.Post.Status = enum.PostOpen.Name()
+		payload.Post.Author = payload.Actor
+	case enum.WebhookNewComment:
+		payload.Comment = &PayloadComment{Content: props.getString("comment")}
+	case enum.WebhookChangeStatus:
+		payload.Post.PreviousStatus = props.getString("post_old_status")
+	case enum.WebhookDeletePost:
+		payload.Post.Status = enum.PostDeleted.Name()
+	}
+
+	if _, ok := props["post_target"]; ok {
+		isPublic, _ := props["post_target_public"].(bool)
+		payload.Post.Target = &PayloadTarget{
+			Kind:     props.getString("post_target_kind"),
+			Value:    props.getString("post_target_value"),
+			Name:     props.getString("post_target"),
+			IsPublic: isPublic,
+		}
+	}
+
+	if hasResponse, _ := props["post_response"].(bool); hasResponse {
+		payload.Post.Response = &PayloadResponse{
+			Text:        props.getString("post_response_text"),
+			RespondedAt: props.getTime("post_response_responded_at"),
+			Author:      props.getUser("post_response_author"),
+		}
+		if _, ok := props["post_response_original_number"]; ok {
+			payload.Post.Response.Original = &PayloadOriginalPost{
+				Number: props.getInt("post_response_original_number"),
+				Title:  props.getString("post_response_original_title"),
+				Slug:   props.getString("post_response_original_slug"),
+				Status: props.getString("post_response_original_status"),
+				URL:    props.getString("post_response_original_url"),
+			}
+		}
+	}
+
+	return payload
+}
+
+func (p Props) getUser(keyPrefix string) *PayloadUser {
+	if _, ok := p[keyPrefix+"_id"]; !ok {
+		return nil
+	}
+	return &PayloadUser{
+		ID:        p.getInt(keyPrefix + "_id"),
+		Name:      p.getString(keyPrefix + "_name"),
+		Email:     p.getString(keyPrefix + "_email"),
+		Role:      p.getString(keyPrefix + "_role"),
+		AvatarURL: p.getString(keyPrefix + "_avatar"),
+	}
+}
@@ -0,0 +1,163 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/webhook"
+)
+
+var payloadTenant = &entity.Tenant{ID: 1, Name: "Demonstration", Subdomain: "demo", Locale: "en"}
+var payloadAuthor = &entity.User{ID: 2, Name: "Arya Stark", Email: "arya.stark@got.com", Role: enum.RoleVisitor}
+var payloadAdmin = &entity.User{ID: 1, Name: "Jon Snow", Email: "jon.snow@got.com", Role: enum.RoleAdministrator}
+
+func newPayloadPost() *entity.Post {
+	return &entity.Post{
+		ID:            10,
+		Number:        5,
+		Title:         "Add \"dark\" mode",
+		Slug:          "add-dark-mode",
+		Description:   "Please!",
+		CreatedAt:     time.Date(2026, time.October, 1, 10, 0, 0, 0, time.UTC),
+		User:          payloadAuthor,
+		VotesCount:    12,
+		CommentsCount: 3,
+		Status:        enum.PostPlanned,
+		Tags:          []string{"mobile"},
+		Response: &entity.PostResponse{
+			Text:        "Coming soon",
+			RespondedAt: time.Date(2026, time.October, 2, 10, 0, 0, 0, time.UTC),
+			User:        payloadAdmin,
+		},
+	}
+}
+
+func TestNewPayload_NewPost(t *testing.T) {
+	RegisterT(t)
+
+	post := newPayloadPost()
+	post.Response = nil
+	props := webhook.Props{}
+	props.SetPost(post, "post", "http://demo.test.fider.io", false, false)
+	props.SetUser(payloadAuthor, "author")
+	props.SetTenant(payloadTenant, "tenant", "http://demo.test.fider.io", "http://demo.test.fider.io/logo.png")
+
+	payload := webhook.NewPayload(enum.WebhookNewPost, props)
+	Expect(payload.Version).Equals(webhook.PayloadVersion)
+	Expect(payload.Event).Equals("post.created")
+	Expect(payload.Tenant.Subdomain).Equals("demo")
+	Expect(payload.Tenant.URL).Equals("http://demo.test.fider.io")
+	Expect(payload.Actor.Email).Equals("arya.stark@got.com")
+	Expect(payload.Post.Number).Equals(5)
+	Expect(payload.Post.Title).Equals("Add \"dark\" mode")
+	Expect(payload.Post.URL).Equals("http://demo.test.fider.io/posts/5/add-dark-mode")
+	Expect(payload.Post.Status).Equals("open")
+	Expect(payload.Post.Tags).Equals([]string{"mobile"})
+	Expect(payload.Post.Author).Equals(payload.Actor)
+	Expect(payload.Post.Response).IsNil()
+	Expect(payload.Comment).IsNil()
+
+	content, err := json.Marshal(payload)
+	Expect(err).IsNil()
+	Expect(string(content)).ContainsSubstring(`"title":"Add \"dark\" mode"`)
+	Expect(string(content)).ContainsSubstring(`"tags":["mobile"]`)
+	Expect(string(content)).ContainsSubstring(`"response":null`)
+}
+
+func TestNewPayload_ChangeStatus(t *testing.T) {
+	RegisterT(t)
+
+	props := webhook.Props{"post_old_status": "open"}
+	props.SetPost(newPayloadPost(), "post", "http://demo.test.fider.io", true, true)
+	props.SetUser(payloadAdmin, "author")
+	props.SetTenant(payloadTenant, "tenant", "http://demo.test.fider.io", "")
+
+	payload := webhook.NewPayload(enum.WebhookChangeStatus, props)
+	Expect(payload.Event).Equals("post.status_changed")
+	Expect(payload.Actor.Role).Equals("administrator")
+	Expect(payload.Post.Status).Equals("planned")
+	Expect(payload.Post.PreviousStatus).Equals("open")
+	Expect(payload.Post.Tags).Equals([]string{"mobile"})
+	Expect(payload.Post.Votes).Equals(12)
+	Expect(payload.Post.Comments).Equals(3)
+	Expect(payload.Post.Author.Name).Equals("Arya Stark")
+	Expect(payload.Post.Response.Text).Equals("Coming soon")
+	Expect(payload.Post.Response.Author.Name).Equals("Jon Snow")
+	Expect(payload.Post.Response.Original).IsNil()
+	Expect(payload.Post.Target).IsNil()
+}
+
+func TestNewPayload_WithTarget(t *testing.T) {
+	RegisterT(t)
+
+	post := newPayloadPost()
+	post.Target = &entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q4", IsPublic: true}
+	props := webhook.Props{"comment": "Can't wait"}
+	props.SetPost(post, "post", "http://demo.test.fider.io", true, true)
+	props.SetUser(payloadAuthor, "author")
+
+	Expect(props["post_target"]).Equals("Q4 2026")
+	Expect(props["post_target_kind"]).Equals("quarter")
+
+	payload := webhook.NewPayload(enum.WebhookNewComment, props)
+	Expect(payload.Post.Target).Equals(&webhook.PayloadTarget{
+		Kind:     "quarter",
+		Value:    "2026-Q4",
+		Name:     "Q4 2026",
+		IsPublic: true,
+	})
+}
+
+func TestNewPayload_NewComment(t *testing.T) {
+	RegisterT(t)
+
+	props := webhook.Props{"comment": "I agree"}
+	props.SetPost(newPayloadPost(), "post", "http://demo.test.fider.io", true, true)
+	props.SetUser(payloadAuthor, "author")
+
+	payload := webhook.NewPayload(enum.WebhookNewComment, props)
+	Expect(payload.Event).Equals("comment.created")
+	Expect(payload.Comment.Content).Equals("I agree")
+	Expect(payload.Post.PreviousStatus).Equals("")
+}
+
+func TestNewPayload_DeletePost(t *testing.T) {
+	RegisterT(t)
+
+	props := webhook.Props{}
+	props.SetPost(newPayloadPost(), "post", "http://demo.test.fider.io", true, true)
+	props.SetUser(payloadAdmin, "author")
+
+	payload := webhook.NewPayload(enum.WebhookDeletePost, props)
+	Expect(payload.Event).Equals("post.deleted")
+	Expect(payload.Post.Status).Equals("deleted")
+}
+
+func TestSchema(t *testing.T) {
+	RegisterT(t)
+
+	schema := webhook.Schema(enum.WebhookNewComment)
+	Expect(schema["type"]).Equals("object")
+	Expect(schema["required"]).Equals([]string{"version", "event", "tenant", "actor", "post", "comment"})
+
+	properties := schema["properties"].(map[string]any)
+	Expect(properties["event"].(map[string]any)["const"]).Equals("comment.created")
+	Expect(properties["actor"].(map[string]any)["type"]).Equals([]string{"object", "null"})
+
+	post := properties["post"].(map[string]any)
+	postProperties := post["properties"].(map[string]any)
+	Expect(postProperties["created_at"]).Equals(map[string]any{"type": "string", "format": "date-time"})
+	Expect(postProperties["tags"].(map[string]any)["items"]).Equals(map[string]any{"type": "string"})
+	Expect(postProperties["previous_status"]).IsNil()
+
+	schema = webhook.Schema(enum.WebhookChangeStatus)
+	properties = schema["properties"].(map[string]any)
+	Expect(properties["comment"]).IsNil()
+	post = properties["post"].(map[string]any)
+	Expect(post["properties"].(map[string]any)["previous_status"]).IsNotNil()
+	Expect(post["required"]).Equals([]string{"id", "number", "title", "slug", "description", "url", "created_at", "status", "tags", "votes", "comments", "author", "response", "target", "previous_status"})
+}
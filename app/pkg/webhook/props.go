@@ -1,6 +1,8 @@
 package webhook
 
 import (
+	"time"
+
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 )
@@ -44,6 +46,7 @@ func (p Props) SetPost(post *entity.Post, keyPrefix, baseURL string, includeAllF
 		p[keyPrefix+"_description"] = post.Description
 		p[keyPrefix+"_created_at"] = post.CreatedAt
 		p[keyPrefix+"_url"] = post.Url(baseURL)
+		p[keyPrefix+"_tags"] = post.Tags
 
 		if includeAuthor {
 			p.SetUser(post.User, keyPrefix+"_author")
@@ -54,9 +57,25 @@ func (p Props) SetPost(post *entity.Post, keyPrefix, baseURL string, includeAllF
 			p[keyPrefix+"_votes"] = post.VotesCount
 			p[keyPrefix+"_comments"] = post.CommentsCount
 			p[keyPrefix+"_status"] = post.Status.Name()
-			p[keyPrefix+"_tags"] = post.Tags
 			p[keyPrefix+"_response"] = postResponse != nil
 
+			if post.Score != nil {
+				keyPrefix := keyPrefix + "_score"
+				p.setFloat(keyPrefix, post.Score.Value)
+				p.setFloat(keyPrefix+"_reach", post.Score.Reach)
+				p.setFloat(keyPrefix+"_impact", post.Score.Impact)
+				p.setFloat(keyPrefix+"_confidence", post.Score.Confidence)
+				p.setFloat(keyPrefix+"_effort", post.Score.Effort)
+			}
+
+			if post.Target != nil {
+				keyPrefix := keyPrefix + "_target"
+				p[keyPrefix] = post.Target.String()
+				p[keyPrefix+"_kind"] = post.Target.Kind.Name()
+				p[keyPrefix+"_value"] = post.Target.Value
+				p[keyPrefix+"_public"] = post.Target.IsPublic
+			}
+
 			if postResponse != nil {
 				keyPrefix := keyPrefix + "_response"
 				p[keyPrefix+"_text"] = postResponse.Text
@@ -77,3 +96,45 @@ func (p Props) SetPost(post *entity.Post, keyPrefix, baseURL string, includeAllF
 	}
 	return p
 }
+
+func (p Props) setFloat(key string, value *float64) {
+	if value != nil {
+		p[key] = *value
+	}
+}
+
+func (p Props) getString(key string) string {
+	value, _ := p[key].(string)
+	return value
+}
+
+func (p Props) getStrings(key string) []string {
+	switch value := p[key].(type) {
+	case []string:
+		return value
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func (p Props) getInt(key string) int {
+	switch value := p[key].(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return 0
+}
+
+func (p Props) getTime(key string) time.Time {
+	value, _ := p[key].(time.Time)
+	return value
+}
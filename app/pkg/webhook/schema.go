@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Schema returns the JSON Schema of the standard payload sent by given webhook type
+func Schema(webhookType enum.WebhookType) map[string]any {
+	event := EventName(webhookType)
+	schema := schemaOf(reflect.TypeOf(Payload{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Fider webhook payload for " + event + " events"
+
+	properties := schema["properties"].(map[string]any)
+	properties["version"].(map[string]any)["const"] = PayloadVersion
+	properties["event"].(map[string]any)["const"] = event
+
+	if webhookType == enum.WebhookNewComment {
+		schema["required"] = append(schema["required"].([]string), "comment")
+	} else {
+		delete(properties, "comment")
+	}
+
+	post := properties["post"].(map[string]any)
+	if webhookType == enum.WebhookChangeStatus {
+		post["required"] = append(post["required"].([]string), "previous_status")
+	} else {
+		delete(post["properties"].(map[string]any), "previous_status")
+	}
+
+	return schema
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf describes given type as JSON Schema, based on the json and doc tags of its fields.
+// Fields without omitempty are required, but can be null when they are pointers
+func schemaOf(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema := map[string]any{}
+	switch {
+	case t == timeType:
+		schema["type"] = "string"
+		schema["format"] = "date-time"
+	case t.Kind() == reflect.String:
+		schema["type"] = "string"
+	case t.Kind() == reflect.Int:
+		schema["type"] = "integer"
+	case t.Kind() == reflect.Bool:
+		schema["type"] = "boolean"
+	case t.Kind() == reflect.Slice:
+		schema["type"] = "array"
+		schema["items"] = schemaOf(t.Elem())
+	case t.Kind() == reflect.Struct:
+		properties := map[string]any{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			omitEmpty := options == "omitempty"
+
+			property := schemaOf(field.Type)
+			if !omitEmpty && field.Type.Kind() == reflect.Pointer {
+				property["type"] = []string{property["type"].(string), "null"}
+			}
+			if doc := field.Tag.Get("doc"); doc != "" {
+				property["description"] = doc
+			}
+
+			properties[name] = property
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+		schema["type"] = "object"
+		schema["properties"] = properties
+		schema["required"] = required
+	}
+
+	return schema
+}
@@ -0,0 +1,234 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/crypto/hkdf"
+)
+
+// recordSize is the size of the single record used to encrypt a payload
+const recordSize = 4096
+
+// MaxPayloadSize is the largest payload that fits into a single encrypted record
+const MaxPayloadSize = recordSize - 16 - 1
+
+// GenerateVAPIDKeys creates a new pair of VAPID keys encoded as URL-safe base64
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to generate VAPID keys")
+	}
+	return encode(key.PublicKey().Bytes()), encode(key.Bytes()), nil
+}
+
+// VAPIDAuthorization returns the value of the Authorization header required by push services
+// to identify the application server sending a message to given endpoint
+func VAPIDAuthorization(endpoint, subject, publicKey, privateKey string, expiresAt time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", errors.New("invalid push endpoint '%s'", endpoint)
+	}
+
+	signingKey, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": fmt.Sprintf("%s://%s", u.Scheme, u.Host),
+		"exp": expiresAt.Unix(),
+		"sub": subject,
+	}).SignedString(signingKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign VAPID token")
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", token, publicKey), nil
+}
+
+// pushServiceHosts are the domains of the push services used by browsers.
+// Endpoints are given by visitors, so anything else is rejected to avoid requests to internal hosts
+var pushServiceHosts = []string{
+	"fcm.googleapis.com",
+	"updates.push.services.mozilla.com",
+	"push.apple.com",
+	"notify.windows.com",
+}
+
+// ValidateEndpoint returns an error if given endpoint is not an https URL of a known push service
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.User != nil || (u.Port() != "" && u.Port() != "443") {
+		return errors.New("invalid push endpoint '%s'", endpoint)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, serviceHost := range pushServiceHosts {
+		if host == serviceHost || strings.HasSuffix(host, "."+serviceHost) {
+			return nil
+		}
+	}
+	return errors.New("push endpoint '%s' is not from a known push service", endpoint)
+}
+
+// ValidateKeys returns an error if given subscription keys cannot be used to encrypt payloads
+func ValidateKeys(p256dh, auth string) error {
+	publicKey, err := decode(p256dh)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode subscription public key")
+	}
+	if _, err := ecdh.P256().NewPublicKey(publicKey); err != nil {
+		return errors.Wrap(err, "invalid subscription public key")
+	}
+
+	authSecret, err := decode(auth)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode subscription auth secret")
+	}
+	if len(authSecret) != 16 {
+		return errors.New("subscription auth secret must have 16 bytes, got %d", len(authSecret))
+	}
+	return nil
+}
+
+// Encrypt encrypts given payload for a push subscription using the aes128gcm content encoding
+func Encrypt(payload []byte, p256dh, auth string) ([]byte, error) {
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate ephemeral key")
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	return encrypt(payload, p256dh, auth, serverKey, salt)
+}
+
+func encrypt(payload []byte, p256dh, auth string, serverKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, errors.New("payload has %d bytes, maximum is %d", len(payload), MaxPayloadSize)
+	}
+
+	clientPublicKey, err := decode(p256dh)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode subscription public key")
+	}
+
+	authSecret, err := decode(auth)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode subscription auth secret")
+	}
+
+	clientKey, err := ecdh.P256().NewPublicKey(clientPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid subscription public key")
+	}
+
+	sharedSecret, err := serverKey.ECDH(clientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute shared secret")
+	}
+
+	serverPublicKey := serverKey.PublicKey().Bytes()
+	keyInfo := append([]byte("WebPush: info\x00"), clientPublicKey...)
+	keyInfo = append(keyInfo, serverPublicKey...)
+
+	ikm, err := derive(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	contentKey, err := derive(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := derive(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	// A single record is sent, so the padding delimiter is always 0x02
+	record := append(append([]byte{}, payload...), 0x02)
+
+	header := make([]byte, 0, 16+4+1+len(serverPublicKey))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(serverPublicKey)))
+	header = append(header, serverPublicKey...)
+
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+func derive(secret, salt, info []byte, length int) ([]byte, error) {
+	key := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), key); err != nil {
+		return nil, errors.Wrap(err, "failed to derive key")
+	}
+	return key, nil
+}
+
+func parsePrivateKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	d, err := decode(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode VAPID private key")
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid VAPID private key")
+	}
+
+	// Uncompressed point format is 0x04 || X || Y
+	point := key.PublicKey().Bytes()
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(point[1:33]),
+			Y:     new(big.Int).SetBytes(point[33:]),
+		},
+		D: new(big.Int).SetBytes(d),
+	}, nil
+}
+
+func encode(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// decode accepts both standard and URL-safe base64, with or without padding,
+// as browsers are not consistent when serializing subscription keys
+func decode(value string) ([]byte, error) {
+	value = strings.TrimRight(value, "=")
+	if strings.ContainsAny(value, "+/") {
+		return base64.RawStdEncoding.DecodeString(value)
+	}
+	return base64.RawURLEncoding.DecodeString(value)
+}
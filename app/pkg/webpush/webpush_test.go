@@ -0,0 +1,116 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Test vector from RFC 8291, Section 5
+func TestEncrypt_RFC8291(t *testing.T) {
+	RegisterT(t)
+
+	serverPrivateKey, _ := decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw")
+	serverKey, err := ecdh.P256().NewPrivateKey(serverPrivateKey)
+	Expect(err).IsNil()
+
+	salt, _ := decode("DGv6ra1nlYgDCS1FRnbzlw")
+	payload := []byte("When I grow up, I want to be a watermelon")
+
+	encrypted, err := encrypt(
+		payload,
+		"BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		"BTBZMqHH6r4Tts7J_aSIgg",
+		serverKey,
+		salt,
+	)
+	Expect(err).IsNil()
+	Expect(encode(encrypted)).Equals("DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+}
+
+func TestEncrypt_InvalidSubscription(t *testing.T) {
+	RegisterT(t)
+
+	_, err := Encrypt([]byte("Hello"), "not-a-key", "BTBZMqHH6r4Tts7J_aSIgg")
+	Expect(err).IsNotNil()
+
+	_, err = Encrypt(make([]byte, MaxPayloadSize+1), "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", "BTBZMqHH6r4Tts7J_aSIgg")
+	Expect(err).IsNotNil()
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	RegisterT(t)
+
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	Expect(err).IsNil()
+
+	expiresAt := time.Now().Add(12 * time.Hour)
+	header, err := VAPIDAuthorization("https://push.example.com/send/abc123", "mailto:admin@example.com", publicKey, privateKey, expiresAt)
+	Expect(err).IsNil()
+	Expect(strings.HasPrefix(header, "vapid t=")).IsTrue()
+	Expect(strings.HasSuffix(header, ", k="+publicKey)).IsTrue()
+
+	signingKey, err := parsePrivateKey(privateKey)
+	Expect(err).IsNil()
+
+	tokenString := strings.TrimSuffix(strings.TrimPrefix(header, "vapid t="), ", k="+publicKey)
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		return &signingKey.PublicKey, nil
+	})
+	Expect(err).IsNil()
+
+	claims := token.Claims.(jwt.MapClaims)
+	Expect(claims["aud"]).Equals("https://push.example.com")
+	Expect(claims["sub"]).Equals("mailto:admin@example.com")
+	Expect(int64(claims["exp"].(float64))).Equals(expiresAt.Unix())
+	Expect(token.Method.Alg()).Equals("ES256")
+}
+
+func TestVAPIDAuthorization_InvalidEndpoint(t *testing.T) {
+	RegisterT(t)
+
+	publicKey, privateKey, _ := GenerateVAPIDKeys()
+	_, err := VAPIDAuthorization("not a url", "mailto:admin@example.com", publicKey, privateKey, time.Now())
+	Expect(err).IsNotNil()
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	RegisterT(t)
+
+	for _, endpoint := range []string{
+		"https://fcm.googleapis.com/fcm/send/abc",
+		"https://updates.push.services.mozilla.com/wpush/v2/abc",
+		"https://web.push.apple.com/QGuQyavXutnMHf",
+		"https://wns2-par02p.notify.windows.com/w/?token=abc",
+		"https://fcm.googleapis.com:443/fcm/send/abc",
+	} {
+		Expect(ValidateEndpoint(endpoint)).IsNil()
+	}
+
+	for _, endpoint := range []string{
+		"http://fcm.googleapis.com/fcm/send/abc",
+		"https://localhost/push",
+		"https://127.0.0.1/push",
+		"https://169.254.169.254/latest/meta-data",
+		"https://fcm.googleapis.com.evil.com/fcm/send/abc",
+		"https://evilfcm.googleapis.com.example/abc",
+		"https://fcm.googleapis.com:8080/fcm/send/abc",
+		"https://user@fcm.googleapis.com/fcm/send/abc",
+		"not a url",
+	} {
+		Expect(ValidateEndpoint(endpoint)).IsNotNil()
+	}
+}
+
+func TestValidateKeys(t *testing.T) {
+	RegisterT(t)
+
+	Expect(ValidateKeys("BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", "BTBZMqHH6r4Tts7J_aSIgg")).IsNil()
+	Expect(ValidateKeys("BCVxsr7N/eNgVRqvHtD0zTZsEc6+VV+JvLexhqUzORcxaOzi6+AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4=", "BTBZMqHH6r4Tts7J/aSIgg==")).IsNil()
+	Expect(ValidateKeys("BCVxsr7N", "BTBZMqHH6r4Tts7J_aSIgg")).IsNotNil()
+	Expect(ValidateKeys("BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", "BTBZMq")).IsNotNil()
+}
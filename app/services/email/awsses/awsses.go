@@ -69,7 +69,7 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 			return
 		}
 
-		if !email.CanSendTo(to.Address) {
+		if !email.CanSendTo(ctx, to.Address) {
 			log.Warnf(ctx, "Skipping email to '@{Name} <@{Address}>'.", dto.Props{
 				"Name":    to.Name,
 				"Address": to.Address,
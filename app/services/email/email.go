@@ -1,9 +1,14 @@
 package email
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/env"
 )
 
@@ -27,12 +32,20 @@ func SetBlocklist(s string) {
 	blocklistRegex = regexp.MustCompile(blocklist)
 }
 
-// CanSendTo returns true if Fider is allowed to send email to given address
-func CanSendTo(address string) bool {
+// CanSendTo returns true if Fider is allowed to send email to given address,
+// based on the instance allow/block lists and the email rules of current tenant.
+// Administrators are exempt from the tenant rules, so that they can still sign in if they lock themselves out
+func CanSendTo(ctx context.Context, address string) bool {
 	if strings.TrimSpace(address) == "" {
 		return false
 	}
 
+	if tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); ok && tenant != nil {
+		if !tenant.EmailRules.Allows(address) && !isAdministrator(ctx, address) {
+			return false
+		}
+	}
+
 	if allowlist != "" {
 		return allowlistRegex.MatchString(address)
 	}
@@ -43,3 +56,11 @@ func CanSendTo(address string) bool {
 
 	return true
 }
+
+func isAdministrator(ctx context.Context, address string) bool {
+	getUser := &query.GetUserByEmail{Email: address}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		return false
+	}
+	return getUser.Result.IsAdministrator()
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"testing"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/services/email"
 
 	. "github.com/getfider/fider/app/pkg/assert"
@@ -111,11 +116,46 @@ func TestCanSendTo(t *testing.T) {
 		email.SetAllowlist(testCase.allowlist)
 		email.SetBlocklist(testCase.blocklist)
 		for _, input := range testCase.input {
-			Expect(email.CanSendTo(input)).Equals(testCase.canSend)
+			Expect(email.CanSendTo(context.Background(), input)).Equals(testCase.canSend)
 		}
 	}
 }
 
+func TestCanSendTo_TenantEmailRules(t *testing.T) {
+	RegisterT(t)
+	email.SetAllowlist("")
+	email.SetBlocklist("")
+
+	tenant := &entity.Tenant{
+		EmailRules: entity.TenantEmailRules{
+			BlockedDomains:  []string{"competitor.com"},
+			BlockDisposable: true,
+		},
+	}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, tenant)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
+		if q.Email == "admin@competitor.com" {
+			q.Result = &entity.User{ID: 1, Email: q.Email, Role: enum.RoleAdministrator}
+			return nil
+		}
+		if q.Email == "staff@competitor.com" {
+			q.Result = &entity.User{ID: 2, Email: q.Email, Role: enum.RoleCollaborator}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	Expect(email.CanSendTo(ctx, "jon.snow@got.com")).IsTrue()
+	Expect(email.CanSendTo(ctx, "jon.snow@competitor.com")).IsFalse()
+	Expect(email.CanSendTo(ctx, "jon.snow@mailinator.com")).IsFalse()
+	Expect(email.CanSendTo(context.Background(), "jon.snow@competitor.com")).IsTrue()
+
+	// Administrators can't be locked out by the rules of their own tenant
+	Expect(email.CanSendTo(ctx, "admin@competitor.com")).IsTrue()
+	Expect(email.CanSendTo(ctx, "staff@competitor.com")).IsFalse()
+}
+
 func TestRecipient_String(t *testing.T) {
 	RegisterT(t)
 
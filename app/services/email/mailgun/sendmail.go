@@ -63,7 +63,7 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 	recipientVariables := make(map[string]dto.Props)
 	for _, r := range c.To {
 		if r.Address != "" {
-			if email.CanSendTo(r.Address) {
+			if email.CanSendTo(ctx, r.Address) {
 				form.Add("to", r.String())
 				recipientVariables[r.Address] = r.Props
 			} else {
@@ -71,7 +71,7 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 			localname = u.Hostname()
 		}
 
-		if !email.CanSendTo(to.Address) {
+		if !email.CanSendTo(ctx, to.Address) {
 			log.Warnf(ctx, "Skipping email to '@{Name} <@{Address}>'.", dto.Props{
 				"Name":    to.Name,
 				"Address": to.Address,
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type dbAnnouncement struct {
+	ID          int          `db:"id"`
+	Text        string       `db:"text"`
+	Severity    int          `db:"severity"`
+	Audience    int          `db:"audience"`
+	StartsAt    time.Time    `db:"starts_at"`
+	EndsAt      dbx.NullTime `db:"ends_at"`
+	Dismissible bool         `db:"dismissible"`
+	CreatedAt   time.Time    `db:"created_at"`
+}
+
+func (a *dbAnnouncement) toModel() *entity.Announcement {
+	announcement := &entity.Announcement{
+		ID:          a.ID,
+		Text:        a.Text,
+		Severity:    enum.AnnouncementSeverity(a.Severity),
+		Audience:    enum.AnnouncementAudience(a.Audience),
+		StartsAt:    a.StartsAt,
+		Dismissible: a.Dismissible,
+		CreatedAt:   a.CreatedAt,
+	}
+	if a.EndsAt.Valid {
+		announcement.EndsAt = &a.EndsAt.Time
+	}
+	return announcement
+}
+
+const sqlSelectAnnouncements = `
+	SELECT id, text, severity, audience, starts_at, ends_at, dismissible, created_at
+	FROM announcements
+`
+
+func getAnnouncementByID(ctx context.Context, q *query.GetAnnouncementByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		announcement, err := queryAnnouncement(trx, sqlSelectAnnouncements+"WHERE tenant_id = $1 AND id = $2", tenant.ID, q.AnnouncementID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get announcement with id '%d'", q.AnnouncementID)
+		}
+		q.Result = announcement
+		return nil
+	})
+}
+
+func listAnnouncements(ctx context.Context, q *query.ListAnnouncements) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.Announcement, 0)
+
+		sql := sqlSelectAnnouncements + "WHERE tenant_id = $1 ORDER BY starts_at DESC, id DESC"
+		args := []any{tenant.ID}
+		if q.ActiveOnly {
+			sql = sqlSelectAnnouncements + `
+				WHERE tenant_id = $1 AND starts_at <= $2 AND (ends_at IS NULL OR ends_at > $2)
+				ORDER BY severity DESC, starts_at DESC, id DESC
+			`
+			args = append(args, time.Now())
+		}
+
+		announcements := []*dbAnnouncement{}
+		if err := trx.Select(&announcements, sql, args...); err != nil {
+			return errors.Wrap(err, "failed to list announcements")
+		}
+
+		for _, a := range announcements {
+			announcement := a.toModel()
+			if !q.ActiveOnly || announcement.IsVisibleTo(user) {
+				q.Result = append(q.Result, announcement)
+			}
+		}
+		return nil
+	})
+}
+
+func addNewAnnouncement(ctx context.Context, c *cmd.AddNewAnnouncement) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO announcements (tenant_id, text, severity, audience, starts_at, ends_at, dismissible, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
+		`, tenant.ID, c.Text, c.Severity, c.Audience, c.StartsAt, c.EndsAt, c.Dismissible, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to add new announcement")
+		}
+
+		announcement, err := queryAnnouncement(trx, sqlSelectAnnouncements+"WHERE tenant_id = $1 AND id = $2", tenant.ID, id)
+		c.Result = announcement
+		return err
+	})
+}
+
+func updateAnnouncement(ctx context.Context, c *cmd.UpdateAnnouncement) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE announcements
+			SET text = $1, severity = $2, audience = $3, starts_at = $4, ends_at = $5, dismissible = $6
+			WHERE id = $7 AND tenant_id = $8
+		`, c.Text, c.Severity, c.Audience, c.StartsAt, c.EndsAt, c.Dismissible, c.AnnouncementID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to update announcement with id '%d'", c.AnnouncementID)
+		}
+
+		announcement, err := queryAnnouncement(trx, sqlSelectAnnouncements+"WHERE tenant_id = $1 AND id = $2", tenant.ID, c.AnnouncementID)
+		c.Result = announcement
+		return err
+	})
+}
+
+func deleteAnnouncement(ctx context.Context, c *cmd.DeleteAnnouncement) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`DELETE FROM announcements WHERE id = $1 AND tenant_id = $2`, c.Announcement.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete announcement with id '%d'", c.Announcement.ID)
+		}
+		return nil
+	})
+}
+
+func purgeExpiredAnnouncements(ctx context.Context, c *cmd.PurgeExpiredAnnouncements) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		count, err := trx.Execute(`DELETE FROM announcements WHERE ends_at <= $1`, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to delete expired announcements")
+		}
+		c.NumOfDeletedAnnouncements = int(count)
+		return nil
+	})
+}
+
+func queryAnnouncement(trx *dbx.Trx, query string, args ...any) (*entity.Announcement, error) {
+	announcement := dbAnnouncement{}
+	if err := trx.Get(&announcement, query, args...); err != nil {
+		return nil, err
+	}
+	return announcement.toModel(), nil
+}
@@ -2,17 +2,22 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/lib/pq"
 )
 
 type dbComment struct {
 	ID          int          `db:"id"`
+	PostID      int          `db:"post_id"`
 	Content     string       `db:"content"`
 	CreatedAt   time.Time    `db:"created_at"`
 	User        *dbUser      `db:"user"`
@@ -57,6 +62,17 @@ func addNewComment(ctx context.Context, c *cmd.AddNewComment) error {
 	})
 }
 
+func backdateComment(ctx context.Context, c *cmd.BackdateComment) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE comments SET created_at = $1 WHERE id = $2 AND tenant_id = $3`, c.CreatedAt, c.Comment.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to backdate comment")
+		}
+		c.Comment.CreatedAt = c.CreatedAt
+		return nil
+	})
+}
+
 func updateComment(ctx context.Context, c *cmd.UpdateComment) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		_, err := trx.Execute(`
@@ -129,23 +145,104 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*entity.Comment, 0)
 
+		args := []any{pq.Array([]int{q.Post.ID}), tenant.ID}
+		order, operator := "ASC", ">"
+		if q.Sort == "newest" {
+			order, operator = "DESC", "<"
+		}
+
+		filter := ""
+		if q.Since != nil {
+			args = append(args, *q.Since)
+			filter += fmt.Sprintf(" AND c.created_at > $%d", len(args))
+		}
+		if q.After > 0 {
+			exists, err := trx.Exists("SELECT 1 FROM comments WHERE id = $1 AND post_id = $2 AND tenant_id = $3", q.After, q.Post.ID, tenant.ID)
+			if err != nil {
+				return errors.Wrap(err, "failed to check if comment exists")
+			}
+			if !exists {
+				return app.ErrNotFound
+			}
+
+			args = append(args, q.After)
+			filter += fmt.Sprintf(`
+			AND (c.created_at, c.id) %s (
+				SELECT created_at, id FROM comments WHERE id = $%d AND tenant_id = $2
+			)`, operator, len(args))
+		}
+
+		sqlLimit := "ALL"
+		if q.Limit > 0 {
+			sqlLimit = strconv.Itoa(q.Limit)
+		}
+
+		comments := []*dbComment{}
+		err := trx.Select(&comments, buildCommentsQuery(order, filter)+" LIMIT "+sqlLimit, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed get comments of post with id '%d'", q.Post.ID)
+		}
+
+		q.Result = make([]*entity.Comment, len(comments))
+		for i, comment := range comments {
+			q.Result[i] = comment.toModel(ctx)
+		}
+		return nil
+	})
+}
+
+func getCommentsByPosts(ctx context.Context, q *query.GetCommentsByPosts) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make(map[int][]*entity.Comment, len(q.PostIDs))
+		if len(q.PostIDs) == 0 {
+			return nil
+		}
+
+		order := "ASC"
+		if q.Sort == "newest" {
+			order = "DESC"
+		}
+
+		filter := ""
+		if q.Limit > 0 {
+			filter = " AND c.row_number <= " + strconv.Itoa(q.Limit)
+		}
+
 		comments := []*dbComment{}
-		err := trx.Select(&comments,
-			`WITH agg_attachments AS ( 
+		err := trx.Select(&comments, buildCommentsQuery(order, filter), pq.Array(q.PostIDs), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed get comments of posts")
+		}
+
+		for _, comment := range comments {
+			q.Result[comment.PostID] = append(q.Result[comment.PostID], comment.toModel(ctx))
+		}
+		return nil
+	})
+}
+
+// buildCommentsQuery returns the query of the comments of posts $1 on tenant $2, sorted by post and creation date.
+// Comments are numbered per post as c.row_number, so that filter can limit the number of comments of each post
+func buildCommentsQuery(order, filter string) string {
+	return `WITH agg_attachments AS ( 
 					SELECT 
-							c.id as comment_id, 
+							at.comment_id, 
 							ARRAY_REMOVE(ARRAY_AGG(at.attachment_bkey), NULL) as attachment_bkeys
 					FROM attachments at
-					INNER JOIN comments c
-					ON at.tenant_id = c.tenant_id
-					AND at.post_id = c.post_id
-					AND at.comment_id = c.id
-					WHERE at.post_id = $1
+					WHERE at.post_id = ANY($1)
 					AND at.tenant_id = $2
 					AND at.comment_id IS NOT NULL
-					GROUP BY c.id 
+					GROUP BY at.comment_id 
+			),
+			ranked_comments AS (
+					SELECT *, ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY created_at ` + order + `, id ` + order + `) AS row_number
+					FROM comments
+					WHERE post_id = ANY($1)
+					AND tenant_id = $2
+					AND deleted_at IS NULL
 			)
 			SELECT c.id, 
+					c.post_id,
 					c.content, 
 					c.created_at, 
 					c.edited_at, 
@@ -164,10 +261,7 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 					e.avatar_type AS edited_by_avatar_type, 
 					e.avatar_bkey AS edited_by_avatar_bkey,
 					at.attachment_bkeys
-			FROM comments c
-			INNER JOIN posts p
-			ON p.id = c.post_id
-			AND p.tenant_id = c.tenant_id
+			FROM ranked_comments c
 			INNER JOIN users u
 			ON u.id = c.user_id
 			AND u.tenant_id = c.tenant_id
@@ -176,18 +270,6 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 			AND e.tenant_id = c.tenant_id
 			LEFT JOIN agg_attachments at
 			ON at.comment_id = c.id
-			WHERE p.id = $1
-			AND p.tenant_id = $2
-			AND c.deleted_at IS NULL
-			ORDER BY c.created_at ASC`, q.Post.ID, tenant.ID)
-		if err != nil {
-			return errors.Wrap(err, "failed get comments of post with id '%d'", q.Post.ID)
-		}
-
-		q.Result = make([]*entity.Comment, len(comments))
-		for i, comment := range comments {
-			q.Result[i] = comment.toModel(ctx)
-		}
-		return nil
-	})
+			WHERE c.tenant_id = $2` + filter + `
+			ORDER BY c.post_id, c.created_at ` + order + `, c.id ` + order
 }
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/pkg/web"
 )
@@ -25,7 +26,7 @@ func SanitizeString(input string) string {
 	return strings.ToValidUTF8(input, "")
 }
 
-func getViewData(view string) (string, []enum.PostStatus, string) {
+func getViewData(user *entity.User, view string) (string, []enum.PostStatus, string) {
 	var (
 		condition string
 		sort      string
@@ -66,6 +67,12 @@ func getViewData(view string) (string, []enum.PostStatus, string) {
 			enum.PostCompleted,
 			enum.PostDeclined,
 		}
+	case "highest-score":
+		if user != nil && user.IsCollaborator() {
+			sort = "COALESCE(score, -1)"
+			break
+		}
+		fallthrough
 	case "trending":
 		fallthrough
 	default:
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// jobRunsRetention is how long the history of each job is kept
+const jobRunsRetention = 90 * 24 * time.Hour
+
+type dbJobRun struct {
+	ID         int               `db:"id"`
+	JobName    string            `db:"job_name"`
+	Status     enum.JobRunStatus `db:"status"`
+	Manual     bool              `db:"manual"`
+	StartedAt  time.Time         `db:"started_at"`
+	FinishedAt time.Time         `db:"finished_at"`
+	DurationMs int64             `db:"duration_ms"`
+	Error      dbx.NullString    `db:"error"`
+	Summary    dbx.NullString    `db:"summary"`
+}
+
+func (r *dbJobRun) toModel() *entity.JobRun {
+	run := &entity.JobRun{
+		ID:         r.ID,
+		JobName:    r.JobName,
+		Status:     r.Status,
+		Manual:     r.Manual,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		DurationMs: r.DurationMs,
+		Error:      r.Error.String,
+	}
+	if r.Summary.Valid {
+		_ = json.Unmarshal([]byte(r.Summary.String), &run.Summary)
+	}
+	return run
+}
+
+func addJobRun(ctx context.Context, c *cmd.AddJobRun) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		run := c.Run
+
+		var errorText any
+		if run.Error != "" {
+			errorText = run.Error
+		}
+
+		var summary any
+		if len(run.Summary) > 0 {
+			summary = dto.Props(run.Summary)
+		}
+
+		err := trx.Get(&run.ID, `
+			INSERT INTO job_runs (job_name, status, manual, started_at, finished_at, duration_ms, error, summary)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id
+		`, run.JobName, run.Status, run.Manual, run.StartedAt, run.FinishedAt, run.DurationMs, errorText, summary)
+		if err != nil {
+			return errors.Wrap(err, "failed to add job run")
+		}
+
+		_, err = trx.Execute(
+			"DELETE FROM job_runs WHERE job_name = $1 AND started_at < $2",
+			run.JobName, run.StartedAt.Add(-jobRunsRetention),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete old job runs")
+		}
+
+		return nil
+	})
+}
+
+func listJobRuns(ctx context.Context, q *query.ListJobRuns) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		limit := q.Limit
+		if limit <= 0 {
+			limit = 50
+		}
+
+		runs := []*dbJobRun{}
+		err := trx.Select(&runs, `
+			SELECT id, job_name, status, manual, started_at, finished_at, duration_ms, error, summary::text AS summary
+			FROM job_runs
+			WHERE job_name = $1
+			ORDER BY started_at DESC, id DESC
+			LIMIT $2
+		`, q.JobName, limit)
+		if err != nil {
+			return errors.Wrap(err, "failed to list runs of job '%s'", q.JobName)
+		}
+
+		q.Result = make([]*entity.JobRun, len(runs))
+		for i, run := range runs {
+			q.Result[i] = run.toModel()
+		}
+		return nil
+	})
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,20 @@ func purgeExpiredNotifications(ctx context.Context, c *cmd.PurgeExpiredNotificat
 		return errors.Wrap(err, "failed to delete expired notifications")
 	}
 
+	// Users can choose to keep their notifications for a shorter period
+	userCount, err := trx.Execute(`
+		DELETE FROM notifications n
+		USING user_settings s
+		WHERE s.user_id = n.user_id
+		AND s.tenant_id = n.tenant_id
+		AND s.key = $1
+		AND n.created_at <= NOW() - CAST(s.value AS integer) * INTERVAL '1 day'
+	`, enum.NotificationRetentionSettingsKeyName)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete notifications expired by user retention")
+	}
+	count += userCount
+
 	if err = trx.Commit(); err != nil {
 		return errors.Wrap(err, "failed commit transaction")
 	}
@@ -81,6 +96,23 @@ func markNotificationAsRead(ctx context.Context, c *cmd.MarkNotificationAsRead)
 	})
 }
 
+func markNotificationAsUnread(ctx context.Context, c *cmd.MarkNotificationAsUnread) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if user == nil {
+			return nil
+		}
+
+		_, err := trx.Execute(`
+			UPDATE notifications SET read = false, updated_at = $1
+			WHERE id = $2 AND tenant_id = $3 AND user_id = $4 AND read = true
+		`, time.Now(), c.ID, tenant.ID, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to mark notification as unread")
+		}
+		return nil
+	})
+}
+
 func getNotificationByID(ctx context.Context, q *query.GetNotificationByID) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = nil
@@ -116,6 +148,80 @@ func getActiveNotifications(ctx context.Context, q *query.GetActiveNotifications
 	})
 }
 
+type dbNotification struct {
+	ID        int       `db:"id"`
+	Title     string    `db:"title"`
+	Link      string    `db:"link"`
+	Read      bool      `db:"read"`
+	CreatedAt time.Time `db:"created_at"`
+	Post      *struct {
+		ID     int    `db:"id"`
+		Number int    `db:"number"`
+		Title  string `db:"title"`
+		Slug   string `db:"slug"`
+	} `db:"post"`
+}
+
+func (n *dbNotification) toModel() *entity.Notification {
+	return &entity.Notification{
+		ID:        n.ID,
+		Title:     n.Title,
+		Link:      n.Link,
+		Read:      n.Read,
+		CreatedAt: n.CreatedAt,
+		Post: &entity.NotificationPost{
+			ID:     n.Post.ID,
+			Number: n.Post.Number,
+			Title:  n.Post.Title,
+			Slug:   n.Post.Slug,
+		},
+	}
+}
+
+func listNotifications(ctx context.Context, q *query.ListNotifications) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.Notification, 0)
+
+		args := []any{tenant.ID, user.ID}
+		filter := ""
+		if q.UnreadOnly {
+			filter += " AND n.read = false"
+		}
+		if q.After > 0 {
+			args = append(args, q.After)
+			filter += fmt.Sprintf(`
+			AND (n.created_at, n.id) < (
+				SELECT created_at, id FROM notifications WHERE id = $%d AND tenant_id = $1 AND user_id = $2
+			)`, len(args))
+		}
+
+		sqlLimit := "ALL"
+		if q.Limit > 0 {
+			sqlLimit = strconv.Itoa(q.Limit)
+		}
+
+		notifications := []*dbNotification{}
+		err := trx.Select(&notifications, `
+			SELECT n.id, n.title, COALESCE(n.link, '') AS link, n.read, n.created_at,
+						 p.id AS post_id, p.number AS post_number, p.title AS post_title, p.slug AS post_slug
+			FROM notifications n
+			INNER JOIN posts p
+			ON p.id = n.post_id
+			AND p.tenant_id = n.tenant_id
+			WHERE n.tenant_id = $1 AND n.user_id = $2`+filter+`
+			ORDER BY n.created_at DESC, n.id DESC
+			LIMIT `+sqlLimit, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to list notifications")
+		}
+
+		for _, n := range notifications {
+			q.Result = append(q.Result, n.toModel())
+		}
+		return nil
+	})
+}
+
 func addNewNotification(ctx context.Context, c *cmd.AddNewNotification) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		c.Result = nil
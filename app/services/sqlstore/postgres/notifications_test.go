@@ -8,6 +8,7 @@ import (
 	"github.com/getfider/fider/app/models/query"
 
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
 
 	"github.com/getfider/fider/app"
 	. "github.com/getfider/fider/app/pkg/assert"
@@ -182,3 +183,84 @@ func TestNotificationStorage_PurgeExpiredNotifications(t *testing.T) {
 	Expect(err).IsNil()
 	Expect(purgeCommand.NumOfDeletedNotifications).Equals(2)
 }
+
+func TestNotificationStorage_PurgeExpiredNotifications_UserRetention(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+	defer ResetDatabase()
+
+	newPost := &cmd.AddNewPost{Title: "Title", Description: "Description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.UpdateCurrentUserSettings{
+		Settings: map[string]string{enum.NotificationRetentionSettingsKeyName: "30"},
+	})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx,
+		&cmd.AddNewNotification{User: jonSnow, Title: "Old", Link: "http://www.microsoft.com", PostID: newPost.Result.ID},
+		&cmd.AddNewNotification{User: jonSnow, Title: "Recent", Link: "http://www.google.com", PostID: newPost.Result.ID},
+	)
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AddNewNotification{User: aryaStark, Title: "Old", Link: "http://www.microsoft.com", PostID: newPost.Result.ID},
+	)
+	Expect(err).IsNil()
+
+	rows, err := trx.Execute("UPDATE notifications SET created_at = NOW() - INTERVAL '60 days' WHERE link = 'http://www.microsoft.com'")
+	Expect(err).IsNil()
+	Expect(rows).Equals(int64(2))
+
+	trx.MustCommit()
+
+	// Only Jon Snow chose to keep notifications for 30 days
+	purgeCommand := &cmd.PurgeExpiredNotifications{}
+	err = bus.Dispatch(context.Background(), purgeCommand)
+	Expect(err).IsNil()
+	Expect(purgeCommand.NumOfDeletedNotifications).Equals(1)
+}
+
+func TestNotificationStorage_ListNotifications(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My Post", Description: "Description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	addNotification1 := &cmd.AddNewNotification{User: aryaStark, Title: "First", Link: "/posts/1", PostID: newPost.Result.ID}
+	addNotification2 := &cmd.AddNewNotification{User: aryaStark, Title: "Second", Link: "/posts/1", PostID: newPost.Result.ID}
+	addNotification3 := &cmd.AddNewNotification{User: aryaStark, Title: "Third", Link: "/posts/1", PostID: newPost.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, addNotification1, addNotification2, addNotification3)
+	Expect(err).IsNil()
+
+	firstPage := &query.ListNotifications{Limit: 2}
+	err = bus.Dispatch(aryaStarkCtx, firstPage)
+	Expect(err).IsNil()
+	Expect(firstPage.Result).HasLen(2)
+	Expect(firstPage.Result[0].Title).Equals("Third")
+	Expect(firstPage.Result[1].Title).Equals("Second")
+	Expect(firstPage.Result[0].Post.Title).Equals("My Post")
+
+	secondPage := &query.ListNotifications{Limit: 2, After: firstPage.Result[1].ID}
+	err = bus.Dispatch(aryaStarkCtx, secondPage)
+	Expect(err).IsNil()
+	Expect(secondPage.Result).HasLen(1)
+	Expect(secondPage.Result[0].Title).Equals("First")
+
+	bus.MustDispatch(aryaStarkCtx, &cmd.MarkAllNotificationsAsRead{})
+	bus.MustDispatch(aryaStarkCtx, &cmd.MarkNotificationAsUnread{ID: addNotification2.Result.ID})
+
+	unread := &query.ListNotifications{UnreadOnly: true}
+	err = bus.Dispatch(aryaStarkCtx, unread)
+	Expect(err).IsNil()
+	Expect(unread.Result).HasLen(1)
+	Expect(unread.Result[0].ID).Equals(addNotification2.Result.ID)
+	Expect(unread.Result[0].Read).IsFalse()
+
+	other := &query.ListNotifications{}
+	err = bus.Dispatch(jonSnowCtx, other)
+	Expect(err).IsNil()
+	Expect(other.Result).HasLen(0)
+}
@@ -148,3 +148,13 @@ func saveCustomOAuthConfig(ctx context.Context, c *cmd.SaveCustomOAuthConfig) er
 		return nil
 	})
 }
+
+func deleteCustomOAuthConfig(ctx context.Context, c *cmd.DeleteCustomOAuthConfig) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute("DELETE FROM oauth_providers WHERE tenant_id = $1 AND provider = $2", tenant.ID, c.Provider)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete OAuth Provider")
+		}
+		return nil
+	})
+}
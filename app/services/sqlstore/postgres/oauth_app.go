@@ -0,0 +1,390 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/crypto"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/lib/pq"
+)
+
+const (
+	oauthAccessTokenDuration  = 1 * time.Hour
+	oauthRefreshTokenDuration = 30 * 24 * time.Hour
+)
+
+type dbOAuthApp struct {
+	ID           int       `db:"id"`
+	Name         string    `db:"name"`
+	ClientID     string    `db:"client_id"`
+	RedirectURIs []string  `db:"redirect_uris"`
+	Scopes       []string  `db:"scopes"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func (a *dbOAuthApp) toModel() *entity.OAuthApp {
+	return &entity.OAuthApp{
+		ID:           a.ID,
+		Name:         a.Name,
+		ClientID:     a.ClientID,
+		RedirectURIs: a.RedirectURIs,
+		Scopes:       a.Scopes,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+type dbOAuthAuthorization struct {
+	ID         int          `db:"id"`
+	Scopes     []string     `db:"scopes"`
+	CreatedAt  time.Time    `db:"created_at"`
+	LastUsedAt dbx.NullTime `db:"last_used_at"`
+	App        *dbOAuthApp  `db:"app"`
+}
+
+func (a *dbOAuthAuthorization) toModel() *entity.OAuthAuthorization {
+	authorization := &entity.OAuthAuthorization{
+		ID:        a.ID,
+		App:       a.App.toModel(),
+		Scopes:    a.Scopes,
+		CreatedAt: a.CreatedAt,
+	}
+	if a.LastUsedAt.Valid {
+		authorization.LastUsedAt = &a.LastUsedAt.Time
+	}
+	return authorization
+}
+
+const selectOAuthAppsSQL = `
+	SELECT id, name, client_id, redirect_uris, scopes, created_at
+	FROM oauth_apps
+`
+
+func listOAuthApps(ctx context.Context, q *query.ListOAuthApps) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		apps := []*dbOAuthApp{}
+		err := trx.Select(&apps, selectOAuthAppsSQL+" WHERE tenant_id = $1 ORDER BY id", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list oauth apps")
+		}
+
+		q.Result = make([]*entity.OAuthApp, len(apps))
+		for i, a := range apps {
+			q.Result[i] = a.toModel()
+		}
+		return nil
+	})
+}
+
+func getOAuthAppByID(ctx context.Context, q *query.GetOAuthAppByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		a := dbOAuthApp{}
+		err := trx.Get(&a, selectOAuthAppsSQL+" WHERE id = $1 AND tenant_id = $2", q.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get oauth app with id '%d'", q.ID)
+		}
+		q.Result = a.toModel()
+		return nil
+	})
+}
+
+func getOAuthAppByClientID(ctx context.Context, q *query.GetOAuthAppByClientID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		a := dbOAuthApp{}
+		err := trx.Get(&a, selectOAuthAppsSQL+" WHERE client_id = $1 AND tenant_id = $2", q.ClientID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get oauth app with client id '%s'", q.ClientID)
+		}
+		q.Result = a.toModel()
+		return nil
+	})
+}
+
+func verifyOAuthAppSecret(ctx context.Context, q *query.VerifyOAuthAppSecret) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		a := dbOAuthApp{}
+		err := trx.Get(&a, selectOAuthAppsSQL+" WHERE client_id = $1 AND client_secret_hash = $2 AND tenant_id = $3",
+			q.ClientID, crypto.SHA512(q.ClientSecret), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to verify secret of oauth app with client id '%s'", q.ClientID)
+		}
+		q.Result = a.toModel()
+		return nil
+	})
+}
+
+func createOAuthApp(ctx context.Context, c *cmd.CreateOAuthApp) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		secret := rand.String(64)
+		a := dbOAuthApp{}
+		err := trx.Get(&a, `
+			INSERT INTO oauth_apps (tenant_id, name, client_id, client_secret_hash, redirect_uris, scopes, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, name, client_id, redirect_uris, scopes, created_at
+		`, tenant.ID, c.Name, rand.String(32), crypto.SHA512(secret), pq.Array(c.RedirectURIs), pq.Array(c.Scopes), time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to create oauth app")
+		}
+
+		c.Result = a.toModel()
+		c.ClientSecret = secret
+		return nil
+	})
+}
+
+func updateOAuthApp(ctx context.Context, c *cmd.UpdateOAuthApp) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE oauth_apps SET name = $3, redirect_uris = $4, scopes = $5
+			WHERE id = $1 AND tenant_id = $2
+		`, c.ID, tenant.ID, c.Name, pq.Array(c.RedirectURIs), pq.Array(c.Scopes))
+		if err != nil {
+			return errors.Wrap(err, "failed to update oauth app with id '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+
+		// Authorizations can't keep scopes that the app is no longer allowed to request
+		_, err = trx.Execute(`
+			UPDATE oauth_authorizations SET scopes = ARRAY(SELECT UNNEST(scopes) INTERSECT SELECT UNNEST($3::text[]))
+			WHERE app_id = $1 AND tenant_id = $2
+		`, c.ID, tenant.ID, pq.Array(c.Scopes))
+		if err != nil {
+			return errors.Wrap(err, "failed to restrict scopes of oauth app authorizations")
+		}
+
+		_, err = trx.Execute(`
+			UPDATE oauth_tokens SET scopes = ARRAY(SELECT UNNEST(scopes) INTERSECT SELECT UNNEST($3::text[]))
+			WHERE tenant_id = $2 AND authorization_id IN (SELECT id FROM oauth_authorizations WHERE app_id = $1 AND tenant_id = $2)
+		`, c.ID, tenant.ID, pq.Array(c.Scopes))
+		if err != nil {
+			return errors.Wrap(err, "failed to restrict scopes of oauth app tokens")
+		}
+		return nil
+	})
+}
+
+func deleteOAuthApp(ctx context.Context, c *cmd.DeleteOAuthApp) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute("DELETE FROM oauth_apps WHERE id = $1 AND tenant_id = $2", c.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete oauth app with id '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// upsertOAuthAuthorization stores the consent of a user, which accumulates the scopes granted over time
+func upsertOAuthAuthorization(trx *dbx.Trx, tenantID, appID, userID int, scopes []string) (int, error) {
+	var id int
+	err := trx.Scalar(&id, `
+		INSERT INTO oauth_authorizations (tenant_id, app_id, user_id, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, app_id, user_id) DO UPDATE
+		SET scopes = ARRAY(SELECT UNNEST(oauth_authorizations.scopes) UNION SELECT UNNEST(EXCLUDED.scopes))
+		RETURNING id
+	`, tenantID, appID, userID, pq.Array(scopes), time.Now())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to save oauth authorization")
+	}
+	return id, nil
+}
+
+func addOAuthAuthorizationCode(ctx context.Context, c *cmd.AddOAuthAuthorizationCode) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		authorizationID, err := upsertOAuthAuthorization(trx, tenant.ID, c.AppID, user.ID, c.Scopes)
+		if err != nil {
+			return err
+		}
+
+		code := rand.String(48)
+		_, err = trx.Execute(`
+			INSERT INTO oauth_authorization_codes (code_hash, tenant_id, authorization_id, redirect_uri, code_challenge, scopes, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, crypto.SHA512(code), tenant.ID, authorizationID, c.RedirectURI, c.CodeChallenge, pq.Array(c.Scopes), time.Now().Add(c.ExpiresIn))
+		if err != nil {
+			return errors.Wrap(err, "failed to add oauth authorization code")
+		}
+
+		c.Result = code
+		return nil
+	})
+}
+
+func consumeOAuthAuthorizationCode(ctx context.Context, c *cmd.ConsumeOAuthAuthorizationCode) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		code := struct {
+			AppID         int       `db:"app_id"`
+			UserID        int       `db:"user_id"`
+			RedirectURI   string    `db:"redirect_uri"`
+			CodeChallenge string    `db:"code_challenge"`
+			Scopes        []string  `db:"scopes"`
+			ExpiresAt     time.Time `db:"expires_at"`
+		}{}
+		err := trx.Get(&code, `
+			WITH consumed AS (
+				DELETE FROM oauth_authorization_codes
+				WHERE code_hash = $1 AND tenant_id = $2
+				RETURNING authorization_id, redirect_uri, code_challenge, scopes, expires_at
+			)
+			SELECT a.app_id, a.user_id, c.redirect_uri, c.code_challenge, c.scopes, c.expires_at
+			FROM consumed c
+			INNER JOIN oauth_authorizations a
+			ON a.id = c.authorization_id
+		`, crypto.SHA512(c.Code), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to consume oauth authorization code")
+		}
+
+		c.Result = &entity.OAuthAuthorizationCode{
+			AppID:         code.AppID,
+			UserID:        code.UserID,
+			RedirectURI:   code.RedirectURI,
+			CodeChallenge: code.CodeChallenge,
+			Scopes:        code.Scopes,
+			ExpiresAt:     code.ExpiresAt,
+		}
+		return nil
+	})
+}
+
+func insertOAuthTokens(trx *dbx.Trx, tenantID, authorizationID int, scopes []string) (*entity.OAuthTokens, error) {
+	now := time.Now()
+	tokens := &entity.OAuthTokens{
+		AccessToken:  entity.OAuthAccessTokenPrefix + rand.String(48),
+		RefreshToken: entity.OAuthRefreshTokenPrefix + rand.String(48),
+		ExpiresIn:    int(oauthAccessTokenDuration.Seconds()),
+		Scopes:       scopes,
+	}
+
+	_, err := trx.Execute(`
+		INSERT INTO oauth_tokens (tenant_id, authorization_id, access_token_hash, refresh_token_hash, scopes, access_expires_at, refresh_expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, tenantID, authorizationID, crypto.SHA512(tokens.AccessToken), crypto.SHA512(tokens.RefreshToken), pq.Array(scopes),
+		now.Add(oauthAccessTokenDuration), now.Add(oauthRefreshTokenDuration), now)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to insert oauth tokens")
+	}
+	return tokens, nil
+}
+
+func issueOAuthTokens(ctx context.Context, c *cmd.IssueOAuthTokens) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		authorizationID, err := upsertOAuthAuthorization(trx, tenant.ID, c.AppID, c.UserID, c.Scopes)
+		if err != nil {
+			return err
+		}
+
+		c.Result, err = insertOAuthTokens(trx, tenant.ID, authorizationID, c.Scopes)
+		return err
+	})
+}
+
+func refreshOAuthTokens(ctx context.Context, c *cmd.RefreshOAuthTokens) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		// Refresh tokens are rotated, so the old pair is removed as soon as it's used
+		refreshed := struct {
+			AuthorizationID int      `db:"authorization_id"`
+			Scopes          []string `db:"scopes"`
+		}{}
+		err := trx.Get(&refreshed, `
+			DELETE FROM oauth_tokens t
+			USING oauth_authorizations a
+			WHERE a.id = t.authorization_id
+			AND t.refresh_token_hash = $1 AND t.tenant_id = $2 AND a.app_id = $3 AND t.refresh_expires_at > $4
+			RETURNING t.authorization_id, t.scopes
+		`, crypto.SHA512(c.RefreshToken), tenant.ID, c.AppID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to find oauth refresh token")
+		}
+
+		c.Result, err = insertOAuthTokens(trx, tenant.ID, refreshed.AuthorizationID, refreshed.Scopes)
+		return err
+	})
+}
+
+func getOAuthAccessToken(ctx context.Context, q *query.GetOAuthAccessToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		token := struct {
+			AuthorizationID int      `db:"authorization_id"`
+			AppID           int      `db:"app_id"`
+			UserID          int      `db:"user_id"`
+			Scopes          []string `db:"scopes"`
+		}{}
+		err := trx.Get(&token, `
+			SELECT t.authorization_id, a.app_id, a.user_id, t.scopes
+			FROM oauth_tokens t
+			INNER JOIN oauth_authorizations a
+			ON a.id = t.authorization_id
+			WHERE t.access_token_hash = $1 AND t.tenant_id = $2 AND t.access_expires_at > $3
+		`, crypto.SHA512(q.Token), tenant.ID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to get oauth access token")
+		}
+
+		_, err = trx.Execute("UPDATE oauth_authorizations SET last_used_at = $2 WHERE id = $1", token.AuthorizationID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to update last usage of oauth authorization")
+		}
+
+		q.Result = &entity.OAuthAccessToken{
+			AuthorizationID: token.AuthorizationID,
+			AppID:           token.AppID,
+			UserID:          token.UserID,
+			Scopes:          token.Scopes,
+		}
+		return nil
+	})
+}
+
+func listOAuthAuthorizations(ctx context.Context, q *query.ListOAuthAuthorizations) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		authorizations := []*dbOAuthAuthorization{}
+		err := trx.Select(&authorizations, `
+			SELECT a.id, a.scopes, a.created_at, a.last_used_at,
+					p.id AS app_id, p.name AS app_name, p.client_id AS app_client_id,
+					p.redirect_uris AS app_redirect_uris, p.scopes AS app_scopes, p.created_at AS app_created_at
+			FROM oauth_authorizations a
+			INNER JOIN oauth_apps p
+			ON p.id = a.app_id
+			AND p.tenant_id = a.tenant_id
+			WHERE a.tenant_id = $1 AND a.user_id = $2
+			ORDER BY a.created_at DESC
+		`, tenant.ID, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list oauth authorizations")
+		}
+
+		q.Result = make([]*entity.OAuthAuthorization, len(authorizations))
+		for i, a := range authorizations {
+			q.Result[i] = a.toModel()
+		}
+		return nil
+	})
+}
+
+func revokeOAuthAuthorization(ctx context.Context, c *cmd.RevokeOAuthAuthorization) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(
+			"DELETE FROM oauth_authorizations WHERE id = $1 AND tenant_id = $2 AND user_id = $3",
+			c.ID, tenant.ID, user.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to revoke oauth authorization with id '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,168 @@
+package postgres_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func createTestOAuthApp() *cmd.CreateOAuthApp {
+	createApp := &cmd.CreateOAuthApp{
+		Name:         "Zapier",
+		RedirectURIs: []string{"https://zapier.com/callback"},
+		Scopes:       []string{entity.OAuthScopeRead, entity.OAuthScopeWrite},
+	}
+	bus.MustDispatch(jonSnowCtx, createApp)
+	return createApp
+}
+
+func TestOAuthAppStorage_CreateUpdateDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createApp := createTestOAuthApp()
+	Expect(createApp.Result.ID).NotEquals(0)
+	Expect(createApp.Result.ClientID).HasLen(32)
+	Expect(createApp.ClientSecret).HasLen(64)
+
+	verify := &query.VerifyOAuthAppSecret{ClientID: createApp.Result.ClientID, ClientSecret: createApp.ClientSecret}
+	err := bus.Dispatch(demoTenantCtx, verify)
+	Expect(err).IsNil()
+	Expect(verify.Result.Name).Equals("Zapier")
+
+	verify = &query.VerifyOAuthAppSecret{ClientID: createApp.Result.ClientID, ClientSecret: "wrong"}
+	err = bus.Dispatch(demoTenantCtx, verify)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.UpdateOAuthApp{
+		ID:           createApp.Result.ID,
+		Name:         "Zapier Integration",
+		RedirectURIs: []string{"https://zapier.com/callback", "https://zapier.com/callback2"},
+		Scopes:       []string{entity.OAuthScopeRead},
+	})
+	Expect(err).IsNil()
+
+	getApp := &query.GetOAuthAppByClientID{ClientID: createApp.Result.ClientID}
+	err = bus.Dispatch(demoTenantCtx, getApp)
+	Expect(err).IsNil()
+	Expect(getApp.Result.Name).Equals("Zapier Integration")
+	Expect(getApp.Result.RedirectURIs).Equals([]string{"https://zapier.com/callback", "https://zapier.com/callback2"})
+	Expect(getApp.Result.Scopes).Equals([]string{entity.OAuthScopeRead})
+
+	otherTenantApp := &query.GetOAuthAppByID{ID: createApp.Result.ID}
+	err = bus.Dispatch(avengersTenantCtx, otherTenantApp)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeleteOAuthApp{ID: createApp.Result.ID})
+	Expect(err).IsNil()
+
+	listApps := &query.ListOAuthApps{}
+	err = bus.Dispatch(demoTenantCtx, listApps)
+	Expect(err).IsNil()
+	Expect(listApps.Result).HasLen(0)
+}
+
+func TestOAuthAppStorage_AuthorizationCodeFlow(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createApp := createTestOAuthApp()
+
+	addCode := &cmd.AddOAuthAuthorizationCode{
+		AppID:         createApp.Result.ID,
+		RedirectURI:   "https://zapier.com/callback",
+		CodeChallenge: "challenge",
+		Scopes:        []string{entity.OAuthScopeRead},
+		ExpiresIn:     10 * time.Minute,
+	}
+	err := bus.Dispatch(aryaStarkCtx, addCode)
+	Expect(err).IsNil()
+	Expect(addCode.Result).IsNotEmpty()
+
+	consume := &cmd.ConsumeOAuthAuthorizationCode{Code: addCode.Result}
+	err = bus.Dispatch(demoTenantCtx, consume)
+	Expect(err).IsNil()
+	Expect(consume.Result.AppID).Equals(createApp.Result.ID)
+	Expect(consume.Result.UserID).Equals(aryaStark.ID)
+	Expect(consume.Result.CodeChallenge).Equals("challenge")
+	Expect(consume.Result.ExpiresAt).TemporarilySimilar(time.Now().Add(10*time.Minute), 5*time.Second)
+
+	// Codes can only be used once
+	err = bus.Dispatch(demoTenantCtx, &cmd.ConsumeOAuthAuthorizationCode{Code: addCode.Result})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	issue := &cmd.IssueOAuthTokens{AppID: consume.Result.AppID, UserID: consume.Result.UserID, Scopes: consume.Result.Scopes}
+	err = bus.Dispatch(demoTenantCtx, issue)
+	Expect(err).IsNil()
+	Expect(strings.HasPrefix(issue.Result.AccessToken, entity.OAuthAccessTokenPrefix)).IsTrue()
+	Expect(strings.HasPrefix(issue.Result.RefreshToken, entity.OAuthRefreshTokenPrefix)).IsTrue()
+	Expect(issue.Result.ExpiresIn).Equals(3600)
+
+	getToken := &query.GetOAuthAccessToken{Token: issue.Result.AccessToken}
+	err = bus.Dispatch(demoTenantCtx, getToken)
+	Expect(err).IsNil()
+	Expect(getToken.Result.UserID).Equals(aryaStark.ID)
+	Expect(getToken.Result.HasScope(entity.OAuthScopeRead)).IsTrue()
+	Expect(getToken.Result.HasScope(entity.OAuthScopeWrite)).IsFalse()
+
+	err = bus.Dispatch(avengersTenantCtx, &query.GetOAuthAccessToken{Token: issue.Result.AccessToken})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	refresh := &cmd.RefreshOAuthTokens{AppID: createApp.Result.ID, RefreshToken: issue.Result.RefreshToken}
+	err = bus.Dispatch(demoTenantCtx, refresh)
+	Expect(err).IsNil()
+	Expect(refresh.Result.AccessToken).NotEquals(issue.Result.AccessToken)
+
+	// Refresh tokens are rotated, so previous tokens are no longer valid
+	err = bus.Dispatch(demoTenantCtx, &query.GetOAuthAccessToken{Token: issue.Result.AccessToken})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+	err = bus.Dispatch(demoTenantCtx, &cmd.RefreshOAuthTokens{AppID: createApp.Result.ID, RefreshToken: issue.Result.RefreshToken})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(demoTenantCtx, &query.GetOAuthAccessToken{Token: refresh.Result.AccessToken})
+	Expect(err).IsNil()
+}
+
+func TestOAuthAppStorage_ListAndRevokeAuthorizations(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createApp := createTestOAuthApp()
+
+	issue := &cmd.IssueOAuthTokens{AppID: createApp.Result.ID, UserID: aryaStark.ID, Scopes: []string{entity.OAuthScopeRead}}
+	bus.MustDispatch(demoTenantCtx, issue)
+	bus.MustDispatch(demoTenantCtx, &query.GetOAuthAccessToken{Token: issue.Result.AccessToken})
+
+	// Authorizing an app again adds the new scopes to the existing authorization
+	bus.MustDispatch(demoTenantCtx, &cmd.IssueOAuthTokens{AppID: createApp.Result.ID, UserID: aryaStark.ID, Scopes: []string{entity.OAuthScopeWrite}})
+
+	listAuthorizations := &query.ListOAuthAuthorizations{}
+	err := bus.Dispatch(aryaStarkCtx, listAuthorizations)
+	Expect(err).IsNil()
+	Expect(listAuthorizations.Result).HasLen(1)
+	Expect(listAuthorizations.Result[0].App.Name).Equals("Zapier")
+	Expect(listAuthorizations.Result[0].Scopes).HasLen(2)
+	Expect(listAuthorizations.Result[0].LastUsedAt).IsNotNil()
+
+	jonSnowAuthorizations := &query.ListOAuthAuthorizations{}
+	err = bus.Dispatch(jonSnowCtx, jonSnowAuthorizations)
+	Expect(err).IsNil()
+	Expect(jonSnowAuthorizations.Result).HasLen(0)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.RevokeOAuthAuthorization{ID: listAuthorizations.Result[0].ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.RevokeOAuthAuthorization{ID: listAuthorizations.Result[0].ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &query.GetOAuthAccessToken{Token: issue.Result.AccessToken})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
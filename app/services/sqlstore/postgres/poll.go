@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type dbPoll struct {
+	ID                int        `db:"id"`
+	Question          string     `db:"question"`
+	IsMultipleChoice  bool       `db:"is_multiple_choice"`
+	ResultsVisibility int        `db:"results_visibility"`
+	ClosesAt          *time.Time `db:"closes_at"`
+	CreatedAt         time.Time  `db:"created_at"`
+	VotersCount       int        `db:"voters_count"`
+	MyVotes           []int64    `db:"my_votes"`
+}
+
+func (p *dbPoll) toModel() *entity.Poll {
+	poll := &entity.Poll{
+		ID:                p.ID,
+		Question:          p.Question,
+		IsMultipleChoice:  p.IsMultipleChoice,
+		ResultsVisibility: enum.PollResultsVisibility(p.ResultsVisibility),
+		ClosesAt:          p.ClosesAt,
+		CreatedAt:         p.CreatedAt,
+		VotersCount:       p.VotersCount,
+		Options:           make([]*entity.PollOption, 0),
+		MyVotes:           make([]int, len(p.MyVotes)),
+	}
+	for i, optionID := range p.MyVotes {
+		poll.MyVotes[i] = int(optionID)
+	}
+	return poll
+}
+
+type dbPollOption struct {
+	ID         int    `db:"id"`
+	Text       string `db:"text"`
+	VotesCount int    `db:"votes_count"`
+}
+
+type dbPollVote struct {
+	User *struct {
+		ID            int    `db:"id"`
+		Name          string `db:"name"`
+		Email         string `db:"email"`
+		AvatarType    int64  `db:"avatar_type"`
+		AvatarBlobKey string `db:"avatar_bkey"`
+	} `db:"user"`
+	OptionID  int       `db:"option_id"`
+	Option    string    `db:"option"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (v *dbPollVote) toModel(ctx context.Context) *entity.PollVote {
+	return &entity.PollVote{
+		OptionID:  v.OptionID,
+		Option:    v.Option,
+		CreatedAt: v.CreatedAt,
+		User: &entity.VoteUser{
+			ID:        v.User.ID,
+			Name:      v.User.Name,
+			Email:     v.User.Email,
+			AvatarURL: buildAvatarURL(ctx, enum.AvatarType(v.User.AvatarType), v.User.ID, v.User.Name, v.User.AvatarBlobKey),
+		},
+	}
+}
+
+const sqlSelectPolls = `
+	SELECT p.id, p.question, p.is_multiple_choice, p.results_visibility, p.closes_at, p.created_at,
+				 (SELECT COUNT(DISTINCT v.user_id) FROM poll_votes v WHERE v.poll_id = p.id AND v.tenant_id = p.tenant_id) AS voters_count,
+				 ARRAY(
+					 SELECT v.option_id FROM poll_votes v
+					 WHERE v.poll_id = p.id AND v.tenant_id = p.tenant_id AND v.user_id = $3
+				 ) AS my_votes
+	FROM polls p
+	WHERE p.tenant_id = $1 AND p.post_id = $2
+`
+
+func getPollsByPost(ctx context.Context, q *query.GetPollsByPost) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.Poll, 0)
+
+		polls := []*dbPoll{}
+		err := trx.Select(&polls, sqlSelectPolls+"ORDER BY p.created_at", tenant.ID, q.Post.ID, currentUserID(user))
+		if err != nil {
+			return errors.Wrap(err, "failed to get polls of post '%d'", q.Post.ID)
+		}
+
+		for _, p := range polls {
+			poll, err := fillPollOptions(trx, tenant, user, p.toModel())
+			if err != nil {
+				return err
+			}
+			q.Result = append(q.Result, poll)
+		}
+		return nil
+	})
+}
+
+func getPollByID(ctx context.Context, q *query.GetPollByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = nil
+
+		p := dbPoll{}
+		err := trx.Get(&p, sqlSelectPolls+"AND p.id = $4", tenant.ID, q.Post.ID, currentUserID(user), q.PollID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get poll with id '%d'", q.PollID)
+		}
+
+		poll, err := fillPollOptions(trx, tenant, user, p.toModel())
+		if err != nil {
+			return err
+		}
+		q.Result = poll
+		return nil
+	})
+}
+
+func listPollVotes(ctx context.Context, q *query.ListPollVotes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.PollVote, 0)
+
+		votes := []*dbPollVote{}
+		err := trx.Select(&votes, `
+			SELECT
+				v.option_id,
+				o.text AS option,
+				v.created_at,
+				u.id AS user_id,
+				u.name AS user_name,
+				u.email AS user_email,
+				u.avatar_type AS user_avatar_type,
+				u.avatar_bkey AS user_avatar_bkey
+			FROM poll_votes v
+			INNER JOIN poll_options o
+			ON o.id = v.option_id
+			AND o.tenant_id = v.tenant_id
+			INNER JOIN users u
+			ON u.id = v.user_id
+			AND u.tenant_id = v.tenant_id
+			WHERE v.poll_id = $1
+			AND v.tenant_id = $2
+			ORDER BY v.created_at, o.position
+		`, q.Poll.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get votes of poll '%d'", q.Poll.ID)
+		}
+
+		for _, vote := range votes {
+			q.Result = append(q.Result, vote.toModel(ctx))
+		}
+		return nil
+	})
+}
+
+func addNewPoll(ctx context.Context, c *cmd.AddNewPoll) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		c.Result = nil
+		now := time.Now()
+
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO polls (tenant_id, post_id, question, is_multiple_choice, results_visibility, closes_at, created_at, created_by_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
+		`, tenant.ID, c.Post.ID, c.Question, c.IsMultipleChoice, c.ResultsVisibility, c.ClosesAt, now, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to add new poll")
+		}
+
+		poll := &entity.Poll{
+			ID:                id,
+			Question:          c.Question,
+			IsMultipleChoice:  c.IsMultipleChoice,
+			ResultsVisibility: c.ResultsVisibility,
+			ClosesAt:          c.ClosesAt,
+			CreatedAt:         now,
+			Options:           make([]*entity.PollOption, 0),
+			MyVotes:           make([]int, 0),
+			HasResults:        true,
+		}
+
+		for i, text := range c.Options {
+			var optionID int
+			err := trx.Get(&optionID, `
+				INSERT INTO poll_options (tenant_id, poll_id, text, position)
+				VALUES ($1, $2, $3, $4) RETURNING id
+			`, tenant.ID, id, text, i)
+			if err != nil {
+				return errors.Wrap(err, "failed to add option to poll")
+			}
+			poll.Options = append(poll.Options, &entity.PollOption{ID: optionID, Text: text})
+		}
+
+		c.Result = poll
+		return nil
+	})
+}
+
+func deletePoll(ctx context.Context, c *cmd.DeletePoll) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`DELETE FROM polls WHERE id = $1 AND tenant_id = $2`, c.Poll.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete poll")
+		}
+		return nil
+	})
+}
+
+func setPollVote(ctx context.Context, c *cmd.SetPollVote) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			`DELETE FROM poll_votes WHERE poll_id = $1 AND user_id = $2 AND tenant_id = $3`,
+			c.Poll.ID, user.ID, tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to clear previous votes from poll")
+		}
+
+		now := time.Now()
+		for _, optionID := range c.OptionIDs {
+			_, err := trx.Execute(
+				`INSERT INTO poll_votes (tenant_id, poll_id, option_id, user_id, created_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+				tenant.ID, c.Poll.ID, optionID, user.ID, now,
+			)
+			if err != nil {
+				return errors.Wrap(err, "failed to add vote to poll")
+			}
+		}
+		return nil
+	})
+}
+
+func removePollVote(ctx context.Context, c *cmd.RemovePollVote) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			`DELETE FROM poll_votes WHERE poll_id = $1 AND user_id = $2 AND tenant_id = $3`,
+			c.Poll.ID, user.ID, tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove vote from poll")
+		}
+		return nil
+	})
+}
+
+func fillPollOptions(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User, poll *entity.Poll) (*entity.Poll, error) {
+	options := []*dbPollOption{}
+	err := trx.Select(&options, `
+		SELECT o.id, o.text,
+					 (SELECT COUNT(*) FROM poll_votes v WHERE v.option_id = o.id AND v.tenant_id = o.tenant_id) AS votes_count
+		FROM poll_options o
+		WHERE o.poll_id = $1 AND o.tenant_id = $2
+		ORDER BY o.position
+	`, poll.ID, tenant.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get options of poll '%d'", poll.ID)
+	}
+
+	poll.HasResults = poll.CanSeeResults(user)
+	for _, o := range options {
+		option := &entity.PollOption{ID: o.ID, Text: o.Text}
+		if poll.HasResults {
+			option.VotesCount = o.VotesCount
+		}
+		poll.Options = append(poll.Options, option)
+	}
+
+	if !poll.HasResults {
+		poll.VotersCount = 0
+	}
+	return poll, nil
+}
+
+func currentUserID(user *entity.User) int {
+	if user == nil {
+		return 0
+	}
+	return user.ID
+}
@@ -0,0 +1,124 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestPollStorage_AddAndVote(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newPoll := &cmd.AddNewPoll{
+		Post:              newPost.Result,
+		Question:          "Which platform do you use?",
+		Options:           []string{"Windows", "macOS", "Linux"},
+		IsMultipleChoice:  true,
+		ResultsVisibility: enum.PollResultsPublic,
+	}
+	err = bus.Dispatch(jonSnowCtx, newPoll)
+	Expect(err).IsNil()
+	Expect(newPoll.Result.ID).IsNotEmpty()
+	Expect(newPoll.Result.Options).HasLen(3)
+
+	windows, linux := newPoll.Result.Options[0].ID, newPoll.Result.Options[2].ID
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.SetPollVote{Poll: newPoll.Result, OptionIDs: []int{windows, linux}})
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetPollVote{Poll: newPoll.Result, OptionIDs: []int{windows}})
+	Expect(err).IsNil()
+
+	getPoll := &query.GetPollByID{Post: newPost.Result, PollID: newPoll.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, getPoll)
+	Expect(err).IsNil()
+	Expect(getPoll.Result.HasResults).IsTrue()
+	Expect(getPoll.Result.VotersCount).Equals(2)
+	Expect(getPoll.Result.MyVotes).Equals([]int{windows, linux})
+	Expect(getPoll.Result.Options[0].VotesCount).Equals(2)
+	Expect(getPoll.Result.Options[1].VotesCount).Equals(0)
+	Expect(getPoll.Result.Options[2].VotesCount).Equals(1)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.SetPollVote{Poll: newPoll.Result, OptionIDs: []int{linux}})
+	Expect(err).IsNil()
+
+	listVotes := &query.ListPollVotes{Poll: newPoll.Result}
+	err = bus.Dispatch(jonSnowCtx, listVotes)
+	Expect(err).IsNil()
+	Expect(listVotes.Result).HasLen(2)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.RemovePollVote{Poll: newPoll.Result})
+	Expect(err).IsNil()
+
+	getPolls := &query.GetPollsByPost{Post: newPost.Result}
+	err = bus.Dispatch(aryaStarkCtx, getPolls)
+	Expect(err).IsNil()
+	Expect(getPolls.Result).HasLen(1)
+	Expect(getPolls.Result[0].VotersCount).Equals(1)
+	Expect(getPolls.Result[0].MyVotes).HasLen(0)
+}
+
+func TestPollStorage_StaffOnlyResults(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newPoll := &cmd.AddNewPoll{
+		Post:              newPost.Result,
+		Question:          "Which platform do you use?",
+		Options:           []string{"Windows", "Linux"},
+		ResultsVisibility: enum.PollResultsStaff,
+	}
+	err = bus.Dispatch(jonSnowCtx, newPoll)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.SetPollVote{Poll: newPoll.Result, OptionIDs: []int{newPoll.Result.Options[0].ID}})
+	Expect(err).IsNil()
+
+	getPoll := &query.GetPollByID{Post: newPost.Result, PollID: newPoll.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, getPoll)
+	Expect(err).IsNil()
+	Expect(getPoll.Result.HasResults).IsFalse()
+	Expect(getPoll.Result.VotersCount).Equals(0)
+	Expect(getPoll.Result.Options[0].VotesCount).Equals(0)
+	Expect(getPoll.Result.MyVotes).HasLen(1)
+
+	err = bus.Dispatch(jonSnowCtx, getPoll)
+	Expect(err).IsNil()
+	Expect(getPoll.Result.HasResults).IsTrue()
+	Expect(getPoll.Result.VotersCount).Equals(1)
+	Expect(getPoll.Result.Options[0].VotesCount).Equals(1)
+}
+
+func TestPollStorage_Delete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newPoll := &cmd.AddNewPoll{Post: newPost.Result, Question: "Yes or no?", Options: []string{"Yes", "No"}, ResultsVisibility: enum.PollResultsPublic}
+	err = bus.Dispatch(jonSnowCtx, newPoll)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeletePoll{Poll: newPoll.Result})
+	Expect(err).IsNil()
+
+	getPoll := &query.GetPollByID{Post: newPost.Result, PollID: newPoll.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getPoll)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
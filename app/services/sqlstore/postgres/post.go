@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
@@ -21,30 +24,44 @@ import (
 )
 
 type dbPost struct {
-	ID             int            `db:"id"`
-	Number         int            `db:"number"`
-	Title          string         `db:"title"`
-	Slug           string         `db:"slug"`
-	Description    string         `db:"description"`
-	CreatedAt      time.Time      `db:"created_at"`
-	User           *dbUser        `db:"user"`
-	HasVoted       bool           `db:"has_voted"`
-	VotesCount     int            `db:"votes_count"`
-	CommentsCount  int            `db:"comments_count"`
-	RecentVotes    int            `db:"recent_votes_count"`
-	RecentComments int            `db:"recent_comments_count"`
-	Status         int            `db:"status"`
-	Response       sql.NullString `db:"response"`
-	RespondedAt    dbx.NullTime   `db:"response_date"`
-	ResponseUser   *dbUser        `db:"response_user"`
-	OriginalNumber sql.NullInt64  `db:"original_number"`
-	OriginalTitle  sql.NullString `db:"original_title"`
-	OriginalSlug   sql.NullString `db:"original_slug"`
-	OriginalStatus sql.NullInt64  `db:"original_status"`
-	Tags           []string       `db:"tags"`
+	ID             int             `db:"id"`
+	Number         int             `db:"number"`
+	Title          string          `db:"title"`
+	Slug           string          `db:"slug"`
+	Description    string          `db:"description"`
+	CreatedAt      time.Time       `db:"created_at"`
+	User           *dbUser         `db:"user"`
+	HasVoted       bool            `db:"has_voted"`
+	VotesCount     int             `db:"votes_count"`
+	CommentsCount  int             `db:"comments_count"`
+	RecentVotes    int             `db:"recent_votes_count"`
+	RecentComments int             `db:"recent_comments_count"`
+	Status         int             `db:"status"`
+	Response       sql.NullString  `db:"response"`
+	RespondedAt    dbx.NullTime    `db:"response_date"`
+	ResponseUser   *dbUser         `db:"response_user"`
+	OriginalNumber sql.NullInt64   `db:"original_number"`
+	OriginalTitle  sql.NullString  `db:"original_title"`
+	OriginalSlug   sql.NullString  `db:"original_slug"`
+	OriginalStatus sql.NullInt64   `db:"original_status"`
+	Tags           []string        `db:"tags"`
+	Reach          sql.NullFloat64 `db:"score_reach"`
+	Impact         sql.NullFloat64 `db:"score_impact"`
+	Confidence     sql.NullFloat64 `db:"score_confidence"`
+	Effort         sql.NullFloat64 `db:"score_effort"`
+	Score          sql.NullFloat64 `db:"score"`
+	TargetKind     sql.NullInt64   `db:"target_kind"`
+	TargetValue    sql.NullString  `db:"target_value"`
+	TargetPublic   bool            `db:"target_public"`
+	Rank           sql.NullFloat64 `db:"rank"`
 }
 
 func (i *dbPost) toModel(ctx context.Context) *entity.Post {
+	user, _ := ctx.Value(app.UserCtxKey).(*entity.User)
+	return i.toModelWith(ctx, user != nil && user.IsCollaborator())
+}
+
+func (i *dbPost) toModelWith(ctx context.Context, includeStaffFields bool) *entity.Post {
 	post := &entity.Post{
 		ID:            i.ID,
 		Number:        i.Number,
@@ -58,6 +75,25 @@ func (i *dbPost) toModel(ctx context.Context) *entity.Post {
 		Status:        enum.PostStatus(i.Status),
 		User:          i.User.toModel(ctx),
 		Tags:          i.Tags,
+		Rank:          nullFloat(i.Rank),
+	}
+
+	if includeStaffFields {
+		post.Score = &entity.PostScore{
+			Reach:      nullFloat(i.Reach),
+			Impact:     nullFloat(i.Impact),
+			Confidence: nullFloat(i.Confidence),
+			Effort:     nullFloat(i.Effort),
+			Value:      nullFloat(i.Score),
+		}
+	}
+
+	if i.TargetKind.Valid && (i.TargetPublic || includeStaffFields) {
+		post.Target = &entity.PostTarget{
+			Kind:     enum.PostTargetKind(i.TargetKind.Int64),
+			Value:    i.TargetValue.String,
+			IsPublic: i.TargetPublic,
+		}
 	}
 
 	if i.Response.Valid {
@@ -149,7 +185,16 @@ var (
 																d.slug AS original_slug,
 																d.status AS original_status,
 																COALESCE(agg_t.tags, ARRAY[]::text[]) AS tags,
-																COALESCE(%s, false) AS has_voted
+																COALESCE(%s, false) AS has_voted,
+																p.score_reach,
+																p.score_impact,
+																p.score_confidence,
+																p.score_effort,
+																%s AS score,
+																p.target_kind,
+																p.target_value,
+																p.target_public,
+																p.rank
 													FROM posts p
 													INNER JOIN users u
 													ON u.id = p.user_id
@@ -200,15 +245,20 @@ func setPostResponse(ctx context.Context, c *cmd.SetPostResponse) error {
 			respondedAt = c.Post.Response.RespondedAt
 		}
 
+		// Ranks are only meaningful within a status, so posts moving to another status go to the end of it
 		_, err := trx.Execute(`
 		UPDATE posts 
-		SET response = $3, original_id = NULL, response_date = $4, response_user_id = $5, status = $6 
+		SET response = $3, original_id = NULL, response_date = $4, response_user_id = $5, status = $6,
+				rank = CASE WHEN status = $6 THEN rank ELSE NULL END
 		WHERE id = $1 and tenant_id = $2
 		`, c.Post.ID, tenant.ID, c.Text, respondedAt, user.ID, c.Status)
 		if err != nil {
 			return errors.Wrap(err, "failed to update post's response")
 		}
 
+		if c.Post.Status != c.Status {
+			c.Post.Rank = nil
+		}
 		c.Post.Status = c.Status
 		c.Post.Response = &entity.PostResponse{
 			Text:        c.Text,
@@ -310,6 +360,180 @@ func addNewPost(ctx context.Context, c *cmd.AddNewPost) error {
 	})
 }
 
+func setPostScore(ctx context.Context, c *cmd.SetPostScore) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+		UPDATE posts 
+		SET score_reach = $3, score_impact = $4, score_confidence = $5, score_effort = $6
+		WHERE id = $1 AND tenant_id = $2
+		`, c.Post.ID, tenant.ID, c.Reach, c.Impact, c.Confidence, c.Effort)
+		if err != nil {
+			return errors.Wrap(err, "failed to update post's score")
+		}
+		return nil
+	})
+}
+
+func setPostTarget(ctx context.Context, c *cmd.SetPostTarget) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var (
+			kind   *enum.PostTargetKind
+			value  *string
+			public = true
+		)
+		if c.Target != nil {
+			kind, value, public = &c.Target.Kind, &c.Target.Value, c.Target.IsPublic
+		}
+
+		_, err := trx.Execute(`
+		UPDATE posts 
+		SET target_kind = $3, target_value = $4, target_public = $5
+		WHERE id = $1 AND tenant_id = $2
+		`, c.Post.ID, tenant.ID, kind, value, public)
+		if err != nil {
+			return errors.Wrap(err, "failed to update post's target")
+		}
+
+		c.Post.Target = c.Target
+		return nil
+	})
+}
+
+const (
+	// postRankGap is the distance between consecutive posts when ranks are (re)assigned
+	postRankGap = 1000.0
+	// postRankMinGap is how close two ranks can get before the whole status is renumbered
+	postRankMinGap = 1e-6
+)
+
+// reorderPost gives the post a rank between the reference post and its neighbour, so usually only one row is updated.
+// When the reference post is not ranked yet, the unranked posts of that status are ranked by votes after the ranked ones,
+// and when there is no room left between two ranks, the whole status is renumbered
+func reorderPost(ctx context.Context, c *cmd.ReorderPost) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		reference, before := c.After, false
+		if c.Before != nil {
+			reference, before = c.Before, true
+		}
+		if reference == nil {
+			return errors.New("ReorderPost requires either Before or After")
+		}
+
+		status := reference.Status
+		rank, ok, err := findPostRank(trx, tenant, status, c.Post.ID, reference.ID, before)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			_, err = trx.Execute(`
+			UPDATE posts 
+			SET rank = r.n * $4
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (ORDER BY rank, id) AS n
+				FROM posts
+				WHERE tenant_id = $1 AND status = $2 AND rank IS NOT NULL AND id != $3
+			) r
+			WHERE posts.id = r.id AND posts.tenant_id = $1
+			`, tenant.ID, status, c.Post.ID, postRankGap)
+			if err != nil {
+				return errors.Wrap(err, "failed to renumber post ranks")
+			}
+
+			rank, _, err = findPostRank(trx, tenant, status, c.Post.ID, reference.ID, before)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = trx.Execute(`UPDATE posts SET rank = $3 WHERE id = $1 AND tenant_id = $2`, c.Post.ID, tenant.ID, rank)
+		if err != nil {
+			return errors.Wrap(err, "failed to update post's rank")
+		}
+
+		c.Post.Rank = &rank
+		return nil
+	})
+}
+
+// findPostRank returns the rank right before or after the reference post, and false if there is no room for it
+func findPostRank(trx *dbx.Trx, tenant *entity.Tenant, status enum.PostStatus, postID, referenceID int, before bool) (float64, bool, error) {
+	var refRank sql.NullFloat64
+	err := trx.Scalar(&refRank, "SELECT rank FROM posts WHERE id = $1 AND tenant_id = $2", referenceID, tenant.ID)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "failed to get post's rank")
+	}
+
+	if !refRank.Valid {
+		_, err = trx.Execute(`
+		WITH unranked AS (
+			SELECT p.id, ROW_NUMBER() OVER (
+				ORDER BY (SELECT COUNT(*) FROM post_votes v WHERE v.post_id = p.id AND v.tenant_id = p.tenant_id) DESC, p.id
+			) AS n
+			FROM posts p
+			WHERE p.tenant_id = $1 AND p.status = $2 AND p.rank IS NULL AND p.id != $3
+		), last AS (
+			SELECT COALESCE(MAX(rank), 0) AS rank
+			FROM posts
+			WHERE tenant_id = $1 AND status = $2 AND id != $3
+		)
+		UPDATE posts 
+		SET rank = last.rank + unranked.n * $4
+		FROM unranked, last
+		WHERE posts.id = unranked.id AND posts.tenant_id = $1
+		`, tenant.ID, status, postID, postRankGap)
+		if err != nil {
+			return 0, false, errors.Wrap(err, "failed to rank posts")
+		}
+
+		err = trx.Scalar(&refRank, "SELECT rank FROM posts WHERE id = $1 AND tenant_id = $2", referenceID, tenant.ID)
+		if err != nil {
+			return 0, false, errors.Wrap(err, "failed to get post's rank")
+		}
+	}
+
+	neighbourQuery := "SELECT MAX(rank) FROM posts WHERE tenant_id = $1 AND status = $2 AND id != $3 AND rank < $4"
+	if !before {
+		neighbourQuery = "SELECT MIN(rank) FROM posts WHERE tenant_id = $1 AND status = $2 AND id != $3 AND rank > $4"
+	}
+
+	var neighbour sql.NullFloat64
+	if err := trx.Scalar(&neighbour, neighbourQuery, tenant.ID, status, postID, refRank.Float64); err != nil {
+		return 0, false, errors.Wrap(err, "failed to get neighbour post's rank")
+	}
+
+	if !neighbour.Valid {
+		if before {
+			return refRank.Float64 - postRankGap, true, nil
+		}
+		return refRank.Float64 + postRankGap, true, nil
+	}
+
+	if math.Abs(refRank.Float64-neighbour.Float64) < postRankMinGap {
+		return 0, false, nil
+	}
+	return (refRank.Float64 + neighbour.Float64) / 2, true, nil
+}
+
+func backdatePost(ctx context.Context, c *cmd.BackdatePost) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE posts SET created_at = $3 WHERE id = $1 AND tenant_id = $2`, c.Post.ID, tenant.ID, c.CreatedAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to backdate post")
+		}
+		c.Post.CreatedAt = c.CreatedAt
+
+		if !c.RespondedAt.IsZero() && c.Post.Response != nil {
+			_, err := trx.Execute(`UPDATE posts SET response_date = $3 WHERE id = $1 AND tenant_id = $2`, c.Post.ID, tenant.ID, c.RespondedAt)
+			if err != nil {
+				return errors.Wrap(err, "failed to backdate post's response")
+			}
+			c.Post.Response.RespondedAt = c.RespondedAt
+		}
+		return nil
+	})
+}
+
 func updatePost(ctx context.Context, c *cmd.UpdatePost) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		_, err := trx.Execute(`UPDATE posts SET title = $1, slug = $2, description = $3 
@@ -329,18 +553,20 @@ func updatePost(ctx context.Context, c *cmd.UpdatePost) error {
 
 func getPostByID(ctx context.Context, q *query.GetPostByID) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		post, err := querySinglePost(ctx, trx, buildPostQuery(user, "p.tenant_id = $1 AND p.id = $2"), tenant.ID, q.PostID)
+		includeStaffFields := q.IncludeStaffFields || (user != nil && user.IsCollaborator())
+		post := dbPost{}
+		err := trx.Get(&post, buildPostQueryWith(tenant, user, includeStaffFields, "p.tenant_id = $1 AND p.id = $2"), tenant.ID, q.PostID)
 		if err != nil {
 			return errors.Wrap(err, "failed to get post with id '%d'", q.PostID)
 		}
-		q.Result = post
+		q.Result = post.toModelWith(ctx, includeStaffFields)
 		return nil
 	})
 }
 
 func getPostBySlug(ctx context.Context, q *query.GetPostBySlug) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		post, err := querySinglePost(ctx, trx, buildPostQuery(user, "p.tenant_id = $1 AND p.slug = $2"), tenant.ID, q.Slug)
+		post, err := querySinglePost(ctx, trx, buildPostQuery(tenant, user, "p.tenant_id = $1 AND p.slug = $2"), tenant.ID, q.Slug)
 		if err != nil {
 			return errors.Wrap(err, "failed to get post with slug '%s'", q.Slug)
 		}
@@ -351,7 +577,7 @@ func getPostBySlug(ctx context.Context, q *query.GetPostBySlug) error {
 
 func getPostByNumber(ctx context.Context, q *query.GetPostByNumber) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		post, err := querySinglePost(ctx, trx, buildPostQuery(user, "p.tenant_id = $1 AND p.number = $2"), tenant.ID, q.Number)
+		post, err := querySinglePost(ctx, trx, buildPostQuery(tenant, user, "p.tenant_id = $1 AND p.number = $2"), tenant.ID, q.Number)
 		if err != nil {
 			return errors.Wrap(err, "failed to get post with number '%d'", q.Number)
 		}
@@ -360,9 +586,29 @@ func getPostByNumber(ctx context.Context, q *query.GetPostByNumber) error {
 	})
 }
 
+func getPostsByNumbers(ctx context.Context, q *query.GetPostsByNumbers) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make(map[int]*entity.Post, len(q.Numbers))
+		if len(q.Numbers) == 0 {
+			return nil
+		}
+
+		posts := []*dbPost{}
+		err := trx.Select(&posts, buildPostQuery(tenant, user, "p.tenant_id = $1 AND p.number = ANY($2)"), tenant.ID, pq.Array(q.Numbers))
+		if err != nil {
+			return errors.Wrap(err, "failed to get posts by numbers")
+		}
+
+		for _, post := range posts {
+			q.Result[post.Number] = post.toModel(ctx)
+		}
+		return nil
+	})
+}
+
 func searchPosts(ctx context.Context, q *query.SearchPosts) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		innerQuery := buildPostQuery(user, "p.tenant_id = $1 AND p.status = ANY($2)")
+		innerQuery := buildPostQuery(tenant, user, "p.tenant_id = $1 AND p.status = ANY($2)")
 
 		if q.Tags == nil {
 			q.Tags = []string{}
@@ -394,7 +640,7 @@ func searchPosts(ctx context.Context, q *query.SearchPosts) error {
 				enum.PostDeclined,
 			}), ToTSQuery(q.Query), SanitizeString(q.Query))
 		} else {
-			condition, statuses, sort := getViewData(q.View)
+			condition, statuses, sort := getViewData(user, q.View)
 			sql := fmt.Sprintf(`
 				SELECT * FROM (%s) AS q 
 				WHERE tags @> $3 %s
@@ -427,6 +673,57 @@ func getAllPosts(ctx context.Context, q *query.GetAllPosts) error {
 	})
 }
 
+func getRoadmap(ctx context.Context, q *query.GetRoadmap) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if len(q.Statuses) == 0 {
+			q.Statuses = []enum.PostStatus{enum.PostPlanned, enum.PostStarted}
+		}
+
+		sort := "votes_count DESC, id"
+		if q.View == "ranked" {
+			// Ranked posts come first on each status, followed by the unranked ones in the same order they'd be ranked
+			sort = "status, rank NULLS LAST, votes_count DESC, id"
+		}
+
+		var posts []*dbPost
+		sql := fmt.Sprintf(`
+			SELECT * FROM (%s) AS q 
+			ORDER BY %s
+		`, buildPostQuery(tenant, user, "p.tenant_id = $1 AND p.status = ANY($2)"), sort)
+		if err := trx.Select(&posts, sql, tenant.ID, pq.Array(q.Statuses)); err != nil {
+			return errors.Wrap(err, "failed to get roadmap")
+		}
+
+		// Targets hidden from current user were already removed, so those posts end up on the group without target
+		groups := make([]*entity.RoadmapGroup, 0)
+		for _, dbPost := range posts {
+			post := dbPost.toModel(ctx)
+			idx := slices.IndexFunc(groups, func(g *entity.RoadmapGroup) bool {
+				return sameRoadmapTarget(g.Target, post.Target)
+			})
+			if idx < 0 {
+				groups = append(groups, &entity.RoadmapGroup{Target: post.Target, Posts: []*entity.Post{}})
+				idx = len(groups) - 1
+			}
+			groups[idx].Posts = append(groups[idx].Posts, post)
+		}
+
+		slices.SortStableFunc(groups, func(a, b *entity.RoadmapGroup) int {
+			return entity.ComparePostTargets(a.Target, b.Target)
+		})
+		q.Result = groups
+		return nil
+	})
+}
+
+// sameRoadmapTarget ignores the visibility, so that public and private posts of a target are listed together
+func sameRoadmapTarget(a, b *entity.PostTarget) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Kind == b.Kind && a.Value == b.Value
+}
+
 func querySinglePost(ctx context.Context, trx *dbx.Trx, query string, args ...any) (*entity.Post, error) {
 	post := dbPost{}
 
@@ -437,14 +734,32 @@ func querySinglePost(ctx context.Context, trx *dbx.Trx, query string, args ...an
 	return post.toModel(ctx), nil
 }
 
-func buildPostQuery(user *entity.User, filter string) string {
+func buildPostQuery(tenant *entity.Tenant, user *entity.User, filter string) string {
+	return buildPostQueryWith(tenant, user, user != nil && user.IsCollaborator(), filter)
+}
+
+func buildPostQueryWith(tenant *entity.Tenant, user *entity.User, includeStaffFields bool, filter string) string {
 	tagCondition := `AND tags.is_public = true`
-	if user != nil && user.IsCollaborator() {
+	if includeStaffFields {
 		tagCondition = ``
 	}
 	hasVotedSubQuery := "null"
 	if user != nil {
 		hasVotedSubQuery = fmt.Sprintf("(SELECT true FROM post_votes WHERE post_id = p.id AND user_id = %d)", user.ID)
 	}
-	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, filter)
+	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, postScoreFormula(tenant.PrioritizationFormula), filter)
+}
+
+func postScoreFormula(formula enum.PrioritizationFormula) string {
+	if formula == enum.PrioritizationICE {
+		return "p.score_impact * p.score_confidence / 100 / NULLIF(p.score_effort, 0)"
+	}
+	return "p.score_reach * p.score_impact * p.score_confidence / 100 / NULLIF(p.score_effort, 0)"
+}
+
+func nullFloat(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
 }
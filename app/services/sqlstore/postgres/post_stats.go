@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"math"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/analytics"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type dbDailyPostViews struct {
+	Day            string `db:"day"`
+	Views          int    `db:"views"`
+	UniqueVisitors int    `db:"unique_visitors"`
+}
+
+type dbPostViewStats struct {
+	PostNumber     int    `db:"number"`
+	PostTitle      string `db:"title"`
+	PostSlug       string `db:"slug"`
+	Views          int    `db:"views"`
+	UniqueVisitors int    `db:"unique_visitors"`
+	Votes          int    `db:"votes"`
+}
+
+type dbReferrerStats struct {
+	Domain string `db:"domain"`
+	Views  int    `db:"views"`
+}
+
+func (s *dbPostViewStats) toModel() *entity.PostViewStats {
+	return &entity.PostViewStats{
+		PostNumber:     s.PostNumber,
+		PostTitle:      s.PostTitle,
+		PostSlug:       s.PostSlug,
+		Views:          s.Views,
+		UniqueVisitors: s.UniqueVisitors,
+		Votes:          s.Votes,
+		ConversionRate: conversionRate(s.Votes, s.UniqueVisitors),
+	}
+}
+
+// conversionRate is the ratio of votes to unique visitors, rounded to 4 decimal places
+func conversionRate(votes, uniqueVisitors int) float64 {
+	if uniqueVisitors == 0 {
+		return 0
+	}
+	return math.Round(float64(votes)/float64(uniqueVisitors)*10000) / 10000
+}
+
+func recordPostView(ctx context.Context, c *cmd.RecordPostView) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		day := analytics.Day(c.ViewedAt)
+
+		isNewVisitor, err := trx.Execute(`
+			INSERT INTO post_view_visitors (tenant_id, post_id, day, visitor_hash)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING
+		`, tenant.ID, c.PostID, day, c.VisitorHash)
+		if err != nil {
+			return errors.Wrap(err, "failed to add visitor of post '%d'", c.PostID)
+		}
+
+		_, err = trx.Execute(`
+			INSERT INTO post_view_stats (tenant_id, post_id, day, views, unique_visitors)
+			VALUES ($1, $2, $3, 1, $4)
+			ON CONFLICT (tenant_id, post_id, day) DO UPDATE
+			SET views = post_view_stats.views + 1, unique_visitors = post_view_stats.unique_visitors + EXCLUDED.unique_visitors
+		`, tenant.ID, c.PostID, day, isNewVisitor)
+		if err != nil {
+			return errors.Wrap(err, "failed to increase views of post '%d'", c.PostID)
+		}
+
+		if c.ReferrerDomain != "" {
+			_, err = trx.Execute(`
+				INSERT INTO post_referrer_stats (tenant_id, post_id, day, domain, views)
+				VALUES ($1, $2, $3, $4, 1)
+				ON CONFLICT (tenant_id, post_id, day, domain) DO UPDATE
+				SET views = post_referrer_stats.views + 1
+			`, tenant.ID, c.PostID, day, c.ReferrerDomain)
+			if err != nil {
+				return errors.Wrap(err, "failed to increase views from '%s' of post '%d'", c.ReferrerDomain, c.PostID)
+			}
+		}
+
+		return nil
+	})
+}
+
+func purgeExpiredPostStats(ctx context.Context, c *cmd.PurgeExpiredPostStats) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		before := analytics.Day(c.Before)
+
+		for _, table := range []string{"post_view_stats", "post_referrer_stats"} {
+			count, err := trx.Execute("DELETE FROM "+table+" WHERE day < $1", before)
+			if err != nil {
+				return errors.Wrap(err, "failed to delete expired rows of '%s'", table)
+			}
+			c.NumOfDeletedRows += int(count)
+		}
+
+		_, err := trx.Execute("DELETE FROM post_view_visitors WHERE day < CURRENT_DATE - 1")
+		if err != nil {
+			return errors.Wrap(err, "failed to delete visitors of past days")
+		}
+		return nil
+	})
+}
+
+func getPostStats(ctx context.Context, q *query.GetPostStats) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		since := analytics.Day(q.Since)
+		q.Result = &entity.PostStats{
+			Daily:     make([]*entity.DailyPostViews, 0),
+			Referrers: make([]*entity.ReferrerStats, 0),
+		}
+
+		days := []*dbDailyPostViews{}
+		err := trx.Select(&days, `
+			SELECT to_char(day, 'YYYY-MM-DD') AS day, views, unique_visitors
+			FROM post_view_stats
+			WHERE tenant_id = $1 AND post_id = $2 AND day >= $3
+			ORDER BY day
+		`, tenant.ID, q.PostID, since)
+		if err != nil {
+			return errors.Wrap(err, "failed to get daily views of post '%d'", q.PostID)
+		}
+
+		for _, day := range days {
+			q.Result.Views += day.Views
+			q.Result.UniqueVisitors += day.UniqueVisitors
+			q.Result.Daily = append(q.Result.Daily, &entity.DailyPostViews{
+				Day:            day.Day,
+				Views:          day.Views,
+				UniqueVisitors: day.UniqueVisitors,
+			})
+		}
+
+		referrers := []*dbReferrerStats{}
+		err = trx.Select(&referrers, `
+			SELECT domain, SUM(views) AS views
+			FROM post_referrer_stats
+			WHERE tenant_id = $1 AND post_id = $2 AND day >= $3
+			GROUP BY domain
+			ORDER BY views DESC, domain
+			LIMIT 20
+		`, tenant.ID, q.PostID, since)
+		if err != nil {
+			return errors.Wrap(err, "failed to get referrers of post '%d'", q.PostID)
+		}
+
+		for _, referrer := range referrers {
+			q.Result.Referrers = append(q.Result.Referrers, &entity.ReferrerStats{Domain: referrer.Domain, Views: referrer.Views})
+		}
+
+		err = trx.Scalar(&q.Result.Votes, `
+			SELECT COUNT(*) FROM post_votes WHERE tenant_id = $1 AND post_id = $2 AND created_at >= $3
+		`, tenant.ID, q.PostID, q.Since)
+		if err != nil {
+			return errors.Wrap(err, "failed to count votes of post '%d'", q.PostID)
+		}
+
+		q.Result.ConversionRate = conversionRate(q.Result.Votes, q.Result.UniqueVisitors)
+		return nil
+	})
+}
+
+func listTopPostsByViews(ctx context.Context, q *query.ListTopPostsByViews) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		posts := []*dbPostViewStats{}
+		err := trx.Select(&posts, `
+			SELECT p.number, p.title, p.slug, SUM(s.views) AS views, SUM(s.unique_visitors) AS unique_visitors,
+			(SELECT COUNT(*) FROM post_votes v WHERE v.tenant_id = p.tenant_id AND v.post_id = p.id AND v.created_at >= $3) AS votes
+			FROM post_view_stats s
+			INNER JOIN posts p
+			ON p.id = s.post_id
+			AND p.tenant_id = s.tenant_id
+			WHERE s.tenant_id = $1 AND s.day >= $2 AND p.status != $4
+			GROUP BY p.id, p.tenant_id, p.number, p.title, p.slug
+			ORDER BY views DESC, p.number DESC
+			LIMIT $5
+		`, tenant.ID, analytics.Day(q.Since), q.Since, enum.PostDeleted, q.Limit)
+		if err != nil {
+			return errors.Wrap(err, "failed to list top posts by views")
+		}
+
+		q.Result = make([]*entity.PostViewStats, len(posts))
+		for i, post := range posts {
+			q.Result[i] = post.toModel()
+		}
+		return nil
+	})
+}
+
+func listTopReferrers(ctx context.Context, q *query.ListTopReferrers) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
+		referrers := []*dbReferrerStats{}
+		err := trx.Select(&referrers, `
+			SELECT domain, SUM(views) AS views
+			FROM post_referrer_stats
+			WHERE tenant_id = $1 AND day >= $2
+			GROUP BY domain
+			ORDER BY views DESC, domain
+			LIMIT $3
+		`, tenant.ID, analytics.Day(q.Since), q.Limit)
+		if err != nil {
+			return errors.Wrap(err, "failed to list top referrers")
+		}
+
+		q.Result = make([]*entity.ReferrerStats, len(referrers))
+		for i, referrer := range referrers {
+			q.Result[i] = &entity.ReferrerStats{Domain: referrer.Domain, Views: referrer.Views}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,74 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestPostStatsStorage_RecordAndGet(t *testing.T) {
+	ctx := SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	for _, view := range []*cmd.RecordPostView{
+		{PostID: newPost.Result.ID, ViewedAt: yesterday, VisitorHash: "visitor-1"},
+		{PostID: newPost.Result.ID, ViewedAt: now, VisitorHash: "visitor-1", ReferrerDomain: "google.com"},
+		{PostID: newPost.Result.ID, ViewedAt: now, VisitorHash: "visitor-1"},
+		{PostID: newPost.Result.ID, ViewedAt: now, VisitorHash: "visitor-2", ReferrerDomain: "google.com"},
+		{PostID: newPost.Result.ID, ViewedAt: now, VisitorHash: "visitor-3", ReferrerDomain: "news.ycombinator.com"},
+	} {
+		err = bus.Dispatch(demoTenantCtx, view)
+		Expect(err).IsNil()
+	}
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AddVote{Post: newPost.Result, User: aryaStark})
+	Expect(err).IsNil()
+
+	getStats := &query.GetPostStats{PostID: newPost.Result.ID, Since: now.Add(-7 * 24 * time.Hour)}
+	err = bus.Dispatch(demoTenantCtx, getStats)
+	Expect(err).IsNil()
+	Expect(getStats.Result.Views).Equals(5)
+	Expect(getStats.Result.UniqueVisitors).Equals(4)
+	Expect(getStats.Result.Votes).Equals(1)
+	Expect(getStats.Result.ConversionRate).Equals(0.25)
+	Expect(getStats.Result.Daily).HasLen(2)
+	Expect(getStats.Result.Daily[1].Views).Equals(4)
+	Expect(getStats.Result.Daily[1].UniqueVisitors).Equals(3)
+	Expect(getStats.Result.Referrers).HasLen(2)
+	Expect(getStats.Result.Referrers[0].Domain).Equals("google.com")
+	Expect(getStats.Result.Referrers[0].Views).Equals(2)
+
+	topPosts := &query.ListTopPostsByViews{Since: now, Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, topPosts)
+	Expect(err).IsNil()
+	Expect(topPosts.Result).HasLen(1)
+	Expect(topPosts.Result[0].PostNumber).Equals(newPost.Result.Number)
+	Expect(topPosts.Result[0].Views).Equals(4)
+	Expect(topPosts.Result[0].Votes).Equals(1)
+
+	topReferrers := &query.ListTopReferrers{Since: now, Limit: 1}
+	err = bus.Dispatch(demoTenantCtx, topReferrers)
+	Expect(err).IsNil()
+	Expect(topReferrers.Result).HasLen(1)
+	Expect(topReferrers.Result[0].Domain).Equals("google.com")
+
+	otherTenantStats := &query.ListTopReferrers{Since: now, Limit: 10}
+	err = bus.Dispatch(avengersTenantCtx, otherTenantStats)
+	Expect(err).IsNil()
+	Expect(otherTenantStats.Result).HasLen(0)
+
+	purge := &cmd.PurgeExpiredPostStats{Before: now}
+	err = bus.Dispatch(ctx, purge)
+	Expect(err).IsNil()
+	Expect(purge.NumOfDeletedRows).Equals(1)
+}
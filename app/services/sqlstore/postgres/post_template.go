@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbPostTemplate struct {
+	ID               int      `db:"id"`
+	Name             string   `db:"name"`
+	Content          string   `db:"content"`
+	RequiredSections []string `db:"required_sections"`
+	IsDefault        bool     `db:"is_default"`
+	Tags             []string `db:"tags"`
+}
+
+func (t *dbPostTemplate) toModel() *entity.PostTemplate {
+	return &entity.PostTemplate{
+		ID:               t.ID,
+		Name:             t.Name,
+		Content:          t.Content,
+		RequiredSections: t.RequiredSections,
+		IsDefault:        t.IsDefault,
+		Tags:             t.Tags,
+	}
+}
+
+const sqlSelectPostTemplates = `
+	SELECT pt.id, pt.name, pt.content, pt.required_sections, pt.is_default,
+				 ARRAY(
+					 SELECT t.slug FROM tags t
+					 WHERE t.post_template_id = pt.id AND t.tenant_id = pt.tenant_id
+					 ORDER BY t.name
+				 ) AS tags
+	FROM post_templates pt
+`
+
+func getPostTemplateByID(ctx context.Context, q *query.GetPostTemplateByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		template, err := queryPostTemplate(trx, sqlSelectPostTemplates+"WHERE pt.tenant_id = $1 AND pt.id = $2", tenant.ID, q.TemplateID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get post template with id '%d'", q.TemplateID)
+		}
+		q.Result = template
+		return nil
+	})
+}
+
+func getPostTemplateForTag(ctx context.Context, q *query.GetPostTemplateForTag) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = nil
+
+		if q.Tag != nil {
+			template, err := queryPostTemplate(trx, sqlSelectPostTemplates+`
+				INNER JOIN tags tt
+				ON tt.post_template_id = pt.id
+				AND tt.tenant_id = pt.tenant_id
+				WHERE pt.tenant_id = $1 AND tt.id = $2
+			`, tenant.ID, q.Tag.ID)
+			if err == nil {
+				q.Result = template
+				return nil
+			}
+			if errors.Cause(err) != app.ErrNotFound {
+				return errors.Wrap(err, "failed to get post template of tag '%s'", q.Tag.Slug)
+			}
+		}
+
+		template, err := queryPostTemplate(trx, sqlSelectPostTemplates+"WHERE pt.tenant_id = $1 AND pt.is_default = true", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get default post template")
+		}
+		q.Result = template
+		return nil
+	})
+}
+
+func getAllPostTemplates(ctx context.Context, q *query.GetAllPostTemplates) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.PostTemplate, 0)
+
+		templates := []*dbPostTemplate{}
+		err := trx.Select(&templates, sqlSelectPostTemplates+"WHERE pt.tenant_id = $1 ORDER BY pt.name", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all post templates")
+		}
+
+		for _, template := range templates {
+			q.Result = append(q.Result, template.toModel())
+		}
+		return nil
+	})
+}
+
+func addNewPostTemplate(ctx context.Context, c *cmd.AddNewPostTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		c.Result = nil
+
+		if c.IsDefault {
+			if err := clearDefaultPostTemplate(trx, tenant); err != nil {
+				return err
+			}
+		}
+
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO post_templates (name, content, required_sections, is_default, created_at, tenant_id)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
+		`, c.Name, c.Content, pq.Array(nonNilStrings(c.RequiredSections)), c.IsDefault, time.Now(), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to add new post template")
+		}
+
+		if err := attachPostTemplateToTags(trx, tenant, id, c.Tags); err != nil {
+			return err
+		}
+
+		template, err := queryPostTemplate(trx, sqlSelectPostTemplates+"WHERE pt.tenant_id = $1 AND pt.id = $2", tenant.ID, id)
+		c.Result = template
+		return err
+	})
+}
+
+func updatePostTemplate(ctx context.Context, c *cmd.UpdatePostTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		c.Result = nil
+
+		if c.IsDefault {
+			if err := clearDefaultPostTemplate(trx, tenant); err != nil {
+				return err
+			}
+		}
+
+		_, err := trx.Execute(`
+			UPDATE post_templates SET name = $1, content = $2, required_sections = $3, is_default = $4
+			WHERE id = $5 AND tenant_id = $6
+		`, c.Name, c.Content, pq.Array(nonNilStrings(c.RequiredSections)), c.IsDefault, c.TemplateID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to update post template")
+		}
+
+		if err := attachPostTemplateToTags(trx, tenant, c.TemplateID, c.Tags); err != nil {
+			return err
+		}
+
+		template, err := queryPostTemplate(trx, sqlSelectPostTemplates+"WHERE pt.tenant_id = $1 AND pt.id = $2", tenant.ID, c.TemplateID)
+		c.Result = template
+		return err
+	})
+}
+
+func deletePostTemplate(ctx context.Context, c *cmd.DeletePostTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE tags SET post_template_id = NULL WHERE post_template_id = $1 AND tenant_id = $2`, c.Template.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to detach post template with id '%d' from tags", c.Template.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM post_templates WHERE id = $1 AND tenant_id = $2`, c.Template.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete post template with id '%d'", c.Template.ID)
+		}
+		return nil
+	})
+}
+
+func clearDefaultPostTemplate(trx *dbx.Trx, tenant *entity.Tenant) error {
+	_, err := trx.Execute(`UPDATE post_templates SET is_default = false WHERE tenant_id = $1 AND is_default = true`, tenant.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to clear default post template")
+	}
+	return nil
+}
+
+func attachPostTemplateToTags(trx *dbx.Trx, tenant *entity.Tenant, templateID int, tags []*entity.Tag) error {
+	tagIDs := make([]int, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	_, err := trx.Execute(`
+		UPDATE tags SET post_template_id = NULL
+		WHERE tenant_id = $1 AND post_template_id = $2 AND id <> ALL($3)
+	`, tenant.ID, templateID, pq.Array(tagIDs))
+	if err != nil {
+		return errors.Wrap(err, "failed to detach post template with id '%d' from tags", templateID)
+	}
+
+	_, err = trx.Execute(`
+		UPDATE tags SET post_template_id = $2
+		WHERE tenant_id = $1 AND id = ANY($3)
+	`, tenant.ID, templateID, pq.Array(tagIDs))
+	if err != nil {
+		return errors.Wrap(err, "failed to attach post template with id '%d' to tags", templateID)
+	}
+	return nil
+}
+
+func queryPostTemplate(trx *dbx.Trx, query string, args ...any) (*entity.PostTemplate, error) {
+	template := dbPostTemplate{}
+	if err := trx.Get(&template, query, args...); err != nil {
+		return nil, err
+	}
+	return template.toModel(), nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,139 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestPostTemplateStorage_AddAndGet(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewTag := &cmd.AddNewTag{Name: "Bug", Color: "FF0000", IsPublic: true}
+	err := bus.Dispatch(demoTenantCtx, addNewTag)
+	Expect(err).IsNil()
+
+	addNewTemplate := &cmd.AddNewPostTemplate{
+		Name:             "Bug Report",
+		Content:          "## Steps to reproduce\n\n## Expected behavior",
+		RequiredSections: []string{"Steps to reproduce"},
+		Tags:             []*entity.Tag{addNewTag.Result},
+	}
+	err = bus.Dispatch(demoTenantCtx, addNewTemplate)
+	Expect(err).IsNil()
+	Expect(addNewTemplate.Result.ID).NotEquals(0)
+
+	getTemplate := &query.GetPostTemplateByID{TemplateID: addNewTemplate.Result.ID}
+	err = bus.Dispatch(demoTenantCtx, getTemplate)
+	Expect(err).IsNil()
+	Expect(getTemplate.Result.Name).Equals("Bug Report")
+	Expect(getTemplate.Result.Content).Equals("## Steps to reproduce\n\n## Expected behavior")
+	Expect(getTemplate.Result.RequiredSections).Equals([]string{"Steps to reproduce"})
+	Expect(getTemplate.Result.IsDefault).IsFalse()
+	Expect(getTemplate.Result.Tags).Equals([]string{"bug"})
+
+	getTemplate = &query.GetPostTemplateByID{TemplateID: addNewTemplate.Result.ID}
+	err = bus.Dispatch(avengersTenantCtx, getTemplate)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestPostTemplateStorage_GetForTag(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addBug := &cmd.AddNewTag{Name: "Bug", Color: "FF0000", IsPublic: true}
+	addFeature := &cmd.AddNewTag{Name: "Feature", Color: "00FF00", IsPublic: true}
+	err := bus.Dispatch(demoTenantCtx, addBug, addFeature)
+	Expect(err).IsNil()
+
+	getForTag := &query.GetPostTemplateForTag{Tag: addBug.Result}
+	err = bus.Dispatch(demoTenantCtx, getForTag)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	addDefault := &cmd.AddNewPostTemplate{Name: "General", Content: "## Context", IsDefault: true}
+	addBugReport := &cmd.AddNewPostTemplate{Name: "Bug Report", Content: "## Steps to reproduce", Tags: []*entity.Tag{addBug.Result}}
+	err = bus.Dispatch(demoTenantCtx, addDefault, addBugReport)
+	Expect(err).IsNil()
+
+	getForTag = &query.GetPostTemplateForTag{Tag: addBug.Result}
+	err = bus.Dispatch(demoTenantCtx, getForTag)
+	Expect(err).IsNil()
+	Expect(getForTag.Result.ID).Equals(addBugReport.Result.ID)
+
+	getForTag = &query.GetPostTemplateForTag{Tag: addFeature.Result}
+	err = bus.Dispatch(demoTenantCtx, getForTag)
+	Expect(err).IsNil()
+	Expect(getForTag.Result.ID).Equals(addDefault.Result.ID)
+
+	getForTag = &query.GetPostTemplateForTag{}
+	err = bus.Dispatch(demoTenantCtx, getForTag)
+	Expect(err).IsNil()
+	Expect(getForTag.Result.ID).Equals(addDefault.Result.ID)
+}
+
+func TestPostTemplateStorage_UpdateMovesDefaultAndTags(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addBug := &cmd.AddNewTag{Name: "Bug", Color: "FF0000", IsPublic: true}
+	addFeature := &cmd.AddNewTag{Name: "Feature", Color: "00FF00", IsPublic: true}
+	err := bus.Dispatch(demoTenantCtx, addBug, addFeature)
+	Expect(err).IsNil()
+
+	addFirst := &cmd.AddNewPostTemplate{Name: "First", Content: "## A", IsDefault: true, Tags: []*entity.Tag{addBug.Result}}
+	addSecond := &cmd.AddNewPostTemplate{Name: "Second", Content: "## B"}
+	err = bus.Dispatch(demoTenantCtx, addFirst, addSecond)
+	Expect(err).IsNil()
+
+	updateSecond := &cmd.UpdatePostTemplate{
+		TemplateID: addSecond.Result.ID,
+		Name:       "Second",
+		Content:    "## B",
+		IsDefault:  true,
+		Tags:       []*entity.Tag{addBug.Result, addFeature.Result},
+	}
+	err = bus.Dispatch(demoTenantCtx, updateSecond)
+	Expect(err).IsNil()
+	Expect(updateSecond.Result.IsDefault).IsTrue()
+	Expect(updateSecond.Result.Tags).Equals([]string{"bug", "feature"})
+
+	getAll := &query.GetAllPostTemplates{}
+	err = bus.Dispatch(demoTenantCtx, getAll)
+	Expect(err).IsNil()
+	Expect(getAll.Result).HasLen(2)
+	Expect(getAll.Result[0].Name).Equals("First")
+	Expect(getAll.Result[0].IsDefault).IsFalse()
+	Expect(getAll.Result[0].Tags).HasLen(0)
+	Expect(getAll.Result[1].Name).Equals("Second")
+}
+
+func TestPostTemplateStorage_Delete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addBug := &cmd.AddNewTag{Name: "Bug", Color: "FF0000", IsPublic: true}
+	err := bus.Dispatch(demoTenantCtx, addBug)
+	Expect(err).IsNil()
+
+	addTemplate := &cmd.AddNewPostTemplate{Name: "Bug Report", Content: "## Steps", Tags: []*entity.Tag{addBug.Result}}
+	err = bus.Dispatch(demoTenantCtx, addTemplate)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.DeletePostTemplate{Template: addTemplate.Result})
+	Expect(err).IsNil()
+
+	getForTag := &query.GetPostTemplateForTag{Tag: addBug.Result}
+	err = bus.Dispatch(demoTenantCtx, getForTag)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	getTag := &query.GetTagBySlug{Slug: "bug"}
+	err = bus.Dispatch(demoTenantCtx, getTag)
+	Expect(err).IsNil()
+}
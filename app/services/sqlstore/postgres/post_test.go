@@ -1,11 +1,13 @@
 package postgres_test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 
@@ -96,6 +98,29 @@ func TestPostStorage_AddAndGet(t *testing.T) {
 	Expect(postBySlug.Result.User.Email).Equals("jon.snow@got.com")
 }
 
+func TestPostStorage_GetPostsByNumbers(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	post1 := &cmd.AddNewPost{Title: "My first post", Description: "with this description"}
+	post2 := &cmd.AddNewPost{Title: "My second post", Description: "with another description"}
+	err := bus.Dispatch(jonSnowCtx, post1, post2)
+	Expect(err).IsNil()
+
+	getPosts := &query.GetPostsByNumbers{Numbers: []int{post2.Result.Number, 999}}
+	err = bus.Dispatch(jonSnowCtx, getPosts)
+	Expect(err).IsNil()
+	Expect(getPosts.Result).HasLen(1)
+	Expect(getPosts.Result[post2.Result.Number].ID).Equals(post2.Result.ID)
+	Expect(getPosts.Result[post2.Result.Number].Title).Equals("My second post")
+
+	// Posts of other tenants are not returned
+	getPosts = &query.GetPostsByNumbers{Numbers: []int{post1.Result.Number}}
+	err = bus.Dispatch(avengersTenantCtx, getPosts)
+	Expect(err).IsNil()
+	Expect(getPosts.Result).HasLen(0)
+}
+
 func TestPostStorage_GetInvalid(t *testing.T) {
 	SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
@@ -131,6 +156,83 @@ func TestPostStorage_AddAndReturnComments(t *testing.T) {
 	Expect(commentsByPost.Result[1].User.Name).Equals("Arya Stark")
 }
 
+func TestPostStorage_GetCommentsByPost_Paginated(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	for i := 1; i <= 3; i++ {
+		err = bus.Dispatch(jonSnowCtx, &cmd.AddNewComment{Post: newPost.Result, Content: fmt.Sprintf("Comment #%d", i)})
+		Expect(err).IsNil()
+	}
+
+	firstPage := &query.GetCommentsByPost{Post: newPost.Result, Limit: 2}
+	err = bus.Dispatch(aryaStarkCtx, firstPage)
+	Expect(err).IsNil()
+	Expect(firstPage.Result).HasLen(2)
+	Expect(firstPage.Result[0].Content).Equals("Comment #1")
+	Expect(firstPage.Result[1].Content).Equals("Comment #2")
+
+	secondPage := &query.GetCommentsByPost{Post: newPost.Result, Limit: 2, After: firstPage.Result[1].ID}
+	err = bus.Dispatch(aryaStarkCtx, secondPage)
+	Expect(err).IsNil()
+	Expect(secondPage.Result).HasLen(1)
+	Expect(secondPage.Result[0].Content).Equals("Comment #3")
+
+	unknownCursor := &query.GetCommentsByPost{Post: newPost.Result, Limit: 2, After: 999999}
+	err = bus.Dispatch(aryaStarkCtx, unknownCursor)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	newest := &query.GetCommentsByPost{Post: newPost.Result, Sort: "newest", Limit: 2}
+	err = bus.Dispatch(aryaStarkCtx, newest)
+	Expect(err).IsNil()
+	Expect(newest.Result).HasLen(2)
+	Expect(newest.Result[0].Content).Equals("Comment #3")
+	Expect(newest.Result[1].Content).Equals("Comment #2")
+
+	future := time.Now().Add(time.Hour)
+	since := &query.GetCommentsByPost{Post: newPost.Result, Since: &future}
+	err = bus.Dispatch(aryaStarkCtx, since)
+	Expect(err).IsNil()
+	Expect(since.Result).HasLen(0)
+}
+
+func TestPostStorage_GetCommentsByPosts(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost1 := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	newPost2 := &cmd.AddNewPost{Title: "My other post", Description: "with another description"}
+	newPost3 := &cmd.AddNewPost{Title: "My quiet post", Description: "without comments"}
+	err := bus.Dispatch(jonSnowCtx, newPost1, newPost2, newPost3)
+	Expect(err).IsNil()
+
+	for i := 1; i <= 3; i++ {
+		err = bus.Dispatch(jonSnowCtx, &cmd.AddNewComment{Post: newPost1.Result, Content: fmt.Sprintf("Comment #%d", i)})
+		Expect(err).IsNil()
+	}
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddNewComment{Post: newPost2.Result, Content: "Other comment"})
+	Expect(err).IsNil()
+
+	getComments := &query.GetCommentsByPosts{
+		PostIDs: []int{newPost1.Result.ID, newPost2.Result.ID, newPost3.Result.ID},
+		Sort:    "newest",
+		Limit:   2,
+	}
+	err = bus.Dispatch(aryaStarkCtx, getComments)
+	Expect(err).IsNil()
+	Expect(getComments.Result[newPost1.Result.ID]).HasLen(2)
+	Expect(getComments.Result[newPost1.Result.ID][0].Content).Equals("Comment #3")
+	Expect(getComments.Result[newPost1.Result.ID][1].Content).Equals("Comment #2")
+	Expect(getComments.Result[newPost2.Result.ID]).HasLen(1)
+	Expect(getComments.Result[newPost2.Result.ID][0].Content).Equals("Other comment")
+	Expect(getComments.Result[newPost2.Result.ID][0].User.Name).Equals("Arya Stark")
+	Expect(getComments.Result[newPost3.Result.ID]).HasLen(0)
+}
+
 func TestPostStorage_AddGetUpdateComment(t *testing.T) {
 	SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
@@ -263,6 +365,42 @@ func TestPostStorage_AddVote(t *testing.T) {
 	Expect(getPost.Result.VotesCount).Equals(2)
 }
 
+func TestPostStorage_AddVote_WithReason(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddVote{Post: newPost.Result, User: aryaStark, Reason: "We need it for our audit", Importance: enum.VoteImportanceCritical})
+	Expect(err).IsNil()
+
+	// Voting again without a reason keeps the previous one
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddVote{Post: newPost.Result, User: aryaStark})
+	Expect(err).IsNil()
+
+	listVotes := &query.ListPostVotes{PostID: newPost.Result.ID, IncludeReason: true}
+	err = bus.Dispatch(jonSnowCtx, listVotes)
+	Expect(err).IsNil()
+	Expect(listVotes.Result).HasLen(1)
+	Expect(listVotes.Result[0].Reason).Equals("We need it for our audit")
+	Expect(listVotes.Result[0].Importance).Equals(enum.VoteImportanceCritical)
+
+	listVotes = &query.ListPostVotes{PostID: newPost.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, listVotes)
+	Expect(err).IsNil()
+	Expect(listVotes.Result[0].Reason).Equals("")
+	Expect(int(listVotes.Result[0].Importance)).Equals(0)
+
+	allVotes := &query.ListAllVotes{}
+	err = bus.Dispatch(jonSnowCtx, allVotes)
+	Expect(err).IsNil()
+	Expect(allVotes.Result).HasLen(1)
+	Expect(allVotes.Result[0].Post.Number).Equals(newPost.Result.Number)
+	Expect(allVotes.Result[0].Reason).Equals("We need it for our audit")
+}
+
 func TestPostStorage_AddVote_Twice(t *testing.T) {
 	SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
@@ -561,6 +699,29 @@ func TestPostStorage_ListVotesOfPost(t *testing.T) {
 	Expect(listVotes.Result[1].User.Email).Equals("arya.stark@got.com")
 }
 
+func TestPostStorage_ListVotesByPosts(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost1 := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	newPost2 := &cmd.AddNewPost{Title: "My other post", Description: "with another description"}
+	err := bus.Dispatch(jonSnowCtx, newPost1, newPost2)
+	Expect(err).IsNil()
+
+	bus.MustDispatch(jonSnowCtx, &cmd.AddVote{Post: newPost1.Result, User: jonSnow})
+	bus.MustDispatch(jonSnowCtx, &cmd.AddVote{Post: newPost1.Result, User: aryaStark})
+	bus.MustDispatch(jonSnowCtx, &cmd.AddVote{Post: newPost2.Result, User: aryaStark})
+
+	listVotes := &query.ListVotesByPosts{PostIDs: []int{newPost1.Result.ID, newPost2.Result.ID}, Limit: 1, IncludeEmail: true}
+	err = bus.Dispatch(jonSnowCtx, listVotes)
+	Expect(err).IsNil()
+	Expect(listVotes.Result[newPost1.Result.ID]).HasLen(1)
+	Expect(listVotes.Result[newPost1.Result.ID][0].User.Name).Equals("Jon Snow")
+	Expect(listVotes.Result[newPost1.Result.ID][0].User.Email).Equals("jon.snow@got.com")
+	Expect(listVotes.Result[newPost2.Result.ID]).HasLen(1)
+	Expect(listVotes.Result[newPost2.Result.ID][0].User.Name).Equals("Arya Stark")
+}
+
 func TestPostStorage_Attachments(t *testing.T) {
 	SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
@@ -651,3 +812,204 @@ func TestPostStorage_Attachments(t *testing.T) {
 	Expect(err).IsNil()
 	Expect(getAttachments1.Result).HasLen(0)
 }
+
+func TestPostStorage_SetPostScore(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	post1 := &cmd.AddNewPost{Title: "My first post", Description: "with this description"}
+	post2 := &cmd.AddNewPost{Title: "My second post", Description: "with another description"}
+	err := bus.Dispatch(jonSnowCtx, post1, post2)
+	Expect(err).IsNil()
+
+	reach, impact, confidence, effort := 1000.0, 2.0, 80.0, 4.0
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetPostScore{Post: post2.Result, Reach: &reach, Impact: &impact, Confidence: &confidence, Effort: &effort})
+	Expect(err).IsNil()
+
+	getPost := &query.GetPostByID{PostID: post2.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getPost)
+	Expect(err).IsNil()
+	Expect(*getPost.Result.Score.Reach).Equals(1000.0)
+	Expect(*getPost.Result.Score.Value).Equals(400.0)
+
+	err = bus.Dispatch(aryaStarkCtx, getPost)
+	Expect(err).IsNil()
+	Expect(getPost.Result.Score).IsNil()
+
+	getPostWithStaffFields := &query.GetPostByID{PostID: post2.Result.ID, IncludeStaffFields: true}
+	err = bus.Dispatch(aryaStarkCtx, getPostWithStaffFields)
+	Expect(err).IsNil()
+	Expect(*getPostWithStaffFields.Result.Score.Value).Equals(400.0)
+
+	search := &query.SearchPosts{View: "highest-score"}
+	err = bus.Dispatch(jonSnowCtx, search)
+	Expect(err).IsNil()
+	Expect(search.Result).HasLen(2)
+	Expect(search.Result[0].ID).Equals(post2.Result.ID)
+	Expect(search.Result[1].Score.Value).IsNil()
+}
+
+func TestPostStorage_Backdate(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newComment := &cmd.AddNewComment{Post: newPost.Result, Content: "This is my comment"}
+	err = bus.Dispatch(jonSnowCtx,
+		newComment,
+		&cmd.AddVote{Post: newPost.Result, User: jonSnow},
+		&cmd.SetPostResponse{Post: newPost.Result, Text: "Planned", Status: enum.PostPlanned},
+	)
+	Expect(err).IsNil()
+
+	createdAt := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.BackdatePost{Post: newPost.Result, CreatedAt: createdAt, RespondedAt: createdAt.AddDate(0, 0, 5)},
+		&cmd.BackdateComment{Comment: newComment.Result, CreatedAt: createdAt.AddDate(0, 0, 1)},
+		&cmd.BackdateVote{Post: newPost.Result, User: jonSnow, CreatedAt: createdAt.AddDate(0, 0, 2)},
+	)
+	Expect(err).IsNil()
+
+	postByID := &query.GetPostByID{PostID: newPost.Result.ID}
+	getComments := &query.GetCommentsByPost{Post: newPost.Result}
+	listVotes := &query.ListPostVotes{PostID: newPost.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, postByID, getComments, listVotes)
+	Expect(err).IsNil()
+
+	Expect(postByID.Result.CreatedAt.UTC()).Equals(createdAt)
+	Expect(postByID.Result.Response.RespondedAt.UTC()).Equals(createdAt.AddDate(0, 0, 5))
+	Expect(getComments.Result[0].CreatedAt.UTC()).Equals(createdAt.AddDate(0, 0, 1))
+	Expect(listVotes.Result[0].CreatedAt.UTC()).Equals(createdAt.AddDate(0, 0, 2))
+}
+
+func TestPostStorage_SetPostTarget(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	target := &entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q3", IsPublic: false}
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetPostTarget{Post: newPost.Result, Target: target})
+	Expect(err).IsNil()
+
+	getPost := &query.GetPostByID{PostID: newPost.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getPost)
+	Expect(err).IsNil()
+	Expect(getPost.Result.Target).Equals(target)
+
+	err = bus.Dispatch(aryaStarkCtx, getPost)
+	Expect(err).IsNil()
+	Expect(getPost.Result.Target).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetPostTarget{Post: newPost.Result, Target: nil})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, getPost)
+	Expect(err).IsNil()
+	Expect(getPost.Result.Target).IsNil()
+}
+
+func TestPostStorage_GetRoadmap(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	post1 := &cmd.AddNewPost{Title: "My first post", Description: "with this description"}
+	post2 := &cmd.AddNewPost{Title: "My second post", Description: "with this description"}
+	post3 := &cmd.AddNewPost{Title: "My third post", Description: "with this description"}
+	post4 := &cmd.AddNewPost{Title: "My fourth post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, post1, post2, post3, post4)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.SetPostResponse{Post: post1.Result, Text: "Soon", Status: enum.PostPlanned},
+		&cmd.SetPostResponse{Post: post2.Result, Text: "Soon", Status: enum.PostStarted},
+		&cmd.SetPostResponse{Post: post3.Result, Text: "Soon", Status: enum.PostPlanned},
+		&cmd.SetPostTarget{Post: post1.Result, Target: &entity.PostTarget{Kind: enum.PostTargetMilestone, Value: "v2.0", IsPublic: true}},
+		&cmd.SetPostTarget{Post: post2.Result, Target: &entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: true}},
+		&cmd.SetPostTarget{Post: post3.Result, Target: &entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: false}},
+		&cmd.SetPostTarget{Post: post4.Result, Target: &entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-08", IsPublic: true}},
+	)
+	Expect(err).IsNil()
+
+	roadmap := &query.GetRoadmap{}
+	err = bus.Dispatch(jonSnowCtx, roadmap)
+	Expect(err).IsNil()
+	Expect(roadmap.Result).HasLen(2)
+	Expect(roadmap.Result[0].Target.Value).Equals("2026-09")
+	Expect(roadmap.Result[0].Posts).HasLen(2)
+	Expect(roadmap.Result[1].Target.Value).Equals("v2.0")
+	Expect(roadmap.Result[1].Posts).HasLen(1)
+
+	err = bus.Dispatch(aryaStarkCtx, roadmap)
+	Expect(err).IsNil()
+	Expect(roadmap.Result).HasLen(3)
+	Expect(roadmap.Result[0].Posts).HasLen(1)
+	Expect(roadmap.Result[0].Posts[0].ID).Equals(post2.Result.ID)
+	Expect(roadmap.Result[1].Target.Value).Equals("v2.0")
+	Expect(roadmap.Result[2].Target).IsNil()
+	Expect(roadmap.Result[2].Posts[0].ID).Equals(post3.Result.ID)
+}
+
+func TestPostStorage_ReorderPost(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	post1 := &cmd.AddNewPost{Title: "My first post", Description: "with this description"}
+	post2 := &cmd.AddNewPost{Title: "My second post", Description: "with this description"}
+	post3 := &cmd.AddNewPost{Title: "My third post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, post1, post2, post3)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.SetPostResponse{Post: post1.Result, Text: "Soon", Status: enum.PostPlanned},
+		&cmd.SetPostResponse{Post: post2.Result, Text: "Soon", Status: enum.PostPlanned},
+		&cmd.SetPostResponse{Post: post3.Result, Text: "Soon", Status: enum.PostPlanned},
+		&cmd.AddVote{Post: post2.Result, User: aryaStark},
+	)
+	Expect(err).IsNil()
+
+	rankedIDs := func() []int {
+		roadmap := &query.GetRoadmap{View: "ranked"}
+		err := bus.Dispatch(jonSnowCtx, roadmap)
+		Expect(err).IsNil()
+		Expect(roadmap.Result).HasLen(1)
+		ids := make([]int, 0)
+		for _, post := range roadmap.Result[0].Posts {
+			ids = append(ids, post.ID)
+		}
+		return ids
+	}
+
+	// Unranked posts are sorted by votes
+	Expect(rankedIDs()).Equals([]int{post2.Result.ID, post1.Result.ID, post3.Result.ID})
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.ReorderPost{Post: post3.Result, Before: post2.Result})
+	Expect(err).IsNil()
+	Expect(post3.Result.Rank).IsNotNil()
+	Expect(rankedIDs()).Equals([]int{post3.Result.ID, post2.Result.ID, post1.Result.ID})
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.ReorderPost{Post: post1.Result, After: post3.Result})
+	Expect(err).IsNil()
+	Expect(rankedIDs()).Equals([]int{post3.Result.ID, post1.Result.ID, post2.Result.ID})
+
+	for i := 0; i < 60; i++ {
+		err = bus.Dispatch(jonSnowCtx, &cmd.ReorderPost{Post: post2.Result, Before: post1.Result})
+		Expect(err).IsNil()
+		err = bus.Dispatch(jonSnowCtx, &cmd.ReorderPost{Post: post1.Result, Before: post2.Result})
+		Expect(err).IsNil()
+	}
+	Expect(rankedIDs()).Equals([]int{post3.Result.ID, post1.Result.ID, post2.Result.ID})
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetPostResponse{Post: post1.Result, Text: "Working on it", Status: enum.PostStarted})
+	Expect(err).IsNil()
+
+	getPost := &query.GetPostByID{PostID: post1.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getPost)
+	Expect(err).IsNil()
+	Expect(getPost.Result.Rank).IsNil()
+}
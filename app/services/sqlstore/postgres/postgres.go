@@ -35,9 +35,11 @@ func (s Service) Init() {
 
 	bus.AddHandler(markAllNotificationsAsRead)
 	bus.AddHandler(markNotificationAsRead)
+	bus.AddHandler(markNotificationAsUnread)
 	bus.AddHandler(countUnreadNotifications)
 	bus.AddHandler(getNotificationByID)
 	bus.AddHandler(getActiveNotifications)
+	bus.AddHandler(listNotifications)
 	bus.AddHandler(addNewNotification)
 	bus.AddHandler(addSubscriber)
 	bus.AddHandler(removeSubscriber)
@@ -53,20 +55,55 @@ func (s Service) Init() {
 	bus.AddHandler(assignTag)
 	bus.AddHandler(unassignTag)
 
+	bus.AddHandler(getPostTemplateByID)
+	bus.AddHandler(getPostTemplateForTag)
+	bus.AddHandler(getAllPostTemplates)
+	bus.AddHandler(addNewPostTemplate)
+	bus.AddHandler(updatePostTemplate)
+	bus.AddHandler(deletePostTemplate)
+
+	bus.AddHandler(getAnnouncementByID)
+	bus.AddHandler(listAnnouncements)
+	bus.AddHandler(addNewAnnouncement)
+	bus.AddHandler(updateAnnouncement)
+	bus.AddHandler(deleteAnnouncement)
+	bus.AddHandler(purgeExpiredAnnouncements)
+
+	bus.AddHandler(getPollsByPost)
+	bus.AddHandler(getPollByID)
+	bus.AddHandler(listPollVotes)
+	bus.AddHandler(addNewPoll)
+	bus.AddHandler(deletePoll)
+	bus.AddHandler(setPollVote)
+	bus.AddHandler(removePollVote)
+
+	bus.AddHandler(savePushSubscription)
+	bus.AddHandler(deletePushSubscription)
+	bus.AddHandler(listPushSubscriptions)
+
 	bus.AddHandler(addVote)
 	bus.AddHandler(removeVote)
+	bus.AddHandler(backdateVote)
 	bus.AddHandler(listPostVotes)
+	bus.AddHandler(listVotesByPosts)
+	bus.AddHandler(listAllVotes)
 
 	bus.AddHandler(addNewPost)
 	bus.AddHandler(updatePost)
 	bus.AddHandler(getPostByID)
 	bus.AddHandler(getPostBySlug)
 	bus.AddHandler(getPostByNumber)
+	bus.AddHandler(getPostsByNumbers)
 	bus.AddHandler(searchPosts)
 	bus.AddHandler(getAllPosts)
+	bus.AddHandler(getRoadmap)
 	bus.AddHandler(countPostPerStatus)
 	bus.AddHandler(markPostAsDuplicate)
 	bus.AddHandler(setPostResponse)
+	bus.AddHandler(setPostScore)
+	bus.AddHandler(setPostTarget)
+	bus.AddHandler(reorderPost)
+	bus.AddHandler(backdatePost)
 	bus.AddHandler(postIsReferenced)
 
 	bus.AddHandler(setAttachments)
@@ -76,9 +113,11 @@ func (s Service) Init() {
 
 	bus.AddHandler(addNewComment)
 	bus.AddHandler(updateComment)
+	bus.AddHandler(backdateComment)
 	bus.AddHandler(deleteComment)
 	bus.AddHandler(getCommentByID)
 	bus.AddHandler(getCommentsByPost)
+	bus.AddHandler(getCommentsByPosts)
 
 	bus.AddHandler(countUsers)
 	bus.AddHandler(blockUser)
@@ -86,10 +125,13 @@ func (s Service) Init() {
 	bus.AddHandler(regenerateAPIKey)
 	bus.AddHandler(userSubscribedTo)
 	bus.AddHandler(deleteCurrentUser)
+	bus.AddHandler(previewUserMerge)
+	bus.AddHandler(mergeUsers)
 	bus.AddHandler(changeUserEmail)
 	bus.AddHandler(changeUserRole)
 	bus.AddHandler(updateCurrentUserSettings)
 	bus.AddHandler(getCurrentUserSettings)
+	bus.AddHandler(listCurrentUserSubscriptions)
 	bus.AddHandler(registerUser)
 	bus.AddHandler(registerUserProvider)
 	bus.AddHandler(updateCurrentUser)
@@ -108,8 +150,23 @@ func (s Service) Init() {
 	bus.AddHandler(updateTenantSettings)
 	bus.AddHandler(updateTenantPrivacySettings)
 	bus.AddHandler(updateTenantEmailAuthAllowedSettings)
+	bus.AddHandler(updateTenantEmailRules)
 	bus.AddHandler(updateTenantAdvancedSettings)
 
+	bus.AddHandler(recordPostView)
+	bus.AddHandler(purgeExpiredPostStats)
+	bus.AddHandler(getPostStats)
+	bus.AddHandler(listTopPostsByViews)
+	bus.AddHandler(listTopReferrers)
+
+	bus.AddHandler(addTenantSignUp)
+	bus.AddHandler(approveTenantSignUp)
+	bus.AddHandler(rejectTenantSignUp)
+	bus.AddHandler(getTenantSignUpByID)
+	bus.AddHandler(getTenantSignUpByTenantID)
+	bus.AddHandler(listTenantSignUps)
+	bus.AddHandler(countTenantSignUpsByIPAddress)
+
 	bus.AddHandler(getVerificationByKey)
 	bus.AddHandler(saveVerificationKey)
 	bus.AddHandler(setKeyAsVerified)
@@ -117,6 +174,7 @@ func (s Service) Init() {
 	bus.AddHandler(listCustomOAuthConfig)
 	bus.AddHandler(getCustomOAuthConfigByProvider)
 	bus.AddHandler(saveCustomOAuthConfig)
+	bus.AddHandler(deleteCustomOAuthConfig)
 
 	bus.AddHandler(getWebhook)
 	bus.AddHandler(listAllWebhooks)
@@ -126,6 +184,21 @@ func (s Service) Init() {
 	bus.AddHandler(deleteWebhook)
 	bus.AddHandler(markWebhookAsFailed)
 
+	bus.AddHandler(listOAuthApps)
+	bus.AddHandler(getOAuthAppByID)
+	bus.AddHandler(getOAuthAppByClientID)
+	bus.AddHandler(verifyOAuthAppSecret)
+	bus.AddHandler(createOAuthApp)
+	bus.AddHandler(updateOAuthApp)
+	bus.AddHandler(deleteOAuthApp)
+	bus.AddHandler(addOAuthAuthorizationCode)
+	bus.AddHandler(consumeOAuthAuthorizationCode)
+	bus.AddHandler(issueOAuthTokens)
+	bus.AddHandler(refreshOAuthTokens)
+	bus.AddHandler(getOAuthAccessToken)
+	bus.AddHandler(listOAuthAuthorizations)
+	bus.AddHandler(revokeOAuthAuthorization)
+
 	bus.AddHandler(getBillingState)
 	bus.AddHandler(activateBillingSubscription)
 	bus.AddHandler(cancelBillingSubscription)
@@ -134,6 +207,9 @@ func (s Service) Init() {
 
 	bus.AddHandler(setSystemSettings)
 	bus.AddHandler(getSystemSettings)
+
+	bus.AddHandler(addJobRun)
+	bus.AddHandler(listJobRuns)
 }
 
 type SqlHandler func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error
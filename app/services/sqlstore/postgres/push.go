@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type dbPushSubscription struct {
+	ID        int            `db:"id"`
+	UserID    int            `db:"user_id"`
+	Endpoint  string         `db:"endpoint"`
+	P256dh    string         `db:"key_p256dh"`
+	Auth      string         `db:"key_auth"`
+	UserAgent sql.NullString `db:"user_agent"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (s *dbPushSubscription) toModel() *entity.PushSubscription {
+	return &entity.PushSubscription{
+		ID:        s.ID,
+		UserID:    s.UserID,
+		Endpoint:  s.Endpoint,
+		P256dh:    s.P256dh,
+		Auth:      s.Auth,
+		UserAgent: s.UserAgent.String,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// maxPushUserAgentLength is the size of push_subscriptions.user_agent
+const maxPushUserAgentLength = 500
+
+func savePushSubscription(ctx context.Context, c *cmd.SavePushSubscription) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		userAgent := c.UserAgent
+		if runes := []rune(userAgent); len(runes) > maxPushUserAgentLength {
+			userAgent = string(runes[:maxPushUserAgentLength])
+		}
+
+		// The same browser might be re-registered by a different user, so the endpoint is moved over
+		_, err := trx.Execute(`
+			INSERT INTO push_subscriptions (tenant_id, user_id, endpoint, key_p256dh, key_auth, user_agent, created_at)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+			ON CONFLICT (tenant_id, endpoint) DO UPDATE
+			SET user_id = EXCLUDED.user_id, key_p256dh = EXCLUDED.key_p256dh, key_auth = EXCLUDED.key_auth, user_agent = EXCLUDED.user_agent
+		`, tenant.ID, user.ID, c.Endpoint, c.P256dh, c.Auth, userAgent, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to save push subscription")
+		}
+		return nil
+	})
+}
+
+func deletePushSubscription(ctx context.Context, c *cmd.DeletePushSubscription) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"DELETE FROM push_subscriptions WHERE tenant_id = $1 AND user_id = $2 AND endpoint = $3",
+			tenant.ID, c.UserID, c.Endpoint,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete push subscription")
+		}
+		return nil
+	})
+}
+
+func listPushSubscriptions(ctx context.Context, q *query.ListPushSubscriptions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.PushSubscription, 0)
+
+		subscriptions := []*dbPushSubscription{}
+		err := trx.Select(&subscriptions, `
+			SELECT id, user_id, endpoint, key_p256dh, key_auth, user_agent, created_at
+			FROM push_subscriptions
+			WHERE tenant_id = $1 AND user_id = $2
+			ORDER BY created_at
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get push subscriptions of user '%d'", q.UserID)
+		}
+
+		for _, s := range subscriptions {
+			q.Result = append(q.Result, s.toModel())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,76 @@
+package postgres_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestPushSubscriptionStorage_SaveListDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	subscribe := &cmd.SavePushSubscription{
+		Endpoint:  "https://fcm.googleapis.com/fcm/send/abc",
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+		UserAgent: "Chrome",
+	}
+	err := bus.Dispatch(jonSnowCtx, subscribe)
+	Expect(err).IsNil()
+
+	// Same browser registered again by another user
+	err = bus.Dispatch(aryaStarkCtx, subscribe)
+	Expect(err).IsNil()
+
+	jonSubscriptions := &query.ListPushSubscriptions{UserID: jonSnow.ID}
+	err = bus.Dispatch(jonSnowCtx, jonSubscriptions)
+	Expect(err).IsNil()
+	Expect(jonSubscriptions.Result).HasLen(0)
+
+	aryaSubscriptions := &query.ListPushSubscriptions{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, aryaSubscriptions)
+	Expect(err).IsNil()
+	Expect(aryaSubscriptions.Result).HasLen(1)
+	Expect(aryaSubscriptions.Result[0].Endpoint).Equals(subscribe.Endpoint)
+	Expect(aryaSubscriptions.Result[0].UserAgent).Equals("Chrome")
+
+	// Other users can't remove it
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeletePushSubscription{UserID: jonSnow.ID, Endpoint: subscribe.Endpoint})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, aryaSubscriptions)
+	Expect(err).IsNil()
+	Expect(aryaSubscriptions.Result).HasLen(1)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.DeletePushSubscription{UserID: aryaStark.ID, Endpoint: subscribe.Endpoint})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, aryaSubscriptions)
+	Expect(err).IsNil()
+	Expect(aryaSubscriptions.Result).HasLen(0)
+}
+
+func TestPushSubscriptionStorage_LongUserAgent(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	subscribe := &cmd.SavePushSubscription{
+		Endpoint:  "https://fcm.googleapis.com/fcm/send/abc",
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+		UserAgent: strings.Repeat("é", 600),
+	}
+	err := bus.Dispatch(jonSnowCtx, subscribe)
+	Expect(err).IsNil()
+
+	jonSubscriptions := &query.ListPushSubscriptions{UserID: jonSnow.ID}
+	err = bus.Dispatch(jonSnowCtx, jonSubscriptions)
+	Expect(err).IsNil()
+	Expect(jonSubscriptions.Result).HasLen(1)
+	Expect(jonSubscriptions.Result[0].UserAgent).Equals(strings.Repeat("é", 500))
+}
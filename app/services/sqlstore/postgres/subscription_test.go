@@ -352,3 +352,26 @@ func TestSubscription_EmailSupressed(t *testing.T) {
 	Expect(q.Result).HasLen(1)
 	Expect(q.Result[0].ID).Equals(jonSnow.ID)
 }
+
+func TestSubscription_ListCurrentUserSubscriptions(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost1 := &cmd.AddNewPost{Title: "Post #1", Description: "Description #1"}
+	newPost2 := &cmd.AddNewPost{Title: "Post #2", Description: "Description #2"}
+	bus.MustDispatch(aryaStarkCtx, newPost1, newPost2)
+
+	bus.MustDispatch(aryaStarkCtx, &cmd.RemoveSubscriber{Post: newPost1.Result, User: aryaStark})
+
+	subscriptions := &query.ListCurrentUserSubscriptions{}
+	err := bus.Dispatch(aryaStarkCtx, subscriptions)
+	Expect(err).IsNil()
+	Expect(subscriptions.Result).HasLen(1)
+	Expect(subscriptions.Result[0].Number).Equals(newPost2.Result.Number)
+	Expect(subscriptions.Result[0].Title).Equals("Post #2")
+
+	subscriptions = &query.ListCurrentUserSubscriptions{}
+	err = bus.Dispatch(jonSnowCtx, subscriptions)
+	Expect(err).IsNil()
+	Expect(subscriptions.Result).HasLen(0)
+}
@@ -17,18 +17,20 @@ import (
 )
 
 type dbTenant struct {
-	ID                 int    `db:"id"`
-	Name               string `db:"name"`
-	Subdomain          string `db:"subdomain"`
-	CNAME              string `db:"cname"`
-	Invitation         string `db:"invitation"`
-	WelcomeMessage     string `db:"welcome_message"`
-	Status             int    `db:"status"`
-	Locale             string `db:"locale"`
-	IsPrivate          bool   `db:"is_private"`
-	LogoBlobKey        string `db:"logo_bkey"`
-	CustomCSS          string `db:"custom_css"`
-	IsEmailAuthAllowed bool   `db:"is_email_auth_allowed"`
+	ID                    int                     `db:"id"`
+	Name                  string                  `db:"name"`
+	Subdomain             string                  `db:"subdomain"`
+	CNAME                 string                  `db:"cname"`
+	Invitation            string                  `db:"invitation"`
+	WelcomeMessage        string                  `db:"welcome_message"`
+	Status                int                     `db:"status"`
+	Locale                string                  `db:"locale"`
+	IsPrivate             bool                    `db:"is_private"`
+	LogoBlobKey           string                  `db:"logo_bkey"`
+	CustomCSS             string                  `db:"custom_css"`
+	IsEmailAuthAllowed    bool                    `db:"is_email_auth_allowed"`
+	PrioritizationFormula int                     `db:"prioritization_formula"`
+	EmailRules            entity.TenantEmailRules `db:"email_rules"`
 }
 
 func (t *dbTenant) toModel() *entity.Tenant {
@@ -37,18 +39,20 @@ func (t *dbTenant) toModel() *entity.Tenant {
 	}
 
 	tenant := &entity.Tenant{
-		ID:                 t.ID,
-		Name:               t.Name,
-		Subdomain:          t.Subdomain,
-		CNAME:              t.CNAME,
-		Invitation:         t.Invitation,
-		WelcomeMessage:     t.WelcomeMessage,
-		Status:             enum.TenantStatus(t.Status),
-		Locale:             t.Locale,
-		IsPrivate:          t.IsPrivate,
-		LogoBlobKey:        t.LogoBlobKey,
-		CustomCSS:          t.CustomCSS,
-		IsEmailAuthAllowed: t.IsEmailAuthAllowed,
+		ID:                    t.ID,
+		Name:                  t.Name,
+		Subdomain:             t.Subdomain,
+		CNAME:                 t.CNAME,
+		Invitation:            t.Invitation,
+		WelcomeMessage:        t.WelcomeMessage,
+		Status:                enum.TenantStatus(t.Status),
+		Locale:                t.Locale,
+		IsPrivate:             t.IsPrivate,
+		LogoBlobKey:           t.LogoBlobKey,
+		CustomCSS:             t.CustomCSS,
+		IsEmailAuthAllowed:    t.IsEmailAuthAllowed,
+		PrioritizationFormula: enum.PrioritizationFormula(t.PrioritizationFormula),
+		EmailRules:            t.EmailRules,
 	}
 
 	return tenant
@@ -127,6 +131,17 @@ func updateTenantPrivacySettings(ctx context.Context, c *cmd.UpdateTenantPrivacy
 	})
 }
 
+func updateTenantEmailRules(ctx context.Context, c *cmd.UpdateTenantEmailRules) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute("UPDATE tenants SET email_rules = $1 WHERE id = $2", c.Rules, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed update tenant email rules")
+		}
+		tenant.EmailRules = c.Rules
+		return nil
+	})
+}
+
 func updateTenantEmailAuthAllowedSettings(ctx context.Context, c *cmd.UpdateTenantEmailAuthAllowedSettings) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		_, err := trx.Execute("UPDATE tenants SET is_email_auth_allowed = $1 WHERE id = $2", c.IsEmailAuthAllowed, tenant.ID)
@@ -160,13 +175,14 @@ func updateTenantSettings(ctx context.Context, c *cmd.UpdateTenantSettings) erro
 
 func updateTenantAdvancedSettings(ctx context.Context, c *cmd.UpdateTenantAdvancedSettings) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		query := "UPDATE tenants SET custom_css = $1 WHERE id = $2"
-		_, err := trx.Execute(query, c.CustomCSS, tenant.ID)
+		query := "UPDATE tenants SET custom_css = $1, prioritization_formula = $2 WHERE id = $3"
+		_, err := trx.Execute(query, c.CustomCSS, c.PrioritizationFormula, tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed update tenant advanced settings")
 		}
 
 		tenant.CustomCSS = c.CustomCSS
+		tenant.PrioritizationFormula = c.PrioritizationFormula
 		return nil
 	})
 }
@@ -259,7 +275,7 @@ func getFirstTenant(ctx context.Context, q *query.GetFirstTenant) error {
 		tenant := dbTenant{}
 
 		err := trx.Get(&tenant, `
-			SELECT id, name, subdomain, cname, invitation, locale, welcome_message, status, is_private, logo_bkey, custom_css, is_email_auth_allowed
+			SELECT id, name, subdomain, cname, invitation, locale, welcome_message, status, is_private, logo_bkey, custom_css, is_email_auth_allowed, prioritization_formula, email_rules
 			FROM tenants
 			ORDER BY id LIMIT 1
 		`)
@@ -278,7 +294,7 @@ func getTenantByDomain(ctx context.Context, q *query.GetTenantByDomain) error {
 		tenant := dbTenant{}
 
 		err := trx.Get(&tenant, `
-			SELECT id, name, subdomain, cname, invitation, locale, welcome_message, status, is_private, logo_bkey, custom_css, is_email_auth_allowed
+			SELECT id, name, subdomain, cname, invitation, locale, welcome_message, status, is_private, logo_bkey, custom_css, is_email_auth_allowed, prioritization_formula, email_rules
 			FROM tenants t
 			WHERE subdomain = $1 OR subdomain = $2 OR cname = $3 
 			ORDER BY cname DESC
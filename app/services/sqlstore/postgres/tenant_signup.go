@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+type dbTenantSignUp struct {
+	ID              int                     `db:"id"`
+	TenantID        int                     `db:"tenant_id"`
+	TenantName      string                  `db:"tenant_name"`
+	TenantSubdomain string                  `db:"tenant_subdomain"`
+	Name            dbx.NullString          `db:"name"`
+	Email           dbx.NullString          `db:"email"`
+	IPAddress       string                  `db:"ip_address"`
+	Status          enum.TenantSignUpStatus `db:"status"`
+	CreatedAt       time.Time               `db:"created_at"`
+	ReviewedAt      dbx.NullTime            `db:"reviewed_at"`
+}
+
+func (s *dbTenantSignUp) toModel() *entity.TenantSignUp {
+	signUp := &entity.TenantSignUp{
+		ID:              s.ID,
+		TenantID:        s.TenantID,
+		TenantName:      s.TenantName,
+		TenantSubdomain: s.TenantSubdomain,
+		Name:            s.Name.String,
+		Email:           s.Email.String,
+		IPAddress:       s.IPAddress,
+		Status:          s.Status,
+		CreatedAt:       s.CreatedAt,
+	}
+	if s.ReviewedAt.Valid {
+		signUp.ReviewedAt = &s.ReviewedAt.Time
+	}
+	return signUp
+}
+
+const selectTenantSignUpsSQL = `
+	SELECT s.id, s.tenant_id, t.name AS tenant_name, t.subdomain AS tenant_subdomain,
+			s.name, s.email, s.ip_address, s.status, s.created_at, s.reviewed_at
+	FROM tenant_signups s
+	INNER JOIN tenants t
+	ON t.id = s.tenant_id
+`
+
+func addTenantSignUp(ctx context.Context, c *cmd.AddTenantSignUp) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		signUp := c.SignUp
+		err := trx.Get(&signUp.ID, `
+			INSERT INTO tenant_signups (tenant_id, name, email, ip_address, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`, signUp.TenantID, signUp.Name, signUp.Email, signUp.IPAddress, signUp.Status, signUp.CreatedAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to add sign-up of tenant '%d'", signUp.TenantID)
+		}
+		return nil
+	})
+}
+
+func approveTenantSignUp(ctx context.Context, c *cmd.ApproveTenantSignUp) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		var tenantID int
+		err := trx.Scalar(&tenantID, `
+			UPDATE tenant_signups SET status = $2, reviewed_at = $3
+			WHERE id = $1 AND status = $4
+			RETURNING tenant_id
+		`, c.SignUpID, enum.TenantSignUpApproved, time.Now(), enum.TenantSignUpPending)
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return app.ErrSignUpAlreadyReviewed
+			}
+			return errors.Wrap(err, "failed to approve sign-up '%d'", c.SignUpID)
+		}
+
+		// Sites created by email have no users until the email is verified, which then activates it
+		rows, err := trx.Execute(`
+			UPDATE tenants SET status = $2
+			WHERE id = $1 AND status = $3
+			AND EXISTS (SELECT 1 FROM users WHERE tenant_id = $1)
+		`, tenantID, enum.TenantActive, enum.TenantPending)
+		if err != nil {
+			return errors.Wrap(err, "failed to activate tenant '%d'", tenantID)
+		}
+
+		c.Activated = rows > 0
+		return nil
+	})
+}
+
+func rejectTenantSignUp(ctx context.Context, c *cmd.RejectTenantSignUp) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		var tenantID int
+		err := trx.Scalar(&tenantID, `
+			UPDATE tenant_signups SET status = $2, reviewed_at = $3
+			WHERE id = $1 AND status = $4
+			RETURNING tenant_id
+		`, c.SignUpID, enum.TenantSignUpRejected, time.Now(), enum.TenantSignUpPending)
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return app.ErrSignUpAlreadyReviewed
+			}
+			return errors.Wrap(err, "failed to reject sign-up '%d'", c.SignUpID)
+		}
+
+		_, err = trx.Execute("UPDATE tenants SET status = $2 WHERE id = $1", tenantID, enum.TenantDisabled)
+		if err != nil {
+			return errors.Wrap(err, "failed to disable tenant '%d'", tenantID)
+		}
+		return nil
+	})
+}
+
+func getTenantSignUpByID(ctx context.Context, q *query.GetTenantSignUpByID) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		signUp := dbTenantSignUp{}
+		err := trx.Get(&signUp, selectTenantSignUpsSQL+" WHERE s.id = $1", q.SignUpID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get sign-up '%d'", q.SignUpID)
+		}
+		q.Result = signUp.toModel()
+		return nil
+	})
+}
+
+func getTenantSignUpByTenantID(ctx context.Context, q *query.GetTenantSignUpByTenantID) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		signUp := dbTenantSignUp{}
+		err := trx.Get(&signUp, selectTenantSignUpsSQL+" WHERE s.tenant_id = $1", q.TenantID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get sign-up of tenant '%d'", q.TenantID)
+		}
+		q.Result = signUp.toModel()
+		return nil
+	})
+}
+
+func listTenantSignUps(ctx context.Context, q *query.ListTenantSignUps) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		signUps := []*dbTenantSignUp{}
+		err := trx.Select(&signUps, selectTenantSignUpsSQL+`
+			WHERE s.status = $1
+			ORDER BY s.created_at DESC, s.id DESC
+			LIMIT 200
+		`, q.Status)
+		if err != nil {
+			return errors.Wrap(err, "failed to list sign-ups")
+		}
+
+		q.Result = make([]*entity.TenantSignUp, len(signUps))
+		for i, signUp := range signUps {
+			q.Result[i] = signUp.toModel()
+		}
+		return nil
+	})
+}
+
+func countTenantSignUpsByIPAddress(ctx context.Context, q *query.CountTenantSignUpsByIPAddress) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		err := trx.Scalar(&q.Result, `
+			SELECT COUNT(*) FROM tenant_signups WHERE ip_address = $1 AND created_at >= $2
+		`, q.IPAddress, q.Since)
+		if err != nil {
+			return errors.Wrap(err, "failed to count sign-ups from '%s'", q.IPAddress)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,137 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestTenantSignUpStorage_Approve(t *testing.T) {
+	ctx := SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createTenant := &cmd.CreateTenant{Name: "My Domain Inc.", Subdomain: "mydomain", Status: enum.TenantPending}
+	err := bus.Dispatch(ctx, createTenant)
+	Expect(err).IsNil()
+
+	addSignUp := &cmd.AddTenantSignUp{SignUp: &entity.TenantSignUp{
+		TenantID:  createTenant.Result.ID,
+		Name:      "Jon Snow",
+		Email:     "jon.snow@mydomain.com",
+		IPAddress: "203.0.113.7",
+		Status:    enum.TenantSignUpPending,
+		CreatedAt: time.Now(),
+	}}
+	err = bus.Dispatch(ctx, addSignUp)
+	Expect(err).IsNil()
+	Expect(addSignUp.SignUp.ID).IsNotEmpty()
+
+	countByIP := &query.CountTenantSignUpsByIPAddress{IPAddress: "203.0.113.7", Since: time.Now().Add(-24 * time.Hour)}
+	err = bus.Dispatch(ctx, countByIP)
+	Expect(err).IsNil()
+	Expect(countByIP.Result).Equals(1)
+
+	listPending := &query.ListTenantSignUps{Status: enum.TenantSignUpPending}
+	err = bus.Dispatch(ctx, listPending)
+	Expect(err).IsNil()
+	Expect(listPending.Result).HasLen(1)
+	Expect(listPending.Result[0].TenantSubdomain).Equals("mydomain")
+	Expect(listPending.Result[0].Email).Equals("jon.snow@mydomain.com")
+	Expect(listPending.Result[0].ReviewedAt).IsNil()
+
+	// Email is not verified yet, so the site is not activated
+	approve := &cmd.ApproveTenantSignUp{SignUpID: addSignUp.SignUp.ID}
+	err = bus.Dispatch(ctx, approve)
+	Expect(err).IsNil()
+	Expect(approve.Activated).IsFalse()
+
+	getByTenant := &query.GetTenantSignUpByTenantID{TenantID: createTenant.Result.ID}
+	err = bus.Dispatch(ctx, getByTenant)
+	Expect(err).IsNil()
+	Expect(getByTenant.Result.Status).Equals(enum.TenantSignUpApproved)
+	Expect(getByTenant.Result.ReviewedAt).IsNotNil()
+
+	// Only pending sign-ups can be reviewed, so a late rejection can't disable an approved site
+	err = bus.Dispatch(ctx, &cmd.RejectTenantSignUp{SignUpID: addSignUp.SignUp.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrSignUpAlreadyReviewed)
+
+	err = bus.Dispatch(ctx, getByTenant)
+	Expect(err).IsNil()
+	Expect(getByTenant.Result.Status).Equals(enum.TenantSignUpApproved)
+
+	getByDomain := &query.GetTenantByDomain{Domain: "mydomain"}
+	err = bus.Dispatch(ctx, getByDomain)
+	Expect(err).IsNil()
+	Expect(getByDomain.Result.Status).Equals(enum.TenantPending)
+}
+
+func TestTenantSignUpStorage_ApproveWithUsers(t *testing.T) {
+	ctx := SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createTenant := &cmd.CreateTenant{Name: "My Domain Inc.", Subdomain: "mydomain", Status: enum.TenantPending}
+	err := bus.Dispatch(ctx, createTenant)
+	Expect(err).IsNil()
+
+	newTenantCtx := withTenant(ctx, createTenant.Result)
+	err = bus.Dispatch(newTenantCtx, &cmd.RegisterUser{User: &entity.User{Name: "Jon Snow", Email: "jon.snow@mydomain.com", Role: enum.RoleAdministrator}})
+	Expect(err).IsNil()
+
+	addSignUp := &cmd.AddTenantSignUp{SignUp: &entity.TenantSignUp{
+		TenantID:  createTenant.Result.ID,
+		IPAddress: "203.0.113.7",
+		Status:    enum.TenantSignUpPending,
+		CreatedAt: time.Now(),
+	}}
+	err = bus.Dispatch(ctx, addSignUp)
+	Expect(err).IsNil()
+
+	approve := &cmd.ApproveTenantSignUp{SignUpID: addSignUp.SignUp.ID}
+	err = bus.Dispatch(ctx, approve)
+	Expect(err).IsNil()
+	Expect(approve.Activated).IsTrue()
+
+	getByDomain := &query.GetTenantByDomain{Domain: "mydomain"}
+	err = bus.Dispatch(ctx, getByDomain)
+	Expect(err).IsNil()
+	Expect(getByDomain.Result.Status).Equals(enum.TenantActive)
+}
+
+func TestTenantSignUpStorage_Reject(t *testing.T) {
+	ctx := SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createTenant := &cmd.CreateTenant{Name: "Cheap Loans", Subdomain: "cheaploans", Status: enum.TenantPending}
+	err := bus.Dispatch(ctx, createTenant)
+	Expect(err).IsNil()
+
+	addSignUp := &cmd.AddTenantSignUp{SignUp: &entity.TenantSignUp{
+		TenantID:  createTenant.Result.ID,
+		IPAddress: "203.0.113.7",
+		Status:    enum.TenantSignUpPending,
+		CreatedAt: time.Now(),
+	}}
+	err = bus.Dispatch(ctx, addSignUp)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(ctx, &cmd.RejectTenantSignUp{SignUpID: addSignUp.SignUp.ID})
+	Expect(err).IsNil()
+
+	getByID := &query.GetTenantSignUpByID{SignUpID: addSignUp.SignUp.ID}
+	err = bus.Dispatch(ctx, getByID)
+	Expect(err).IsNil()
+	Expect(getByID.Result.Status).Equals(enum.TenantSignUpRejected)
+
+	getByDomain := &query.GetTenantByDomain{Domain: "cheaploans"}
+	err = bus.Dispatch(ctx, getByDomain)
+	Expect(err).IsNil()
+	Expect(getByDomain.Result.Status).Equals(enum.TenantDisabled)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 
@@ -222,7 +223,8 @@ func TestTenantStorage_AdvancedSettings(t *testing.T) {
 	defer TeardownDatabaseTest()
 
 	err := bus.Dispatch(demoTenantCtx, &cmd.UpdateTenantAdvancedSettings{
-		CustomCSS: ".primary { color: red; }",
+		CustomCSS:             ".primary { color: red; }",
+		PrioritizationFormula: enum.PrioritizationICE,
 	})
 	Expect(err).IsNil()
 
@@ -230,6 +232,30 @@ func TestTenantStorage_AdvancedSettings(t *testing.T) {
 	err = bus.Dispatch(demoTenantCtx, getByDomain)
 	Expect(err).IsNil()
 	Expect(getByDomain.Result.CustomCSS).Equals(".primary { color: red; }")
+	Expect(getByDomain.Result.PrioritizationFormula).Equals(enum.PrioritizationICE)
+}
+
+func TestTenantStorage_EmailRules(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	getByDomain := &query.GetTenantByDomain{Domain: "demo"}
+	err := bus.Dispatch(demoTenantCtx, getByDomain)
+	Expect(err).IsNil()
+	Expect(getByDomain.Result.EmailRules.Allows("jon.snow@mailinator.com")).IsTrue()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.UpdateTenantEmailRules{
+		Rules: entity.TenantEmailRules{
+			BlockedDomains:  []string{"competitor.com"},
+			BlockDisposable: true,
+		},
+	})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getByDomain)
+	Expect(err).IsNil()
+	Expect(getByDomain.Result.EmailRules.BlockedDomains).Equals([]string{"competitor.com"})
+	Expect(getByDomain.Result.EmailRules.BlockDisposable).IsTrue()
 }
 
 func TestTenantStorage_SaveFindSet_VerificationKey(t *testing.T) {
@@ -408,3 +434,33 @@ func TestTenantStorage_Save_Get_ListOAuthConfig(t *testing.T) {
 	Expect(customConfigs.Result[0].JSONUserNamePath).Equals("New user.name")
 	Expect(customConfigs.Result[0].JSONUserEmailPath).Equals("New user.email")
 }
+
+func TestTenantStorage_DeleteOAuthConfig(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.SaveCustomOAuthConfig{
+		Logo:         &dto.ImageUpload{},
+		Provider:     "_TEST",
+		DisplayName:  "My Provider",
+		ClientID:     "823187ahjjfdha8fds7yfdashfjkdsa",
+		ClientSecret: "jijads78d76cn347768x3t4668q275",
+		AuthorizeURL: "http://provider/oauth/authorize",
+		TokenURL:     "http://provider/oauth/token",
+		Scope:        "profile email",
+	})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(avengersTenantCtx, &cmd.DeleteCustomOAuthConfig{Provider: "_TEST"})
+	Expect(err).IsNil()
+
+	getConfig := &query.GetCustomOAuthConfigByProvider{Provider: "_TEST"}
+	err = bus.Dispatch(demoTenantCtx, getConfig)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.DeleteCustomOAuthConfig{Provider: "_TEST"})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getConfig)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
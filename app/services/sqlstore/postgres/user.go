@@ -126,8 +126,11 @@ func deleteCurrentUser(ctx context.Context, c *cmd.DeleteCurrentUser) error {
 			{"notifications", "user_id"},
 			{"notifications", "author_id"},
 			{"post_votes", "user_id"},
+			{"poll_votes", "user_id"},
 			{"post_subscribers", "user_id"},
+			{"push_subscriptions", "user_id"},
 			{"email_verifications", "user_id"},
+			{"oauth_authorizations", "user_id"},
 		}
 
 		for _, table := range tables {
@@ -274,6 +277,43 @@ func getCurrentUserSettings(ctx context.Context, q *query.GetCurrentUserSettings
 	})
 }
 
+func listCurrentUserSubscriptions(ctx context.Context, q *query.ListCurrentUserSubscriptions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		type dbPostSubscription struct {
+			Number       int       `db:"number"`
+			Title        string    `db:"title"`
+			Slug         string    `db:"slug"`
+			Status       int       `db:"status"`
+			SubscribedAt time.Time `db:"subscribed_at"`
+		}
+
+		var subscriptions []*dbPostSubscription
+		err := trx.Select(&subscriptions, `
+			SELECT p.number, p.title, p.slug, p.status, s.created_at AS subscribed_at
+			FROM post_subscribers s
+			INNER JOIN posts p
+			ON p.id = s.post_id
+			AND p.tenant_id = s.tenant_id
+			WHERE s.tenant_id = $1 AND s.user_id = $2 AND s.status = $3 AND p.status != $4
+			ORDER BY s.created_at DESC`, tenant.ID, user.ID, enum.SubscriberActive, enum.PostDeleted)
+		if err != nil {
+			return errors.Wrap(err, "failed to list user subscriptions")
+		}
+
+		q.Result = make([]*entity.PostSubscription, len(subscriptions))
+		for i, s := range subscriptions {
+			q.Result[i] = &entity.PostSubscription{
+				Number:       s.Number,
+				Title:        s.Title,
+				Slug:         s.Slug,
+				Status:       enum.PostStatus(s.Status),
+				SubscribedAt: s.SubscribedAt,
+			}
+		}
+		return nil
+	})
+}
+
 func registerUser(ctx context.Context, c *cmd.RegisterUser) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, _ *entity.User) error {
 		now := time.Now()
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// userMergeTables lists every column referencing a user that is moved during a merge.
+// Rows matching duplicate (if any) already have an equivalent for the target user and are dropped instead.
+// On all queries $1 is the source user, $2 is the tenant and $3 is the target user.
+var userMergeTables = []struct {
+	table     string
+	column    string
+	duplicate string
+	count     func(s *entity.UserMergeSummary) *entity.UserMergeCount
+}{
+	{"posts", "user_id", "", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.Posts }},
+	{"posts", "response_user_id", "", nil},
+	{"comments", "user_id", "", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.Comments }},
+	{"comments", "edited_by_id", "", nil},
+	{"comments", "deleted_by_id", "", nil},
+	{"post_votes", "user_id", "post_id IN (SELECT post_id FROM post_votes WHERE user_id = $3 AND tenant_id = $2)", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.Votes }},
+	{"poll_votes", "user_id", "poll_id IN (SELECT poll_id FROM poll_votes WHERE user_id = $3 AND tenant_id = $2)", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.PollVotes }},
+	{"post_subscribers", "user_id", "post_id IN (SELECT post_id FROM post_subscribers WHERE user_id = $3 AND tenant_id = $2)", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.Subscriptions }},
+	{"notifications", "user_id", "", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.Notifications }},
+	{"notifications", "author_id", "", nil},
+	{"user_providers", "user_id", "provider IN (SELECT provider FROM user_providers WHERE user_id = $3 AND tenant_id = $2)", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.Providers }},
+	{"user_settings", "user_id", "key IN (SELECT key FROM user_settings WHERE user_id = $3 AND tenant_id = $2)", func(s *entity.UserMergeSummary) *entity.UserMergeCount { return &s.Settings }},
+	{"push_subscriptions", "user_id", "", nil},
+	{"post_tags", "created_by_id", "", nil},
+	{"polls", "created_by_id", "", nil},
+	{"attachments", "user_id", "", nil},
+}
+
+func previewUserMerge(ctx context.Context, q *query.PreviewUserMerge) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		summary, err := summarizeUserMerge(trx, tenant, q.SourceUserID, q.TargetUserID)
+		if err != nil {
+			return err
+		}
+		q.Result = summary
+		return nil
+	})
+}
+
+func mergeUsers(ctx context.Context, c *cmd.MergeUsers) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		summary, err := summarizeUserMerge(trx, tenant, c.SourceUserID, c.TargetUserID)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range userMergeTables {
+			if t.duplicate != "" {
+				if _, err := trx.Execute(
+					fmt.Sprintf("DELETE FROM %s WHERE %s = $1 AND tenant_id = $2 AND %s", t.table, t.column, t.duplicate),
+					c.SourceUserID, tenant.ID, c.TargetUserID,
+				); err != nil {
+					return errors.Wrap(err, "failed to drop duplicate %s records", t.table)
+				}
+			}
+
+			if _, err := trx.Execute(
+				fmt.Sprintf("UPDATE %s SET %s = $3 WHERE %s = $1 AND tenant_id = $2", t.table, t.column, t.column),
+				c.SourceUserID, tenant.ID, c.TargetUserID,
+			); err != nil {
+				return errors.Wrap(err, "failed to move %s records", t.table)
+			}
+		}
+
+		if _, err := trx.Execute(
+			"DELETE FROM email_verifications WHERE user_id = $1 AND tenant_id = $2",
+			c.SourceUserID, tenant.ID,
+		); err != nil {
+			return errors.Wrap(err, "failed to delete email verifications of merged user")
+		}
+
+		// Applications authorized by the merged user must be authorized again by the target user
+		if _, err := trx.Execute(
+			"DELETE FROM oauth_authorizations WHERE user_id = $1 AND tenant_id = $2",
+			c.SourceUserID, tenant.ID,
+		); err != nil {
+			return errors.Wrap(err, "failed to delete oauth authorizations of merged user")
+		}
+
+		action := "deleted"
+		if c.BlockSource {
+			action = "blocked"
+			_, err = trx.Execute(
+				"UPDATE users SET status = $3, api_key = null, api_key_date = null WHERE id = $1 AND tenant_id = $2",
+				c.SourceUserID, tenant.ID, enum.UserBlocked,
+			)
+		} else {
+			_, err = trx.Execute(
+				"UPDATE users SET role = $3, status = $4, name = '', email = '', api_key = null, api_key_date = null WHERE id = $1 AND tenant_id = $2",
+				c.SourceUserID, tenant.ID, enum.RoleVisitor, enum.UserDeleted,
+			)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to remove merged user")
+		}
+
+		if _, err := trx.Execute(
+			"INSERT INTO audit_logs (tenant_id, user_id, action, details, created_at) VALUES ($1, $2, $3, $4, $5)",
+			tenant.ID, user.ID, "user.merge", dto.Props{
+				"sourceUserID": c.SourceUserID,
+				"targetUserID": c.TargetUserID,
+				"source":       action,
+				"summary":      summary,
+			}, time.Now(),
+		); err != nil {
+			return errors.Wrap(err, "failed to add audit log for user merge")
+		}
+
+		c.Result = summary
+		return nil
+	})
+}
+
+func summarizeUserMerge(trx *dbx.Trx, tenant *entity.Tenant, sourceUserID, targetUserID int) (*entity.UserMergeSummary, error) {
+	summary := &entity.UserMergeSummary{}
+	for _, t := range userMergeTables {
+		if t.count == nil {
+			continue
+		}
+
+		count := t.count(summary)
+		if t.duplicate == "" {
+			err := trx.Scalar(&count.Moved,
+				fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = $1 AND tenant_id = $2", t.table, t.column),
+				sourceUserID, tenant.ID,
+			)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to count %s records", t.table)
+			}
+			continue
+		}
+
+		err := trx.Scalar(&count.Dropped,
+			fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = $1 AND tenant_id = $2 AND %s", t.table, t.column, t.duplicate),
+			sourceUserID, tenant.ID, targetUserID,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to count duplicate %s records", t.table)
+		}
+
+		err = trx.Scalar(&count.Moved,
+			fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = $1 AND tenant_id = $2 AND NOT %s", t.table, t.column, t.duplicate),
+			sourceUserID, tenant.ID, targetUserID,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to count %s records", t.table)
+		}
+	}
+	return summary, nil
+}
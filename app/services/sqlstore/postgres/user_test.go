@@ -420,3 +420,72 @@ func TestUserStorage_BlockUser(t *testing.T) {
 	Expect(err).IsNil()
 	Expect(getUser.Result.Status).Equals(enum.UserActive)
 }
+
+func TestUserStorage_MergeUsers(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	post1 := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, post1)
+	Expect(err).IsNil()
+
+	post2 := &cmd.AddNewPost{Title: "My other post", Description: "with another description"}
+	err = bus.Dispatch(sansaStarkCtx, post2)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddVote{Post: post1.Result, User: aryaStark})
+	Expect(err).IsNil()
+	err = bus.Dispatch(sansaStarkCtx, &cmd.AddVote{Post: post1.Result, User: sansaStark})
+	Expect(err).IsNil()
+	err = bus.Dispatch(sansaStarkCtx, &cmd.AddVote{Post: post2.Result, User: sansaStark})
+	Expect(err).IsNil()
+	err = bus.Dispatch(sansaStarkCtx, &cmd.AddNewComment{Post: post1.Result, Content: "Me too!"})
+	Expect(err).IsNil()
+
+	preview := &query.PreviewUserMerge{SourceUserID: sansaStark.ID, TargetUserID: aryaStark.ID}
+	err = bus.Dispatch(jonSnowCtx, preview)
+	Expect(err).IsNil()
+	Expect(preview.Result.Posts).Equals(entity.UserMergeCount{Moved: 1})
+	Expect(preview.Result.Comments).Equals(entity.UserMergeCount{Moved: 1})
+	Expect(preview.Result.Votes).Equals(entity.UserMergeCount{Moved: 1, Dropped: 1})
+
+	merge := &cmd.MergeUsers{SourceUserID: sansaStark.ID, TargetUserID: aryaStark.ID}
+	err = bus.Dispatch(jonSnowCtx, merge)
+	Expect(err).IsNil()
+	Expect(merge.Result).Equals(preview.Result)
+
+	getByID := &query.GetUserByID{UserID: sansaStark.ID}
+	err = bus.Dispatch(jonSnowCtx, getByID)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	for _, post := range []*entity.Post{post1.Result, post2.Result} {
+		listVotes := &query.ListPostVotes{PostID: post.ID}
+		err = bus.Dispatch(jonSnowCtx, listVotes)
+		Expect(err).IsNil()
+		Expect(listVotes.Result).HasLen(1)
+		Expect(listVotes.Result[0].User.ID).Equals(aryaStark.ID)
+	}
+
+	getPost := &query.GetPostByID{PostID: post2.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getPost)
+	Expect(err).IsNil()
+	Expect(getPost.Result.User.ID).Equals(aryaStark.ID)
+
+	var count int
+	err = trx.Scalar(&count, "SELECT COUNT(*) FROM audit_logs WHERE tenant_id = $1 AND action = 'user.merge'", demoTenant.ID)
+	Expect(err).IsNil()
+	Expect(count).Equals(1)
+}
+
+func TestUserStorage_MergeUsers_BlockSource(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(jonSnowCtx, &cmd.MergeUsers{SourceUserID: sansaStark.ID, TargetUserID: aryaStark.ID, BlockSource: true})
+	Expect(err).IsNil()
+
+	getByID := &query.GetUserByID{UserID: sansaStark.ID}
+	err = bus.Dispatch(jonSnowCtx, getByID)
+	Expect(err).IsNil()
+	Expect(getByID.Result.Status).Equals(enum.UserBlocked)
+}
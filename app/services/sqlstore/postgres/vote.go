@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"strconv"
 	"time"
 
@@ -11,22 +12,32 @@ import (
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/lib/pq"
 )
 
 type dbVote struct {
-	User *struct {
+	PostID int `db:"post_id"`
+	User   *struct {
 		ID            int    `db:"id"`
 		Name          string `db:"name"`
 		Email         string `db:"email"`
 		AvatarType    int64  `db:"avatar_type"`
 		AvatarBlobKey string `db:"avatar_bkey"`
 	} `db:"user"`
-	CreatedAt time.Time `db:"created_at"`
+	Post *struct {
+		Number int    `db:"number"`
+		Title  string `db:"title"`
+	} `db:"post"`
+	CreatedAt  time.Time      `db:"created_at"`
+	Reason     sql.NullString `db:"reason"`
+	Importance sql.NullInt64  `db:"importance"`
 }
 
 func (v *dbVote) toModel(ctx context.Context) *entity.Vote {
 	vote := &entity.Vote{
-		CreatedAt: v.CreatedAt,
+		CreatedAt:  v.CreatedAt,
+		Reason:     v.Reason.String,
+		Importance: enum.VoteImportance(v.Importance.Int64),
 		User: &entity.VoteUser{
 			ID:        v.User.ID,
 			Name:      v.User.Name,
@@ -34,6 +45,12 @@ func (v *dbVote) toModel(ctx context.Context) *entity.Vote {
 			AvatarURL: buildAvatarURL(ctx, enum.AvatarType(v.User.AvatarType), v.User.ID, v.User.Name, v.User.AvatarBlobKey),
 		},
 	}
+	if v.Post != nil {
+		vote.Post = &entity.VotePost{
+			Number: v.Post.Number,
+			Title:  v.Post.Title,
+		}
+	}
 	return vote
 }
 
@@ -43,9 +60,22 @@ func addVote(ctx context.Context, c *cmd.AddVote) error {
 			return nil
 		}
 
-		_, err := trx.Execute(
-			`INSERT INTO post_votes (tenant_id, user_id, post_id, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
-			tenant.ID, c.User.ID, c.Post.ID, time.Now(),
+		var (
+			reason     sql.NullString
+			importance sql.NullInt64
+		)
+		if c.Reason != "" {
+			reason = sql.NullString{String: c.Reason, Valid: true}
+		}
+		if c.Importance > 0 {
+			importance = sql.NullInt64{Int64: int64(c.Importance), Valid: true}
+		}
+
+		_, err := trx.Execute(`
+			INSERT INTO post_votes (tenant_id, user_id, post_id, created_at, reason, importance) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (user_id, post_id) DO UPDATE
+			SET reason = COALESCE(EXCLUDED.reason, post_votes.reason), importance = COALESCE(EXCLUDED.importance, post_votes.importance)`,
+			tenant.ID, c.User.ID, c.Post.ID, time.Now(), reason, importance,
 		)
 
 		if err != nil {
@@ -71,6 +101,16 @@ func removeVote(ctx context.Context, c *cmd.RemoveVote) error {
 	})
 }
 
+func backdateVote(ctx context.Context, c *cmd.BackdateVote) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE post_votes SET created_at = $1 WHERE user_id = $2 AND post_id = $3 AND tenant_id = $4`, c.CreatedAt, c.User.ID, c.Post.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to backdate vote")
+		}
+		return nil
+	})
+}
+
 func listPostVotes(ctx context.Context, q *query.ListPostVotes) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*entity.Vote, 0)
@@ -84,10 +124,16 @@ func listPostVotes(ctx context.Context, q *query.ListPostVotes) error {
 			emailColumn = "u.email"
 		}
 
+		reasonColumns := "NULL AS reason, NULL AS importance"
+		if q.IncludeReason {
+			reasonColumns = "pv.reason, pv.importance"
+		}
+
 		votes := []*dbVote{}
 		err := trx.Select(&votes, `
 		SELECT 
 			pv.created_at, 
+			`+reasonColumns+`,
 			u.id AS user_id,
 			u.name AS user_name,
 			`+emailColumn+` AS user_email,
@@ -113,3 +159,97 @@ func listPostVotes(ctx context.Context, q *query.ListPostVotes) error {
 		return nil
 	})
 }
+
+func listVotesByPosts(ctx context.Context, q *query.ListVotesByPosts) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make(map[int][]*entity.Vote, len(q.PostIDs))
+		if len(q.PostIDs) == 0 {
+			return nil
+		}
+
+		filter := ""
+		if q.Limit > 0 {
+			filter = "WHERE pv.row_number <= " + strconv.Itoa(q.Limit)
+		}
+
+		emailColumn := "''"
+		if q.IncludeEmail {
+			emailColumn = "u.email"
+		}
+
+		reasonColumns := "NULL AS reason, NULL AS importance"
+		if q.IncludeReason {
+			reasonColumns = "pv.reason, pv.importance"
+		}
+
+		votes := []*dbVote{}
+		err := trx.Select(&votes, `
+		SELECT 
+			pv.post_id,
+			pv.created_at, 
+			`+reasonColumns+`,
+			u.id AS user_id,
+			u.name AS user_name,
+			`+emailColumn+` AS user_email,
+			u.avatar_type AS user_avatar_type,
+			u.avatar_bkey AS user_avatar_bkey
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY created_at) AS row_number
+			FROM post_votes
+			WHERE post_id = ANY($1)
+			AND tenant_id = $2
+		) pv
+		INNER JOIN users u
+		ON u.id = pv.user_id
+		AND u.tenant_id = pv.tenant_id 
+		`+filter+`
+		ORDER BY pv.post_id, pv.created_at`, pq.Array(q.PostIDs), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get votes of posts")
+		}
+
+		for _, vote := range votes {
+			q.Result[vote.PostID] = append(q.Result[vote.PostID], vote.toModel(ctx))
+		}
+
+		return nil
+	})
+}
+
+func listAllVotes(ctx context.Context, q *query.ListAllVotes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		votes := []*dbVote{}
+		err := trx.Select(&votes, `
+		SELECT 
+			pv.created_at, 
+			pv.reason,
+			pv.importance,
+			p.number AS post_number,
+			p.title AS post_title,
+			u.id AS user_id,
+			u.name AS user_name,
+			u.email AS user_email,
+			u.avatar_type AS user_avatar_type,
+			u.avatar_bkey AS user_avatar_bkey
+		FROM post_votes pv
+		INNER JOIN posts p
+		ON p.id = pv.post_id
+		AND p.tenant_id = pv.tenant_id
+		INNER JOIN users u
+		ON u.id = pv.user_id
+		AND u.tenant_id = pv.tenant_id 
+		WHERE pv.tenant_id = $1
+		AND p.status != $2
+		ORDER BY p.number, pv.created_at`, tenant.ID, enum.PostDeleted)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all votes")
+		}
+
+		q.Result = make([]*entity.Vote, len(votes))
+		for i, vote := range votes {
+			q.Result[i] = vote.toModel(ctx)
+		}
+
+		return nil
+	})
+}
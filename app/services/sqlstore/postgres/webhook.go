@@ -12,7 +12,7 @@ func getWebhook(ctx context.Context, q *query.GetWebhook) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		webhook := &entity.Webhook{}
 		err := trx.Get(webhook, `
-			SELECT id, name, type, status, url, content, http_method, http_headers 
+			SELECT id, name, type, status, format, url, content, http_method, http_headers, conditions 
 			FROM webhooks 
 			WHERE tenant_id = $1 AND id = $2`, tenant.ID, q.ID)
 		if err != nil {
@@ -28,7 +28,7 @@ func listAllWebhooks(ctx context.Context, q *query.ListAllWebhooks) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		webhooks := []*entity.Webhook{}
 		err := trx.Select(&webhooks, `
-			SELECT id, name, type, status, url, content, http_method, http_headers 
+			SELECT id, name, type, status, format, url, content, http_method, http_headers, conditions 
 			FROM webhooks 
 			WHERE tenant_id = $1 
 			ORDER BY id`, tenant.ID)
@@ -45,7 +45,7 @@ func listAllWebhooksByType(ctx context.Context, q *query.ListAllWebhooksByType)
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		webhooks := []*entity.Webhook{}
 		err := trx.Select(&webhooks, `
-			SELECT id, name, type, status, url, content, http_method, http_headers 
+			SELECT id, name, type, status, format, url, content, http_method, http_headers, conditions 
 			FROM webhooks 
 			WHERE tenant_id = $1 AND type = $2 
 			ORDER BY id`, tenant.ID, q.Type)
@@ -62,7 +62,7 @@ func listActiveWebhooksByType(ctx context.Context, q *query.ListActiveWebhooksBy
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		webhooks := []*entity.Webhook{}
 		err := trx.Select(&webhooks, `
-			SELECT id, name, type, status, url, content, http_method, http_headers 
+			SELECT id, name, type, status, format, url, content, http_method, http_headers, conditions 
 			FROM webhooks 
 			WHERE tenant_id = $1 AND type = $2 AND status = $3 
 			ORDER BY id`, tenant.ID, q.Type, enum.WebhookEnabled)
@@ -82,14 +82,14 @@ func createEditWebhook(ctx context.Context, q *query.CreateEditWebhook) error {
 
 		if q.ID == 0 {
 			err = trx.Get(&id, `
-				INSERT INTO webhooks (name, type, status, url, content, http_method, http_headers, tenant_id) 
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
-				RETURNING id`, q.Name, q.Type, q.Status, q.Url, q.Content, q.HttpMethod, q.HttpHeaders, tenant.ID)
+				INSERT INTO webhooks (name, type, status, format, url, content, http_method, http_headers, conditions, tenant_id) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) 
+				RETURNING id`, q.Name, q.Type, q.Status, q.Format, q.Url, q.Content, q.HttpMethod, q.HttpHeaders, q.Conditions, tenant.ID)
 		} else {
 			_, err = trx.Execute(`
 				UPDATE webhooks 
-				SET name = $3, type = $4, status = $5, url = $6, content = $7, http_method = $8, http_headers = $9 
-				WHERE tenant_id = $1 AND id = $2`, tenant.ID, q.ID, q.Name, q.Type, q.Status, q.Url, q.Content, q.HttpMethod, q.HttpHeaders)
+				SET name = $3, type = $4, status = $5, url = $6, content = $7, http_method = $8, http_headers = $9, conditions = $10, format = $11 
+				WHERE tenant_id = $1 AND id = $2`, tenant.ID, q.ID, q.Name, q.Type, q.Status, q.Url, q.Content, q.HttpMethod, q.HttpHeaders, q.Conditions, q.Format)
 		}
 
 		if err != nil {
@@ -37,7 +37,8 @@ You can use ` + "`" + `{{ markdown .post_description }}` + "`" + ` to parse __Ma
 		RespondedAt: time.Date(2021, time.July, 9, 15, 29, 57, 0, time.UTC),
 		User:        nil,
 	},
-	Tags: []string{"tag1", "tag2"},
+	Tags:   []string{"tag1", "tag2"},
+	Target: &entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2021-Q3", IsPublic: true},
 }
 
 func dummyTriggerProps(c context.Context, webhookType enum.WebhookType) webhook.Props {
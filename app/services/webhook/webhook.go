@@ -1,13 +1,17 @@
 package webhook
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/tpl"
 	"github.com/getfider/fider/app/pkg/webhook"
@@ -63,6 +67,15 @@ func triggerWebhooks(ctx context.Context, c *cmd.TriggerWebhooks) error {
 	}
 
 	for _, webhook_ := range webhooks.Result {
+		if ok, reason := c.Props.Match(webhook_.Conditions); !ok {
+			log.Debugf(ctx, "Webhook #@{ID:yellow} @{Name:blue} skipped: @{Reason}", dto.Props{
+				"ID":     webhook_.ID,
+				"Name":   webhook_.Name,
+				"Reason": reason,
+			})
+			continue
+		}
+
 		_, err = triggerWebhook(ctx, webhook_, c.Props)
 		if err != nil {
 			return err
@@ -81,16 +94,26 @@ func triggerWebhook(ctx context.Context, webhook *entity.Webhook, props webhook.
 	if err != nil {
 		return resultWithError(ctx, "Could not parse webhook URL template", err.Error(), result)
 	}
-	result.Content, err = executeTemplate(fmt.Sprintf("%s-content", fullName), webhook.Content, props)
-	if err != nil {
-		return resultWithError(ctx, "Could not parse webhook content template", err.Error(), result)
+	headers := webhook.HttpHeaders
+	if webhook.Format == enum.WebhookFormatStandard {
+		content, err := standardPayload(webhook.Type, props)
+		if err != nil {
+			return resultWithError(ctx, "Could not build webhook standard payload", err.Error(), result)
+		}
+		result.Content = string(content)
+		headers = withJSONContentType(headers)
+	} else {
+		result.Content, err = executeTemplate(fmt.Sprintf("%s-content", fullName), webhook.Content, props)
+		if err != nil {
+			return resultWithError(ctx, "Could not parse webhook content template", err.Error(), result)
+		}
 	}
 
 	httpRequest := &cmd.HTTPRequest{
 		URL:       result.Url,
 		Body:      strings.NewReader(result.Content),
 		Method:    webhook.HttpMethod,
-		Headers:   webhook.HttpHeaders,
+		Headers:   headers,
 		BasicAuth: nil,
 	}
 	err = bus.Dispatch(ctx, httpRequest)
@@ -123,13 +146,27 @@ func previewWebhook(ctx context.Context, c *cmd.PreviewWebhook) error {
 		c.Result.Url.Error = err.Error()
 		// Do not propagate error: it's a preview
 	}
-	c.Result.Content.Value, err = executeTemplate("preview-content", c.Content, props)
-	if err != nil {
-		c.Result.Content.Message = "Could not parse webhook content template"
-		c.Result.Content.Error = err.Error()
-		// Do not propagate error: it's a preview
+	if c.Format == enum.WebhookFormatStandard {
+		content, err := standardPayload(c.Type, props)
+		if err != nil {
+			return err
+		}
+		indented := new(bytes.Buffer)
+		if err := json.Indent(indented, content, "", "  "); err != nil {
+			return errors.Wrap(err, "failed to indent webhook standard payload")
+		}
+		c.Result.Content.Value = indented.String()
+	} else {
+		c.Result.Content.Value, err = executeTemplate("preview-content", c.Content, props)
+		if err != nil {
+			c.Result.Content.Message = "Could not parse webhook content template"
+			c.Result.Content.Error = err.Error()
+			// Do not propagate error: it's a preview
+		}
 	}
 
+	c.Result.Trigger.WouldTrigger, c.Result.Trigger.Reason = props.Match(c.Conditions)
+
 	return nil
 }
 
@@ -147,6 +184,27 @@ func executeTemplate(name, text string, props webhook.Props) (string, error) {
 	return replacedText, nil
 }
 
+func standardPayload(webhookType enum.WebhookType, props webhook.Props) ([]byte, error) {
+	content, err := json.Marshal(webhook.NewPayload(webhookType, props))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal webhook standard payload")
+	}
+	return content, nil
+}
+
+// withJSONContentType returns a copy of given headers with a JSON Content-Type, unless one is already set
+func withJSONContentType(headers entity.HttpHeaders) entity.HttpHeaders {
+	result := entity.HttpHeaders{}
+	for name, value := range headers {
+		if strings.EqualFold(name, "Content-Type") {
+			return headers
+		}
+		result[name] = value
+	}
+	result["Content-Type"] = "application/json"
+	return result
+}
+
 func resultWithError(ctx context.Context, message, error string, result *dto.WebhookTriggerResult) (*dto.WebhookTriggerResult, error) {
 	result.Success = false
 	result.Message = message
@@ -0,0 +1,137 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/markdown"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/webpush"
+)
+
+// messageTTL is how long (in seconds) push services should keep a message for offline devices
+const messageTTL = "86400"
+
+func init() {
+	bus.Register(Service{})
+}
+
+type Service struct{}
+
+func (s Service) Name() string {
+	return "Web Push"
+}
+
+func (s Service) Category() string {
+	return "webpush"
+}
+
+func (s Service) Enabled() bool {
+	return env.IsWebPushEnabled()
+}
+
+func (s Service) Init() {
+	bus.AddListener(sendWebPush)
+}
+
+type message struct {
+	Title string `json:"title"`
+	Link  string `json:"link,omitempty"`
+}
+
+func sendWebPush(ctx context.Context, c *cmd.SendWebPush) error {
+	subscriptions := &query.ListPushSubscriptions{UserID: c.User.ID}
+	if err := bus.Dispatch(ctx, subscriptions); err != nil {
+		return err
+	}
+
+	if len(subscriptions.Result) == 0 {
+		return nil
+	}
+
+	msg := message{Title: markdown.PlainText(c.Title)}
+	if c.Link != "" {
+		msg.Link = web.BaseURL(ctx) + c.Link
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal push message")
+	}
+
+	// A single broken subscription should not prevent other devices from being notified
+	for _, subscription := range subscriptions.Result {
+		if err := push(ctx, subscription, payload); err != nil {
+			log.Error(ctx, err)
+		}
+	}
+
+	return nil
+}
+
+func push(ctx context.Context, subscription *entity.PushSubscription, payload []byte) error {
+	if err := webpush.ValidateEndpoint(subscription.Endpoint); err != nil {
+		return errors.Wrap(err, "refusing to send push message to subscription '%d'", subscription.ID)
+	}
+
+	body, err := webpush.Encrypt(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt push message for subscription '%d'", subscription.ID)
+	}
+
+	authorization, err := webpush.VAPIDAuthorization(
+		subscription.Endpoint,
+		env.Config.WebPush.Subject,
+		env.Config.WebPush.VAPIDPublicKey,
+		env.Config.WebPush.VAPIDPrivateKey,
+		time.Now().Add(12*time.Hour),
+	)
+	if err != nil {
+		return err
+	}
+
+	req := &cmd.HTTPRequest{
+		URL:    subscription.Endpoint,
+		Body:   bytes.NewReader(body),
+		Method: http.MethodPost,
+		Headers: map[string]string{
+			"Authorization":    authorization,
+			"Content-Encoding": "aes128gcm",
+			"Content-Type":     "application/octet-stream",
+			"TTL":              messageTTL,
+		},
+	}
+	if err := bus.Dispatch(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to send push message to subscription '%d'", subscription.ID)
+	}
+
+	// Push services reply with 404 or 410 when a subscription has expired or was unsubscribed
+	if req.ResponseStatusCode == http.StatusNotFound || req.ResponseStatusCode == http.StatusGone {
+		log.Debugf(ctx, "Removing expired push subscription @{ID:yellow}", dto.Props{
+			"ID": subscription.ID,
+		})
+		return bus.Dispatch(ctx, &cmd.DeletePushSubscription{UserID: subscription.UserID, Endpoint: subscription.Endpoint})
+	}
+
+	if req.ResponseStatusCode >= http.StatusBadRequest {
+		log.Warnf(ctx, "Push service for subscription @{ID:yellow} returned @{Code:red}: @{Body}", dto.Props{
+			"ID":   subscription.ID,
+			"Code": req.ResponseStatusCode,
+			"Body": strings.TrimSpace(string(req.ResponseBody)),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package webpush_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/webpush"
+)
+
+var subscriptions = []*entity.PushSubscription{
+	{
+		ID:       1,
+		UserID:   mock.AryaStark.ID,
+		Endpoint: "https://fcm.googleapis.com/fcm/send/active",
+		P256dh:   "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:     "BTBZMqHH6r4Tts7J_aSIgg",
+	},
+	{
+		ID:       2,
+		UserID:   mock.AryaStark.ID,
+		Endpoint: "https://updates.push.services.mozilla.com/wpush/v2/expired",
+		P256dh:   "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:     "BTBZMqHH6r4Tts7J_aSIgg",
+	},
+	{
+		ID:       3,
+		UserID:   mock.AryaStark.ID,
+		Endpoint: "https://169.254.169.254/latest/meta-data",
+		P256dh:   "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:     "BTBZMqHH6r4Tts7J_aSIgg",
+	},
+}
+
+func TestSendWebPush(t *testing.T) {
+	RegisterT(t)
+	env.Config.WebPush.VAPIDPublicKey = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	env.Config.WebPush.VAPIDPrivateKey = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	env.Config.WebPush.Subject = "mailto:admin@fider.io"
+	bus.Init(webpush.Service{})
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListPushSubscriptions) error {
+		Expect(q.UserID).Equals(mock.AryaStark.ID)
+		q.Result = subscriptions
+		return nil
+	})
+
+	requests := make([]*cmd.HTTPRequest, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.HTTPRequest) error {
+		requests = append(requests, c)
+		c.ResponseStatusCode = http.StatusCreated
+		if c.URL == subscriptions[1].Endpoint {
+			c.ResponseStatusCode = http.StatusGone
+		}
+		return nil
+	})
+
+	deleted := make([]string, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeletePushSubscription) error {
+		Expect(c.UserID).Equals(mock.AryaStark.ID)
+		deleted = append(deleted, c.Endpoint)
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, mock.DemoTenant)
+	bus.Publish(ctx, &cmd.SendWebPush{
+		User:  mock.AryaStark,
+		Title: "**Jon Snow** left a comment on **My Post**",
+		Link:  "/posts/1/my-post",
+	})
+
+	Expect(requests).HasLen(2)
+	Expect(requests[0].URL).Equals(subscriptions[0].Endpoint)
+	Expect(requests[0].Method).Equals(http.MethodPost)
+	Expect(requests[0].Headers["Content-Encoding"]).Equals("aes128gcm")
+	Expect(requests[0].Headers["TTL"]).Equals("86400")
+	Expect(requests[0].Headers["Authorization"]).ContainsSubstring("vapid t=")
+	Expect(deleted).Equals([]string{subscriptions[1].Endpoint})
+}
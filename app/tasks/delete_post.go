@@ -15,7 +15,7 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
-// NotifyAboutDeletedPost sends a notification (web and email) to subscribers of the post that has been deleted
+// NotifyAboutDeletedPost sends a notification (web, push and email) to subscribers of the post that has been deleted
 func NotifyAboutDeletedPost(post *entity.Post, deleteCommentAdded bool) worker.Task {
 	return describe("Notify about deleted post", func(c *worker.Context) error {
 
@@ -25,12 +25,17 @@ func NotifyAboutDeletedPost(post *entity.Post, deleteCommentAdded bool) worker.T
 		title := fmt.Sprintf("**%s** deleted **%s**", author.Name, post.Title)
 
 		// Webhook
+		webhookPost, err := getWebhookPost(c, post)
+		if err != nil {
+			return c.Failure(err)
+		}
+
 		webhookProps := webhook.Props{}
-		webhookProps.SetPost(post, "post", baseURL, true, true)
+		webhookProps.SetPost(webhookPost, "post", baseURL, true, true)
 		webhookProps.SetUser(author, "author")
 		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 
-		err := bus.Dispatch(c, &cmd.TriggerWebhooks{
+		err = bus.Dispatch(c, &cmd.TriggerWebhooks{
 			Type:  enum.WebhookDeletePost,
 			Props: webhookProps,
 		})
@@ -63,6 +68,11 @@ func NotifyAboutDeletedPost(post *entity.Post, deleteCommentAdded bool) worker.T
 			}
 		}
 
+		// Push notification
+		if err := sendWebPush(c, post, enum.NotificationEventChangeStatus, title, ""); err != nil {
+			return c.Failure(err)
+		}
+
 		// Email notification
 		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventChangeStatus)
 		if err != nil {
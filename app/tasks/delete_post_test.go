@@ -2,6 +2,7 @@ package tasks_test
 
 import (
 	"context"
+	"github.com/getfider/fider/app"
 	"html/template"
 	"testing"
 	"time"
@@ -38,6 +39,10 @@ func TestNotifyAboutDeletePostTask(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		return app.ErrNotFound
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -145,6 +150,10 @@ func TestNotifyAboutDeletePostTask_NoComment(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		return app.ErrNotFound
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -15,7 +15,7 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
-//NotifyAboutNewComment sends a notification (web and email) to subscribers
+//NotifyAboutNewComment sends a notification (web, push and email) to subscribers
 func NotifyAboutNewComment(post *entity.Post, comment string) worker.Task {
 	return describe("Notify about new comment", func(c *worker.Context) error {
 		// Web notification
@@ -41,6 +41,11 @@ func NotifyAboutNewComment(post *entity.Post, comment string) worker.Task {
 			}
 		}
 
+		// Push notification
+		if err := sendWebPush(c, post, enum.NotificationEventNewComment, title, link); err != nil {
+			return c.Failure(err)
+		}
+
 		// Email notification
 		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewComment)
 		if err != nil {
@@ -76,8 +81,13 @@ func NotifyAboutNewComment(post *entity.Post, comment string) worker.Task {
 			Props:        mailProps,
 		})
 
+		webhookPost, err := getWebhookPost(c, post)
+		if err != nil {
+			return c.Failure(err)
+		}
+
 		webhookProps := webhook.Props{"comment": comment}
-		webhookProps.SetPost(post, "post", baseURL, true, true)
+		webhookProps.SetPost(webhookPost, "post", baseURL, true, true)
 		webhookProps.SetUser(author, "author")
 		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 
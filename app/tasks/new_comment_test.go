@@ -2,6 +2,7 @@ package tasks_test
 
 import (
 	"context"
+	"github.com/getfider/fider/app"
 	"html/template"
 	"testing"
 
@@ -52,6 +53,16 @@ func TestNotifyAboutNewCommentTask(t *testing.T) {
 		Description: "TypeScript is great, please add support for it",
 		User:        mock.JonSnow,
 	}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		Expect(q.IncludeStaffFields).IsTrue()
+		Expect(ctx.Value(app.UserCtxKey)).Equals(mock.AryaStark)
+		reloaded := *post
+		score := 12.5
+		reloaded.Score = &entity.PostScore{Value: &score}
+		q.Result = &reloaded
+		return nil
+	})
+
 	task := tasks.NotifyAboutNewComment(post, "I agree")
 
 	err := worker.
@@ -95,6 +106,7 @@ func TestNotifyAboutNewCommentTask(t *testing.T) {
 	Expect(triggerWebhooks.Type).Equals(enum.WebhookNewComment)
 	Expect(triggerWebhooks.Props).ContainsProps(webhook.Props{
 		"comment":           "I agree",
+		"post_score":        12.5,
 		"post_id":           post.ID,
 		"post_number":       post.Number,
 		"post_title":        post.Title,
@@ -0,0 +1,94 @@
+package tasks
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// NotifyAboutNewPoll sends a notification (web, push and email) to subscribers
+func NotifyAboutNewPoll(post *entity.Post, poll *entity.Poll) worker.Task {
+	return describe("Notify about new poll", func(c *worker.Context) error {
+		// Web notification
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventNewPoll)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		author := c.User()
+		title := fmt.Sprintf("**%s** opened a poll on **%s**", author.Name, post.Title)
+		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+		for _, user := range users {
+			if user.ID != author.ID {
+				err = bus.Dispatch(c, &cmd.AddNewNotification{
+					User:   user,
+					Title:  title,
+					Link:   link,
+					PostID: post.ID,
+				})
+				if err != nil {
+					return c.Failure(err)
+				}
+			}
+		}
+
+		// Push notification
+		if err := sendWebPush(c, post, enum.NotificationEventNewPoll, title, link); err != nil {
+			return c.Failure(err)
+		}
+
+		// Email notification
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewPoll)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		to := make([]dto.Recipient, 0)
+		for _, user := range users {
+			if user.ID != author.ID {
+				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+			}
+		}
+
+		var options strings.Builder
+		options.WriteString("<ul>")
+		for _, option := range poll.Options {
+			options.WriteString("<li>" + template.HTMLEscapeString(option.Text) + "</li>")
+		}
+		options.WriteString("</ul>")
+
+		tenant := c.Tenant()
+		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+		mailProps := dto.Props{
+			"title":       post.Title,
+			"question":    poll.Question,
+			"siteName":    tenant.Name,
+			"userName":    author.Name,
+			"content":     template.HTML(options.String()),
+			"postLink":    linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"view":        linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"unsubscribe": linkWithText(i18n.T(c, "email.subscription.unsubscribe"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"change":      linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
+			"logo":        logoURL,
+		}
+
+		bus.Publish(c, &cmd.SendMail{
+			From:         dto.Recipient{Name: author.Name},
+			To:           to,
+			TemplateName: "new_poll",
+			Props:        mailProps,
+		})
+
+		return nil
+	})
+}
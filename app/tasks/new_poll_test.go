@@ -0,0 +1,89 @@
+package tasks_test
+
+import (
+	"context"
+	"html/template"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email/emailmock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func TestNotifyAboutNewPollTask(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{
+			mock.JonSnow,
+			mock.AryaStark,
+		}
+		return nil
+	})
+
+	worker := mock.NewWorker()
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.JonSnow,
+	}
+	poll := &entity.Poll{
+		ID:       1,
+		Question: "Which editor do you use?",
+		Options: []*entity.PollOption{
+			{ID: 1, Text: "VS Code"},
+			{ID: 2, Text: "Vim"},
+		},
+	}
+	task := tasks.NotifyAboutNewPoll(post, poll)
+
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("new_poll")
+	Expect(emailmock.MessageHistory[0].Tenant).Equals(mock.DemoTenant)
+	Expect(emailmock.MessageHistory[0].Props).Equals(dto.Props{
+		"title":       "Add support for TypeScript",
+		"question":    "Which editor do you use?",
+		"postLink":    "<a href='http://domain.com/posts/1/add-support-for-typescript'>#1</a>",
+		"siteName":    "Demonstration",
+		"userName":    "Arya Stark",
+		"content":     template.HTML("<ul><li>VS Code</li><li>Vim</li></ul>"),
+		"view":        "<a href='http://domain.com/posts/1/add-support-for-typescript'>view it on your browser</a>",
+		"change":      "<a href='http://domain.com/settings'>change your notification preferences</a>",
+		"unsubscribe": "<a href='http://domain.com/posts/1/add-support-for-typescript'>unsubscribe from it</a>",
+		"logo":        "https://fider.io/images/logo-100x100.png",
+	})
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
+		Name:    "Jon Snow",
+		Address: "jon.snow@got.com",
+		Props:   dto.Props{},
+	})
+
+	Expect(addNewNotification).IsNotNil()
+	Expect(addNewNotification.PostID).Equals(post.ID)
+	Expect(addNewNotification.Link).Equals("/posts/1/add-support-for-typescript")
+	Expect(addNewNotification.Title).Equals("**Arya Stark** opened a poll on **Add support for TypeScript**")
+	Expect(addNewNotification.User).Equals(mock.JonSnow)
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
-//NotifyAboutNewPost sends a notification (web and email) to subscribers
+//NotifyAboutNewPost sends a notification (web, push and email) to subscribers
 func NotifyAboutNewPost(post *entity.Post) worker.Task {
 	return describe("Notify about new post", func(c *worker.Context) error {
 		// Web notification
@@ -41,6 +41,11 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 			}
 		}
 
+		// Push notification
+		if err := sendWebPush(c, post, enum.NotificationEventNewPost, title, link); err != nil {
+			return c.Failure(err)
+		}
+
 		// Email notification
 		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewPost)
 		if err != nil {
@@ -75,8 +80,14 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 			Props:        mailProps,
 		})
 
+		// Tags are assigned after the post is created, so it's reloaded to include them
+		webhookPost, err := getWebhookPost(c, post)
+		if err != nil {
+			return c.Failure(err)
+		}
+
 		webhookProps := webhook.Props{}
-		webhookProps.SetPost(post, "post", baseURL, false, false)
+		webhookProps.SetPost(webhookPost, "post", baseURL, false, false)
 		webhookProps.SetUser(author, "author")
 		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 
@@ -15,6 +15,7 @@ import (
 	"github.com/getfider/fider/app/models/dto"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/mock"
 	"github.com/getfider/fider/app/services/email/emailmock"
 	"github.com/getfider/fider/app/tasks"
@@ -51,6 +52,12 @@ func TestNotifyAboutNewPostTask(t *testing.T) {
 		Slug:        "add-support-for-typescript",
 		Description: "TypeScript is great, please add support for it",
 	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+
 	task := tasks.NotifyAboutNewPost(post)
 
 	err := worker.
@@ -109,3 +116,100 @@ func TestNotifyAboutNewPostTask(t *testing.T) {
 		"tenant_url":       "http://domain.com",
 	})
 }
+
+func TestNotifyAboutNewPostTask_WebPush(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	env.Config.WebPush.VAPIDPublicKey = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	env.Config.WebPush.VAPIDPrivateKey = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	defer func() {
+		env.Config.WebPush.VAPIDPublicKey = ""
+		env.Config.WebPush.VAPIDPrivateKey = ""
+	}()
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = &entity.Post{ID: q.PostID}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow}
+		if q.Channel == enum.NotificationChannelPush {
+			q.Result = append(q.Result, mock.AryaStark)
+		}
+		return nil
+	})
+
+	pushes := make([]*cmd.SendWebPush, 0)
+	bus.AddListener(func(ctx context.Context, c *cmd.SendWebPush) error {
+		pushes = append(pushes, c)
+		return nil
+	})
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add support for TypeScript", Slug: "add-support-for-typescript"}
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutNewPost(post))
+
+	Expect(err).IsNil()
+	Expect(pushes).HasLen(1)
+	Expect(pushes[0].User).Equals(mock.AryaStark)
+	Expect(pushes[0].Title).Equals("New post: **Add support for TypeScript**")
+	Expect(pushes[0].Link).Equals("/posts/1/add-support-for-typescript")
+}
+
+func TestNotifyAboutNewPostTask_WebhookWithTags(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{}
+		return nil
+	})
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add support for TypeScript", Slug: "add-support-for-typescript", Tags: []string{}}
+
+	// Tags are assigned right after the post is created
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		reloaded := *post
+		reloaded.Tags = []string{"feature"}
+		q.Result = &reloaded
+		return nil
+	})
+
+	var triggerWebhooks *cmd.TriggerWebhooks
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		triggerWebhooks = c
+		return nil
+	})
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutNewPost(post))
+
+	Expect(err).IsNil()
+	Expect(triggerWebhooks.Props["post_tags"]).Equals([]string{"feature"})
+
+	ok, _ := triggerWebhooks.Props.Match(entity.WebhookConditions{IncludeTags: []string{"feature"}, AuthorRoles: []string{"visitor"}})
+	Expect(ok).IsTrue()
+
+	ok, _ = triggerWebhooks.Props.Match(entity.WebhookConditions{ExcludeTags: []string{"feature"}})
+	Expect(ok).IsFalse()
+}
@@ -0,0 +1,21 @@
+package tasks
+
+import (
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/ogimage"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// PurgePostOGImage removes the cached Open Graph image of a post that has changed.
+// Given post must hold the values from before the change, so the outdated image can be located.
+func PurgePostOGImage(post entity.Post) worker.Task {
+	return describe("Purge post Open Graph image", func(c *worker.Context) error {
+		key := ogimage.PostBlobKey(&post, c.Tenant().LogoBlobKey)
+		if err := bus.Dispatch(c, &cmd.DeleteBlob{Key: key}); err != nil {
+			return c.Failure(err)
+		}
+		return nil
+	})
+}
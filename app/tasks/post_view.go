@@ -0,0 +1,17 @@
+package tasks
+
+import (
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// RecordPostView increases the daily view counters of a post
+func RecordPostView(view *cmd.RecordPostView) worker.Task {
+	return describe("Record post view", func(c *worker.Context) error {
+		if err := bus.Dispatch(c, view); err != nil {
+			return c.Failure(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,31 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func TestRecordPostViewTask(t *testing.T) {
+	RegisterT(t)
+
+	var recorded *cmd.RecordPostView
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordPostView) error {
+		recorded = c
+		return nil
+	})
+
+	view := &cmd.RecordPostView{PostID: 1, ViewedAt: time.Now(), VisitorHash: "1234", ReferrerDomain: "google.com"}
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		Execute(tasks.RecordPostView(view))
+
+	Expect(err).IsNil()
+	Expect(recorded).Equals(view)
+}
@@ -1,11 +1,15 @@
 package tasks
 
 import (
+	"time"
+
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/jwt"
 	"github.com/getfider/fider/app/pkg/web"
 	"github.com/getfider/fider/app/pkg/worker"
 )
@@ -58,3 +62,56 @@ func SendWelcomeEmail(name, email, baseURL string) worker.Task {
 		return nil
 	})
 }
+
+// NotifyOperatorsAboutSignUp is used to ask operators to approve or reject a new site
+func NotifyOperatorsAboutSignUp(signUp *entity.TenantSignUp, siteURL, reviewBaseURL string) worker.Task {
+	return describe("Notify operators about sign up", func(c *worker.Context) error {
+		to := make([]dto.Recipient, 0)
+		for _, operator := range env.OperatorEmails() {
+			approveToken, err := signUpReviewToken(signUp, operator, true)
+			if err != nil {
+				return c.Failure(err)
+			}
+			rejectToken, err := signUpReviewToken(signUp, operator, false)
+			if err != nil {
+				return c.Failure(err)
+			}
+
+			to = append(to, dto.NewRecipient("", operator, dto.Props{
+				"approve": linkWithText("Approve", reviewBaseURL, "/signup/review?k=%s", approveToken),
+				"reject":  linkWithText("Reject", reviewBaseURL, "/signup/review?k=%s", rejectToken),
+			}))
+		}
+
+		if len(to) == 0 {
+			return nil
+		}
+
+		bus.Publish(c, &cmd.SendMail{
+			From:         dto.Recipient{Name: "Fider"},
+			To:           to,
+			TemplateName: "signup_review_email",
+			Props: dto.Props{
+				"siteName":  signUp.TenantName,
+				"siteURL":   link(siteURL, "/"),
+				"name":      signUp.Name,
+				"email":     signUp.Email,
+				"ipAddress": signUp.IPAddress,
+				"logo":      web.LogoURL(c),
+			},
+		})
+
+		return nil
+	})
+}
+
+func signUpReviewToken(signUp *entity.TenantSignUp, operator string, approve bool) (string, error) {
+	return jwt.Encode(jwt.SignUpReviewClaims{
+		SignUpID: signUp.ID,
+		Approve:  approve,
+		Operator: operator,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(7 * 24 * time.Hour)),
+		},
+	})
+}
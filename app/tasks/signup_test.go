@@ -1,12 +1,16 @@
 package tasks_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/jwt"
 	"github.com/getfider/fider/app/pkg/mock"
 	"github.com/getfider/fider/app/services/email/emailmock"
 	"github.com/getfider/fider/app/tasks"
@@ -42,3 +46,53 @@ func TestSendSignUpEmailTask(t *testing.T) {
 		},
 	})
 }
+
+func TestNotifyOperatorsAboutSignUpTask(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+	env.Config.Operator.Emails = "jon.snow@got.com,arya.stark@got.com"
+
+	worker := mock.NewWorker()
+	task := tasks.NotifyOperatorsAboutSignUp(&entity.TenantSignUp{
+		ID:         5,
+		TenantName: "My Company",
+		Name:       "Sansa Stark",
+		Email:      "sansa.stark@got.com",
+		IPAddress:  "203.0.113.7",
+	}, "http://mycompany.test.fider.io", "http://login.test.fider.io")
+
+	err := worker.Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("signup_review_email")
+	Expect(emailmock.MessageHistory[0].Props["siteName"]).Equals("My Company")
+	Expect(emailmock.MessageHistory[0].Props["email"]).Equals("sansa.stark@got.com")
+	Expect(emailmock.MessageHistory[0].Props["ipAddress"]).Equals("203.0.113.7")
+	Expect(emailmock.MessageHistory[0].To).HasLen(2)
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals("jon.snow@got.com")
+	Expect(emailmock.MessageHistory[0].To[1].Address).Equals("arya.stark@got.com")
+
+	approve := emailmock.MessageHistory[0].To[0].Props["approve"].(string)
+	Expect(approve).ContainsSubstring("http://login.test.fider.io/signup/review?k=")
+	token := strings.TrimSuffix(strings.SplitN(approve, "?k=", 2)[1], "'>Approve</a>")
+
+	claims, err := jwt.DecodeSignUpReviewClaims(token)
+	Expect(err).IsNil()
+	Expect(claims.SignUpID).Equals(5)
+	Expect(claims.Approve).IsTrue()
+	Expect(claims.Operator).Equals("jon.snow@got.com")
+}
+
+func TestNotifyOperatorsAboutSignUpTask_NoOperators(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	worker := mock.NewWorker()
+	task := tasks.NotifyOperatorsAboutSignUp(&entity.TenantSignUp{ID: 5}, "http://mycompany.test.fider.io", "http://login.test.fider.io")
+
+	err := worker.Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(0)
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
-//NotifyAboutStatusChange sends a notification (web and email) to subscribers
+//NotifyAboutStatusChange sends a notification (web, push and email) to subscribers
 func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) worker.Task {
 	return describe("Notify about post status change", func(c *worker.Context) error {
 		//Don't notify if previous status is the same
@@ -46,6 +46,11 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 			}
 		}
 
+		// Push notification
+		if err := sendWebPush(c, post, enum.NotificationEventChangeStatus, title, link); err != nil {
+			return c.Failure(err)
+		}
+
 		// Email notification
 		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventChangeStatus)
 		if err != nil {
@@ -88,8 +93,13 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 			Props:        props,
 		})
 
+		webhookPost, err := getWebhookPost(c, post)
+		if err != nil {
+			return c.Failure(err)
+		}
+
 		webhookProps := webhook.Props{"post_old_status": prevStatus.Name()}
-		webhookProps.SetPost(post, "post", baseURL, true, true)
+		webhookProps.SetPost(webhookPost, "post", baseURL, true, true)
 		webhookProps.SetUser(author, "author")
 		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 
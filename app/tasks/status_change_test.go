@@ -60,6 +60,11 @@ func TestNotifyAboutStatusChangeTask(t *testing.T) {
 		},
 	}
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+
 	task := tasks.NotifyAboutStatusChange(post, enum.PostOpen)
 
 	err := worker.
@@ -177,6 +182,11 @@ func TestNotifyAboutStatusChangeTask_Duplicate(t *testing.T) {
 		},
 	}
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+
 	task := tasks.NotifyAboutStatusChange(post, enum.PostOpen)
 
 	err := worker.
@@ -0,0 +1,104 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// NotifyAboutTargetChange sends a notification (web, push and email) to subscribers of status changes
+// Private targets are only known by the staff, so changing them doesn't notify anyone
+func NotifyAboutTargetChange(post *entity.Post, prevTarget *entity.PostTarget) worker.Task {
+	return describe("Notify about post target change", func(c *worker.Context) error {
+		target := publicTarget(post.Target)
+		if target.Equals(publicTarget(prevTarget)) {
+			return nil
+		}
+
+		// Web notification
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventChangeStatus)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		author := c.User()
+		title := fmt.Sprintf("**%s** removed the target release of **%s**", author.Name, post.Title)
+		if target != nil {
+			title = fmt.Sprintf("**%s** changed the target release of **%s** to **%s**", author.Name, post.Title, target.String())
+		}
+		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+		for _, user := range users {
+			if user.ID != author.ID {
+				err = bus.Dispatch(c, &cmd.AddNewNotification{
+					User:   user,
+					Title:  title,
+					Link:   link,
+					PostID: post.ID,
+				})
+				if err != nil {
+					return c.Failure(err)
+				}
+			}
+		}
+
+		// Push notification
+		if err := sendWebPush(c, post, enum.NotificationEventChangeStatus, title, link); err != nil {
+			return c.Failure(err)
+		}
+
+		// Email notification
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventChangeStatus)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		to := make([]dto.Recipient, 0)
+		for _, user := range users {
+			if user.ID != author.ID {
+				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+			}
+		}
+
+		var targetName string
+		if target != nil {
+			targetName = target.String()
+		}
+
+		tenant := c.Tenant()
+		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+		props := dto.Props{
+			"title":       post.Title,
+			"postLink":    linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"siteName":    tenant.Name,
+			"target":      targetName,
+			"view":        linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"unsubscribe": linkWithText(i18n.T(c, "email.subscription.unsubscribe"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"change":      linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
+			"logo":        logoURL,
+		}
+
+		bus.Publish(c, &cmd.SendMail{
+			From:         dto.Recipient{Name: author.Name},
+			To:           to,
+			TemplateName: "change_target",
+			Props:        props,
+		})
+
+		return nil
+	})
+}
+
+func publicTarget(target *entity.PostTarget) *entity.PostTarget {
+	if target != nil && target.IsPublic {
+		return target
+	}
+	return nil
+}
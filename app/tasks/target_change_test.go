@@ -0,0 +1,130 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email/emailmock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func newTargetPost(target *entity.PostTarget) *entity.Post {
+	return &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.AryaStark,
+		Status: enum.PostPlanned,
+		Target: target,
+	}
+}
+
+func TestNotifyAboutTargetChangeTask(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		Expect(q.Event.UserSettingsKeyName).Equals(enum.NotificationEventChangeStatus.UserSettingsKeyName)
+		q.Result = []*entity.User{
+			mock.AryaStark,
+		}
+		return nil
+	})
+
+	post := newTargetPost(&entity.PostTarget{Kind: enum.PostTargetQuarter, Value: "2026-Q3", IsPublic: true})
+	task := tasks.NotifyAboutTargetChange(post, nil)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("change_target")
+	Expect(emailmock.MessageHistory[0].Props).Equals(dto.Props{
+		"title":       "Add support for TypeScript",
+		"postLink":    "<a href='http://domain.com/posts/1/add-support-for-typescript'>#1</a>",
+		"siteName":    "Demonstration",
+		"target":      "Q3 2026",
+		"view":        "<a href='http://domain.com/posts/1/add-support-for-typescript'>view it on your browser</a>",
+		"change":      "<a href='http://domain.com/settings'>change your notification preferences</a>",
+		"unsubscribe": "<a href='http://domain.com/posts/1/add-support-for-typescript'>unsubscribe from it</a>",
+		"logo":        "https://fider.io/images/logo-100x100.png",
+	})
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals("arya.stark@got.com")
+
+	Expect(addNewNotification).IsNotNil()
+	Expect(addNewNotification.PostID).Equals(post.ID)
+	Expect(addNewNotification.Link).Equals("/posts/1/add-support-for-typescript")
+	Expect(addNewNotification.Title).Equals("**Jon Snow** changed the target release of **Add support for TypeScript** to **Q3 2026**")
+	Expect(addNewNotification.User).Equals(mock.AryaStark)
+}
+
+func TestNotifyAboutTargetChangeTask_Removed(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{
+			mock.AryaStark,
+		}
+		return nil
+	})
+
+	post := newTargetPost(&entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: false})
+	task := tasks.NotifyAboutTargetChange(post, &entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: true})
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].Props["target"]).Equals("")
+	Expect(addNewNotification.Title).Equals("**Jon Snow** removed the target release of **Add support for TypeScript**")
+}
+
+func TestNotifyAboutTargetChangeTask_PrivateTarget(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	prevTarget := &entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-09", IsPublic: false}
+	post := newTargetPost(&entity.PostTarget{Kind: enum.PostTargetMonth, Value: "2026-10", IsPublic: false})
+	task := tasks.NotifyAboutTargetChange(post, prevTarget)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(0)
+	Expect(bus.GetCallCount(&query.GetActiveSubscribers{})).Equals(0)
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/worker"
 )
 
@@ -32,3 +36,41 @@ func getActiveSubscribers(ctx context.Context, post *entity.Post, channel enum.N
 	err := bus.Dispatch(ctx, q)
 	return q.Result, err
 }
+
+// sendWebPush delivers a web notification to the browsers of subscribers that enabled push notifications
+func sendWebPush(c *worker.Context, post *entity.Post, event enum.NotificationEvent, title, link string) error {
+	if !env.IsWebPushEnabled() {
+		return nil
+	}
+
+	users, err := getActiveSubscribers(c, post, enum.NotificationChannelPush, event)
+	if err != nil {
+		return err
+	}
+
+	author := c.User()
+	for _, user := range users {
+		if user.ID != author.ID {
+			bus.Publish(c, &cmd.SendWebPush{
+				User:  user,
+				Title: title,
+				Link:  link,
+			})
+		}
+	}
+	return nil
+}
+
+// getWebhookPost reloads the post with staff-only fields (score, private targets and tags), so that
+// webhook payloads always include them no matter who triggered the event.
+// Deleted posts can't be reloaded, so those are used as given
+func getWebhookPost(ctx context.Context, post *entity.Post) (*entity.Post, error) {
+	getPost := &query.GetPostByID{PostID: post.ID, IncludeStaffFields: true}
+	err := bus.Dispatch(ctx, getPost)
+	if errors.Cause(err) == app.ErrNotFound {
+		return post, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return getPost.Result, nil
+}
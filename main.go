@@ -13,6 +13,12 @@ func main() {
 		os.Exit(cmd.RunPing())
 	} else if len(args) > 0 && args[0] == "migrate" {
 		os.Exit(cmd.RunMigrate())
+	} else if len(args) > 0 && args[0] == "vapid" {
+		os.Exit(cmd.RunGenerateVAPIDKeys())
+	} else if len(args) > 0 && args[0] == "config" {
+		os.Exit(cmd.RunConfig(args[1:]))
+	} else if len(args) > 0 && args[0] == "demo" {
+		os.Exit(cmd.RunDemo(args[1:]))
 	} else {
 		os.Exit(cmd.RunServer())
 	}
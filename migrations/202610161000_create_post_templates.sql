@@ -0,0 +1,16 @@
+create table if not exists post_templates (
+  id                serial not null,
+  tenant_id         int not null,
+  name              varchar(60) not null,
+  content           text not null,
+  required_sections text[] not null default '{}',
+  is_default        boolean not null default false,
+  created_at        timestamptz not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id)
+);
+
+CREATE UNIQUE INDEX post_template_default_key ON post_templates (tenant_id) WHERE is_default = true;
+
+ALTER TABLE tags ADD post_template_id INT NULL;
+ALTER TABLE tags ADD FOREIGN KEY (post_template_id) REFERENCES post_templates(id);
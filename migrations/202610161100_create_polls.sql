@@ -0,0 +1,42 @@
+create table if not exists polls (
+  id                 serial not null,
+  tenant_id          int not null,
+  post_id            int not null,
+  question           varchar(200) not null,
+  is_multiple_choice boolean not null,
+  results_visibility smallint not null,
+  closes_at          timestamptz null,
+  created_at         timestamptz not null,
+  created_by_id      int not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (post_id) references posts(id),
+  foreign key (created_by_id) references users(id)
+);
+
+create table if not exists poll_options (
+  id        serial not null,
+  tenant_id int not null,
+  poll_id   int not null,
+  text      varchar(100) not null,
+  position  int not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (poll_id) references polls(id) on delete cascade
+);
+
+create table if not exists poll_votes (
+  tenant_id  int not null,
+  poll_id    int not null,
+  option_id  int not null,
+  user_id    int not null,
+  created_at timestamptz not null,
+  primary key (option_id, user_id),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (poll_id) references polls(id) on delete cascade,
+  foreign key (option_id) references poll_options(id) on delete cascade,
+  foreign key (user_id) references users(id)
+);
+
+CREATE INDEX poll_post_key ON polls (tenant_id, post_id);
+CREATE INDEX poll_votes_poll_user_key ON poll_votes (poll_id, user_id);
@@ -0,0 +1,6 @@
+ALTER TABLE posts ADD score_reach REAL NULL;
+ALTER TABLE posts ADD score_impact REAL NULL;
+ALTER TABLE posts ADD score_confidence REAL NULL;
+ALTER TABLE posts ADD score_effort REAL NULL;
+
+ALTER TABLE tenants ADD prioritization_formula SMALLINT NOT NULL DEFAULT 1;
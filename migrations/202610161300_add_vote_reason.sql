@@ -0,0 +1,2 @@
+ALTER TABLE post_votes ADD reason VARCHAR(280) NULL;
+ALTER TABLE post_votes ADD importance SMALLINT NULL;
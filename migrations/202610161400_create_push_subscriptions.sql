@@ -0,0 +1,16 @@
+create table if not exists push_subscriptions (
+  id         serial not null,
+  tenant_id  int not null,
+  user_id    int not null,
+  endpoint   text not null,
+  key_p256dh varchar(200) not null,
+  key_auth   varchar(100) not null,
+  user_agent varchar(500) null,
+  created_at timestamptz not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (user_id) references users(id)
+);
+
+create unique index push_subscriptions_endpoint_key on push_subscriptions (tenant_id, endpoint);
+create index push_subscriptions_user_key on push_subscriptions (tenant_id, user_id);
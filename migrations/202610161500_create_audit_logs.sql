@@ -0,0 +1,13 @@
+create table if not exists audit_logs (
+  id         serial not null,
+  tenant_id  int not null,
+  user_id    int null,
+  action     varchar(50) not null,
+  details    jsonb null,
+  created_at timestamptz not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (user_id) references users(id)
+);
+
+CREATE INDEX audit_logs_tenant_key ON audit_logs (tenant_id, created_at);
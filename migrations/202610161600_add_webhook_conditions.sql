@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD conditions JSONB NULL;
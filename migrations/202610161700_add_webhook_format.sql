@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD format SMALLINT NOT NULL DEFAULT 1;
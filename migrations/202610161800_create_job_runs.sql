@@ -0,0 +1,14 @@
+create table if not exists job_runs (
+  id          serial not null,
+  job_name    varchar(100) not null,
+  status      smallint not null,
+  manual      boolean not null,
+  started_at  timestamptz not null,
+  finished_at timestamptz not null,
+  duration_ms int not null,
+  error       text null,
+  summary     jsonb null,
+  primary key (id)
+);
+
+CREATE INDEX job_runs_job_name_key ON job_runs (job_name, started_at);
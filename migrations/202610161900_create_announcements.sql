@@ -0,0 +1,15 @@
+create table if not exists announcements (
+  id            serial not null,
+  tenant_id     int not null,
+  text          text not null,
+  severity      smallint not null,
+  audience      smallint not null,
+  starts_at     timestamptz not null,
+  ends_at       timestamptz null,
+  dismissible   boolean not null default true,
+  created_at    timestamptz not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id)
+);
+
+CREATE INDEX announcements_tenant_id_starts_at_idx ON announcements (tenant_id, starts_at);
@@ -0,0 +1 @@
+ALTER TABLE tenants ADD email_rules JSONB NOT NULL DEFAULT '{}';
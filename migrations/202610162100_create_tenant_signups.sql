@@ -0,0 +1,16 @@
+create table if not exists tenant_signups (
+  id          serial not null,
+  tenant_id   int not null,
+  name        varchar(100) null,
+  email       varchar(200) null,
+  ip_address  varchar(50) not null,
+  status      smallint not null,
+  created_at  timestamptz not null,
+  reviewed_at timestamptz null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id)
+);
+
+CREATE UNIQUE INDEX tenant_signups_tenant_id_key ON tenant_signups (tenant_id);
+CREATE INDEX tenant_signups_ip_address_key ON tenant_signups (ip_address, created_at);
+CREATE INDEX tenant_signups_status_key ON tenant_signups (status, created_at);
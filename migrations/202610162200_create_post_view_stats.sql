@@ -0,0 +1,33 @@
+create table if not exists post_view_stats (
+  tenant_id       int not null,
+  post_id         int not null,
+  day             date not null,
+  views           int not null,
+  unique_visitors int not null,
+  primary key (tenant_id, post_id, day),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (post_id) references posts(id)
+);
+
+create table if not exists post_referrer_stats (
+  tenant_id int not null,
+  post_id   int not null,
+  day       date not null,
+  domain    varchar(255) not null,
+  views     int not null,
+  primary key (tenant_id, post_id, day, domain),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (post_id) references posts(id)
+);
+
+create table if not exists post_view_visitors (
+  tenant_id    int not null,
+  post_id      int not null,
+  day          date not null,
+  visitor_hash char(64) not null,
+  primary key (tenant_id, post_id, day, visitor_hash)
+);
+
+CREATE INDEX post_view_stats_tenant_id_day_key ON post_view_stats (tenant_id, day);
+CREATE INDEX post_referrer_stats_tenant_id_day_key ON post_referrer_stats (tenant_id, day);
+CREATE INDEX post_view_visitors_day_key ON post_view_visitors (day);
@@ -0,0 +1,62 @@
+create table if not exists oauth_apps (
+  id                 serial not null,
+  tenant_id          int not null,
+  name               varchar(100) not null,
+  client_id          varchar(64) not null,
+  client_secret_hash varchar(128) not null,
+  redirect_uris      text[] not null,
+  scopes             text[] not null,
+  created_at         timestamptz not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id)
+);
+
+CREATE UNIQUE INDEX oauth_apps_client_id_key ON oauth_apps (client_id);
+CREATE INDEX oauth_apps_tenant_id_key ON oauth_apps (tenant_id);
+
+create table if not exists oauth_authorizations (
+  id           serial not null,
+  tenant_id    int not null,
+  app_id       int not null,
+  user_id      int not null,
+  scopes       text[] not null,
+  created_at   timestamptz not null,
+  last_used_at timestamptz null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (app_id) references oauth_apps(id) on delete cascade,
+  foreign key (user_id) references users(id)
+);
+
+CREATE UNIQUE INDEX oauth_authorizations_app_user_key ON oauth_authorizations (tenant_id, app_id, user_id);
+
+create table if not exists oauth_authorization_codes (
+  code_hash        varchar(128) not null,
+  tenant_id        int not null,
+  authorization_id int not null,
+  redirect_uri     text not null,
+  code_challenge   varchar(128) not null,
+  scopes           text[] not null,
+  expires_at       timestamptz not null,
+  primary key (code_hash),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (authorization_id) references oauth_authorizations(id) on delete cascade
+);
+
+create table if not exists oauth_tokens (
+  id                  serial not null,
+  tenant_id           int not null,
+  authorization_id    int not null,
+  access_token_hash   varchar(128) not null,
+  refresh_token_hash  varchar(128) not null,
+  scopes              text[] not null,
+  access_expires_at   timestamptz not null,
+  refresh_expires_at  timestamptz not null,
+  created_at          timestamptz not null,
+  primary key (id),
+  foreign key (tenant_id) references tenants(id),
+  foreign key (authorization_id) references oauth_authorizations(id) on delete cascade
+);
+
+CREATE UNIQUE INDEX oauth_tokens_access_token_hash_key ON oauth_tokens (access_token_hash);
+CREATE UNIQUE INDEX oauth_tokens_refresh_token_hash_key ON oauth_tokens (refresh_token_hash);
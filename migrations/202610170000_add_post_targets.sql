@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD target_kind SMALLINT NULL;
+ALTER TABLE posts ADD target_value VARCHAR(100) NULL;
+ALTER TABLE posts ADD target_public BOOLEAN NOT NULL DEFAULT true;
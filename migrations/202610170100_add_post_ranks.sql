@@ -0,0 +1,2 @@
+ALTER TABLE posts ADD rank DOUBLE PRECISION NULL;
+CREATE INDEX posts_tenant_id_status_rank_key ON posts (tenant_id, status, rank);
@@ -64,3 +64,20 @@ export enum EmailVerificationKind {
   ChangeEmail = 3,
   UserInvitation = 4,
 }
+
+export interface OAuthApp {
+  id: number
+  name: string
+  clientID: string
+  redirectURIs: string[]
+  scopes: string[]
+  createdAt: string
+}
+
+export interface OAuthAuthorization {
+  id: number
+  app: OAuthApp
+  scopes: string[]
+  createdAt: string
+  lastUsedAt?: string
+}
@@ -2,10 +2,11 @@ import React from "react"
 
 import { Modal, Form, Button, PageTitle, Input, Select, SelectOption, ImageUploader, Header } from "@fider/components"
 
-import { UserSettings, UserAvatarType, ImageUpload } from "@fider/models"
+import { UserSettings, UserAvatarType, ImageUpload, OAuthAuthorization } from "@fider/models"
 import { Failure, actions, Fider } from "@fider/services"
 import { NotificationSettings } from "./components/NotificationSettings"
 import { APIKeyForm } from "./components/APIKeyForm"
+import { AuthorizedApps } from "./components/AuthorizedApps"
 import { DangerZone } from "./components/DangerZone"
 import { t, Trans } from "@lingui/macro"
 
@@ -22,6 +23,7 @@ interface MySettingsPageState {
 
 interface MySettingsPageProps {
   userSettings: UserSettings
+  authorizedApps: OAuthAuthorization[]
 }
 
 export default class MySettingsPage extends React.Component<MySettingsPageProps, MySettingsPageState> {
@@ -216,6 +218,9 @@ export default class MySettingsPage extends React.Component<MySettingsPageProps,
             </Form>
 
             <div className="mt-8">{Fider.session.user.isCollaborator && <APIKeyForm />}</div>
+            <div className="mt-8">
+              <AuthorizedApps authorizations={this.props.authorizedApps || []} />
+            </div>
             <div className="mt-8">
               <DangerZone />
             </div>
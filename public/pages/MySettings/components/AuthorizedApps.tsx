@@ -0,0 +1,59 @@
+import React, { useState } from "react"
+import { Button, Moment } from "@fider/components"
+import { OAuthAuthorization } from "@fider/models"
+import { actions, notify, Fider } from "@fider/services"
+import { Trans } from "@lingui/macro"
+
+interface AuthorizedAppsProps {
+  authorizations: OAuthAuthorization[]
+}
+
+export const AuthorizedApps = (props: AuthorizedAppsProps) => {
+  const [authorizations, setAuthorizations] = useState(props.authorizations)
+
+  const revoke = (id: number) => async () => {
+    const result = await actions.revokeOAuthAuthorization(id)
+    if (result.ok) {
+      setAuthorizations(authorizations.filter((a) => a.id !== id))
+    } else {
+      notify.error("Failed to revoke the application. Try again later")
+    }
+  }
+
+  return (
+    <div>
+      <h4 className="text-title mb-1">
+        <Trans id="mysettings.authorizedapps.title">Authorized Applications</Trans>
+      </h4>
+      {authorizations.length === 0 ? (
+        <p className="text-muted">
+          <Trans id="mysettings.authorizedapps.empty">You haven&apos;t authorized any application to access your account.</Trans>
+        </p>
+      ) : (
+        <ul>
+          {authorizations.map((a) => (
+            <li key={a.id} className="mb-2">
+              <strong>{a.app.name}</strong> <span className="text-muted">({a.scopes.join(", ")})</span>
+              <p className="text-muted text-sm">
+                <Trans id="mysettings.authorizedapps.authorized">
+                  Authorized <Moment locale={Fider.currentLocale} date={a.createdAt} />
+                </Trans>
+                {a.lastUsedAt && (
+                  <>
+                    {" · "}
+                    <Trans id="mysettings.authorizedapps.lastused">
+                      Last used <Moment locale={Fider.currentLocale} date={a.lastUsedAt} />
+                    </Trans>
+                  </>
+                )}
+              </p>
+              <Button variant="danger" size="small" onClick={revoke(a.id)}>
+                <Trans id="mysettings.authorizedapps.revoke">Revoke</Trans>
+              </Button>
+            </li>
+          ))}
+        </ul>
+      )}
+    </div>
+  )
+}
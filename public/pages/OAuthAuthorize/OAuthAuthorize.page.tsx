@@ -0,0 +1,105 @@
+import React from "react"
+import { Button, SignInControl, TenantLogo } from "@fider/components"
+import { actions, navigator, notify } from "@fider/services"
+import { OAuthAuthorizeRequest } from "@fider/services/actions"
+import { useFider } from "@fider/hooks"
+import { Trans } from "@lingui/macro"
+
+interface OAuthAuthorizePageProps {
+  error?: string
+  app?: {
+    name: string
+  }
+  scopes?: string[]
+  request?: OAuthAuthorizeRequest
+}
+
+const ScopeDescription = (props: { scope: string }) => {
+  switch (props.scope) {
+    case "read":
+      return <Trans id="oauth.authorize.scope.read">Read posts, comments, votes and notifications on your behalf</Trans>
+    case "write":
+      return <Trans id="oauth.authorize.scope.write">Create and change posts, comments and votes on your behalf</Trans>
+    case "admin":
+      return <Trans id="oauth.authorize.scope.admin">Manage this site with your collaborator or administrator permissions</Trans>
+  }
+  return <>{props.scope}</>
+}
+
+const OAuthAuthorizePage = (props: OAuthAuthorizePageProps) => {
+  const fider = useFider()
+
+  const decide = (approved: boolean) => async () => {
+    if (!props.request) {
+      return
+    }
+
+    const result = await actions.authorizeOAuthApp(props.request, approved)
+    if (result.ok) {
+      navigator.goTo(result.data.redirectURL)
+    } else {
+      notify.error("Failed to authorize the application. Try again later")
+    }
+  }
+
+  const renderContent = () => {
+    if (props.error || !props.app || !props.scopes) {
+      return <p>{props.error}</p>
+    }
+
+    if (!fider.session.isAuthenticated) {
+      return (
+        <>
+          <p>
+            <Trans id="oauth.authorize.signin">
+              Sign in to allow <strong>{props.app.name}</strong> to access your account.
+            </Trans>
+          </p>
+          <SignInControl useEmail={true} redirectTo={navigator.url()} />
+        </>
+      )
+    }
+
+    return (
+      <>
+        <p>
+          <Trans id="oauth.authorize.text">
+            <strong>{props.app.name}</strong> would like to access your account <strong>{fider.session.user.name}</strong> and:
+          </Trans>
+        </p>
+        <ul className="text-left mb-4">
+          {props.scopes.map((scope) => (
+            <li key={scope}>
+              <ScopeDescription scope={scope} />
+            </li>
+          ))}
+        </ul>
+        <p className="text-muted">
+          <Trans id="oauth.authorize.revoke">You can revoke this access at any time from your settings.</Trans>
+        </p>
+        <Button variant="primary" onClick={decide(true)}>
+          <Trans id="oauth.authorize.allow">Allow</Trans>
+        </Button>
+        <Button variant="tertiary" onClick={decide(false)}>
+          <Trans id="oauth.authorize.deny">Deny</Trans>
+        </Button>
+      </>
+    )
+  }
+
+  return (
+    <div id="p-oauth-authorize" className="container page">
+      <div className="w-max-7xl mx-auto text-center mt-8">
+        <div className="h-20 mb-4">
+          <TenantLogo size={100} useFiderIfEmpty={true} />
+        </div>
+        <h1 className="text-display">
+          <Trans id="oauth.authorize.title">Authorize application</Trans>
+        </h1>
+        {renderContent()}
+      </div>
+    </div>
+  )
+}
+
+export default OAuthAuthorizePage
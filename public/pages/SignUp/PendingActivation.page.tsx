@@ -2,22 +2,42 @@ import React from "react"
 import { TenantLogo } from "@fider/components"
 import { Trans } from "@lingui/macro"
 
-const PendingActivation = () => {
+interface PendingActivationProps {
+  awaitingApproval?: boolean
+}
+
+const PendingActivation = (props: PendingActivationProps) => {
   return (
     <div id="p-notinvited" className="container page">
       <div className="w-max-7xl mx-auto text-center mt-8">
         <div className="h-20 mb-4">
           <TenantLogo size={100} useFiderIfEmpty={true} />
         </div>
-        <h1 className="text-display uppercase">
-          <Trans id="page.pendingactivation.title">Your account is pending activation</Trans>
-        </h1>
-        <p>
-          <Trans id="page.pendingactivation.text">We sent you a confirmation email with a link to activate your site.</Trans>
-        </p>
-        <p>
-          <Trans id="page.pendingactivation.text2">Please check your inbox to activate it.</Trans>
-        </p>
+        {props.awaitingApproval ? (
+          <>
+            <h1 className="text-display uppercase">
+              <Trans id="page.pendingactivation.approval.title">Your site is pending approval</Trans>
+            </h1>
+            <p>
+              <Trans id="page.pendingactivation.approval.text">Our team is reviewing your site and will activate it shortly.</Trans>
+            </p>
+            <p>
+              <Trans id="page.pendingactivation.approval.text2">We&apos;ll let you know by email once it&apos;s ready.</Trans>
+            </p>
+          </>
+        ) : (
+          <>
+            <h1 className="text-display uppercase">
+              <Trans id="page.pendingactivation.title">Your account is pending activation</Trans>
+            </h1>
+            <p>
+              <Trans id="page.pendingactivation.text">We sent you a confirmation email with a link to activate your site.</Trans>
+            </p>
+            <p>
+              <Trans id="page.pendingactivation.text2">Please check your inbox to activate it.</Trans>
+            </p>
+          </>
+        )}
       </div>
     </div>
   )
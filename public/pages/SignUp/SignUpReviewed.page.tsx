@@ -0,0 +1,74 @@
+import React, { useState } from "react"
+import { Button, TenantLogo } from "@fider/components"
+import { actions, notify } from "@fider/services"
+
+type SignUpStatus = "pending" | "approved" | "rejected"
+
+interface SignUpReviewedProps {
+  tenantName: string
+  tenantURL: string
+  status: SignUpStatus
+  approve: boolean
+  reviewKey: string
+}
+
+const SignUpReviewed = (props: SignUpReviewedProps) => {
+  const [status, setStatus] = useState<SignUpStatus>(props.status)
+
+  const confirm = async () => {
+    const result = await actions.reviewTenantSignUp(props.reviewKey)
+    if (result.ok) {
+      setStatus(result.data.status)
+    } else if (result.error?.errors?.length) {
+      notify.error(result.error.errors[0].message)
+    } else {
+      notify.error("Failed to review the sign-up. Try again later")
+    }
+  }
+
+  const renderContent = () => {
+    if (status === "pending") {
+      return (
+        <>
+          <p>
+            Do you want to {props.approve ? "approve" : "reject"} <strong>{props.tenantName}</strong>?
+          </p>
+          <Button variant={props.approve ? "primary" : "danger"} onClick={confirm}>
+            {props.approve ? "Approve" : "Reject"}
+          </Button>
+        </>
+      )
+    }
+
+    if (status === "approved") {
+      return (
+        <p>
+          <a className="text-link" href={props.tenantURL}>
+            {props.tenantName}
+          </a>{" "}
+          has been approved.
+        </p>
+      )
+    }
+
+    return (
+      <p>
+        <strong>{props.tenantName}</strong> has been {status}.
+      </p>
+    )
+  }
+
+  return (
+    <div id="p-signup-reviewed" className="container page">
+      <div className="w-max-7xl mx-auto text-center mt-8">
+        <div className="h-20 mb-4">
+          <TenantLogo size={100} useFiderIfEmpty={true} />
+        </div>
+        <h1 className="text-display uppercase">Sign-up {status}</h1>
+        {renderContent()}
+      </div>
+    </div>
+  )
+}
+
+export default SignUpReviewed
@@ -107,3 +107,11 @@ export interface CreateEditOAuthConfigRequest {
 export const saveOAuthConfig = async (request: CreateEditOAuthConfigRequest): Promise<Result> => {
   return await http.post("/_api/admin/oauth", request)
 }
+
+export interface ReviewTenantSignUpResponse {
+  status: "pending" | "approved" | "rejected"
+}
+
+export const reviewTenantSignUp = async (key: string): Promise<Result<ReviewTenantSignUpResponse>> => {
+  return await http.post<ReviewTenantSignUpResponse>("/_api/signup/review", { key })
+}
@@ -25,3 +25,21 @@ export const deleteCurrentAccount = async (): Promise<Result> => {
 export const regenerateAPIKey = async (): Promise<Result<{ apiKey: string }>> => {
   return await http.post<{ apiKey: string }>("/_api/user/regenerate-apikey")
 }
+
+export const revokeOAuthAuthorization = async (id: number): Promise<Result> => {
+  return await http.delete(`/_api/user/oauth-apps/${id}`)
+}
+
+export interface OAuthAuthorizeRequest {
+  clientID: string
+  redirectURI: string
+  responseType: string
+  scope: string
+  state: string
+  codeChallenge: string
+  codeChallengeMethod: string
+}
+
+export const authorizeOAuthApp = async (request: OAuthAuthorizeRequest, approved: boolean): Promise<Result<{ redirectURL: string }>> => {
+  return await http.post<{ redirectURL: string }>("/_api/oauth2/authorize", { ...request, approved })
+}